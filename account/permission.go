@@ -22,12 +22,59 @@ type Group struct {
 	Items []*Item `json:"items"`
 }
 
-// Permission permission struct
+// Permission permission struct. A permission with scope is a session permission,
+// it works as active in the contracts of the scope until it expires.
 type Permission struct {
-	Name      string   `json:"name"`
-	Groups    []string `json:"groups"`
-	Items     []*Item  `json:"items"`
-	Threshold int      `json:"threshold"`
+	Name        string   `json:"name"`
+	Groups      []string `json:"groups"`
+	Items       []*Item  `json:"items"`
+	Threshold   int      `json:"threshold"`
+	Scope       []*Scope `json:"scope,omitempty"`
+	Expiry      int64    `json:"expiry,omitempty"`
+	SpendLimit  []*Limit `json:"spend_limit,omitempty"`
+	SpendPeriod int64    `json:"spend_period,omitempty"`
+}
+
+// Scope contract and abi which a session permission works in, abi "*" means all abis of the contract
+type Scope struct {
+	Contract string `json:"contract"`
+	ABI      string `json:"abi"`
+}
+
+// Limit max amount of a token spent by a session permission in a period, the gas paid for the txs isn't limited
+type Limit struct {
+	Token  string `json:"token"`
+	Amount string `json:"amount"`
+}
+
+// IsSession returns whether p is a session permission
+func (p *Permission) IsSession() bool {
+	return len(p.Scope) > 0
+}
+
+// IsExpired returns whether p is expired at time t
+func (p *Permission) IsExpired(t int64) bool {
+	return p.Expiry > 0 && t >= p.Expiry
+}
+
+// InScope returns whether the abi of the contract is in the scope of p
+func (p *Permission) InScope(contract, abi string) bool {
+	for _, s := range p.Scope {
+		if s.Contract == contract && (s.ABI == "*" || s.ABI == abi) {
+			return true
+		}
+	}
+	return false
+}
+
+// SpendLimitOf returns the spend limit of the token, it returns "" if the token can't be spent
+func (p *Permission) SpendLimitOf(token string) string {
+	for _, l := range p.SpendLimit {
+		if l.Token == token {
+			return l.Amount
+		}
+	}
+	return ""
 }
 
 // NewAccount a new empty account
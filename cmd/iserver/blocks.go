@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/iserver"
+	flag "github.com/spf13/pflag"
+)
+
+func loadConfig(configfile string) *common.Config {
+	if configfile == "" {
+		configfile = os.Getenv("GOPATH") + "/src/github.com/iost-official/go-iost/config/iserver.yml"
+	}
+	conf := common.NewConfig(configfile)
+	global.SetGlobalConf(conf)
+	initLogger(conf.Log)
+	return conf
+}
+
+func exportBlocks(args []string) error {
+	fs := flag.NewFlagSet("export-blocks", flag.ExitOnError)
+	configfile := fs.StringP("config", "f", "", "Configuration `file`")
+	from := fs.Int64("from", 0, "The first block `number` to export")
+	to := fs.Int64("to", -1, "The last block `number` to export, default is the last block of the chain")
+	out := fs.StringP("out", "o", "", "The output `file`")
+	fs.Parse(args)
+	if *out == "" {
+		return fmt.Errorf("output file is required")
+	}
+
+	conf := loadConfig(*configfile)
+	bv, err := global.New(conf)
+	if err != nil {
+		return err
+	}
+	defer bv.StateDB().Close()
+	defer bv.BlockChain().Close()
+	if *to < 0 {
+		*to = bv.BlockChain().Length() - 1
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	count, err := iserver.ExportBlocks(bv.BlockChain(), *from, *to, f)
+	if err != nil {
+		return err
+	}
+	ilog.Infof("exported %v blocks to %v", count, *out)
+	return nil
+}
+
+func importBlocks(args []string) error {
+	fs := flag.NewFlagSet("import-blocks", flag.ExitOnError)
+	configfile := fs.StringP("config", "f", "", "Configuration `file`")
+	skipSign := fs.Bool("skip-sign", false, "Skip the signature checks of blocks and txs, only for trusted archives")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: iserver import-blocks [flags] file")
+	}
+
+	conf := loadConfig(*configfile)
+	bv, err := global.New(conf)
+	if err != nil {
+		return err
+	}
+	defer bv.StateDB().Close()
+	defer bv.BlockChain().Close()
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	count, err := iserver.ImportBlocks(bv, f, *skipSign)
+	ilog.Infof("imported %v blocks from %v, block chain length: %v", count, fs.Arg(0), bv.BlockChain().Length())
+	return err
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/iserver"
+	flag "github.com/spf13/pflag"
+)
+
+var dbCommands = map[string]func(args []string) error{
+	"check":   checkDB,
+	"migrate": migrateDB,
+}
+
+func dbCommand(args []string) error {
+	if len(args) == 0 || dbCommands[args[0]] == nil {
+		return fmt.Errorf("usage: iserver db check|migrate [flags]")
+	}
+	return dbCommands[args[0]](args[1:])
+}
+
+func checkDB(args []string) error {
+	fs := flag.NewFlagSet("db check", flag.ExitOnError)
+	configfile := fs.StringP("config", "f", "", "Configuration `file`")
+	repair := fs.Bool("repair", false, "Roll the database back to the last consistent block and truncate the block cache WAL")
+	fs.Parse(args)
+
+	conf := loadConfig(*configfile)
+	bv, err := global.New(conf)
+	if err != nil {
+		return err
+	}
+	r := iserver.CheckDB(bv)
+	bv.StateDB().Close()
+	bv.BlockChain().Close()
+
+	ilog.Infof("block chain length: %v, last consistent block: %v, state db block: %v, block cache WAL: %v/%v valid entries", r.Length, r.Consistent, r.StateNumber, r.WALValid, r.WALEntries)
+	for _, issue := range r.Issues {
+		ilog.Warnf("%v", issue)
+	}
+	if r.OK() {
+		ilog.Infof("database is consistent")
+		return nil
+	}
+	if !*repair {
+		return fmt.Errorf("found %v issues, run with --repair to fix them", len(r.Issues))
+	}
+	if err := iserver.RepairDB(conf, r); err != nil {
+		return err
+	}
+	ilog.Infof("database is repaired")
+	return nil
+}
+
+func migrateDB(args []string) error {
+	fs := flag.NewFlagSet("db migrate", flag.ExitOnError)
+	configfile := fs.StringP("config", "f", "", "Configuration `file`")
+	backend := fs.String("backend", "", "The storage backend to migrate to, leveldb or boltdb")
+	out := fs.StringP("out", "o", "", "The output `directory`, set it as ldbpath in the config to use the new databases")
+	fs.Parse(args)
+	if *out == "" {
+		return fmt.Errorf("output directory is required")
+	}
+	to, err := kv.ParseStorageType(*backend)
+	if err != nil {
+		return err
+	}
+
+	conf := loadConfig(*configfile)
+	if err := iserver.MigrateDB(conf, to, *out); err != nil {
+		return err
+	}
+	ilog.Infof("migrated the databases to %v, set db.backend to %v in the config to use them", *out, to)
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 var (
 	configfile = flag.StringP("config", "f", "", "Configuration `file`")
 	help       = flag.BoolP("help", "h", false, "Display available options")
+	undoDepth  = flag.Int64("undo_depth", 0, "Keep the latest `n` flushes of the state db to repair it with db check --repair, overrides db.undodepth")
 )
 
 func initMetrics(metricsConfig *common.MetricsConfig) error {
@@ -66,20 +67,40 @@ func initLogger(logConfig *common.LogConfig) {
 	ilog.InitLogger(logger)
 }
 
+var commands = map[string]func(args []string) error{
+	"export-blocks": exportBlocks,
+	"import-blocks": importBlocks,
+	"db":            dbCommand,
+}
+
+func runCommand(name string, args []string) {
+	err := commands[name](args)
+	if err != nil {
+		ilog.Errorf("%v failed. err=%v", name, err)
+	}
+	ilog.Stop()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if _, ok := commands[os.Args[1]]; ok {
+			runCommand(os.Args[1], os.Args[2:])
+			return
+		}
+	}
+
 	flag.Parse()
 	if *help {
 		flag.Usage()
 	}
 
-	if *configfile == "" {
-		*configfile = os.Getenv("GOPATH") + "/src/github.com/iost-official/go-iost/config/iserver.yml"
+	conf := loadConfig(*configfile)
+	if flag.CommandLine.Changed("undo_depth") {
+		conf.DB.UndoDepth = *undoDepth
 	}
-
-	conf := common.NewConfig(*configfile)
-	global.SetGlobalConf(conf)
-
-	initLogger(conf.Log)
 	ilog.Infof("Config Information:\n%v", conf.YamlString())
 	ilog.Infof("build time:%v", global.BuildTime)
 	ilog.Infof("git hash:%v", global.GitHash)
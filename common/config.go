@@ -52,6 +52,14 @@ type GenesisConfig struct {
 // DBConfig config of the database
 type DBConfig struct {
 	LdbPath string
+	// Backend is the storage backend of the databases, leveldb or boltdb, default is leveldb.
+	Backend string
+	// PruneBlocks keeps txs and receipts of the latest PruneBlocks blocks only, 0 means no limit.
+	PruneBlocks int64
+	// PruneAge keeps txs and receipts of blocks produced in the latest PruneAge only, 0 means no limit.
+	PruneAge time.Duration
+	// UndoDepth keeps the latest UndoDepth flushes of the state db in a journal for iserver db check --repair, 0 disables it.
+	UndoDepth int64
 }
 
 // VMConfig config of the v8vm
@@ -81,6 +89,8 @@ type RPCConfig struct {
 	GRPCAddr     string
 	AllowOrigins []string
 	TryTx        bool
+	// TraceTx enables the traceTx api, which re-executes a whole block for each request.
+	TraceTx bool
 }
 
 // FileLogConfig is the config for filewriter of ilog.
@@ -124,6 +134,16 @@ type VersionConfig struct {
 	ProtocolVersion string
 }
 
+// ForkConfig is the block numbers from which the new block head versions and tx rules are required.
+type ForkConfig struct {
+	// V1Height is the number of the first block which must be V1 and commit the state hash, 0 means V1 is not activated.
+	V1Height int64
+	// V2Height is the number of the first block which must be V2 and carry the VRF proof of its ed25519 witness key, 0 means V2 is not activated.
+	V2Height int64
+	// MigrationHeight is the number of the first block in which a tx only updating contract code has the raised gas limit, 0 means it's not activated.
+	MigrationHeight int64
+}
+
 // Config provide all configuration for the application
 type Config struct {
 	ACC     *ACCConfig
@@ -136,6 +156,7 @@ type Config struct {
 	Metrics *MetricsConfig
 	Debug   *DebugConfig
 	Version *VersionConfig
+	Fork    *ForkConfig
 }
 
 // LoadYamlAsViper load yaml file as viper object
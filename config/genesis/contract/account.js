@@ -1,3 +1,13 @@
+const RECOVERY_PERMISSION = "recovery";
+const MAX_GUARDIANS = 10;
+const MIN_RECOVERY_DELAY = 86400;
+const MAX_RECOVERY_DELAY = 86400 * 90;
+const MAX_SESSION_SCOPE = 10;
+const MAX_SESSION_LIMITS = 10;
+const MAX_SESSION_DURATION = 86400 * 30 * 1e9;
+const PREMIUM_ID_LENGTH = 5;
+const BASE58_ALPHABET = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz";
+
 class Account {
     constructor() {
 
@@ -86,6 +96,25 @@ class Account {
         }
     }
 
+    // premium ids are won in auctions of domain.iost, admin can sign up them to reserve
+    _checkPremium(id) {
+        if (block.number === 0) {
+            return
+        }
+        if (id.length > PREMIUM_ID_LENGTH && !storage.globalMapHas("domain.iost", "premium_account", id)) {
+            return
+        }
+        const publisher = blockchain.publisher();
+        if (publisher === storage.get("adminID")) {
+            return
+        }
+        if (storage.globalMapGet("domain.iost", "account_winner", id) !== publisher) {
+            throw new Error("id invalid. premium id should be won in auction of domain.iost > " + id);
+        }
+        // the claim fails after the claim period, and the won id is no longer reserved
+        blockchain.callWithAuth("domain.iost", "claimAccount", [id, publisher]);
+    }
+
     _checkPermValid(perm) {
         if (block.number === 0) {
             return
@@ -101,12 +130,104 @@ class Account {
         }
     }
 
+    // a pubkey is 32 bytes of ed25519 or 33 bytes of secp256k1 in base58
+    _checkPubkeyValid(pubkey) {
+        if (typeof pubkey !== "string" || pubkey.length === 0 || pubkey.length > 50) {
+            throw new Error("pubkey invalid > " + pubkey);
+        }
+        let bytes = [];
+        for (const ch of pubkey) {
+            let carry = BASE58_ALPHABET.indexOf(ch);
+            if (carry < 0) {
+                throw new Error("pubkey invalid. pubkey contains invalid character > " + ch);
+            }
+            for (let i = 0; i < bytes.length; i++) {
+                carry += bytes[i] * 58;
+                bytes[i] = carry & 0xff;
+                carry >>= 8;
+            }
+            while (carry > 0) {
+                bytes.push(carry & 0xff);
+                carry >>= 8;
+            }
+        }
+        let len = bytes.length;
+        for (let i = 0; i < pubkey.length && pubkey[i] === "1"; i++) {
+            len++;
+        }
+        if (len !== 32 && len !== 33) {
+            throw new Error("pubkey invalid. pubkey should be 32 or 33 bytes > " + pubkey);
+        }
+    }
+
     _checkWeight(weight) {
         if (weight <= 0) {
             throw "weight less than zero"
         }
     }
 
+    _checkNotRecovery(perm) {
+        if (perm === RECOVERY_PERMISSION) {
+            throw new Error("recovery permission should be changed by setRecovery or dropRecovery");
+        }
+    }
+
+    _dropSessionSpent(id, perm) {
+        if (storage.mapHas("spent", id + "." + perm)) {
+            storage.mapDel("spent", id + "." + perm);
+        }
+    }
+
+    _checkSessionConfig(config) {
+        if (!Array.isArray(config.scope) || config.scope.length === 0 || config.scope.length > MAX_SESSION_SCOPE) {
+            throw new Error("scope should be an array of 1 to " + MAX_SESSION_SCOPE + " items");
+        }
+        for (const s of config.scope) {
+            if (typeof s.contract !== "string" || s.contract.length === 0 || typeof s.abi !== "string" || s.abi.length === 0) {
+                throw new Error("scope should be {\"contract\": contract, \"abi\": abi or \"*\"}");
+            }
+        }
+        if (!Number.isInteger(config.expiry) || config.expiry <= block.time || config.expiry > block.time + MAX_SESSION_DURATION) {
+            throw new Error("expiry should be in 30 days after the block time > " + config.expiry);
+        }
+        const limits = config.spend_limit === undefined ? [] : config.spend_limit;
+        if (!Array.isArray(limits) || limits.length > MAX_SESSION_LIMITS) {
+            throw new Error("spend_limit should be an array of at most " + MAX_SESSION_LIMITS + " items");
+        }
+        for (const l of limits) {
+            if (typeof l.token !== "string" || typeof l.amount !== "string") {
+                throw new Error("spend_limit should be {\"token\": token, \"amount\": amount}");
+            }
+            const amount = new BigNumber(l.amount);
+            if (amount.isNaN() || amount.isNegative()) {
+                throw new Error("invalid amount of spend_limit > " + l.amount);
+            }
+        }
+        const period = config.spend_period === undefined ? 0 : config.spend_period;
+        if (!Number.isInteger(period) || period < 0) {
+            throw new Error("spend_period should be a non-negative integer");
+        }
+        return {
+            scope: config.scope.map(s => ({contract: s.contract, abi: s.abi})),
+            expiry: config.expiry,
+            spend_limit: limits.map(l => ({token: l.token, amount: l.amount})),
+            spend_period: period,
+        };
+    }
+
+    // the guardians are checked one by one, because the auth of a permission falls back to active and owner
+    _requireGuardians(perm) {
+        let weight = 0;
+        for (const item of perm.items) {
+            if (blockchain.requireAuth(item.id, item.permission)) {
+                weight += item.weight;
+            }
+        }
+        if (weight < perm.threshold) {
+            throw new Error("guardians not enough. weight " + weight + " < threshold " + perm.threshold);
+        }
+    }
+
     /**
      * @param  {string} id - this is a string
      *
@@ -116,6 +237,7 @@ class Account {
             throw new Error("id existed > " + id);
         }
         this._checkIdValid(id);
+        this._checkPremium(id);
         const referrer = blockchain.publisher();
         let account = {};
         account.id = id;
@@ -157,6 +279,7 @@ class Account {
 
     addPermission(id, perm, thres) {
         this._ra(id);
+        this._checkNotRecovery(perm);
         this._checkPermValid(perm);
         let acc = this._loadAccount(id);
         if (acc.permissions[perm] !== undefined) {
@@ -175,18 +298,21 @@ class Account {
 
     dropPermission(id, perm) {
         this._ra(id);
+        this._checkNotRecovery(perm);
         if (perm === "active" || perm === "owner") {
             throw "drop active or owner is forbidden"
         }
         let acc = this._loadAccount(id);
         acc.permissions[perm] = undefined;
         this._saveAccount(acc);
+        this._dropSessionSpent(id, perm);
 
         blockchain.receipt(JSON.stringify([id, perm]));
     }
 
     assignPermission(id, perm, un, weight) {
         this._ra(id);
+        this._checkNotRecovery(perm);
         this._checkWeight(weight);
         let acc = this._loadAccount(id);
         const index = Account._find(acc.permissions[perm].items, un);
@@ -218,6 +344,7 @@ class Account {
 
     revokePermission(id, perm, un) {
         this._ra(id);
+        this._checkNotRecovery(perm);
         let acc = this._loadAccount(id);
         const index = Account._findPermission(acc.permissions[perm].items, un);
         if (index < 0) {
@@ -308,6 +435,7 @@ class Account {
 
     assignPermissionToGroup(id, perm, group) {
         this._ra(id);
+        this._checkNotRecovery(perm);
         let acc = this._loadAccount(id);
         if (acc.groups[group] === undefined) {
             throw new Error("group does not exist");
@@ -329,6 +457,197 @@ class Account {
 
         blockchain.receipt(JSON.stringify([id, perm, group]));
     }
+
+    /**
+     * add a session permission, which works as active in the scope until it expires.
+     * the spend limit counts tokens only, the gas of the txs published with the session is not limited.
+     * @param  {string} id - the account
+     * @param  {string} perm - name of the permission
+     * @param  {string} key - the public key of the session
+     * @param  {Object} config - {"scope": [{"contract": contract, "abi": abi or "*"}], "expiry": block time in ns,
+     *                           "spend_limit": [{"token": token, "amount": amount}], "spend_period": seconds, 0 means the lifetime}
+     */
+    addSession(id, perm, key, config) {
+        this._ra(id);
+        this._checkNotRecovery(perm);
+        this._checkPermValid(perm);
+        let acc = this._loadAccount(id);
+        if (acc.permissions[perm] !== undefined) {
+            throw new Error("permission already exist");
+        }
+        const session = this._checkSessionConfig(config);
+        acc.permissions[perm] = {
+            name: perm,
+            groups: [],
+            items: [{
+                id: key,
+                is_key_pair: true,
+                weight: 1,
+            }],
+            threshold: 1,
+            scope: session.scope,
+            expiry: session.expiry,
+            spend_limit: session.spend_limit,
+            spend_period: session.spend_period,
+        };
+        this._saveAccount(acc);
+
+        blockchain.receipt(JSON.stringify([id, perm, key, session]));
+    }
+
+    dropSession(id, perm) {
+        this._ra(id);
+        let acc = this._loadAccount(id);
+        if (acc.permissions[perm] === undefined || acc.permissions[perm].scope === undefined) {
+            throw new Error("session does not exist");
+        }
+        acc.permissions[perm] = undefined;
+        this._saveAccount(acc);
+        this._dropSessionSpent(id, perm);
+
+        blockchain.receipt(JSON.stringify([id, perm]));
+    }
+
+    /**
+     * set the guardians who can recover the owner key of the account
+     * @param  {string} id - the account
+     * @param  {Array} guardians - [{"id": "guardian" or "guardian@permission", "weight": weight}], permission is active by default
+     * @param  {number} threshold - the total weight of guardians needed to start a recovery
+     * @param  {number} delay - seconds between the start and the finish of a recovery, the owner can cancel it during the delay
+     */
+    setRecovery(id, guardians, threshold, delay) {
+        this._ra(id);
+        if (!Array.isArray(guardians) || guardians.length === 0 || guardians.length > MAX_GUARDIANS) {
+            throw new Error("guardians should be an array of 1 to " + MAX_GUARDIANS + " items");
+        }
+        if (!Number.isInteger(delay) || delay < MIN_RECOVERY_DELAY || delay > MAX_RECOVERY_DELAY) {
+            throw new Error("delay should be between " + MIN_RECOVERY_DELAY + "," + MAX_RECOVERY_DELAY + " > " + delay);
+        }
+        let acc = this._loadAccount(id);
+        let items = [];
+        let total = 0;
+        for (const g of guardians) {
+            if (!Number.isInteger(g.weight)) {
+                throw new Error("weight should be integer");
+            }
+            this._checkWeight(g.weight);
+            const len = g.id.indexOf("@");
+            const item = {
+                id: len < 0 ? g.id : g.id.substring(0, len),
+                permission: len < 0 ? "active" : g.id.substring(len+1, g.id.length),
+                is_key_pair: false,
+                weight: g.weight
+            };
+            if (item.id === id) {
+                throw new Error("account can't be its own guardian");
+            }
+            if (!this._hasAccount(item.id)) {
+                throw new Error("guardian not exists > " + item.id);
+            }
+            if (Account._findPermission(items, item.id + "@" + item.permission) >= 0) {
+                throw new Error("guardian duplicated > " + g.id);
+            }
+            items.push(item);
+            total += item.weight;
+        }
+        if (!Number.isInteger(threshold) || threshold <= 0 || threshold > total) {
+            throw new Error("threshold should be between 1," + total + " > " + threshold);
+        }
+        acc.permissions[RECOVERY_PERMISSION] = {
+            name: RECOVERY_PERMISSION,
+            groups: [],
+            items: items,
+            threshold: threshold,
+        };
+        this._saveAccount(acc);
+        storage.mapPut("recovery", id, JSON.stringify({delay: delay}), id);
+        if (storage.mapHas("recoveryRequest", id)) {
+            storage.mapDel("recoveryRequest", id);
+        }
+
+        blockchain.receipt(JSON.stringify([id, guardians, threshold, delay]));
+    }
+
+    dropRecovery(id) {
+        this._ra(id);
+        let acc = this._loadAccount(id);
+        acc.permissions[RECOVERY_PERMISSION] = undefined;
+        this._saveAccount(acc);
+        if (storage.mapHas("recovery", id)) {
+            storage.mapDel("recovery", id);
+        }
+        if (storage.mapHas("recoveryRequest", id)) {
+            storage.mapDel("recoveryRequest", id);
+        }
+
+        blockchain.receipt(JSON.stringify([id]));
+    }
+
+    // start a recovery to the new owner key, it needs the auth of guardians and is paid by the publisher.
+    // A started recovery is overwritten and its delay starts again.
+    initiateRecovery(id, owner) {
+        if (!this._hasAccount(id)) {
+            throw new Error("account not exists > " + id);
+        }
+        this._checkPubkeyValid(owner);
+        let acc = this._loadAccount(id);
+        if (acc.permissions[RECOVERY_PERMISSION] === undefined || !storage.mapHas("recovery", id)) {
+            throw new Error("recovery not set");
+        }
+        this._requireGuardians(acc.permissions[RECOVERY_PERMISSION]);
+        if (storage.mapHas("recoveryRequest", id)) {
+            storage.mapDel("recoveryRequest", id);
+        }
+        const recovery = JSON.parse(storage.mapGet("recovery", id));
+        const request = {
+            owner: owner,
+            time: block.time + recovery.delay * 1e9,
+        };
+        storage.mapPut("recoveryRequest", id, JSON.stringify(request), blockchain.publisher());
+
+        blockchain.receipt(JSON.stringify([id, owner, request.time]));
+    }
+
+    // cancel a started recovery, it needs the auth of owner or guardians
+    cancelRecovery(id) {
+        if (!storage.mapHas("recoveryRequest", id)) {
+            throw new Error("no recovery started");
+        }
+        if (!blockchain.requireAuth(id, "owner")) {
+            const acc = this._loadAccount(id);
+            this._requireGuardians(acc.permissions[RECOVERY_PERMISSION]);
+        }
+        storage.mapDel("recoveryRequest", id);
+
+        blockchain.receipt(JSON.stringify([id]));
+    }
+
+    // rotate the owner key after the delay, the transaction should be signed by the new owner key
+    finishRecovery(id) {
+        if (!storage.mapHas("recoveryRequest", id)) {
+            throw new Error("no recovery started");
+        }
+        const request = JSON.parse(storage.mapGet("recoveryRequest", id));
+        if (block.time < request.time) {
+            throw new Error("recovery can be finished after " + request.time);
+        }
+        storage.mapDel("recoveryRequest", id);
+        let acc = this._loadAccount(id);
+        acc.permissions.owner = {
+            name: "owner",
+            groups: [],
+            items: [{
+                id: request.owner,
+                is_key_pair: true,
+                weight: 1,
+            }],
+            threshold: 1,
+        };
+        this._saveAccount(acc);
+        this._ra(id);
+
+        blockchain.receipt(JSON.stringify([id, request.owner]));
+    }
 }
 
 module.exports = Account;
@@ -1,3 +1,20 @@
+const MAX_LEVELS = 100;
+const MAX_LEVEL_ORDERS = 50;
+const MAX_MATCHES = 20;
+const MAX_FEE_RATE = "0.01";
+const DEFAULT_PAIR = "default";
+const DEFAULT_MIN_VALUE = "1";
+
+// Orders of a token pair "base:quote" are kept in two books sorted by price, the buy book from the highest price
+// and the sell book from the lowest. Amounts are in the base token and prices are in the quote token per base token.
+// An order is worth at least the minimum value of its pair in the quote token, and the rest of an order whose value
+// is cut to zero is closed as dust, so the book never keeps an order that can't be filled.
+// A full book gives way to a better price by closing its worst level, and a full level to a larger order by
+// closing its smallest order, the closed orders are refunded.
+// Clients can read the depth from the contract storage without sending a transaction:
+//   levels:<pair>:<side>           json array of prices, the best first
+//   level:<pair>:<side> -> price   json {"amount": amount left at the price, "orders": order ids in time priority}
+//   orders -> order id             json of the order
 class DexContract {
     init(){}
     _requireAuth(account, permission) {
@@ -6,6 +23,10 @@ class DexContract {
             throw new Error("require auth failed. ret = " + ret);
         }
     }
+    _requireOwner() {
+        const owner = storage.globalMapGet("system.iost", "contract_owner", blockchain.contractName());
+        this._requireAuth(owner, "active");
+    }
     can_update(data) {
         const owner = storage.globalMapGet("system.iost", "contract_owner", blockchain.contractName());
         return blockchain.requireAuth(owner, "active");
@@ -21,44 +42,427 @@ class DexContract {
     }
     _put_order(orderID, orderJSON) {
         storage.mapPut("orders", orderID, JSON.stringify(orderJSON));
-        storage.put("order_id_cur", orderID.toString());
     }
     _del_order(orderID) {
         storage.mapDel("orders", orderID);
     }
-    place_order(sellType, sellAmount, buyType, buyAmount) {
-        let orderID = this._new_order_id();
+    _decimal(token) {
+        const decimal = storage.globalMapGet("token.iost", "TI" + token, "decimal");
+        if (decimal === null || decimal === "") {
+            throw new Error("token not exists: " + token);
+        }
+        return Number(decimal);
+    }
+    _pay_token(order) {
+        return order.side === "buy" ? order.quote : order.base;
+    }
+    _remaining(order) {
+        return new BigNumber(order.amount).minus(order.filled);
+    }
+    // whether the rest of the order is worth nothing in the quote token at its price
+    _dust(order) {
+        return !this._value(this._remaining(order), order.price, order.quote).gt(0);
+    }
+    _expired(order) {
+        return order.expiry !== 0 && order.expiry <= block.time;
+    }
+    // value in the quote token of amount at price, the dust below the decimal of quote is cut
+    _value(amount, price, quote) {
+        return new BigNumber(amount).times(price).decimalPlaces(this._decimal(quote), BigNumber.ROUND_DOWN);
+    }
+    _pay(token, to, amount, memo) {
+        if (amount.gt(0)) {
+            blockchain.callWithAuth("token.iost", "transfer",
+                [token, blockchain.contractName(), to, amount.toFixed(), memo]);
+        }
+    }
+
+    // book
+    _levels_key(pair, side) {
+        return "levels:" + pair + ":" + side;
+    }
+    _levels(pair, side) {
+        const key = this._levels_key(pair, side);
+        if (!storage.has(key)) {
+            return [];
+        }
+        return JSON.parse(storage.get(key));
+    }
+    _put_levels(pair, side, levels) {
+        const key = this._levels_key(pair, side);
+        if (levels.length === 0) {
+            storage.del(key);
+        } else {
+            storage.put(key, JSON.stringify(levels));
+        }
+    }
+    _get_level(pair, side, price) {
+        const key = "level:" + pair + ":" + side;
+        if (!storage.mapHas(key, price)) {
+            return {amount: "0", orders: []};
+        }
+        return JSON.parse(storage.mapGet(key, price));
+    }
+    _put_level(pair, side, price, level) {
+        const key = "level:" + pair + ":" + side;
+        if (level.orders.length === 0) {
+            storage.mapDel(key, price);
+        } else {
+            storage.mapPut(key, price, JSON.stringify(level));
+        }
+    }
+    // whether price a is better than price b in the book of side
+    _better(side, a, b) {
+        return side === "buy" ? new BigNumber(a).gt(b) : new BigNumber(a).lt(b);
+    }
+    _add_to_book(order) {
+        const pair = order.base + ":" + order.quote;
+        const levels = this._levels(pair, order.side);
+        let i = 0;
+        while (i < levels.length && this._better(order.side, levels[i], order.price)) {
+            i++;
+        }
+        if (i === levels.length || levels[i] !== order.price) {
+            if (levels.length >= MAX_LEVELS) {
+                if (i === levels.length) {
+                    throw new Error("price is worse than the " + MAX_LEVELS + " levels in the book");
+                }
+                this._evict_level(pair, order.side, levels.pop());
+            }
+            levels.splice(i, 0, order.price);
+            this._put_levels(pair, order.side, levels);
+        }
+        const level = this._get_level(pair, order.side, order.price);
+        if (level.orders.length >= MAX_LEVEL_ORDERS) {
+            this._evict_smallest(level, order);
+        }
+        level.orders.push(order.id);
+        level.amount = new BigNumber(level.amount).plus(this._remaining(order)).toFixed();
+        this._put_level(pair, order.side, order.price, level);
+    }
+    // close all orders at the worst price of a full book, the price is removed from the levels by the caller
+    _evict_level(pair, side, price) {
+        for (const orderID of this._get_level(pair, side, price).orders) {
+            this._close(this._get_order(orderID), "evicted");
+        }
+        storage.mapDel("level:" + pair + ":" + side, price);
+    }
+    // close the smallest order of a full level if the order is larger
+    _evict_smallest(level, order) {
+        let smallest = null;
+        for (const orderID of level.orders) {
+            const o = this._get_order(orderID);
+            if (smallest === null || this._remaining(o).lt(this._remaining(smallest))) {
+                smallest = o;
+            }
+        }
+        if (!this._remaining(order).gt(this._remaining(smallest))) {
+            throw new Error("too many orders at price " + order.price + ", max " + MAX_LEVEL_ORDERS);
+        }
+        level.orders.splice(level.orders.indexOf(smallest.id), 1);
+        level.amount = new BigNumber(level.amount).minus(this._remaining(smallest)).toFixed();
+        this._close(smallest, "evicted");
+    }
+    // remove the order from the book, amount is the part of the order left in the book
+    _remove_from_book(order, amount) {
+        const pair = order.base + ":" + order.quote;
+        const level = this._get_level(pair, order.side, order.price);
+        const idx = level.orders.indexOf(order.id);
+        if (idx < 0) {
+            return;
+        }
+        level.orders.splice(idx, 1);
+        level.amount = new BigNumber(level.amount).minus(amount).toFixed();
+        this._put_level(pair, order.side, order.price, level);
+        if (level.orders.length === 0) {
+            const levels = this._levels(pair, order.side);
+            levels.splice(levels.indexOf(order.price), 1);
+            this._put_levels(pair, order.side, levels);
+        }
+    }
+
+    // fee
+    _fee_rate(pair, role) {
+        if (!storage.has("fee_recipient")) {
+            return new BigNumber(0);
+        }
+        let fee = null;
+        if (storage.mapHas("fees", pair)) {
+            fee = JSON.parse(storage.mapGet("fees", pair));
+        } else if (storage.mapHas("fees", DEFAULT_PAIR)) {
+            fee = JSON.parse(storage.mapGet("fees", DEFAULT_PAIR));
+        }
+        return new BigNumber(fee === null ? 0 : fee[role]);
+    }
+    // pays amount of token to the account of the order and the fee of its role to the fee recipient
+    _pay_with_fee(order, role, token, amount, memo) {
+        const fee = amount.times(this._fee_rate(order.base + ":" + order.quote, role))
+            .decimalPlaces(this._decimal(token), BigNumber.ROUND_DOWN);
+        this._pay(token, order.owner, amount.minus(fee), memo);
+        if (fee.gt(0)) {
+            this._pay(token, storage.get("fee_recipient"), fee, "fee:" + order.id);
+            blockchain.emit("fee_charged", {order_id: order.id, role: role, token: token, amount: fee.toFixed()});
+        }
+    }
+    set_fee(pair, makerRate, takerRate) {
+        this._requireOwner();
+        const max = new BigNumber(MAX_FEE_RATE);
+        for (const rate of [makerRate, takerRate]) {
+            const r = new BigNumber(rate);
+            if (!r.isFinite() || r.lt(0) || r.gt(max)) {
+                throw new Error("fee rate should be between 0 and " + MAX_FEE_RATE);
+            }
+        }
+        storage.mapPut("fees", pair === "" ? DEFAULT_PAIR : pair, JSON.stringify({maker: makerRate, taker: takerRate}));
+    }
+    _min_value(pair) {
+        if (storage.mapHas("min_values", pair)) {
+            return new BigNumber(storage.mapGet("min_values", pair));
+        }
+        if (storage.mapHas("min_values", DEFAULT_PAIR)) {
+            return new BigNumber(storage.mapGet("min_values", DEFAULT_PAIR));
+        }
+        return new BigNumber(DEFAULT_MIN_VALUE);
+    }
+    // the minimum value in the quote token of a new order of pair, or of all pairs without their own if pair is empty
+    set_min_value(pair, value) {
+        this._requireOwner();
+        const v = new BigNumber(value);
+        if (!v.isFinite() || !v.gt(0)) {
+            throw new Error("min value should be positive");
+        }
+        storage.mapPut("min_values", pair === "" ? DEFAULT_PAIR : pair, v.toFixed());
+    }
+    set_fee_recipient(account) {
+        this._requireOwner();
+        if (account === "") {
+            storage.del("fee_recipient");
+        } else {
+            storage.put("fee_recipient", account);
+        }
+    }
+
+    // fill amount of the maker order by the taker order at the price of the maker, it returns false if the value is too small
+    _fill(maker, taker, amount) {
+        const value = this._value(amount, maker.price, maker.quote);
+        if (!value.gt(0)) {
+            return false;
+        }
+        const buyer = maker.side === "buy" ? maker : taker;
+        const seller = maker.side === "buy" ? taker : maker;
+        buyer.locked = new BigNumber(buyer.locked).minus(value).toFixed();
+        seller.locked = new BigNumber(seller.locked).minus(amount).toFixed();
+        maker.filled = new BigNumber(maker.filled).plus(amount).toFixed();
+        taker.filled = new BigNumber(taker.filled).plus(amount).toFixed();
+
+        const roles = maker.side === "buy" ? ["maker", "taker"] : ["taker", "maker"];
+        this._pay_with_fee(buyer, roles[0], maker.base, amount, "fill:" + maker.id);
+        this._pay_with_fee(seller, roles[1], maker.quote, value, "fill:" + maker.id);
+        blockchain.emit("order_filled", {
+            maker_order_id: maker.id,
+            taker_order_id: taker.id,
+            pair: maker.base + ":" + maker.quote,
+            side: taker.side,
+            price: maker.price,
+            amount: amount.toFixed()
+        });
+        return true;
+    }
+    // refund the locked token of the order and delete it
+    _close(order, reason) {
+        this._pay(this._pay_token(order), order.owner, new BigNumber(order.locked), reason + ":" + order.id);
+        this._del_order(order.id);
+        blockchain.emit("order_closed", {order_id: order.id, reason: reason, refund: order.locked});
+    }
+    // match the taker order with the best orders in the book at most MAX_MATCHES times
+    _match(taker) {
+        const pair = taker.base + ":" + taker.quote;
+        const side = taker.side === "buy" ? "sell" : "buy";
+        let matches = 0;
+        while (matches < MAX_MATCHES && this._remaining(taker).gt(0)) {
+            const levels = this._levels(pair, side);
+            if (levels.length === 0 || this._better(side, taker.price, levels[0])) {
+                break;
+            }
+            const level = this._get_level(pair, side, levels[0]);
+            const maker = this._get_order(level.orders[0]);
+            matches++;
+            if (this._expired(maker)) {
+                this._remove_from_book(maker, this._remaining(maker));
+                this._close(maker, "expired");
+                continue;
+            }
+            if (this._dust(maker)) {
+                this._remove_from_book(maker, this._remaining(maker));
+                this._close(maker, "dust");
+                continue;
+            }
+            const amount = BigNumber.min(this._remaining(taker), this._remaining(maker));
+            if (!this._fill(maker, taker, amount)) {
+                // the rest of the taker is dust, it's closed by the caller
+                break;
+            }
+            this._update_maker(maker, level, amount);
+        }
+    }
+    // update the maker in the book after amount of it is filled, it's closed when the rest is dust
+    _update_maker(maker, level, amount) {
+        if (this._remaining(maker).gt(0) && !this._dust(maker)) {
+            level.amount = new BigNumber(level.amount).minus(amount).toFixed();
+            this._put_level(maker.base + ":" + maker.quote, maker.side, maker.price, level);
+            this._put_order(maker.id, maker);
+        } else {
+            this._remove_from_book(maker, new BigNumber(amount).plus(this._remaining(maker)));
+            this._close(maker, this._remaining(maker).gt(0) ? "dust" : "filled");
+        }
+    }
+    // whether the order crosses the best price of the other book, the rest of it can't be put in the book then
+    _crossed(order) {
+        const side = order.side === "buy" ? "sell" : "buy";
+        const levels = this._levels(order.base + ":" + order.quote, side);
+        return levels.length > 0 && !this._better(side, order.price, levels[0]);
+    }
+
+    /**
+     * place an order of pair base:quote, it's matched with the orders in the book at once
+     * and the rest is put in the book until it's filled, cancelled or expired.
+     *
+     * @param base    {string}  token to buy or sell
+     * @param quote   {string}  token to pay or receive
+     * @param side    {string}  "buy" or "sell"
+     * @param price   {string}  amount of quote per base
+     * @param amount  {string}  amount of base
+     * @param expiry  {number}  block time in nanoseconds when the order expires, 0 means never
+     */
+    place_order(base, quote, side, price, amount, expiry) {
+        if (side !== "buy" && side !== "sell") {
+            throw new Error("side should be buy or sell");
+        }
+        if (base === quote) {
+            throw new Error("base and quote should be different");
+        }
+        const p = new BigNumber(price);
+        const a = new BigNumber(amount);
+        if (!p.isFinite() || !p.gt(0) || !a.isFinite() || !a.gt(0)) {
+            throw new Error("price and amount should be positive");
+        }
+        if (a.decimalPlaces() > this._decimal(base)) {
+            throw new Error("amount has too many decimals");
+        }
+        if (expiry !== 0 && expiry <= block.time) {
+            throw new Error("order expired");
+        }
+        const minValue = this._min_value(base + ":" + quote);
+        if (this._value(a, p, quote).lt(minValue)) {
+            throw new Error("order value should be at least " + minValue.toFixed() + " " + quote);
+        }
+        const order = {
+            id: this._new_order_id(),
+            owner: blockchain.publisher(),
+            base: base,
+            quote: quote,
+            side: side,
+            price: p.toFixed(),
+            amount: a.toFixed(),
+            filled: "0",
+            locked: side === "buy" ? a.times(p).decimalPlaces(this._decimal(quote), BigNumber.ROUND_UP).toFixed() : a.toFixed(),
+            expiry: expiry
+        };
+        storage.put("order_id_cur", order.id);
         blockchain.callWithAuth("token.iost", "transfer",
-            [sellType, blockchain.publisher(), blockchain.contractName(), sellAmount, "put" + orderID]);
-        this._put_order(orderID, {"sell_type": sellType,
-            "sell_amount": sellAmount,
-            "buy_type": buyType,
-            "buy_amount": buyAmount,
-            "seller": blockchain.publisher()
+            [this._pay_token(order), order.owner, blockchain.contractName(), order.locked, "put" + order.id]);
+        blockchain.emit("order_placed", {
+            order_id: order.id,
+            owner: order.owner,
+            pair: base + ":" + quote,
+            side: side,
+            price: order.price,
+            amount: order.amount
         });
-        return orderID;
+
+        this._match(order);
+        if (!this._remaining(order).gt(0)) {
+            this._close(order, "filled");
+        } else if (this._dust(order)) {
+            this._close(order, "dust");
+        } else if (this._crossed(order)) {
+            // out of MAX_MATCHES, the rest is refunded instead of crossing the book
+            this._close(order, "unmatched");
+        } else {
+            this._add_to_book(order);
+            this._put_order(order.id, order);
+        }
+        return order.id;
     }
     cancel_order(orderID) {
         let orderJSON = this._get_order(orderID);
         if (orderJSON === null) {
             throw new Error("not a valid order");
         }
-        this._requireAuth(orderJSON["seller"], "active");
-        blockchain.callWithAuth("token.iost", "transfer",
-            [orderJSON["sell_type"], blockchain.contractName(), orderJSON["seller"], orderJSON["sell_amount"], "cancel" + orderID]);
-        this._del_order(orderID);
+        this._requireAuth(orderJSON["owner"], "active");
+        this._remove_from_book(orderJSON, this._remaining(orderJSON));
+        this._close(orderJSON, "cancel");
     }
-    take_order(orderID) {
+    // anyone can remove an expired order from the book, the locked token is returned to the owner
+    cancel_expired(orderID) {
         let orderJSON = this._get_order(orderID);
         if (orderJSON === null) {
             throw new Error("not a valid order");
         }
+        if (!this._expired(orderJSON)) {
+            throw new Error("order not expired");
+        }
+        this._remove_from_book(orderJSON, this._remaining(orderJSON));
+        this._close(orderJSON, "expired");
+    }
+    // take amount of the order at its price directly
+    take_order(orderID, amount) {
+        let maker = this._get_order(orderID);
+        if (maker === null) {
+            throw new Error("not a valid order");
+        }
+        if (this._expired(maker)) {
+            throw new Error("order expired");
+        }
+        const a = BigNumber.min(new BigNumber(amount), this._remaining(maker));
+        if (!a.gt(0) || a.decimalPlaces() > this._decimal(maker.base)) {
+            throw new Error("invalid amount");
+        }
+        const taker = {
+            id: "",
+            owner: blockchain.publisher(),
+            base: maker.base,
+            quote: maker.quote,
+            side: maker.side === "buy" ? "sell" : "buy",
+            price: maker.price,
+            amount: a.toFixed(),
+            filled: "0",
+            locked: maker.side === "buy" ? a.toFixed() : this._value(a, maker.price, maker.quote).toFixed()
+        };
         blockchain.callWithAuth("token.iost", "transfer",
-            [orderJSON["buy_type"], blockchain.publisher(), orderJSON["seller"], orderJSON["buy_amount"], "take" + orderID]);
-        blockchain.callWithAuth("token.iost", "transfer",
-            [orderJSON["sell_type"], blockchain.contractName(), blockchain.publisher(), orderJSON["sell_amount"], "finish" + orderID]);
-        this._del_order(orderID);
+            [this._pay_token(taker), taker.owner, blockchain.contractName(), taker.locked, "take" + orderID]);
+        if (!this._fill(maker, taker, a)) {
+            throw new Error("amount too small");
+        }
+        this._update_maker(maker, this._get_level(maker.base + ":" + maker.quote, maker.side, maker.price), a);
+    }
+
+    // read apis
+    get_order(orderID) {
+        if (!storage.mapHas("orders", orderID)) {
+            throw new Error("not a valid order");
+        }
+        return storage.mapGet("orders", orderID);
+    }
+    // json array of {"price", "amount"} of at most limit best levels in the book
+    get_depth(base, quote, side, limit) {
+        const pair = base + ":" + quote;
+        const depth = [];
+        for (const price of this._levels(pair, side).slice(0, limit)) {
+            depth.push({price: price, amount: this._get_level(pair, side, price).amount});
+        }
+        return JSON.stringify(depth);
     }
 }
 
-module.exports = DexContract;
\ No newline at end of file
+module.exports = DexContract;
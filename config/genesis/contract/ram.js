@@ -262,6 +262,55 @@ class RAMContract {
         this._changeAccountTotalRAM(from, -amount);
         this._changeAccountTotalRAM(to, amount);
     }
+
+    _getLentRAM(from, to) {
+        if (!storage.mapHas("LR" + from, to)) {
+            return 0;
+        }
+        return this._mapGet("LR" + from, to);
+    }
+    _changeLentRAM(from, to, delta) {
+        const lent = this._getLentRAM(from, to) + delta;
+        if (lent === 0) {
+            this._mapDel("LR" + from, to);
+        } else {
+            this._mapPut("LR" + from, to, lent);
+        }
+    }
+
+    // lend ram of from with its allowance to spender, the ram can be reclaimed by spender later
+    lendFrom(spender, from, to, amount) {
+        if (amount < 10) {
+            throw new Error("minimum ram amount for trading is 10 byte");
+        }
+        this._requireAuth(spender, transferPermission);
+        if (this._getAccountSelfRAM(from) < amount) {
+            throw new Error("self ram amount " + this._getAccountSelfRAM(from) + ", not enough for lend");
+        }
+        const data = [this._getTokenName(), spender, from, to, amount.toString(), ""];
+        blockchain.callWithAuth("token.iost", "transferFrom", data);
+        this._changeAccountSelfRAM(from, -amount);
+        this._changeAccountTotalRAM(from, -amount);
+        this._changeAccountTotalRAM(to, amount);
+        this._changeLentRAM(from, to, amount);
+    }
+
+    // reclaim ram lent by lendFrom from the borrower with its allowance to spender
+    reclaim(spender, from, to, amount) {
+        if (amount <= 0) {
+            throw new Error("invalid ram amount " + amount);
+        }
+        this._requireAuth(spender, transferPermission);
+        if (this._getLentRAM(from, to) < amount) {
+            throw new Error("lent ram amount " + this._getLentRAM(from, to) + ", not enough for reclaim");
+        }
+        const data = [this._getTokenName(), spender, to, from, amount.toString(), ""];
+        blockchain.callWithAuth("token.iost", "transferFrom", data);
+        this._changeAccountTotalRAM(to, -amount);
+        this._changeAccountSelfRAM(from, amount);
+        this._changeAccountTotalRAM(from, amount);
+        this._changeLentRAM(from, to, -amount);
+    }
 }
 
 module.exports = RAMContract;
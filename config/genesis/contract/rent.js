@@ -0,0 +1,270 @@
+const TRANSFER_PERMISSION = "transfer";
+const UPDATE_PERMISSION = "active";
+const GAS = "gas";
+const RAM = "ram";
+const IOST_DECIMAL = 8;
+const DAY = 24 * 3600 * 1e9;
+const MAX_RENT_DAYS = 365;
+// the unpledged iost is frozen for 3 days in gas.iost
+const UNPLEDGE_FREEZE_TIME = 3 * DAY;
+const MIN_GAS_AMOUNT = 1;
+const MIN_RAM_AMOUNT = 10;
+// gas of the scheduled expire paid by the renter
+const EXPIRE_GAS = 300000;
+
+// Holders offer gas or ram at a price in iost per day, an offer is rented by one renter at a time.
+//   gas: the iost to pledge is escrowed in the contract, the contract pledges it for the renter and unpledges it
+//        on expiry. The offer can't be rented or cancelled until the unpledged iost is unfrozen.
+//   ram: the ram of the owner is lent with its allowance to the contract and reclaimed on expiry with the allowance
+//        given by the renter. The deposit of the renter makes up the ram which is not returned. The offer is closed
+//        on expiry since the allowance of the owner is used up.
+// The renter pays the price of all the days when renting, and the gas of expire(id) which is scheduled to the end of
+// the rental with system.iost scheduleCall, so the rental expires in the first block from its end. Anyone can
+// expire it from its end as well, e.g. when the scheduled call failed, which cancels the scheduled call.
+// It needs system.iost 1.1.0.
+class RentContract {
+    init() {
+    }
+
+    initAdmin(adminID) {
+        const bn = block.number;
+        if (bn !== 0) {
+            throw new Error("init out of genesis block");
+        }
+        storage.put("adminID", adminID);
+    }
+
+    can_update(data) {
+        const admin = storage.get("adminID");
+        this._requireAuth(admin, UPDATE_PERMISSION);
+        return true;
+    }
+
+    _requireAuth(account, permission) {
+        const ret = blockchain.requireAuth(account, permission);
+        if (ret !== true) {
+            throw new Error("require auth failed. ret = " + ret);
+        }
+    }
+
+    _getOffer(id) {
+        if (!storage.mapHas("offers", id)) {
+            throw new Error("offer not exists: " + id);
+        }
+        return JSON.parse(storage.mapGet("offers", id));
+    }
+    _putOffer(offer) {
+        storage.mapPut("offers", offer.id, JSON.stringify(offer));
+    }
+    _closeOffer(offer, reason) {
+        storage.mapDel("offers", offer.id);
+        blockchain.emit("offer_closed", {offer_id: offer.id, reason: reason});
+    }
+
+    // amount of iost which is not negative and has at most 8 decimals
+    _iost(amount, name) {
+        const a = new BigNumber(amount);
+        if (!a.isFinite() || a.lt(0) || a.decimalPlaces() > IOST_DECIMAL) {
+            throw new Error("invalid " + name + " " + amount);
+        }
+        return a;
+    }
+    _checkAmount(kind, amount) {
+        const a = new BigNumber(amount);
+        if (kind === GAS) {
+            if (!a.isFinite() || a.lt(MIN_GAS_AMOUNT) || a.decimalPlaces() > IOST_DECIMAL) {
+                throw new Error("gas amount should be iost not less than " + MIN_GAS_AMOUNT);
+            }
+        } else if (kind === RAM) {
+            if (!a.isInteger() || a.lt(MIN_RAM_AMOUNT)) {
+                throw new Error("ram amount should be integer not less than " + MIN_RAM_AMOUNT);
+            }
+        } else {
+            throw new Error("kind should be " + GAS + " or " + RAM);
+        }
+        return a;
+    }
+    _pay(to, amount, memo) {
+        if (amount.gt(0)) {
+            blockchain.callWithAuth("token.iost", "transfer",
+                ["iost", blockchain.contractName(), to, amount.toFixed(), memo]);
+        }
+    }
+
+    _ramAllowance(owner) {
+        return new BigNumber(blockchain.call("token.iost", "allowance", [RAM, owner, blockchain.contractName()])[0]);
+    }
+    // set the ram allowance of owner to the contract, owner should sign the transaction
+    _approveRAM(owner, allowance) {
+        if (allowance.gt(0)) {
+            blockchain.callWithAuth("token.iost", "approve", [RAM, owner, blockchain.contractName(), allowance.toFixed()]);
+        } else {
+            blockchain.callWithAuth("token.iost", "revoke", [RAM, owner, blockchain.contractName()]);
+        }
+    }
+
+    /**
+     * offer gas or ram to rent.
+     *
+     * @param kind     {string}  "gas" or "ram"
+     * @param owner    {string}  account of the gas or ram
+     * @param amount   {string}  iost to pledge for gas, or bytes of ram
+     * @param price    {string}  iost per day
+     * @param maxDays  {number}  max days of a rental
+     * @param deposit  {string}  iost paid by the renter of ram as a deposit, it should be "0" for gas
+     */
+    offer(kind, owner, amount, price, maxDays, deposit) {
+        this._requireAuth(owner, TRANSFER_PERMISSION);
+        const a = this._checkAmount(kind, amount);
+        const p = this._iost(price, "price");
+        const d = this._iost(deposit, "deposit");
+        if (!Number.isInteger(maxDays) || maxDays < 1 || maxDays > MAX_RENT_DAYS) {
+            throw new Error("max days should be between 1 and " + MAX_RENT_DAYS);
+        }
+        if (kind === GAS) {
+            if (!d.isZero()) {
+                throw new Error("deposit is only for ram");
+            }
+            blockchain.callWithAuth("token.iost", "transfer", ["iost", owner, blockchain.contractName(), a.toFixed(), "offer gas"]);
+        } else {
+            this._approveRAM(owner, this._ramAllowance(owner).plus(a));
+        }
+
+        const id = (Number(storage.get("offerID")) + 1).toString();
+        storage.put("offerID", id);
+        this._putOffer({
+            id: id,
+            kind: kind,
+            owner: owner,
+            amount: a.toFixed(),
+            price: p.toFixed(),
+            maxDays: maxDays,
+            deposit: d.toFixed(),
+            renter: "",
+            end: 0,
+            expireCall: "",
+            availableTime: 0
+        });
+        blockchain.emit("offer_created", {
+            offer_id: id,
+            kind: kind,
+            owner: owner,
+            amount: a.toFixed(),
+            price: p.toFixed(),
+            max_days: maxDays
+        });
+        return id;
+    }
+
+    // cancel an offer which is not rented, the escrowed iost or the allowance of ram is returned
+    cancelOffer(id) {
+        const offer = this._getOffer(id);
+        this._requireAuth(offer.owner, TRANSFER_PERMISSION);
+        if (offer.renter !== "") {
+            throw new Error("offer is rented until " + offer.end);
+        }
+        if (offer.kind === GAS) {
+            if (block.time < offer.availableTime) {
+                throw new Error("unpledged iost is frozen until " + offer.availableTime);
+            }
+            this._pay(offer.owner, new BigNumber(offer.amount), "cancel offer " + id);
+        } else {
+            this._approveRAM(offer.owner, BigNumber.max(this._ramAllowance(offer.owner).minus(offer.amount), 0));
+        }
+        this._closeOffer(offer, "cancel");
+    }
+
+    /**
+     * rent an offer for days, the price of all the days and the deposit are paid at once.
+     *
+     * @param id      {string}  offer id
+     * @param renter  {string}  account which gets the gas or ram
+     * @param days    {number}  days to rent
+     */
+    rent(id, renter, days) {
+        const offer = this._getOffer(id);
+        this._requireAuth(renter, TRANSFER_PERMISSION);
+        if (offer.renter !== "") {
+            throw new Error("offer is rented until " + offer.end);
+        }
+        if (block.time < offer.availableTime) {
+            throw new Error("offer is available from " + offer.availableTime);
+        }
+        if (renter === offer.owner) {
+            throw new Error("can't rent own offer");
+        }
+        if (!Number.isInteger(days) || days < 1 || days > offer.maxDays) {
+            throw new Error("days should be between 1 and " + offer.maxDays);
+        }
+        const cost = new BigNumber(offer.price).times(days);
+        if (cost.gt(0)) {
+            blockchain.callWithAuth("token.iost", "transfer", ["iost", renter, offer.owner, cost.toFixed(), "rent " + id]);
+        }
+        if (offer.kind === GAS) {
+            blockchain.callWithAuth("gas.iost", "pledge", [blockchain.contractName(), renter, offer.amount]);
+        } else {
+            blockchain.callWithAuth("ram.iost", "lendFrom", [blockchain.contractName(), offer.owner, renter, Number(offer.amount)]);
+            // the ram is reclaimed with the allowance of the renter on expiry
+            this._approveRAM(renter, this._ramAllowance(renter).plus(offer.amount));
+            if (new BigNumber(offer.deposit).gt(0)) {
+                blockchain.callWithAuth("token.iost", "transfer", ["iost", renter, blockchain.contractName(), offer.deposit, "deposit " + id]);
+            }
+        }
+
+        offer.renter = renter;
+        offer.end = block.time + days * DAY;
+        offer.expireCall = blockchain.callWithAuth("system.iost", "scheduleCall",
+            [blockchain.contractName(), "expire", [id], days * DAY / 1e9, EXPIRE_GAS])[0];
+        this._putOffer(offer);
+        blockchain.emit("offer_rented", {offer_id: id, renter: renter, cost: cost.toFixed(), end: offer.end});
+    }
+
+    // end the rental of an offer, it's called by the scheduled call, and anyone can call it from the end of the rental
+    expire(id) {
+        const offer = this._getOffer(id);
+        if (offer.renter === "") {
+            throw new Error("offer is not rented");
+        }
+        if (block.time < offer.end) {
+            throw new Error("rental ends at " + offer.end);
+        }
+        // nothing to cancel if it's the scheduled call
+        blockchain.callWithAuth("system.iost", "cancelScheduledCall", [offer.expireCall]);
+        offer.expireCall = "";
+        const renter = offer.renter;
+        let returned = new BigNumber(offer.amount);
+        if (offer.kind === GAS) {
+            blockchain.callWithAuth("gas.iost", "unpledge", [blockchain.contractName(), renter, offer.amount]);
+            offer.renter = "";
+            offer.end = 0;
+            offer.availableTime = block.time + UNPLEDGE_FREEZE_TIME;
+            this._putOffer(offer);
+        } else {
+            // the renter may have used the ram or revoked the allowance, the deposit makes up the rest
+            const balance = new BigNumber(blockchain.call("token.iost", "balanceOf", [RAM, renter])[0]);
+            returned = BigNumber.min(returned, balance, this._ramAllowance(renter));
+            if (returned.gt(0)) {
+                blockchain.callWithAuth("ram.iost", "reclaim", [blockchain.contractName(), offer.owner, renter, returned.toNumber()]);
+            }
+            const deposit = new BigNumber(offer.deposit);
+            const compensation = deposit.times(new BigNumber(offer.amount).minus(returned)).div(offer.amount)
+                .decimalPlaces(IOST_DECIMAL, BigNumber.ROUND_UP);
+            this._pay(offer.owner, compensation, "compensation " + id);
+            this._pay(renter, deposit.minus(compensation), "deposit " + id);
+        }
+        blockchain.emit("rental_expired", {offer_id: id, renter: renter, returned: returned.toFixed()});
+        if (offer.kind === RAM) {
+            this._closeOffer(offer, "expire");
+        }
+    }
+
+    // read apis
+    getOffer(id) {
+        if (!storage.mapHas("offers", id)) {
+            throw new Error("offer not exists: " + id);
+        }
+        return storage.mapGet("offers", id);
+    }
+}
+
+module.exports = RentContract;
@@ -5,7 +5,11 @@ import (
 	"errors"
 	"time"
 
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/crypto/vrf"
 )
 
 var (
@@ -15,6 +19,10 @@ var (
 	errNumber     = errors.New("wrong number")
 	errTxHash     = errors.New("wrong txs hash")
 	errMerkleHash = errors.New("wrong tx receipt merkle hash")
+	errSignature  = errors.New("wrong signature")
+	errWitness    = errors.New("wrong witness")
+	errRandom     = errors.New("wrong randomness proof")
+	errVersion    = errors.New("wrong block head version")
 	// errTxReceipt  = errors.New("wrong tx receipt")
 
 	// TxExecTimeLimit the maximum verify execution time of a transaction
@@ -36,12 +44,73 @@ func VerifyBlockHead(blk *block.Block, parentBlock *block.Block, lib *block.Bloc
 	if bh.Number != parentBlock.Head.Number+1 {
 		return errNumber
 	}
+	if bh.Version != block.HeadVersion(bh.Number) {
+		return errVersion
+	}
 	if !bytes.Equal(blk.CalculateTxMerkleHash(), bh.TxMerkleHash) {
 		return errTxHash
 	}
 	if !bytes.Equal(blk.CalculateTxReceiptMerkleHash(), bh.TxReceiptMerkleHash) {
 		return errMerkleHash
 	}
+	if bh.Version >= block.V2 {
+		if err := VerifyBlockRandom(blk, parentBlock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyBlockRandom verifies that the randomness proof of the block is the VRF proof of the seed of its parent block
+// by the ed25519 key of its witness.
+func VerifyBlockRandom(blk *block.Block, parentBlock *block.Block) error {
+	if blk.Sign == nil || blk.Sign.Algorithm != crypto.Ed25519 {
+		return errRandom
+	}
+	if _, err := vrf.Verify(account.DecodePubkey(blk.Head.Witness), block.RandomSeed(parentBlock), blk.Head.Proof()); err != nil {
+		return errRandom
+	}
+	return nil
+}
+
+// VerifyBlockHeadChain verifies that the block heads are continuous and correctly linked.
+func VerifyBlockHeadChain(blks []*block.Block) error {
+	for i := 1; i < len(blks); i++ {
+		if !bytes.Equal(blks[i].Head.ParentHash, blks[i-1].HeadHash()) {
+			return errParentHash
+		}
+		if blks[i].Head.Number != blks[i-1].Head.Number+1 {
+			return errNumber
+		}
+	}
+	return nil
+}
 
+// VerifyBlockSign verifies the signature of the block head by its witness.
+func VerifyBlockSign(head *block.BlockHead, signature *crypto.Signature) error {
+	if signature == nil {
+		return errSignature
+	}
+	signature.SetPubkey(account.DecodePubkey(head.Witness))
+	hash, err := head.Hash()
+	if err != nil {
+		return err
+	}
+	if !signature.Verify(hash) {
+		return errSignature
+	}
+	return nil
+}
+
+// VerifyBlockWitness verifies that the block is produced in the slot of its witness.
+func VerifyBlockWitness(head *block.BlockHead, witnessList []string) error {
+	if len(witnessList) == 0 {
+		return errWitness
+	}
+	slot := head.Time / 1e9 / common.SlotLength
+	if witnessList[slot%int64(len(witnessList))] != head.Witness {
+		return errWitness
+	}
 	return nil
 }
@@ -1,13 +1,18 @@
 package cverifier
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/smartystreets/goconvey/convey"
 
 	"time"
 
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/crypto/vrf"
 	"github.com/iost-official/go-iost/core/tx"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -63,6 +68,19 @@ func TestVerifyBlockHead(t *testing.T) {
 			convey.So(err, convey.ShouldEqual, errNumber)
 		})
 
+		convey.Convey("Wrong version", func() {
+			block.SetForkConfig(&common.ForkConfig{V1Height: 4})
+			defer block.SetForkConfig(nil)
+			err := VerifyBlockHead(blk, parentBlk, chainTop)
+			convey.So(err, convey.ShouldEqual, errVersion)
+			blk.Head.Version = block.V1
+			err = VerifyBlockHead(blk, parentBlk, chainTop)
+			convey.So(err, convey.ShouldBeNil)
+			block.SetForkConfig(&common.ForkConfig{V1Height: 5})
+			err = VerifyBlockHead(blk, parentBlk, chainTop)
+			convey.So(err, convey.ShouldEqual, errVersion)
+		})
+
 		convey.Convey("Wrong tx hash", func() {
 			tx0 := tx.NewTx(nil, nil, 1000, 1, 300, 0)
 			blk.Txs = append(blk.Txs, tx0)
@@ -75,3 +93,120 @@ func TestVerifyBlockHead(t *testing.T) {
 		})
 	})
 }
+
+func TestVerifyBlockRandom(t *testing.T) {
+	Convey("Test of verify block random", t, func() {
+		witness, err := account.NewKeyPair(nil, crypto.Ed25519)
+		So(err, ShouldBeNil)
+		parentBlk := &block.Block{
+			Head: &block.BlockHead{
+				Number: 3,
+			},
+		}
+		proof, err := vrf.Prove(witness.Seckey, block.RandomSeed(parentBlk))
+		So(err, ShouldBeNil)
+		info, err := json.Marshal(map[string][]byte{"proof": proof})
+		So(err, ShouldBeNil)
+		blk := &block.Block{
+			Head: &block.BlockHead{
+				Version:    block.V2,
+				ParentHash: parentBlk.HeadHash(),
+				Number:     4,
+				Info:       info,
+				Witness:    witness.ReadablePubkey(),
+			},
+			Sign: &crypto.Signature{Algorithm: crypto.Ed25519},
+		}
+
+		Convey("Pass", func() {
+			So(VerifyBlockRandom(blk, parentBlk), ShouldBeNil)
+			So(blk.Head.Random(), ShouldHaveLength, vrf.OutputSize)
+		})
+
+		Convey("Signature instead of proof", func() {
+			info, err := json.Marshal(map[string][]byte{"proof": witness.Sign(block.RandomSeed(parentBlk)).Sig})
+			So(err, ShouldBeNil)
+			blk.Head.Info = info
+			So(VerifyBlockRandom(blk, parentBlk), ShouldEqual, errRandom)
+		})
+
+		Convey("Secp256k1 witness", func() {
+			blk.Sign.Algorithm = crypto.Secp256k1
+			So(VerifyBlockRandom(blk, parentBlk), ShouldEqual, errRandom)
+		})
+
+		Convey("Wrong parent", func() {
+			otherBlk := &block.Block{
+				Head: &block.BlockHead{
+					Number: 3,
+					Info:   info,
+				},
+			}
+			So(VerifyBlockRandom(blk, otherBlk), ShouldEqual, errRandom)
+		})
+
+		Convey("Wrong witness", func() {
+			other, err := account.NewKeyPair(nil, crypto.Ed25519)
+			So(err, ShouldBeNil)
+			blk.Head.Witness = other.ReadablePubkey()
+			So(VerifyBlockRandom(blk, parentBlk), ShouldEqual, errRandom)
+		})
+
+		Convey("No proof", func() {
+			blk.Head.Info = []byte("{}")
+			So(VerifyBlockRandom(blk, parentBlk), ShouldEqual, errRandom)
+		})
+	})
+}
+
+func TestVerifyBlockHeadChain(t *testing.T) {
+	Convey("Test of verify block head chain", t, func() {
+		acc, err := account.NewKeyPair(nil, crypto.Ed25519)
+		So(err, ShouldBeNil)
+		witness := acc.ReadablePubkey()
+		blks := make([]*block.Block, 0)
+		var parentHash []byte
+		for i := int64(0); i < 3; i++ {
+			blk := &block.Block{
+				Head: &block.BlockHead{
+					ParentHash: parentHash,
+					Number:     i,
+					Witness:    witness,
+					Time:       i * common.SlotLength * 1e9,
+				},
+			}
+			blk.CalculateHeadHash()
+			blk.Sign = acc.Sign(blk.HeadHash())
+			parentHash = blk.HeadHash()
+			blks = append(blks, blk)
+		}
+
+		Convey("Pass", func() {
+			So(VerifyBlockHeadChain(blks), ShouldBeNil)
+			for _, blk := range blks {
+				So(VerifyBlockSign(blk.Head, blk.Sign), ShouldBeNil)
+				So(VerifyBlockWitness(blk.Head, []string{witness}), ShouldBeNil)
+			}
+		})
+
+		Convey("Wrong link", func() {
+			blks[2].Head.ParentHash = []byte("fake hash")
+			So(VerifyBlockHeadChain(blks), ShouldEqual, errParentHash)
+			blks[2].Head.ParentHash = blks[1].HeadHash()
+			blks[2].Head.Number = 3
+			So(VerifyBlockHeadChain(blks), ShouldEqual, errNumber)
+		})
+
+		Convey("Wrong sign", func() {
+			blks[1].Head.Time++
+			So(VerifyBlockSign(blks[1].Head, blks[1].Sign), ShouldEqual, errSignature)
+			So(VerifyBlockSign(blks[1].Head, nil), ShouldEqual, errSignature)
+		})
+
+		Convey("Wrong witness", func() {
+			So(VerifyBlockWitness(blks[1].Head, []string{witness, "fake witness"}), ShouldEqual, errWitness)
+			So(VerifyBlockWitness(blks[2].Head, []string{witness, "fake witness"}), ShouldBeNil)
+			So(VerifyBlockWitness(blks[2].Head, nil), ShouldEqual, errWitness)
+		})
+	})
+}
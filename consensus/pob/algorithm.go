@@ -1,10 +1,12 @@
 package pob
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/golang-lru"
 	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/consensus/cverifier"
@@ -13,6 +15,7 @@ import (
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/core/txpool"
 	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/crypto/vrf"
 	"github.com/iost-official/go-iost/db"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/verifier"
@@ -25,6 +28,7 @@ var (
 	errDoubleTx    = errors.New("double tx in block")
 	errTxSignature = errors.New("tx wrong signature")
 	errHeadHash    = errors.New("wrong head hash")
+	errRandomKey   = errors.New("randomness proof needs an ed25519 witness key")
 	generateTxsNum = 0
 )
 
@@ -33,11 +37,27 @@ func generateBlock(acc *account.KeyPair, txPool txpool.TxPool, db db.MVCCDB, lim
 	st := time.Now()
 	pTx, head := txPool.PendingTx()
 	topBlock := head.Block
+	version := block.HeadVersion(topBlock.Head.Number + 1)
+	var proof []byte
+	if version >= block.V2 {
+		if acc.Algorithm != crypto.Ed25519 {
+			return nil, errRandomKey
+		}
+		var err error
+		proof, err = vrf.Prove(acc.Seckey, block.RandomSeed(topBlock))
+		if err != nil {
+			return nil, err
+		}
+	}
+	info, err := json.Marshal(&verifier.Info{Proof: proof})
+	if err != nil {
+		return nil, err
+	}
 	blk := block.Block{
 		Head: &block.BlockHead{
-			Version:    0,
+			Version:    version,
 			ParentHash: topBlock.HeadHash(),
-			Info:       make([]byte, 0),
+			Info:       info,
 			Number:     topBlock.Head.Number + 1,
 			Witness:    acc.ReadablePubkey(),
 			Time:       time.Now().UnixNano(),
@@ -90,7 +110,7 @@ func verifyBasics(head *block.BlockHead, signature *crypto.Signature) error {
 	return nil
 }
 
-func verifyBlock(blk *block.Block, parent *block.Block, lib *block.Block, txPool txpool.TxPool, db db.MVCCDB, chain block.Chain, replay bool) error {
+func verifyBlock(blk *block.Block, parent *block.Block, lib *block.Block, txPool txpool.TxPool, db db.MVCCDB, chain block.Chain, verifiedTxs *lru.Cache, replay bool) error {
 	err := cverifier.VerifyBlockHead(blk, parent, lib)
 	if err != nil {
 		return err
@@ -119,6 +139,10 @@ func verifyBlock(blk *block.Block, parent *block.Block, lib *block.Block, txPool
 			ilog.Infof("FoundChain: %v, %v", t, common.Base58Encode(t.Hash()))
 			return errTxDup
 		case txpool.NotFound:
+			if verifiedTxs != nil && verifiedTxs.Contains(string(t.Hash())) {
+				verifiedTxs.Remove(string(t.Hash()))
+				break
+			}
 			err := t.VerifySelf()
 			if err != nil {
 				return errTxSignature
@@ -2,16 +2,19 @@ package pob
 
 import (
 	"errors"
+	"runtime"
 	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hashicorp/golang-lru"
 	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/consensus/synchronizer/pb"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/blockcache"
 	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/core/txpool"
 	"github.com/iost-official/go-iost/db"
 	"github.com/iost-official/go-iost/ilog"
@@ -44,6 +47,7 @@ var (
 	last2GenBlockTime = 30 * time.Millisecond
 	tWitness          = ""
 	tContinuousNum    = 0
+	verifiedTxsSize   = 100000
 )
 
 type verifyBlockMessage struct {
@@ -68,6 +72,8 @@ type PoB struct {
 	chRecvBlockHash  chan p2p.IncomingMessage
 	chQueryBlock     chan p2p.IncomingMessage
 	chVerifyBlock    chan *verifyBlockMessage
+	verifiedTxs      *lru.Cache
+	txSigSem         chan struct{}
 	wg               *sync.WaitGroup
 	mu               *sync.RWMutex
 }
@@ -90,9 +96,11 @@ func New(account *account.KeyPair, baseVariable global.BaseVariable, blockCache
 		chRecvBlockHash:  p2pService.Register("consensus block head", p2p.NewBlockHash),
 		chQueryBlock:     p2pService.Register("consensus query block", p2p.NewBlockRequest),
 		chVerifyBlock:    make(chan *verifyBlockMessage, 1024),
+		txSigSem:         make(chan struct{}, runtime.NumCPU()),
 		wg:               new(sync.WaitGroup),
 		mu:               new(sync.RWMutex),
 	}
+	p.verifiedTxs, _ = lru.New(verifiedTxsSize)
 	continuousNum = baseVariable.Continuous()
 	staticProperty = newStaticProperty(p.account, blockCache.LinkedRoot().Active())
 	p.recoverBlockcache()
@@ -300,6 +308,9 @@ func (p *PoB) blockLoop() {
 				ilog.Error("fail to decode block")
 				continue
 			}
+			if incomingMessage.Type() == p2p.SyncBlockResponse {
+				p.preVerifyTxs(&blk)
+			}
 			p.chVerifyBlock <- &verifyBlockMessage{blk: &blk, p2pType: incomingMessage.Type()}
 		case <-p.exitSignal:
 			return
@@ -307,6 +318,30 @@ func (p *PoB) blockLoop() {
 	}
 }
 
+// preVerifyTxs verifies the signatures of the block's txs in background, ahead of the block execution.
+// The hashes of the txs passed are recorded in verifiedTxs, so verifyBlock can skip them.
+func (p *PoB) preVerifyTxs(blk *block.Block) {
+	if len(blk.Txs) <= 1 {
+		return
+	}
+	// base tx is not signed.
+	txs := blk.Txs[1:]
+	for _, t := range txs {
+		t.Hash()
+	}
+	go func() {
+		for _, t := range txs {
+			p.txSigSem <- struct{}{}
+			go func(t *tx.Tx) {
+				defer func() { <-p.txSigSem }()
+				if t.VerifySelf() == nil {
+					p.verifiedTxs.Add(string(t.Hash()), true)
+				}
+			}(t)
+		}
+	}()
+}
+
 func (p *PoB) scheduleLoop() {
 	defer p.wg.Done()
 	nextSchedule := timeUntilNextSchedule(time.Now().UnixNano())
@@ -431,7 +466,7 @@ func (p *PoB) addExistingBlock(blk *block.Block, parentBlock *block.Block, repla
 	if !ok {
 		p.verifyDB.Checkout(string(blk.Head.ParentHash))
 		p.txPool.Lock()
-		err := verifyBlock(blk, parentBlock, p.blockCache.LinkedRoot().Block, p.txPool, p.verifyDB, p.blockChain, replay)
+		err := verifyBlock(blk, parentBlock, p.blockCache.LinkedRoot().Block, p.txPool, p.verifyDB, p.blockChain, p.verifiedTxs, replay)
 		p.txPool.Release()
 		if err != nil {
 			ilog.Errorf("verify block failed, blockNum:%v, blockHash:%v. err=%v", blk.Head.Number, common.Base58Encode(blk.HeadHash()), err)
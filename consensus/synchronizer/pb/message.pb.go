@@ -241,12 +241,100 @@ func (m *SyncHeight) GetTime() int64 {
 	return 0
 }
 
+type BlockHeadQuery struct {
+	Start                int64    `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  int64    `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockHeadQuery) Reset()         { *m = BlockHeadQuery{} }
+func (m *BlockHeadQuery) String() string { return proto.CompactTextString(m) }
+func (*BlockHeadQuery) ProtoMessage()    {}
+func (*BlockHeadQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e960d3736d18fa7, []int{4}
+}
+
+func (m *BlockHeadQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeadQuery.Unmarshal(m, b)
+}
+func (m *BlockHeadQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeadQuery.Marshal(b, m, deterministic)
+}
+func (m *BlockHeadQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeadQuery.Merge(m, src)
+}
+func (m *BlockHeadQuery) XXX_Size() int {
+	return xxx_messageInfo_BlockHeadQuery.Size(m)
+}
+func (m *BlockHeadQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeadQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeadQuery proto.InternalMessageInfo
+
+func (m *BlockHeadQuery) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *BlockHeadQuery) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type BlockHeadResponse struct {
+	BlockHeads           [][]byte `protobuf:"bytes,1,rep,name=blockHeads,proto3" json:"blockHeads,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockHeadResponse) Reset()         { *m = BlockHeadResponse{} }
+func (m *BlockHeadResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockHeadResponse) ProtoMessage()    {}
+func (*BlockHeadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1e960d3736d18fa7, []int{5}
+}
+
+func (m *BlockHeadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeadResponse.Unmarshal(m, b)
+}
+func (m *BlockHeadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeadResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockHeadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeadResponse.Merge(m, src)
+}
+func (m *BlockHeadResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockHeadResponse.Size(m)
+}
+func (m *BlockHeadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockHeadResponse proto.InternalMessageInfo
+
+func (m *BlockHeadResponse) GetBlockHeads() [][]byte {
+	if m != nil {
+		return m.BlockHeads
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("msgpb.RequireType", RequireType_name, RequireType_value)
 	proto.RegisterType((*BlockInfo)(nil), "msgpb.BlockInfo")
 	proto.RegisterType((*BlockHashQuery)(nil), "msgpb.BlockHashQuery")
 	proto.RegisterType((*BlockHashResponse)(nil), "msgpb.BlockHashResponse")
 	proto.RegisterType((*SyncHeight)(nil), "msgpb.SyncHeight")
+	proto.RegisterType((*BlockHeadQuery)(nil), "msgpb.BlockHeadQuery")
+	proto.RegisterType((*BlockHeadResponse)(nil), "msgpb.BlockHeadResponse")
 }
 
 func init() {
@@ -254,25 +342,27 @@ func init() {
 }
 
 var fileDescriptor_1e960d3736d18fa7 = []byte{
-	// 308 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xcd, 0x4f, 0x83, 0x40,
-	0x10, 0xc5, 0xc5, 0x6d, 0x6b, 0x9c, 0x9a, 0x06, 0x37, 0xa6, 0x21, 0x9e, 0x08, 0x17, 0x89, 0x31,
-	0xad, 0xa9, 0x07, 0xbd, 0x78, 0x10, 0x43, 0xc4, 0xf8, 0x15, 0xb7, 0xf5, 0xe0, 0x11, 0x70, 0x2c,
-	0x44, 0x59, 0xe8, 0x0e, 0x1c, 0xe8, 0x5f, 0x6f, 0x76, 0xfb, 0x91, 0x7a, 0x7b, 0x6f, 0x98, 0xe1,
-	0xbd, 0x5f, 0x16, 0xce, 0xd2, 0x52, 0x12, 0x4a, 0x6a, 0x68, 0x4c, 0xad, 0x4c, 0x33, 0x55, 0xca,
-	0x7c, 0x89, 0x6a, 0x5c, 0x25, 0xe3, 0x02, 0x89, 0xe2, 0x39, 0x8e, 0x2a, 0x55, 0xd6, 0x25, 0xef,
-	0x16, 0x34, 0xaf, 0x12, 0xef, 0x1a, 0x0e, 0x83, 0xdf, 0x32, 0xfd, 0x79, 0x94, 0xdf, 0x25, 0x1f,
-	0x42, 0x4f, 0x36, 0x45, 0x82, 0xca, 0xb1, 0x5c, 0xcb, 0x67, 0x62, 0xed, 0x38, 0x87, 0x4e, 0x16,
-	0x53, 0xe6, 0xec, 0xbb, 0x96, 0x7f, 0x24, 0x8c, 0xf6, 0x96, 0x30, 0x30, 0x87, 0x51, 0x4c, 0xd9,
-	0x7b, 0x83, 0xaa, 0xe5, 0x17, 0x70, 0xa0, 0x70, 0x31, 0x6b, 0x2b, 0x34, 0xe7, 0x83, 0x09, 0x1f,
-	0x99, 0x8c, 0x91, 0xc0, 0x45, 0x93, 0x2b, 0xd4, 0x5f, 0xc4, 0x66, 0x85, 0x9f, 0x40, 0x97, 0xea,
-	0x58, 0xd5, 0xe6, 0xa7, 0x4c, 0xac, 0x0c, 0xb7, 0x81, 0xa1, 0xfc, 0x72, 0x98, 0x99, 0x69, 0xa9,
-	0xb3, 0x65, 0x53, 0x90, 0xd3, 0x71, 0x99, 0xcf, 0x84, 0xd1, 0x5e, 0x08, 0xc7, 0xdb, 0x6c, 0x81,
-	0x54, 0x69, 0x64, 0x7e, 0x09, 0x90, 0x6c, 0x48, 0xc8, 0xb1, 0x5c, 0xe6, 0xf7, 0x27, 0xf6, 0xba,
-	0xc1, 0x16, 0x51, 0xec, 0xec, 0x78, 0x37, 0x00, 0xd3, 0x56, 0xa6, 0x11, 0xe6, 0xf3, 0xac, 0xd6,
-	0xf0, 0x99, 0x51, 0x1b, 0xf8, 0x95, 0xd3, 0x05, 0xea, 0xbc, 0xc0, 0x75, 0x4f, 0xa3, 0xcf, 0x6f,
-	0xa1, 0xbf, 0x03, 0xc5, 0x39, 0x0c, 0x1e, 0xc2, 0x59, 0xf0, 0xfc, 0x76, 0xff, 0x14, 0xdd, 0x4d,
-	0xa3, 0x70, 0x6a, 0xef, 0xf1, 0x53, 0x18, 0xfe, 0x9f, 0x05, 0x9f, 0xaf, 0x1f, 0x2f, 0x41, 0x28,
-	0x6c, 0x2b, 0xe9, 0x99, 0x27, 0xb8, 0xfa, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x3f, 0x0f, 0x48, 0xd4,
-	0xad, 0x01, 0x00, 0x00,
+	// 344 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xcd, 0x6f, 0xa3, 0x30,
+	0x10, 0xc5, 0x97, 0x90, 0x64, 0xb5, 0x93, 0x28, 0x62, 0xad, 0x55, 0x84, 0xf6, 0x50, 0x21, 0x2e,
+	0x45, 0x55, 0x95, 0x54, 0xc9, 0xa1, 0xb9, 0xf4, 0x50, 0x2a, 0x54, 0xaa, 0x7e, 0xa9, 0x4e, 0x7a,
+	0xe8, 0x11, 0xc8, 0x34, 0xa0, 0x16, 0x43, 0x6c, 0x38, 0x90, 0xbf, 0xbe, 0xb2, 0xf9, 0x50, 0x2a,
+	0xf5, 0xf6, 0xe6, 0x79, 0xc6, 0xf3, 0x7e, 0x36, 0x9c, 0x46, 0x19, 0x13, 0xc8, 0x44, 0x29, 0xe6,
+	0xa2, 0x62, 0x51, 0xcc, 0x33, 0x96, 0x1c, 0x90, 0xcf, 0xf3, 0x70, 0x9e, 0xa2, 0x10, 0xc1, 0x0e,
+	0x67, 0x39, 0xcf, 0x8a, 0x8c, 0x0c, 0x52, 0xb1, 0xcb, 0x43, 0xfb, 0x12, 0xfe, 0xb8, 0x9f, 0x59,
+	0xf4, 0x71, 0xc7, 0xde, 0x33, 0x32, 0x85, 0x21, 0x2b, 0xd3, 0x10, 0xb9, 0xa9, 0x59, 0x9a, 0xa3,
+	0xd3, 0xa6, 0x22, 0x04, 0xfa, 0x71, 0x20, 0x62, 0xb3, 0x67, 0x69, 0xce, 0x98, 0x2a, 0x6d, 0x1f,
+	0x60, 0xa2, 0x06, 0xfd, 0x40, 0xc4, 0x2f, 0x25, 0xf2, 0x8a, 0x9c, 0xc3, 0x6f, 0x8e, 0xfb, 0x4d,
+	0x95, 0xa3, 0x1a, 0x9f, 0x2c, 0xc8, 0x4c, 0xed, 0x98, 0x51, 0xdc, 0x97, 0x09, 0x47, 0x79, 0x42,
+	0xdb, 0x16, 0xf2, 0x0f, 0x06, 0xa2, 0x08, 0x78, 0xa1, 0x2e, 0xd5, 0x69, 0x5d, 0x10, 0x03, 0x74,
+	0x64, 0x5b, 0x53, 0x57, 0x9e, 0x94, 0x72, 0x37, 0x2b, 0x53, 0x61, 0xf6, 0x2d, 0xdd, 0xd1, 0xa9,
+	0xd2, 0xb6, 0x07, 0x7f, 0xbb, 0xdd, 0x14, 0x45, 0x2e, 0x91, 0xc9, 0x05, 0x40, 0xd8, 0x92, 0x08,
+	0x53, 0xb3, 0x74, 0x67, 0xb4, 0x30, 0x9a, 0x04, 0x1d, 0x22, 0x3d, 0xea, 0xb1, 0x57, 0x00, 0xeb,
+	0x8a, 0x45, 0x3e, 0x26, 0xbb, 0xb8, 0x90, 0xf0, 0xb1, 0x52, 0x2d, 0x7c, 0x5d, 0xc9, 0x00, 0x45,
+	0x92, 0x62, 0x93, 0x53, 0x69, 0x7b, 0xd5, 0xc2, 0x63, 0xb0, 0xad, 0xe1, 0x3b, 0x1c, 0xed, 0x07,
+	0x9c, 0x5e, 0x87, 0x63, 0x2f, 0xdb, 0xe8, 0x18, 0x6c, 0xbb, 0xe8, 0x27, 0x4d, 0x74, 0x69, 0xd6,
+	0xd1, 0xc7, 0xf4, 0xc8, 0x39, 0xbb, 0x82, 0xd1, 0xd1, 0x1b, 0x12, 0x02, 0x93, 0x5b, 0x6f, 0xe3,
+	0x3e, 0x3c, 0xdf, 0xdc, 0xfb, 0xd7, 0x6b, 0xdf, 0x5b, 0x1b, 0xbf, 0xc8, 0x7f, 0x98, 0x7e, 0xf7,
+	0xdc, 0xb7, 0xa7, 0xd7, 0x47, 0xd7, 0xa3, 0x86, 0x16, 0x0e, 0xd5, 0x8f, 0x2f, 0xbf, 0x06, 0x00,
+	0xed, 0xde, 0xd6, 0x1a, 0x1c, 0x02, 0x00, 0x00,
 }
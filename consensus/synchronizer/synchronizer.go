@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/consensus/cverifier"
 	msgpb "github.com/iost-official/go-iost/consensus/synchronizer/pb"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/blockcache"
@@ -18,6 +19,8 @@ import (
 var (
 	confirmNumber           int64
 	maxBlockHashQueryNumber int64 = 100
+	maxBlockHeadQueryNumber int64 = 200
+	syncWindowSize          int64 = 3
 	retryTime                     = 5 * time.Second
 	checkTime                     = 3 * time.Second
 	syncHeightTime                = 3 * time.Second
@@ -48,6 +51,7 @@ type SyncImpl struct {
 	heightMap       *sync.Map
 	syncEnd         atomic.Int64
 	lastPrintHeight atomic.Int64
+	headQueryCount  atomic.Int64
 
 	messageChan    chan p2p.IncomingMessage
 	syncHeightChan chan p2p.IncomingMessage
@@ -76,6 +80,8 @@ func NewSynchronizer(basevariable global.BaseVariable, blkcache blockcache.Block
 		p2p.SyncBlockRequest,
 		p2p.SyncBlockHashRequest,
 		p2p.SyncBlockHashResponse,
+		p2p.SyncBlockHeadRequest,
+		p2p.SyncBlockHeadResponse,
 	)
 
 	sy.syncHeightChan = sy.p2pService.Register("sync height", p2p.SyncHeight)
@@ -255,24 +261,90 @@ func (sy *SyncImpl) queryBlockHash(hr *msgpb.BlockHashQuery) {
 	sy.p2pService.Broadcast(bytes, p2p.SyncBlockHashRequest, p2p.UrgentMessage)
 }
 
+func (sy *SyncImpl) sendBlockHashQuery(peerID p2p.PeerID, hr *msgpb.BlockHashQuery) {
+	bytes, err := proto.Marshal(hr)
+	if err != nil {
+		ilog.Errorf("marshal blockhashquery failed. err=%v", err)
+		return
+	}
+	ilog.Debugf("[sync] request block hash. reqtype=%v, nums size=%v, peer=%s", hr.ReqType, len(hr.Nums), peerID.Pretty())
+	sy.p2pService.SendToPeer(peerID, bytes, p2p.SyncBlockHashRequest, p2p.UrgentMessage)
+}
+
+// peersAbove returns the peers whose latest reported height is not lower than the given number.
+func (sy *SyncImpl) peersAbove(number int64) []p2p.PeerID {
+	peers := make([]p2p.PeerID, 0)
+	now := time.Now().Unix()
+	sy.heightMap.Range(func(k, v interface{}) bool {
+		sh, ok := v.(*msgpb.SyncHeight)
+		if !ok || sh.Time+heightAvailableTime < now || sh.Height < number {
+			return true
+		}
+		if peerID, ok := k.(p2p.PeerID); ok {
+			peers = append(peers, peerID)
+		}
+		return true
+	})
+	sort.Slice(peers, func(i int, j int) bool {
+		return peers[i] < peers[j]
+	})
+	return peers
+}
+
+// headPeer returns one of the peers which have the block end, successive calls spread over the peers so that the
+// heads and bodies are fetched in parallel. It returns an empty id if no peer reports the height.
+func (sy *SyncImpl) headPeer(end int64) p2p.PeerID {
+	peers := sy.peersAbove(end)
+	if len(peers) == 0 {
+		return ""
+	}
+	return peers[sy.headQueryCount.Inc()%int64(len(peers))]
+}
+
+// hasPeer returns whether the peer reports a height not lower than the given number recently.
+func (sy *SyncImpl) hasPeer(peerID p2p.PeerID, number int64) bool {
+	v, ok := sy.heightMap.Load(peerID)
+	if !ok {
+		return false
+	}
+	sh, ok := v.(*msgpb.SyncHeight)
+	return ok && sh.Time+heightAvailableTime >= time.Now().Unix() && sh.Height >= number
+}
+
+// queryBlockHead requests the block heads in [start, end] from the peer, or broadcasts a query of their hashes if
+// the peer is empty.
+func (sy *SyncImpl) queryBlockHead(start int64, end int64, peerID p2p.PeerID) {
+	if peerID == "" {
+		sy.queryBlockHash(&msgpb.BlockHashQuery{ReqType: msgpb.RequireType_GETBLOCKHASHES, Start: start, End: end, Nums: nil})
+		return
+	}
+	bytes, err := proto.Marshal(&msgpb.BlockHeadQuery{Start: start, End: end})
+	if err != nil {
+		ilog.Errorf("marshal blockheadquery failed. err=%v", err)
+		return
+	}
+	ilog.Debugf("[sync] request block head. start=%v, end=%v, peer=%s", start, end, peerID.Pretty())
+	sy.p2pService.SendToPeer(peerID, bytes, p2p.SyncBlockHeadRequest, p2p.UrgentMessage)
+}
+
 func (sy *SyncImpl) syncBlocks(startNumber int64, endNumber int64) error {
 	ilog.Debugf("sync Blocks %v, %v", startNumber, endNumber)
 	sy.syncEnd.Store(endNumber)
-	for endNumber > startNumber+maxBlockHashQueryNumber-1 {
-		for sy.blockCache.Head().Head.Number+3 < startNumber {
+	for startNumber <= endNumber {
+		for sy.blockCache.Head().Head.Number+syncWindowSize < startNumber {
 			time.Sleep(500 * time.Millisecond)
 		}
-		for i := startNumber; i < startNumber+maxBlockHashQueryNumber; i++ {
-			sy.reqMap.Store(i, true)
+		end := startNumber + maxBlockHeadQueryNumber - 1
+		if end > endNumber {
+			end = endNumber
 		}
-		sy.queryBlockHash(&msgpb.BlockHashQuery{ReqType: 0, Start: startNumber, End: startNumber + maxBlockHashQueryNumber - 1, Nums: nil})
-		startNumber += maxBlockHashQueryNumber
-	}
-	if startNumber <= endNumber {
-		for i := startNumber; i <= endNumber; i++ {
-			sy.reqMap.Store(i, true)
+		// the pending numbers are retried with the peer of the head query
+		peerID := sy.headPeer(end)
+		for i := startNumber; i <= end; i++ {
+			sy.reqMap.Store(i, peerID)
 		}
-		sy.queryBlockHash(&msgpb.BlockHashQuery{ReqType: 0, Start: startNumber, End: endNumber, Nums: nil})
+		sy.queryBlockHead(startNumber, end, peerID)
+		startNumber = end + 1
 	}
 	return nil
 }
@@ -315,6 +387,22 @@ func (sy *SyncImpl) messageLoop() {
 					break
 				}
 				go sy.handleBlockQuery(&rh, req.From())
+			case p2p.SyncBlockHeadRequest:
+				var rh msgpb.BlockHeadQuery
+				err := proto.Unmarshal(req.Data(), &rh)
+				if err != nil {
+					ilog.Errorf("unmarshal BlockHeadQuery failed:%v", err)
+					break
+				}
+				go sy.handleHeadQuery(&rh, req.From())
+			case p2p.SyncBlockHeadResponse:
+				var rh msgpb.BlockHeadResponse
+				err := proto.Unmarshal(req.Data(), &rh)
+				if err != nil {
+					ilog.Errorf("unmarshal BlockHeadResponse failed:%v", err)
+					break
+				}
+				go sy.handleHeadResp(&rh, req.From())
 			}
 		case <-sy.exitSignal:
 			return
@@ -421,27 +509,144 @@ func (sy *SyncImpl) handleHashResp(rh *msgpb.BlockHashResponse, peerID p2p.PeerI
 	}
 }
 
+func (sy *SyncImpl) handleHeadQuery(rh *msgpb.BlockHeadQuery, peerID p2p.PeerID) {
+	if rh.End < rh.Start || rh.Start < 0 || rh.End-rh.Start >= maxBlockHeadQueryNumber {
+		return
+	}
+	resp := &msgpb.BlockHeadResponse{
+		BlockHeads: make([][]byte, 0, rh.End-rh.Start+1),
+	}
+	for i := rh.Start; i <= rh.End; i++ {
+		blk, err := sy.blockCache.GetBlockByNumber(i)
+		if err != nil {
+			blk, err = sy.baseVariable.BlockChain().GetBlockHeadByNumber(i)
+			if err != nil {
+				break
+			}
+		}
+		b, err := blk.EncodeM()
+		if err != nil {
+			ilog.Errorf("Fail to encode block head: %v, err=%v", i, err)
+			break
+		}
+		resp.BlockHeads = append(resp.BlockHeads, b)
+	}
+	if len(resp.BlockHeads) == 0 {
+		return
+	}
+	bytes, err := proto.Marshal(resp)
+	if err != nil {
+		ilog.Errorf("marshal BlockHeadResponse failed: err=%v", err)
+		return
+	}
+	sy.p2pService.SendToPeer(peerID, bytes, p2p.SyncBlockHeadResponse, p2p.NormalMessage)
+}
+
+// verifyBlockHeads verifies the links, the signatures and the witness schedule of the block heads following parent,
+// and returns the number of the leading heads passed. The schedule in force at a height is the active list of its
+// parent, which is replaced by the pending list on a rotation, so a head matching only the pending list moves the
+// schedule to it.
+func verifyBlockHeads(blks []*block.Block, parent *blockcache.BlockCacheNode) int {
+	active, pending := parent.Active(), parent.Pending()
+	for i, blk := range blks {
+		if i > 0 {
+			if err := cverifier.VerifyBlockHeadChain(blks[i-1 : i+1]); err != nil {
+				ilog.Warnf("verify block head chain failed. err=%v, number=%v", err, blk.Head.Number)
+				return i
+			}
+		}
+		if err := cverifier.VerifyBlockSign(blk.Head, blk.Sign); err != nil {
+			ilog.Warnf("verify block head sign failed. err=%v, number=%v", err, blk.Head.Number)
+			return i
+		}
+		if err := cverifier.VerifyBlockWitness(blk.Head, active); err != nil {
+			if cverifier.VerifyBlockWitness(blk.Head, pending) != nil {
+				ilog.Warnf("verify block head witness failed. err=%v, number=%v", err, blk.Head.Number)
+				return i
+			}
+			active = pending
+		}
+	}
+	return len(blks)
+}
+
+// handleHeadResp verifies the received block heads and creates download missions of their bodies.
+// The heads are split into disjoint ranges over the peers which have them, so the bodies are downloaded in parallel.
+// The heads which can't be verified are synced by their hashes instead, and the full blocks are verified on arrival.
+func (sy *SyncImpl) handleHeadResp(rh *msgpb.BlockHeadResponse, peerID p2p.PeerID) {
+	ilog.Debugf("receive block heads: len=%v", len(rh.BlockHeads))
+	blks := make([]*block.Block, 0, len(rh.BlockHeads))
+	for _, b := range rh.BlockHeads {
+		var blk block.Block
+		if err := blk.Decode(b); err != nil {
+			ilog.Warnf("decode block head failed. err=%v, peer=%s", err, peerID.Pretty())
+			return
+		}
+		blks = append(blks, &blk)
+	}
+	if len(blks) == 0 {
+		return
+	}
+	parent, err := sy.blockCache.Find(blks[0].Head.ParentHash)
+	if err != nil {
+		parent = sy.blockCache.Head()
+	}
+	n := verifyBlockHeads(blks, parent)
+	if n < len(blks) {
+		ilog.Infof("sync block %v - %v by hash, peer=%s", blks[n].Head.Number, blks[len(blks)-1].Head.Number, peerID.Pretty())
+		sy.queryBlockHash(&msgpb.BlockHashQuery{
+			ReqType: msgpb.RequireType_GETBLOCKHASHES,
+			Start:   blks[n].Head.Number,
+			End:     blks[len(blks)-1].Head.Number,
+			Nums:    nil,
+		})
+		blks = blks[:n]
+	}
+	if len(blks) == 0 {
+		return
+	}
+
+	peers := sy.peersAbove(blks[len(blks)-1].Head.Number)
+	if len(peers) == 0 {
+		peers = append(peers, peerID)
+	}
+	size := (len(blks) + len(peers) - 1) / len(peers)
+	for i, blk := range blks {
+		if blk.Head.Number > sy.blockCache.LinkedRoot().Head.Number {
+			sy.dc.CreateMission(string(blk.HeadHash()), blk.Head.Number, peers[i/size])
+		}
+		sy.reqMap.Delete(blk.Head.Number)
+	}
+}
+
+// retryDownloadLoop queries the hashes of the pending numbers again, from the peer of their head query if it still has
+// them, or from all the peers otherwise.
 func (sy *SyncImpl) retryDownloadLoop() {
 	defer sy.wg.Done()
 	for {
 		select {
 		case <-time.After(retryTime):
-			hq := &msgpb.BlockHashQuery{ReqType: 1, Start: 0, End: 0, Nums: make([]int64, 0)}
+			nums := make(map[p2p.PeerID][]int64)
 			sy.reqMap.Range(func(k, v interface{}) bool {
 				num, ok := k.(int64)
 				if !ok {
 					sy.reqMap.Delete(k)
 					return true
 				}
-				hq.Nums = append(hq.Nums, num)
+				peerID, _ := v.(p2p.PeerID)
+				nums[peerID] = append(nums[peerID], num)
 				return true
 			})
-			if len(hq.Nums) > 0 {
-				//ilog.Info("retry download ", hq.Nums)
-				sort.Slice(hq.Nums, func(i int, j int) bool {
-					return hq.Nums[i] < hq.Nums[j]
+			for peerID, ns := range nums {
+				sort.Slice(ns, func(i int, j int) bool {
+					return ns[i] < ns[j]
 				})
-				sy.queryBlockHash(hq)
+				hq := &msgpb.BlockHashQuery{ReqType: msgpb.RequireType_GETBLOCKHASHESBYNUMBER, Start: 0, End: 0, Nums: ns}
+				if peerID != "" && sy.hasPeer(peerID, ns[len(ns)-1]) {
+					sy.sendBlockHashQuery(peerID, hq)
+				} else {
+					sy.queryBlockHash(hq)
+				}
 			}
 		case <-sy.exitSignal:
 			return
@@ -5,9 +5,12 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/iost-official/go-iost/account"
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/consensus/genesis"
+	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/crypto"
 	"github.com/iost-official/go-iost/core/global"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/p2p"
@@ -102,3 +105,73 @@ func TestSynchronizer(t *testing.T) {
 		blockcache.CleanBlockCacheWAL()
 	})
 }
+
+func TestVerifyBlockHeads(t *testing.T) {
+	Convey("Test of verify block heads", t, func() {
+		acc, err := account.NewKeyPair(nil, crypto.Ed25519)
+		So(err, ShouldBeNil)
+		forger, err := account.NewKeyPair(nil, crypto.Ed25519)
+		So(err, ShouldBeNil)
+		witness := acc.ReadablePubkey()
+
+		parent := blockcache.NewBCN(nil, &block.Block{Head: &block.BlockHead{Number: 0}})
+		parent.CalculateHeadHash()
+		parent.SetActive([]string{witness})
+		sign := func(blk *block.Block, kp *account.KeyPair) {
+			blk.CalculateHeadHash()
+			blk.Sign = kp.Sign(blk.HeadHash())
+		}
+		blks := make([]*block.Block, 0)
+		parentHash := parent.HeadHash()
+		for i := int64(1); i <= 3; i++ {
+			blk := &block.Block{
+				Head: &block.BlockHead{
+					ParentHash: parentHash,
+					Number:     i,
+					Witness:    witness,
+					Time:       i * common.SlotLength * 1e9,
+				},
+			}
+			sign(blk, acc)
+			parentHash = blk.HeadHash()
+			blks = append(blks, blk)
+		}
+
+		Convey("Pass", func() {
+			So(verifyBlockHeads(blks, parent), ShouldEqual, 3)
+		})
+
+		Convey("Pass with the pending witness list", func() {
+			parent.SetActive([]string{forger.ReadablePubkey()})
+			parent.SetPending([]string{witness})
+			So(verifyBlockHeads(blks, parent), ShouldEqual, 3)
+		})
+
+		Convey("Broken parent link", func() {
+			blks[2].Head.ParentHash = blks[0].HeadHash()
+			sign(blks[2], acc)
+			So(verifyBlockHeads(blks, parent), ShouldEqual, 2)
+
+			blks[2].Head.ParentHash = blks[1].HeadHash()
+			blks[2].Head.Number = 4
+			sign(blks[2], acc)
+			So(verifyBlockHeads(blks, parent), ShouldEqual, 2)
+		})
+
+		Convey("Forged witness", func() {
+			// signed by a key out of the schedule
+			blks[1].Head.Witness = forger.ReadablePubkey()
+			sign(blks[1], forger)
+			blks[2].Head.ParentHash = blks[1].HeadHash()
+			sign(blks[2], acc)
+			So(verifyBlockHeads(blks, parent), ShouldEqual, 1)
+
+			// claims the scheduled witness without its key
+			blks[1].Head.Witness = witness
+			sign(blks[1], forger)
+			blks[2].Head.ParentHash = blks[1].HeadHash()
+			sign(blks[2], acc)
+			So(verifyBlockHeads(blks, parent), ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,242 @@
+// Package contracttest runs contracts in process for testing, without iserver or network.
+//
+// A Harness executes the genesis block of a fresh chain in an in-memory state db, then lets the tests
+// create accounts, deploy contracts, send txs, advance the block time and check receipts, balances and storage.
+package contracttest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/genesis"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/verifier"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
+)
+
+// Default settings of the harness
+const (
+	AdminID          = "admin"
+	FoundationID     = "foundation"
+	WitnessID        = "producer01"
+	InitialTimestamp = "2018-11-10T11:04:05Z"
+	AdminBalance     = 21000000000
+	DefaultGasLimit  = 100000000
+)
+
+const adminRAM = 1000000
+
+// Unlimited is the amount limit of a tx which can spend any token
+var Unlimited = []*contract.Amount{{Token: "*", Val: "unlimited"}}
+
+// Config is the config of the genesis block of a harness
+type Config struct {
+	ContractPath     string // directory of the genesis contracts, config/genesis/contract of the source tree
+	InitialTimestamp string // RFC3339 time of the genesis block, InitialTimestamp if empty
+	AdminBalance     int64  // IOSTs issued to the admin account, AdminBalance if 0
+}
+
+// Account is an account created by the harness, with its key pair used to sign txs
+type Account struct {
+	ID      string
+	KeyPair *account.KeyPair
+}
+
+// Harness is an in-process chain for contract tests
+type Harness struct {
+	Simulator *verifier.Simulator
+	Admin     *Account
+	Genesis   *block.Block
+}
+
+// New returns a harness with the genesis state of conf, the genesis contracts should be given in conf
+func New(conf *Config) (*Harness, error) {
+	if conf == nil || conf.ContractPath == "" {
+		return nil, errors.New("path of the genesis contracts is not given")
+	}
+	if conf.InitialTimestamp == "" {
+		conf.InitialTimestamp = InitialTimestamp
+	}
+	if conf.AdminBalance == 0 {
+		conf.AdminBalance = AdminBalance
+	}
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	if err != nil {
+		return nil, err
+	}
+	admin := &Account{ID: AdminID, KeyPair: kp}
+	pubkey := kp.ReadablePubkey()
+
+	mvccdb, err := db.NewMVCCDBWithStorage("", kv.MemoryStorage)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := genesis.GenGenesis(mvccdb, &common.GenesisConfig{
+		InitialTimestamp: conf.InitialTimestamp,
+		TokenInfo: &common.TokenInfo{
+			FoundationAccount: FoundationID,
+			IOSTTotalSupply:   90000000000,
+			IOSTDecimal:       8,
+		},
+		WitnessInfo: []*common.Witness{
+			{ID: WitnessID, Owner: pubkey, Active: pubkey, SignatureBlock: pubkey},
+		},
+		AdminInfo:      &common.Witness{ID: AdminID, Owner: pubkey, Active: pubkey, Balance: conf.AdminBalance},
+		FoundationInfo: &common.Witness{ID: FoundationID, Owner: pubkey, Active: pubkey},
+		ContractPath:   conf.ContractPath,
+	})
+	if err != nil {
+		mvccdb.Close()
+		return nil, err
+	}
+
+	logger := ilog.New()
+	logger.Stop()
+	h := &Harness{
+		Simulator: &verifier.Simulator{
+			Visitor:  database.NewVisitor(0, mvccdb),
+			Verifier: &verifier.Verifier{},
+			Mvcc:     mvccdb,
+			Head: &block.BlockHead{
+				ParentHash: blk.HeadHash(),
+				Number:     1,
+				Witness:    WitnessID,
+				Time:       blk.Head.Time + common.SlotLength*int64(time.Second),
+			},
+			Logger:   logger,
+			GasLimit: DefaultGasLimit,
+		},
+		Admin:   admin,
+		Genesis: blk,
+	}
+	// the admin pays ram for the accounts and contracts created in tests
+	r, err := h.Call(admin, "ram.iost", "buy", fmt.Sprintf(`["%v", "%v", %v]`, admin.ID, admin.ID, adminRAM), Unlimited)
+	if err == nil && r.Status.Code != tx.Success {
+		err = fmt.Errorf("buy ram for admin failed: %v", r.Status.Message)
+	}
+	if err != nil {
+		mvccdb.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+// Close releases the state db of the harness
+func (h *Harness) Close() error {
+	return h.Simulator.Mvcc.Close()
+}
+
+// Now returns the time of the current block
+func (h *Harness) Now() time.Time {
+	return time.Unix(0, h.Simulator.Head.Time)
+}
+
+// NextBlock moves to the next block, one slot after the current one
+func (h *Harness) NextBlock() {
+	h.AdvanceTime(common.SlotLength * time.Second)
+}
+
+// AdvanceTime moves to a block d after the current one, the block number is increased by the slots passed
+func (h *Harness) AdvanceTime(d time.Duration) {
+	slots := int64(d / (common.SlotLength * time.Second))
+	if slots < 1 {
+		slots = 1
+	}
+	head := *h.Simulator.Head
+	head.ParentHash = common.Sha3(h.Simulator.Head.ParentHash)
+	head.Number += slots
+	head.Time += int64(d)
+	h.Simulator.Head = &head
+}
+
+// CreateAccount signs up a new account by admin, pledges gasPledge IOSTs for gas (at least 10),
+// buys ram bytes of ram and transfers balance IOSTs to it.
+func (h *Harness) CreateAccount(id string, gasPledge int64, ram int64, balance int64) (*Account, error) {
+	kp, err := account.NewKeyPair(nil, crypto.Ed25519)
+	if err != nil {
+		return nil, err
+	}
+	pubkey := kp.ReadablePubkey()
+	acts := []*tx.Action{
+		tx.NewAction("auth.iost", "signUp", fmt.Sprintf(`["%v", "%v", "%v"]`, id, pubkey, pubkey)),
+	}
+	if ram > 0 {
+		acts = append(acts, tx.NewAction("ram.iost", "buy", fmt.Sprintf(`["%v", "%v", %v]`, h.Admin.ID, id, ram)))
+	}
+	// signUp pledges 10 IOSTs for the new account
+	if gasPledge > 10 {
+		acts = append(acts, tx.NewAction("gas.iost", "pledge", fmt.Sprintf(`["%v", "%v", "%v"]`, h.Admin.ID, id, gasPledge-10)))
+	}
+	if balance > 0 {
+		acts = append(acts, tx.NewAction("token.iost", "transfer", fmt.Sprintf(`["iost", "%v", "%v", "%v", ""]`, h.Admin.ID, id, balance)))
+	}
+	r, err := h.Send(h.Admin, Unlimited, acts...)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status.Code != tx.Success {
+		return nil, fmt.Errorf("create account %v failed: %v", id, r.Status.Message)
+	}
+	return &Account{ID: id, KeyPair: kp}, nil
+}
+
+// Deploy deploys the contract of the js file and the abi file by publisher, returns the contract id
+func (h *Harness) Deploy(publisher *Account, jsPath string, abiPath string) (string, *tx.TxReceipt, error) {
+	c, err := contract.Compile("", jsPath, abiPath)
+	if err != nil {
+		return "", nil, err
+	}
+	return h.Simulator.DeployContract(c, publisher.ID, publisher.KeyPair)
+}
+
+// Call sends a tx of one action signed by signer, args is the json array of the arguments
+func (h *Harness) Call(signer *Account, contractName string, api string, args string, amountLimit []*contract.Amount) (*tx.TxReceipt, error) {
+	return h.Send(signer, amountLimit, tx.NewAction(contractName, api, args))
+}
+
+// Send sends a tx of the actions signed by signer, the tx is executed in the current block.
+// The tx can spend tokens in amountLimit only, Unlimited lets it spend any token.
+// A failed tx is not an error, it's reported in the status of the receipt.
+func (h *Harness) Send(signer *Account, amountLimit []*contract.Amount, acts ...*tx.Action) (*tx.TxReceipt, error) {
+	trx := tx.NewTx(acts, nil, h.Simulator.GasLimit, 100, h.Simulator.Head.Time+int64(time.Minute), 0)
+	trx.Time = h.Simulator.Head.Time
+	trx.AmountLimit = amountLimit
+	return h.Simulator.CallTx(trx, signer.ID, signer.KeyPair)
+}
+
+// Balance returns the balance of the token of acc
+func (h *Harness) Balance(token string, acc string) string {
+	return h.Simulator.Visitor.TokenBalanceFixed(token, acc).ToString()
+}
+
+// Storage returns the value of the key in the storage of contractName, or the field of the map if field is not empty.
+// Strings are returned as is, other values are returned in json.
+func (h *Harness) Storage(contractName string, key string, field string) string {
+	hst := host.NewHost(host.NewContext(nil), h.Simulator.Visitor, nil, nil)
+	var value interface{}
+	if field == "" {
+		value, _ = hst.GlobalGet(contractName, key)
+	} else {
+		value, _ = hst.GlobalMapGet(contractName, key, field)
+	}
+	if value != nil && reflect.TypeOf(value).Kind() == reflect.String {
+		return value.(string)
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
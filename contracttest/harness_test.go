@@ -0,0 +1,83 @@
+package contracttest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/core/tx"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var testConfig = &Config{ContractPath: "../config/genesis/contract"}
+
+func TestHarness(t *testing.T) {
+	Convey("test of contract harness", t, func() {
+		h, err := New(testConfig)
+		So(err, ShouldBeNil)
+		defer h.Close()
+
+		So(h.Balance("iost", AdminID), ShouldNotEqual, "0")
+		alice, err := h.CreateAccount("alice", 100, 10000, 1000)
+		So(err, ShouldBeNil)
+		So(h.Balance("iost", "alice"), ShouldEqual, "1000")
+
+		cname, r, err := h.Deploy(alice, "./test_data/counter.js", "./test_data/counter.js.abi")
+		So(err, ShouldBeNil)
+		So(r.Status.Code, ShouldEqual, tx.Success)
+		So(h.Storage(cname, "count", ""), ShouldEqual, "0")
+
+		Convey("call and advance time", func() {
+			r, err := h.Call(alice, cname, "add", `[3]`, nil)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(h.Storage(cname, "count", ""), ShouldEqual, "3")
+			t0 := h.Now()
+
+			h.AdvanceTime(time.Hour)
+			So(h.Now().Sub(t0), ShouldEqual, time.Hour)
+			r, err = h.Call(alice, cname, "add", `[4]`, nil)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(h.Storage(cname, "count", ""), ShouldEqual, "7")
+			So(h.Storage(cname, "last", "alice"), ShouldEqual, fmt.Sprint(h.Now().UnixNano()))
+		})
+
+		Convey("failed call", func() {
+			r, err := h.Call(alice, cname, "pay", `["alice", "10000"]`, Unlimited)
+			So(err, ShouldBeNil)
+			So(r.Status.Code, ShouldNotEqual, tx.Success)
+			So(h.Balance("iost", "alice"), ShouldEqual, "1000")
+		})
+
+		Convey("call with amount limit", func() {
+			r, err := h.Call(alice, cname, "pay", `["alice", "10"]`, nil)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "exceed amountLimit")
+			So(h.Balance("iost", "alice"), ShouldEqual, "1000")
+
+			r, err = h.Call(alice, cname, "pay", `["alice", "10"]`, []*contract.Amount{{Token: "iost", Val: "10"}})
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(h.Balance("iost", "alice"), ShouldEqual, "990")
+		})
+	})
+}
+
+func TestSpec(t *testing.T) {
+	Convey("test of spec", t, func() {
+		spec, err := LoadSpec("./test_data/counter_test.json")
+		So(err, ShouldBeNil)
+		h, err := New(testConfig)
+		So(err, ShouldBeNil)
+		defer h.Close()
+
+		results, err := spec.Run(h, "./test_data")
+		So(err, ShouldBeNil)
+		So(len(results), ShouldEqual, len(spec.Steps))
+		for _, r := range results {
+			So(r.Err, ShouldBeNil)
+		}
+	})
+}
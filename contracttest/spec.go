@@ -0,0 +1,236 @@
+package contracttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/core/tx"
+)
+
+// Spec is a contract test written in json, it's run by `iwallet test`
+type Spec struct {
+	Accounts  []*AccountSpec  `json:"accounts"`
+	Contracts []*ContractSpec `json:"contracts"`
+	Steps     []*Step         `json:"steps"`
+}
+
+// AccountSpec is an account created before the steps
+type AccountSpec struct {
+	ID        string `json:"id"`
+	GasPledge int64  `json:"gas_pledge"`
+	RAM       int64  `json:"ram"`
+	Balance   int64  `json:"balance"`
+}
+
+// ContractSpec is a contract deployed before the steps, the paths are relative to the spec file.
+// Name is used in the steps instead of the contract id, "@name" in the arguments and the balance checks is replaced with the contract id.
+type ContractSpec struct {
+	Name      string `json:"name"`
+	Code      string `json:"code"`
+	ABI       string `json:"abi"`
+	Publisher string `json:"publisher"`
+}
+
+// Step is a step of a spec. It advances the block time, calls a contract, then checks the receipt,
+// the balances and the storage, each part is skipped if it's empty.
+// The call can spend the tokens in AmountLimit only.
+type Step struct {
+	Name        string             `json:"name"`
+	Advance     string             `json:"advance"`
+	Signer      string             `json:"signer"`
+	Contract    string             `json:"contract"`
+	API         string             `json:"api"`
+	Args        []interface{}      `json:"args"`
+	AmountLimit []*contract.Amount `json:"amount_limit"`
+	Expect      *Expect            `json:"expect"`
+	Balances    []*BalanceCheck    `json:"balances"`
+	Storage     []*StorageCheck    `json:"storage"`
+}
+
+// Expect is the expected receipt of a call, the call should fail with a message containing Error if Error is not empty
+type Expect struct {
+	Error   string   `json:"error"`
+	Returns []string `json:"returns"`
+}
+
+// BalanceCheck checks the token balance of an account
+type BalanceCheck struct {
+	Token   string `json:"token"`
+	Account string `json:"account"`
+	Value   string `json:"value"`
+}
+
+// StorageCheck checks a key, or a field of a map, in the storage of a contract
+type StorageCheck struct {
+	Contract string `json:"contract"`
+	Key      string `json:"key"`
+	Field    string `json:"field"`
+	Value    string `json:"value"`
+}
+
+// StepResult is the result of a step, Err is nil if the step passes
+type StepResult struct {
+	Step    *Step
+	Receipt *tx.TxReceipt
+	Err     error
+}
+
+// LoadSpec reads the spec file
+func LoadSpec(path string) (*Spec, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err := json.Unmarshal(b, spec); err != nil {
+		return nil, fmt.Errorf("invalid spec %v: %v", path, err)
+	}
+	return spec, nil
+}
+
+type specRunner struct {
+	h         *Harness
+	accounts  map[string]*Account
+	contracts map[string]string
+}
+
+// Run creates the accounts and the contracts of spec in h, then runs the steps until one fails.
+// dir is the directory of the contract files. The error is returned if the accounts or the contracts can't be created.
+func (spec *Spec) Run(h *Harness, dir string) ([]*StepResult, error) {
+	r := &specRunner{
+		h:         h,
+		accounts:  map[string]*Account{h.Admin.ID: h.Admin},
+		contracts: make(map[string]string),
+	}
+	for _, a := range spec.Accounts {
+		acc, err := h.CreateAccount(a.ID, a.GasPledge, a.RAM, a.Balance)
+		if err != nil {
+			return nil, err
+		}
+		r.accounts[a.ID] = acc
+	}
+	for _, c := range spec.Contracts {
+		publisher, err := r.account(c.Publisher)
+		if err != nil {
+			return nil, err
+		}
+		id, _, err := h.Deploy(publisher, filepath.Join(dir, c.Code), filepath.Join(dir, c.ABI))
+		if err != nil {
+			return nil, fmt.Errorf("deploy %v failed: %v", c.Name, err)
+		}
+		r.contracts[c.Name] = id
+	}
+
+	results := make([]*StepResult, 0, len(spec.Steps))
+	for _, s := range spec.Steps {
+		res := r.run(s)
+		results = append(results, res)
+		if res.Err != nil {
+			break
+		}
+	}
+	return results, nil
+}
+
+func (r *specRunner) account(id string) (*Account, error) {
+	if id == "" {
+		return r.h.Admin, nil
+	}
+	acc, ok := r.accounts[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown account %v", id)
+	}
+	return acc, nil
+}
+
+func (r *specRunner) contract(name string) string {
+	if id, ok := r.contracts[name]; ok {
+		return id
+	}
+	return name
+}
+
+func (r *specRunner) resolve(s string) string {
+	if strings.HasPrefix(s, "@") {
+		return r.contract(s[1:])
+	}
+	return s
+}
+
+func (r *specRunner) run(s *Step) *StepResult {
+	res := &StepResult{Step: s}
+	if s.Advance != "" {
+		d, err := time.ParseDuration(s.Advance)
+		if err != nil {
+			res.Err = fmt.Errorf("invalid advance %v: %v", s.Advance, err)
+			return res
+		}
+		r.h.AdvanceTime(d)
+	}
+	if s.Contract != "" {
+		res.Receipt, res.Err = r.call(s)
+		if res.Err != nil {
+			return res
+		}
+	}
+	for _, c := range s.Balances {
+		if v := r.h.Balance(c.Token, r.resolve(c.Account)); v != c.Value {
+			res.Err = fmt.Errorf("balance of %v %v is %v, expected %v", c.Account, c.Token, v, c.Value)
+			return res
+		}
+	}
+	for _, c := range s.Storage {
+		if v := r.h.Storage(r.contract(c.Contract), c.Key, c.Field); v != c.Value {
+			res.Err = fmt.Errorf("storage %v %v %v is %v, expected %v", c.Contract, c.Key, c.Field, v, c.Value)
+			return res
+		}
+	}
+	return res
+}
+
+func (r *specRunner) call(s *Step) (*tx.TxReceipt, error) {
+	signer, err := r.account(s.Signer)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]interface{}, len(s.Args))
+	for i, a := range s.Args {
+		if str, ok := a.(string); ok {
+			a = r.resolve(str)
+		}
+		args[i] = a
+	}
+	b, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := r.h.Call(signer, r.contract(s.Contract), s.API, string(b), s.AmountLimit)
+	if err != nil {
+		return receipt, err
+	}
+	expect := s.Expect
+	if expect == nil {
+		expect = &Expect{}
+	}
+	if expect.Error == "" && receipt.Status.Code != tx.Success {
+		return receipt, fmt.Errorf("call failed: %v", receipt.Status.Message)
+	}
+	if expect.Error != "" {
+		if receipt.Status.Code == tx.Success {
+			return receipt, fmt.Errorf("call succeeded, expected error %v", expect.Error)
+		}
+		if !strings.Contains(receipt.Status.Message, expect.Error) {
+			return receipt, fmt.Errorf("call failed with %v, expected error %v", receipt.Status.Message, expect.Error)
+		}
+	}
+	if expect.Returns != nil && !reflect.DeepEqual(receipt.Returns, expect.Returns) {
+		return receipt, fmt.Errorf("call returns %v, expected %v", receipt.Returns, expect.Returns)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,16 @@
+class Counter {
+    init() {
+        storage.put("count", "0");
+    }
+    add(n) {
+        const count = Number(storage.get("count")) + n;
+        storage.put("count", count.toString());
+        storage.mapPut("last", tx.publisher, block.time.toString());
+        return count.toString();
+    }
+    pay(from, amount) {
+        blockchain.deposit(from, amount, "");
+    }
+}
+
+module.exports = Counter;
@@ -11,6 +11,40 @@ import (
 	"github.com/iost-official/go-iost/crypto"
 )
 
+// The versions of block head.
+const (
+	V0 int64 = iota
+	// V1 adds StateHash to the block head.
+	V1
+	// V2 adds the randomness proof of the witness to Info.
+	V2
+)
+
+// The numbers of the first blocks which must be V1 and V2, 0 means the version is not activated.
+var (
+	V1Height int64
+	V2Height int64
+)
+
+// SetForkConfig sets the heights of the block head versions.
+func SetForkConfig(c *common.ForkConfig) {
+	V1Height, V2Height = 0, 0
+	if c != nil {
+		V1Height, V2Height = c.V1Height, c.V2Height
+	}
+}
+
+// HeadVersion returns the version of the block head required at the number.
+func HeadVersion(number int64) int64 {
+	switch {
+	case V2Height > 0 && number >= V2Height:
+		return V2
+	case V1Height > 0 && number >= V1Height:
+		return V1
+	}
+	return V0
+}
+
 // BlockHead is the struct of block head.
 type BlockHead struct { // nolint
 	Version             int64
@@ -22,6 +56,7 @@ type BlockHead struct { // nolint
 	Witness             string
 	Time                int64
 	GasUsage            int64
+	StateHash           []byte
 }
 
 // ToPb convert BlockHead to proto buf data structure.
@@ -35,6 +70,7 @@ func (b *BlockHead) ToPb() *blockpb.BlockHead {
 		Number:              b.Number,
 		Witness:             b.Witness,
 		Time:                b.Time,
+		StateHash:           b.StateHash,
 	}
 }
 
@@ -49,6 +85,9 @@ func (b *BlockHead) ToBytes() []byte {
 	se.WriteInt64(b.Number)
 	se.WriteString(b.Witness)
 	se.WriteInt64(b.Time)
+	if b.Version >= V1 {
+		se.WriteBytes(b.StateHash)
+	}
 	return se.Bytes()
 }
 
@@ -62,6 +101,7 @@ func (b *BlockHead) FromPb(bh *blockpb.BlockHead) *BlockHead {
 	b.Number = bh.Number
 	b.Witness = bh.Witness
 	b.Time = bh.Time
+	b.StateHash = bh.StateHash
 	return b
 }
 
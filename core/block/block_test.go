@@ -2,11 +2,14 @@ package block
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/crypto/vrf"
 	"github.com/smartystreets/goconvey/convey"
 )
 
@@ -26,6 +29,92 @@ func TestBlockHeadSerialize(t *testing.T) {
 	})
 }
 
+func TestBlockHeadStateHash(t *testing.T) {
+	convey.Convey("Test of block head state hash", t, func() {
+		head := BlockHead{
+			Version:    V0,
+			Number:     1,
+			ParentHash: []byte("parent"),
+		}
+		h0, _ := head.Hash()
+		head.StateHash = []byte("state")
+		h1, _ := head.Hash()
+		convey.So(bytes.Equal(h0, h1), convey.ShouldBeTrue)
+
+		head.Version = V1
+		h2, _ := head.Hash()
+		convey.So(bytes.Equal(h1, h2), convey.ShouldBeFalse)
+		head.StateHash = []byte("other")
+		h3, _ := head.Hash()
+		convey.So(bytes.Equal(h2, h3), convey.ShouldBeFalse)
+
+		b, err := head.Encode()
+		convey.So(err, convey.ShouldBeNil)
+		var headRead BlockHead
+		convey.So(headRead.Decode(b), convey.ShouldBeNil)
+		convey.So(string(headRead.StateHash), convey.ShouldEqual, "other")
+	})
+}
+
+func TestHeadVersion(t *testing.T) {
+	convey.Convey("Test of block head version", t, func() {
+		defer SetForkConfig(nil)
+		convey.So(HeadVersion(100), convey.ShouldEqual, V0)
+		SetForkConfig(&common.ForkConfig{V1Height: 10})
+		convey.So(HeadVersion(9), convey.ShouldEqual, V0)
+		convey.So(HeadVersion(10), convey.ShouldEqual, V1)
+		SetForkConfig(&common.ForkConfig{V1Height: 10, V2Height: 20})
+		convey.So(HeadVersion(19), convey.ShouldEqual, V1)
+		convey.So(HeadVersion(20), convey.ShouldEqual, V2)
+		SetForkConfig(nil)
+		convey.So(HeadVersion(10), convey.ShouldEqual, V0)
+	})
+}
+
+func TestBlockRandom(t *testing.T) {
+	convey.Convey("Test of block random", t, func() {
+		parent := &Block{
+			Head: &BlockHead{
+				Number: 1,
+				Info:   []byte(`{"mode":0}`),
+			},
+		}
+		convey.So(parent.Head.Proof(), convey.ShouldBeNil)
+		convey.So(parent.Head.Random(), convey.ShouldBeNil)
+		seed := RandomSeed(parent)
+
+		witness, err := account.NewKeyPair(nil, crypto.Ed25519)
+		convey.So(err, convey.ShouldBeNil)
+		proof, err := vrf.Prove(witness.Seckey, seed)
+		convey.So(err, convey.ShouldBeNil)
+		info, err := json.Marshal(map[string]interface{}{"mode": 0, "proof": proof})
+		convey.So(err, convey.ShouldBeNil)
+		blk := &Block{
+			Head: &BlockHead{
+				Number: 2,
+				Info:   info,
+			},
+		}
+		convey.So(blk.Head.Proof(), convey.ShouldResemble, proof)
+		random, err := vrf.Verify(witness.Pubkey, seed, proof)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(blk.Head.Random(), convey.ShouldResemble, random)
+		convey.So(RandomSeed(blk), convey.ShouldNotResemble, seed)
+
+		other := &Block{
+			Head: &BlockHead{
+				Number: 2,
+				Info:   []byte(`{"mode":1,"proof":"cHJvb2Y="}`),
+			},
+		}
+		convey.So(other.Head.Random(), convey.ShouldBeNil)
+		info, err = json.Marshal(map[string]interface{}{"mode": 1, "proof": proof})
+		convey.So(err, convey.ShouldBeNil)
+		other.Head.Info = info
+		convey.So(RandomSeed(other), convey.ShouldResemble, RandomSeed(blk))
+	})
+}
+
 func TestBlockSerialize(t *testing.T) {
 	convey.Convey("test Push", t, func() {
 		blk := Block{
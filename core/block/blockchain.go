@@ -18,11 +18,16 @@ type BlockChain struct { //nolint:golint
 	rw           sync.RWMutex
 	length       int64
 	txTotal      int64
+	pruned       int64
 }
 
+// ErrPruned is returned when the requested data has been deleted by pruning.
+var ErrPruned = errors.New("data has been pruned")
+
 var (
 	blockLength       = []byte("BlockLength")
 	blockTxTotal      = []byte("BlockTxTotal")
+	blockPruned       = []byte("BlockPruned") // bodies of blocks in [1, BlockPruned) have been deleted
 	blockNumberPrefix = []byte("n")
 	blockPrefix       = []byte("H")
 	txPrefix          = []byte("t")      // txPrefix + tx hash -> block hash + tx hash
@@ -33,9 +38,14 @@ var (
 	delaytxPrefix     = []byte("delay-") // delaytxPrefix + tx hash -> tx data
 )
 
-// NewBlockChain returns a Chain instance
+// NewBlockChain returns a Chain instance saved in leveldb
 func NewBlockChain(path string) (Chain, error) {
-	levelDB, err := kv.NewStorage(path, kv.LevelDBStorage)
+	return NewBlockChainWithStorage(path, kv.LevelDBStorage)
+}
+
+// NewBlockChainWithStorage returns a Chain instance saved in the storage of type t
+func NewBlockChainWithStorage(path string, t kv.StorageType) (Chain, error) {
+	levelDB, err := kv.NewStorage(path, t)
 	if err != nil {
 		return nil, fmt.Errorf("fail to init blockchaindb, %v", err)
 	}
@@ -66,10 +76,19 @@ func NewBlockChain(path string) (Chain, error) {
 			return nil, errors.New("fail to put tx total")
 		}
 	}
+	var pruned int64
+	prunedByte, err := levelDB.Get(blockPruned)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get block pruned, %v", err)
+	}
+	if len(prunedByte) != 0 {
+		pruned = common.BytesToInt64(prunedByte)
+	}
 	BC := &BlockChain{
 		blockChainDB: levelDB,
 		length:       length,
 		txTotal:      txTotal,
+		pruned:       pruned,
 	}
 	BC.CheckLength()
 	return BC, err
@@ -103,6 +122,69 @@ func (bc *BlockChain) TxTotal() int64 {
 	return bc.txTotal
 }
 
+// PrunedNumber returns the number below which block bodies have been pruned.
+func (bc *BlockChain) PrunedNumber() int64 {
+	bc.rw.RLock()
+	defer bc.rw.RUnlock()
+	return bc.pruned
+}
+
+func (bc *BlockChain) isPruned(number int64) bool {
+	return number > 0 && number < bc.PrunedNumber()
+}
+
+// Prune deletes the txs and receipts of blocks before number, the genesis block is never pruned.
+// Block heads, the number and hash indexes are kept, and so are the txs still referred by delay txs.
+func (bc *BlockChain) Prune(number int64) error {
+	start := bc.PrunedNumber()
+	if start < 1 {
+		start = 1
+	}
+	if number > bc.Length()-1 {
+		number = bc.Length() - 1
+	}
+	if number <= start {
+		return nil
+	}
+	keys := make([][]byte, 0)
+	for i := start; i < number; i++ {
+		blk, err := bc.GetBlockHeadByNumber(i)
+		if err != nil {
+			return fmt.Errorf("fail to get block %v, err:%v", i, err)
+		}
+		hash := blk.HeadHash()
+		for _, tHash := range blk.TxHashes {
+			has, err := bc.blockChainDB.Has(append(delaytxPrefix, tHash...))
+			if err != nil {
+				return fmt.Errorf("fail to check delay tx, err:%v", err)
+			}
+			if has {
+				continue
+			}
+			keys = append(keys, append(bTxPrefix, append(hash, tHash...)...))
+		}
+		for _, rHash := range blk.ReceiptHashes {
+			keys = append(keys, append(bReceiptPrefix, append(hash, rHash...)...))
+		}
+	}
+	err := bc.blockChainDB.BeginBatch()
+	if err != nil {
+		return errors.New("fail to begin batch")
+	}
+	for _, key := range keys {
+		bc.blockChainDB.Delete(key)
+	}
+	bc.blockChainDB.Put(blockPruned, common.Int64ToBytes(number))
+	err = bc.blockChainDB.CommitBatch()
+	if err != nil {
+		return fmt.Errorf("fail to prune blocks, err:%s", err)
+	}
+	bc.rw.Lock()
+	bc.pruned = number
+	bc.rw.Unlock()
+	return nil
+}
+
 // Push save the block to database
 func (bc *BlockChain) Push(block *Block) error {
 	err := bc.blockChainDB.BeginBatch()
@@ -154,6 +236,59 @@ func (bc *BlockChain) Push(block *Block) error {
 	return nil
 }
 
+// Truncate deletes the blocks from number length to the top, it is used to repair the database.
+// Delay txs recorded by the deleted blocks are kept.
+func (bc *BlockChain) Truncate(length int64) error {
+	if length < 0 || length >= bc.Length() {
+		return nil
+	}
+	txTotal := bc.TxTotal()
+	keys := make([][]byte, 0)
+	for i := length; i < bc.Length(); i++ {
+		numberKey := append(blockNumberPrefix, common.Int64ToBytes(i)...)
+		keys = append(keys, numberKey)
+		hash, err := bc.blockChainDB.Get(numberKey)
+		if err != nil || len(hash) == 0 {
+			continue
+		}
+		keys = append(keys, append(blockPrefix, hash...))
+		blockByte, err := bc.getBlockByteByHash(hash)
+		if err != nil {
+			continue
+		}
+		var blk Block
+		if err := blk.Decode(blockByte); err != nil {
+			continue
+		}
+		txTotal -= int64(len(blk.TxHashes))
+		for _, tHash := range blk.TxHashes {
+			keys = append(keys, append(txPrefix, tHash...))
+			keys = append(keys, append(bTxPrefix, append(hash, tHash...)...))
+			keys = append(keys, append(txReceiptPrefix, tHash...))
+		}
+		for _, rHash := range blk.ReceiptHashes {
+			keys = append(keys, append(receiptPrefix, rHash...))
+			keys = append(keys, append(bReceiptPrefix, append(hash, rHash...)...))
+		}
+	}
+	err := bc.blockChainDB.BeginBatch()
+	if err != nil {
+		return errors.New("fail to begin batch")
+	}
+	for _, key := range keys {
+		bc.blockChainDB.Delete(key)
+	}
+	bc.blockChainDB.Put(blockLength, common.Int64ToBytes(length))
+	bc.blockChainDB.Put(blockTxTotal, common.Int64ToBytes(txTotal))
+	err = bc.blockChainDB.CommitBatch()
+	if err != nil {
+		return fmt.Errorf("fail to truncate blocks, err:%s", err)
+	}
+	bc.SetLength(length)
+	bc.SetTxTotal(txTotal)
+	return nil
+}
+
 // CheckLength is check length of block in database
 func (bc *BlockChain) CheckLength() {
 	for i := bc.Length(); i > 0; i-- {
@@ -205,6 +340,9 @@ func (bc *BlockChain) GetBlockByHash(hash []byte) (*Block, error) {
 	if err != nil {
 		return nil, errors.New("fail to decode blockByte")
 	}
+	if bc.isPruned(blk.Head.Number) {
+		return nil, ErrPruned
+	}
 	if blk.TxHashes != nil {
 		blk.Txs = make([]*tx.Tx, len(blk.TxHashes))
 		txsMap, err := bc.getBlockTxsMap(hash)
@@ -236,6 +374,30 @@ func (bc *BlockChain) GetBlockByHash(hash []byte) (*Block, error) {
 	return &blk, nil
 }
 
+// GetBlockHeadByNumber gets the block by number without txs and receipts, only their hashes are filled.
+func (bc *BlockChain) GetBlockHeadByNumber(number int64) (*Block, error) {
+	hash, err := bc.GetHashByNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return bc.GetBlockHeadByHash(hash)
+}
+
+// GetBlockHeadByHash gets the block by hash without txs and receipts, only their hashes are filled.
+// Heads of pruned blocks are kept, so it works for them.
+func (bc *BlockChain) GetBlockHeadByHash(hash []byte) (*Block, error) {
+	blockByte, err := bc.getBlockByteByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	var blk Block
+	err = blk.Decode(blockByte)
+	if err != nil {
+		return nil, errors.New("fail to decode blockByte")
+	}
+	return &blk, nil
+}
+
 // GetBlockByNumber is get block by number
 func (bc *BlockChain) GetBlockByNumber(number int64) (*Block, error) {
 	hash, err := bc.GetHashByNumber(number)
@@ -297,6 +459,9 @@ func (bc *BlockChain) GetTx(hash []byte) (*tx.Tx, error) {
 		return nil, fmt.Errorf("failed to Get the tx: %v", err)
 	}
 	if len(txData) == 0 {
+		if len(bTx) != 0 {
+			return nil, ErrPruned
+		}
 		return nil, fmt.Errorf("failed to Get the tx: not found")
 	}
 	err = tx.Decode(txData)
@@ -306,6 +471,18 @@ func (bc *BlockChain) GetTx(hash []byte) (*tx.Tx, error) {
 	return &tx, nil
 }
 
+// GetBlockHashByTxHash gets the hash of the block containing the tx.
+func (bc *BlockChain) GetBlockHashByTxHash(hash []byte) ([]byte, error) {
+	bTx, err := bc.blockChainDB.Get(append(txPrefix, hash...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to Get the tx: %v", err)
+	}
+	if len(bTx) <= len(hash) {
+		return nil, fmt.Errorf("failed to Get the tx: not found")
+	}
+	return bTx[:len(bTx)-len(hash)], nil
+}
+
 // HasTx checks if database has tx.
 func (bc *BlockChain) HasTx(hash []byte) (bool, error) {
 	return bc.blockChainDB.Has(append(txPrefix, hash...))
@@ -322,6 +499,9 @@ func (bc *BlockChain) GetReceipt(hash []byte) (*tx.TxReceipt, error) {
 		return nil, fmt.Errorf("failed to Get the receipt: %v", err)
 	}
 	if len(reData) == 0 {
+		if len(bReHash) != 0 {
+			return nil, ErrPruned
+		}
 		return nil, fmt.Errorf("failed to Get the receipt: not found")
 	}
 	re := tx.TxReceipt{}
@@ -343,6 +523,9 @@ func (bc *BlockChain) GetReceiptByTxHash(hash []byte) (*tx.TxReceipt, error) {
 		return nil, fmt.Errorf("failed to Get the receipt: %v", err)
 	}
 	if len(reData) == 0 {
+		if len(bReHash) != 0 {
+			return nil, ErrPruned
+		}
 		return nil, fmt.Errorf("failed to Get the receipt: not found")
 	}
 	re := tx.TxReceipt{}
@@ -71,6 +71,12 @@ func TestChainImpl(t *testing.T) {
 		So(string(block.Head.Witness), ShouldEqual, string(tBlock.Head.Witness))
 		So(string(block.Head.Time), ShouldEqual, string(tBlock.Head.Time))
 
+		block, err = bc.GetBlockHeadByNumber(bc.Length() - 1)
+		So(err, ShouldBeNil)
+		So(block.Head.Number, ShouldEqual, tBlock.Head.Number)
+		So(string(block.HeadHash()), ShouldEqual, string(tBlock.HeadHash()))
+		So(len(block.Txs), ShouldEqual, 0)
+
 		HeadHash := tBlock.HeadHash()
 		block, err = bc.GetBlockByHash(HeadHash)
 		So(err, ShouldBeNil)
@@ -86,6 +92,129 @@ func TestChainImpl(t *testing.T) {
 	})
 }
 
+func TestPrune(t *testing.T) {
+	Convey("test Prune", t, func() {
+		bc, err := NewBlockChain("./BlockChainDB/")
+		So(err, ShouldBeNil)
+		actions := []*tx.Action{{
+			Contract:   "contract1",
+			ActionName: "actionname1",
+			Data:       "[]",
+		}}
+		txs := make([]*tx.Tx, 0)
+		for i := 0; i < 4; i++ {
+			var delay int64
+			if i == 2 {
+				delay = 1000
+			}
+			txn := tx.NewTx(actions, nil, 100000, 100, int64(i), delay)
+			txs = append(txs, txn)
+			tBlock := &Block{
+				Head: &BlockHead{
+					Version: 2,
+					Number:  int64(i),
+					Time:    int64(i),
+				},
+				Sign:     &crypto.Signature{},
+				Txs:      []*tx.Tx{txn},
+				Receipts: []*tx.TxReceipt{tx.NewTxReceipt(txn.Hash())},
+			}
+			tBlock.CalculateHeadHash()
+			So(bc.Push(tBlock), ShouldBeNil)
+		}
+
+		So(bc.Prune(3), ShouldBeNil)
+		So(bc.PrunedNumber(), ShouldEqual, 3)
+
+		_, err = bc.GetBlockByNumber(0)
+		So(err, ShouldBeNil)
+		_, err = bc.GetBlockByNumber(1)
+		So(err, ShouldEqual, ErrPruned)
+		blk, err := bc.GetBlockHeadByNumber(1)
+		So(err, ShouldBeNil)
+		So(blk.Head.Number, ShouldEqual, 1)
+		_, err = bc.GetBlockByHash(blk.HeadHash())
+		So(err, ShouldEqual, ErrPruned)
+		head, err := bc.GetBlockHeadByHash(blk.HeadHash())
+		So(err, ShouldBeNil)
+		So(head.Head.Number, ShouldEqual, 1)
+		So(len(head.Txs), ShouldEqual, 0)
+		hash, err := bc.GetBlockHashByTxHash(txs[1].Hash())
+		So(err, ShouldBeNil)
+		So(hash, ShouldResemble, blk.HeadHash())
+		blk, err = bc.GetBlockByNumber(3)
+		So(err, ShouldBeNil)
+		So(len(blk.Txs), ShouldEqual, 1)
+
+		_, err = bc.GetTx(txs[1].Hash())
+		So(err, ShouldEqual, ErrPruned)
+		_, err = bc.GetReceiptByTxHash(txs[1].Hash())
+		So(err, ShouldEqual, ErrPruned)
+		has, err := bc.HasTx(txs[1].Hash())
+		So(err, ShouldBeNil)
+		So(has, ShouldBeTrue)
+
+		// the delay tx is kept for its defer tx
+		_, err = bc.GetTx(txs[2].Hash())
+		So(err, ShouldBeNil)
+		_, err = bc.GetTx(txs[3].Hash())
+		So(err, ShouldBeNil)
+
+		bc.Close()
+		bc, err = NewBlockChain("./BlockChainDB/")
+		So(err, ShouldBeNil)
+		So(bc.PrunedNumber(), ShouldEqual, 3)
+		bc.Close()
+		os.RemoveAll("./BlockChainDB/")
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	Convey("test Truncate", t, func() {
+		bc, err := NewBlockChain("./BlockChainDB/")
+		So(err, ShouldBeNil)
+		actions := []*tx.Action{{
+			Contract:   "contract1",
+			ActionName: "actionname1",
+			Data:       "[]",
+		}}
+		txs := make([]*tx.Tx, 0)
+		for i := 0; i < 3; i++ {
+			txn := tx.NewTx(actions, nil, 100000, 100, int64(i), 0)
+			txs = append(txs, txn)
+			tBlock := &Block{
+				Head: &BlockHead{
+					Version: 2,
+					Number:  int64(i),
+				},
+				Sign:     &crypto.Signature{},
+				Txs:      []*tx.Tx{txn},
+				Receipts: []*tx.TxReceipt{tx.NewTxReceipt(txn.Hash())},
+			}
+			tBlock.CalculateHeadHash()
+			So(bc.Push(tBlock), ShouldBeNil)
+		}
+
+		So(bc.Truncate(1), ShouldBeNil)
+		So(bc.Length(), ShouldEqual, 1)
+		So(bc.TxTotal(), ShouldEqual, 1)
+		_, err = bc.GetBlockByNumber(1)
+		So(err, ShouldNotBeNil)
+		has, err := bc.HasTx(txs[1].Hash())
+		So(err, ShouldBeNil)
+		So(has, ShouldBeFalse)
+		_, err = bc.GetTx(txs[0].Hash())
+		So(err, ShouldBeNil)
+
+		bc.Close()
+		bc, err = NewBlockChain("./BlockChainDB/")
+		So(err, ShouldBeNil)
+		So(bc.Length(), ShouldEqual, 1)
+		bc.Close()
+		os.RemoveAll("./BlockChainDB/")
+	})
+}
+
 func BenchmarkBlock(b *testing.B) {
 	a1, _ := account.NewKeyPair(nil, crypto.Secp256k1)
 	a2, _ := account.NewKeyPair(nil, crypto.Secp256k1)
@@ -13,8 +13,11 @@ type Chain interface {
 	Top() (*Block, error)
 	GetHashByNumber(number int64) ([]byte, error)
 	GetBlockByNumber(number int64) (*Block, error)
+	GetBlockHeadByNumber(number int64) (*Block, error)
 	GetBlockByHash(blockHash []byte) (*Block, error)
+	GetBlockHeadByHash(blockHash []byte) (*Block, error)
 	GetTx(hash []byte) (*tx.Tx, error)
+	GetBlockHashByTxHash(hash []byte) ([]byte, error)
 	HasTx(hash []byte) (bool, error)
 	GetReceipt(Hash []byte) (*tx.TxReceipt, error)
 	GetReceiptByTxHash(Hash []byte) (*tx.TxReceipt, error)
@@ -23,4 +26,7 @@ type Chain interface {
 	Close()
 	AllDelaytx() ([]*tx.Tx, error)
 	Draw(int64, int64) string
+	Prune(number int64) error
+	PrunedNumber() int64
+	Truncate(length int64) error
 }
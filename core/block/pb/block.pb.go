@@ -56,6 +56,7 @@ type BlockHead struct {
 	Number               int64    `protobuf:"varint,6,opt,name=number,proto3" json:"number,omitempty"`
 	Witness              string   `protobuf:"bytes,7,opt,name=witness,proto3" json:"witness,omitempty"`
 	Time                 int64    `protobuf:"varint,8,opt,name=time,proto3" json:"time,omitempty"`
+	StateHash            []byte   `protobuf:"bytes,9,opt,name=stateHash,proto3" json:"stateHash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -142,6 +143,13 @@ func (m *BlockHead) GetTime() int64 {
 	return 0
 }
 
+func (m *BlockHead) GetStateHash() []byte {
+	if m != nil {
+		return m.StateHash
+	}
+	return nil
+}
+
 type Block struct {
 	Head                 *BlockHead       `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
 	Sign                 *pb.Signature    `protobuf:"bytes,2,opt,name=sign,proto3" json:"sign,omitempty"`
@@ -238,32 +246,33 @@ func init() {
 func init() { proto.RegisterFile("core/block/pb/block.proto", fileDescriptor_dc6664e18d413fc7) }
 
 var fileDescriptor_dc6664e18d413fc7 = []byte{
-	// 427 bytes of a gzipped FileDescriptorProto
+	// 436 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x4f, 0x6f, 0xd3, 0x40,
-	0x10, 0xc5, 0x71, 0xed, 0x3a, 0xce, 0x24, 0x40, 0x34, 0x48, 0x68, 0xc9, 0x01, 0x59, 0x51, 0x41,
-	0x16, 0xa8, 0x76, 0x15, 0x38, 0x71, 0x2b, 0xa7, 0x1c, 0xfa, 0x47, 0xda, 0xf6, 0xc2, 0xd1, 0x76,
-	0x37, 0xc9, 0xaa, 0x89, 0x77, 0xb5, 0xbb, 0x01, 0xf7, 0x6b, 0xf0, 0x5d, 0xb9, 0xa3, 0x1d, 0x3b,
-	0xa1, 0x45, 0x48, 0xdc, 0xf6, 0xbd, 0xf9, 0xed, 0x78, 0xde, 0x78, 0xe1, 0x4d, 0xad, 0x8c, 0x28,
-	0xaa, 0x8d, 0xaa, 0xef, 0x0b, 0x5d, 0x75, 0x87, 0x5c, 0x1b, 0xe5, 0x14, 0x0e, 0x48, 0xe8, 0x6a,
-	0xfa, 0x65, 0x25, 0xdd, 0x7a, 0x57, 0xe5, 0xb5, 0xda, 0x16, 0x52, 0x59, 0x77, 0xaa, 0x96, 0x4b,
-	0x59, 0xcb, 0x72, 0x53, 0xac, 0xd4, 0xa9, 0x37, 0x8a, 0xda, 0x3c, 0x68, 0xa7, 0x7c, 0x03, 0x2b,
-	0x57, 0x4d, 0xe9, 0x76, 0x46, 0x74, 0x4d, 0xa6, 0x9f, 0xff, 0x7f, 0xd7, 0x0f, 0xe0, 0x5a, 0x7f,
-	0xd9, 0xb5, 0xdd, 0xad, 0xd9, 0xaf, 0x00, 0x86, 0x5f, 0xfd, 0xd7, 0x17, 0xa2, 0xbc, 0x43, 0x06,
-	0x83, 0xef, 0xc2, 0x58, 0xa9, 0x1a, 0x16, 0xa4, 0x41, 0x16, 0xf2, 0xbd, 0xc4, 0xb7, 0x00, 0xba,
-	0x34, 0xa2, 0x71, 0x8b, 0xd2, 0xae, 0xd9, 0x51, 0x1a, 0x64, 0x63, 0xfe, 0xc8, 0xc1, 0x19, 0x8c,
-	0x5d, 0x7b, 0x29, 0xcc, 0xfd, 0x46, 0x10, 0x11, 0x12, 0xf1, 0xc4, 0xc3, 0x33, 0x78, 0xe5, 0x5a,
-	0x2e, 0x6a, 0x21, 0xb5, 0x7b, 0x84, 0x46, 0x84, 0xfe, 0xab, 0x84, 0x08, 0x91, 0x6c, 0x96, 0x8a,
-	0x1d, 0x13, 0x42, 0x67, 0x7c, 0x0d, 0x71, 0xb3, 0xdb, 0x56, 0xc2, 0xb0, 0x98, 0x46, 0xec, 0x95,
-	0x9f, 0xfd, 0x87, 0x74, 0x8d, 0xb0, 0x96, 0x0d, 0xd2, 0x20, 0x1b, 0xf2, 0xbd, 0xf4, 0x5d, 0x9c,
-	0xdc, 0x0a, 0x96, 0x10, 0x4f, 0xe7, 0xd9, 0xcf, 0x23, 0x38, 0xa6, 0xdc, 0xf8, 0x1e, 0xa2, 0xb5,
-	0x28, 0xef, 0x28, 0xf0, 0x68, 0x8e, 0x79, 0xff, 0x2f, 0xf2, 0xc3, 0x56, 0x38, 0xd5, 0xf1, 0x04,
-	0x22, 0xbf, 0x72, 0xca, 0x3e, 0x9a, 0x4f, 0x72, 0x2b, 0x57, 0xba, 0xca, 0x6f, 0xf6, 0x7f, 0x81,
-	0x53, 0x15, 0xa7, 0x10, 0xba, 0xd6, 0xb2, 0x30, 0x0d, 0xb3, 0xd1, 0x3c, 0xc9, 0x5d, 0xab, 0xab,
-	0xfc, 0xb6, 0xe5, 0xde, 0xc4, 0x8f, 0x90, 0x98, 0x2e, 0xa2, 0x65, 0x11, 0x01, 0x2f, 0x0f, 0x40,
-	0xe7, 0xf3, 0x03, 0x80, 0x53, 0x48, 0x5c, 0xeb, 0x97, 0x20, 0x2c, 0x3b, 0x4e, 0xc3, 0x6c, 0xcc,
-	0x0f, 0x1a, 0x4f, 0xe0, 0x79, 0xcf, 0xf5, 0x40, 0x4c, 0xc0, 0x53, 0x13, 0xcf, 0x60, 0x48, 0x59,
-	0x6e, 0x1f, 0xb4, 0xa0, 0x95, 0xbc, 0xf8, 0x3b, 0x9d, 0xaf, 0xf0, 0x3f, 0xd0, 0x87, 0x77, 0xfd,
-	0x5b, 0xf0, 0x02, 0x01, 0xe2, 0xab, 0x6b, 0x7e, 0x79, 0x7e, 0x31, 0x79, 0x86, 0x63, 0x48, 0xae,
-	0xaf, 0x2e, 0xbe, 0x2d, 0xce, 0x6f, 0x16, 0x93, 0xa0, 0x8a, 0xe9, 0xe9, 0x7c, 0xfa, 0x1d, 0x00,
-	0x00, 0xff, 0xff, 0xb4, 0x8b, 0xe6, 0x72, 0xd2, 0x02, 0x00, 0x00,
+	0x10, 0xc5, 0x71, 0xed, 0x38, 0xf6, 0x24, 0x40, 0x34, 0x48, 0x68, 0x89, 0x10, 0xb2, 0xa2, 0x82,
+	0x2c, 0x50, 0xed, 0x2a, 0x70, 0xe2, 0x56, 0x4e, 0x39, 0xf4, 0x8f, 0xb4, 0xed, 0x85, 0xa3, 0xed,
+	0x6e, 0x92, 0x55, 0x13, 0xaf, 0xb5, 0xbb, 0x01, 0xf7, 0x6b, 0xc0, 0x17, 0x46, 0x3b, 0x76, 0xd2,
+	0x16, 0x21, 0x71, 0xdb, 0xf7, 0xe6, 0xb7, 0xe3, 0xf1, 0x9b, 0x85, 0x37, 0x95, 0xd2, 0x22, 0x2f,
+	0x37, 0xaa, 0xba, 0xcb, 0x9b, 0xb2, 0x3b, 0x64, 0x8d, 0x56, 0x56, 0xe1, 0x90, 0x44, 0x53, 0x4e,
+	0xbf, 0xae, 0xa4, 0x5d, 0xef, 0xca, 0xac, 0x52, 0xdb, 0x5c, 0x2a, 0x63, 0x4f, 0xd4, 0x72, 0x29,
+	0x2b, 0x59, 0x6c, 0xf2, 0x95, 0x3a, 0x71, 0x46, 0x5e, 0xe9, 0xfb, 0xc6, 0x2a, 0xd7, 0xc0, 0xc8,
+	0x55, 0x5d, 0xd8, 0x9d, 0x16, 0x5d, 0x93, 0xe9, 0x97, 0xff, 0xdf, 0x75, 0x03, 0xd8, 0xd6, 0x5d,
+	0xb6, 0x6d, 0x77, 0x6b, 0xf6, 0xfb, 0x08, 0xe2, 0x6f, 0xee, 0xeb, 0x0b, 0x51, 0xdc, 0x22, 0x83,
+	0xe1, 0x0f, 0xa1, 0x8d, 0x54, 0x35, 0xf3, 0x12, 0x2f, 0xf5, 0xf9, 0x5e, 0xe2, 0x3b, 0x80, 0xa6,
+	0xd0, 0xa2, 0xb6, 0x8b, 0xc2, 0xac, 0xd9, 0x51, 0xe2, 0xa5, 0x63, 0xfe, 0xc8, 0xc1, 0x19, 0x8c,
+	0x6d, 0x7b, 0x21, 0xf4, 0xdd, 0x46, 0x10, 0xe1, 0x13, 0xf1, 0xc4, 0xc3, 0x53, 0x78, 0x65, 0x5b,
+	0x2e, 0x2a, 0x21, 0x1b, 0xfb, 0x08, 0x0d, 0x08, 0xfd, 0x57, 0x09, 0x11, 0x02, 0x59, 0x2f, 0x15,
+	0x1b, 0x10, 0x42, 0x67, 0x7c, 0x0d, 0x61, 0xbd, 0xdb, 0x96, 0x42, 0xb3, 0x90, 0x46, 0xec, 0x95,
+	0x9b, 0xfd, 0xa7, 0xb4, 0xb5, 0x30, 0x86, 0x0d, 0x13, 0x2f, 0x8d, 0xf9, 0x5e, 0xba, 0x2e, 0x56,
+	0x6e, 0x05, 0x8b, 0x88, 0xa7, 0x33, 0xbe, 0x85, 0xd8, 0xd8, 0xc2, 0x76, 0x13, 0xc4, 0xd4, 0xfe,
+	0xc1, 0x98, 0xfd, 0x3a, 0x82, 0x01, 0xa5, 0x82, 0x1f, 0x20, 0x58, 0x8b, 0xe2, 0x96, 0xe2, 0x18,
+	0xcd, 0x31, 0xeb, 0x37, 0x95, 0x1d, 0x32, 0xe3, 0x54, 0xc7, 0x63, 0x08, 0xdc, 0x42, 0x28, 0x99,
+	0xd1, 0x7c, 0x92, 0x19, 0xb9, 0x6a, 0xca, 0xec, 0x7a, 0xbf, 0x23, 0x4e, 0x55, 0x9c, 0x82, 0x6f,
+	0x5b, 0xc3, 0xfc, 0xc4, 0x4f, 0x47, 0xf3, 0x28, 0xb3, 0x6d, 0x53, 0x66, 0x37, 0x2d, 0x77, 0x26,
+	0x7e, 0x82, 0x48, 0x77, 0x01, 0x18, 0x16, 0x10, 0xf0, 0xf2, 0x00, 0x74, 0x3e, 0x3f, 0x00, 0x38,
+	0x85, 0xc8, 0xb6, 0x6e, 0x54, 0x61, 0xd8, 0x20, 0xf1, 0xd3, 0x31, 0x3f, 0x68, 0x3c, 0x86, 0xe7,
+	0x3d, 0xd7, 0x03, 0x21, 0x01, 0x4f, 0x4d, 0x3c, 0x85, 0x98, 0xfe, 0xe5, 0xe6, 0xbe, 0x11, 0x14,
+	0xd8, 0x8b, 0xbf, 0xff, 0xce, 0x55, 0xf8, 0x03, 0xf4, 0xf1, 0x7d, 0xff, 0x52, 0x9c, 0x40, 0x80,
+	0xf0, 0xf2, 0x8a, 0x5f, 0x9c, 0x9d, 0x4f, 0x9e, 0xe1, 0x18, 0xa2, 0xab, 0xcb, 0xf3, 0xef, 0x8b,
+	0xb3, 0xeb, 0xc5, 0xc4, 0x2b, 0x43, 0x7a, 0x58, 0x9f, 0xff, 0x0c, 0x00, 0xcd, 0x0e, 0x7c, 0xd7,
+	0xf0, 0x02, 0x00, 0x00,
 }
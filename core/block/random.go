@@ -0,0 +1,44 @@
+package block
+
+import (
+	"encoding/json"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto/vrf"
+)
+
+// Proof returns the randomness proof carried in Info, nil if the block head has none.
+// The proof is the ECVRF proof of RandomSeed by the ed25519 key of the witness.
+func (b *BlockHead) Proof() []byte {
+	var info struct {
+		Proof []byte `json:"proof"`
+	}
+	if err := json.Unmarshal(b.Info, &info); err != nil {
+		return nil
+	}
+	return info.Proof
+}
+
+// Random returns the random value of the block head, which is the output of its VRF proof.
+// It returns nil if the block head has no valid randomness proof.
+// The value is unique for the witness key and the parent block, so the witness can't choose it.
+func (b *BlockHead) Random() []byte {
+	random, err := vrf.ProofToHash(b.Proof())
+	if err != nil {
+		return nil
+	}
+	return random
+}
+
+// RandomSeed returns the message the witness of the child block of parent signs as the randomness proof.
+// The seed is chained by the random values only, so it can't be changed by choosing the txs of a block.
+func RandomSeed(parent *Block) []byte {
+	random := parent.Head.Random()
+	if random == nil {
+		random = parent.HeadHash()
+	}
+	se := common.NewSimpleEncoder()
+	se.WriteBytes(random)
+	se.WriteInt64(parent.Head.Number + 1)
+	return common.Sha3(se.Bytes())
+}
@@ -1,6 +1,7 @@
 package blockcache
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strconv"
@@ -34,6 +35,8 @@ type conAlgo interface {
 const (
 	// DelSingleBlockTime ...
 	DelSingleBlockTime int64 = 10
+	// maxPruneBlocks is the maximum number of blocks pruned in one flush.
+	maxPruneBlocks int64 = 1000
 )
 
 // BCNType type of BlockCacheNode
@@ -284,6 +287,95 @@ func (bc *BlockCacheImpl) NewWAL(config *common.Config) (err error) {
 
 }
 
+// CheckWAL reads the block cache WAL, and returns the number of its entries and the number of the leading valid ones.
+// An entry is valid if it can be decoded and its block links to a block no higher than top in the chain,
+// or to a block of an earlier valid entry. A torn write at the tail is zeroed as the block cache does on startup.
+func CheckWAL(config *common.Config, chain block.Chain, top int64) (total int, valid int, err error) {
+	entries, err := readWAL(config)
+	if err != nil {
+		return 0, 0, err
+	}
+	inChain := func(hash []byte, number int64) bool {
+		if number < 0 || number > top {
+			return false
+		}
+		h, err := chain.GetHashByNumber(number)
+		return err == nil && bytes.Equal(h, hash)
+	}
+	known := make(map[string]bool)
+	for _, entry := range entries {
+		var bcMessage BcMessage
+		if err := proto.Unmarshal(entry.Data, &bcMessage); err != nil {
+			break
+		}
+		if bcMessage.Type == BcMessageType_SetRootType {
+			if !known[string(bcMessage.Data)] {
+				head, err := chain.GetBlockHeadByHash(bcMessage.Data)
+				if err != nil || !inChain(bcMessage.Data, head.Head.Number) {
+					break
+				}
+			}
+			valid++
+			continue
+		}
+		blk, _, err := decodeBCN(bcMessage.Data)
+		if err != nil {
+			break
+		}
+		if !known[string(blk.Head.ParentHash)] && !inChain(blk.Head.ParentHash, blk.Head.Number-1) {
+			break
+		}
+		known[string(blk.HeadHash())] = true
+		valid++
+	}
+	return len(entries), valid, nil
+}
+
+// TruncateWAL keeps the first n entries of the block cache WAL.
+// The old WAL is moved into Corrupted for later analysis, as NewWAL does.
+func TruncateWAL(config *common.Config, n int) error {
+	walPath := config.DB.LdbPath + blockCacheWALDir
+	entries, err := readWAL(config)
+	if err != nil && n > 0 {
+		return err
+	}
+	if err == nil && n >= len(entries) {
+		return nil
+	}
+	corruptWalPath := walPath + "Corrupted"
+	if err := os.RemoveAll(corruptWalPath); err != nil {
+		return err
+	}
+	if err := os.Rename(walPath, corruptWalPath); err != nil {
+		return err
+	}
+	w, err := wal.Create(walPath, []byte("block_cache_wal"))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries[:n] {
+		if _, err := w.SaveSingle(wal.Entry{Data: entry.Data}); err != nil {
+			w.Close()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+func readWAL(config *common.Config) ([]wal.Entry, error) {
+	walPath := config.DB.LdbPath + blockCacheWALDir
+	if !wal.Exist(walPath) {
+		return nil, nil
+	}
+	w, err := wal.OpenForRead(walPath)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+	_, entries, err := w.ReadAll()
+	return entries, err
+}
+
 // Recover recover previews block cache
 func (bc *BlockCacheImpl) Recover(p conAlgo) (err error) {
 	if bc.wal.HasDecoder() {
@@ -535,6 +627,10 @@ func (bc *BlockCacheImpl) flush(retain *BlockCacheNode) error {
 		retain.LibWitnessHandle()
 		bc.SetLinkedRoot(retain)
 
+		if err := bc.prune(retain.Block); err != nil {
+			ilog.Warnf("Prune BlockChain failed: %v", err)
+		}
+
 		metricsTxTotal.Set(float64(bc.baseVariable.BlockChain().TxTotal()), nil)
 
 		if blockchainDBSize, err := bc.baseVariable.BlockChain().Size(); err != nil {
@@ -562,6 +658,45 @@ func (bc *BlockCacheImpl) flush(retain *BlockCacheNode) error {
 	return nil
 }
 
+// prune deletes the txs and receipts of blocks out of the retention configured by DBConfig.
+func (bc *BlockCacheImpl) prune(top *block.Block) error {
+	conf := bc.baseVariable.Config()
+	if conf == nil || conf.DB == nil || (conf.DB.PruneBlocks <= 0 && conf.DB.PruneAge <= 0) {
+		return nil
+	}
+	chain := bc.baseVariable.BlockChain()
+	start := chain.PrunedNumber()
+	target := top.Head.Number + 1
+	if conf.DB.PruneBlocks > 0 && top.Head.Number+1-conf.DB.PruneBlocks < target {
+		target = top.Head.Number + 1 - conf.DB.PruneBlocks
+	}
+	if conf.DB.PruneAge > 0 {
+		deadline := top.Head.Time - int64(conf.DB.PruneAge)
+		// find the first block produced after deadline
+		lo, hi := start, target
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			blk, err := chain.GetBlockHeadByNumber(mid)
+			if err != nil {
+				return err
+			}
+			if blk.Head.Time > deadline {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		target = lo
+	}
+	if target > start+maxPruneBlocks {
+		target = start + maxPruneBlocks
+	}
+	if target <= start {
+		return nil
+	}
+	return chain.Prune(target)
+}
+
 // Flush is save a block
 func (bc *BlockCacheImpl) Flush(bcn *BlockCacheNode) {
 	bc.flush(bcn)
@@ -1,6 +1,8 @@
 package blockcache
 
 import (
+	"errors"
+	"os"
 	"testing"
 	//	"fmt"
 
@@ -9,6 +11,8 @@ import (
 	"github.com/iost-official/go-iost/db/mocks"
 
 	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db/wal"
 	"github.com/iost-official/go-iost/vm/database"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/iost-official/go-iost/common"
@@ -268,3 +272,49 @@ func StringSliceEqual(a, b []string) bool {
 	}
 	return true
 }
+
+func TestCheckWAL(t *testing.T) {
+	ctl := NewController(t)
+	b0 := genBlock(nil, "w0", 0)
+	b1 := genBlock(b0, "w1", 1)
+	b2 := genBlock(b1, "w2", 2)
+	b3 := genBlock(b2, "w3", 3)
+	s1 := genBlock(nil, "w1", 1)
+
+	chain := core_mock.NewMockChain(ctl)
+	chain.EXPECT().GetHashByNumber(int64(0)).AnyTimes().Return(b0.HeadHash(), nil)
+	chain.EXPECT().GetHashByNumber(Any()).AnyTimes().Return(nil, errors.New("not found"))
+	config := &common.Config{
+		DB: &common.DBConfig{
+			LdbPath: "WALTest/",
+		},
+	}
+	defer os.RemoveAll("WALTest/")
+
+	Convey("Test of block cache WAL check", t, func() {
+		w, err := wal.Create(config.DB.LdbPath+blockCacheWALDir, []byte("block_cache_wal"))
+		So(err, ShouldBeNil)
+		bc := &BlockCacheImpl{wal: w}
+		for _, b := range []*block.Block{b1, b2, s1, b3} {
+			b.Sign = &crypto.Signature{}
+			_, err := bc.writeAddNodeWAL(NewBCN(nil, b))
+			So(err, ShouldBeNil)
+		}
+		So(w.Close(), ShouldBeNil)
+
+		total, valid, err := CheckWAL(config, chain, 0)
+		So(err, ShouldBeNil)
+		So(total, ShouldEqual, 4)
+		So(valid, ShouldEqual, 2)
+		_, valid, err = CheckWAL(config, chain, -1)
+		So(err, ShouldBeNil)
+		So(valid, ShouldEqual, 0)
+
+		So(TruncateWAL(config, 2), ShouldBeNil)
+		total, valid, err = CheckWAL(config, chain, 0)
+		So(err, ShouldBeNil)
+		So(total, ShouldEqual, 2)
+		So(valid, ShouldEqual, 2)
+		So(wal.Exist(config.DB.LdbPath+blockCacheWALDir+"Corrupted"), ShouldBeTrue)
+	})
+}
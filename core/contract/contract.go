@@ -83,11 +83,14 @@ func (c *Contract) B64Decode(str string) error {
 	return proto.Unmarshal(buf, c)
 }
 
-// VerifySelf verify contract's size
+// VerifySelf verify contract's size and event declarations
 func (c *Contract) VerifySelf() error {
 	if len(c.Code) > codeSizeLimit {
 		return errors.New("code size invalid")
 	}
+	if c.Info != nil {
+		return c.verifyEvents()
+	}
 	return nil
 }
 
@@ -117,8 +120,6 @@ func Compile(id, src, abi string) (*Contract, error) {
 	if err != nil {
 		return nil, err
 	}
-	code := string(bs)
-
 	as, err := ioutil.ReadFile(abi)
 	if err != nil {
 		return nil, err
@@ -132,12 +133,28 @@ func Compile(id, src, abi string) (*Contract, error) {
 	c := Contract{
 		ID:   id,
 		Info: &info,
-		Code: code,
+		Code: EncodeCode(info.Lang, bs),
 	}
 
 	return &c, nil
 }
 
+// EncodeCode converts the source file of a contract to its code, the binary of a wasm contract is encoded in base64
+func EncodeCode(lang string, src []byte) string {
+	if lang == "wasm" {
+		return base64.StdEncoding.EncodeToString(src)
+	}
+	return string(src)
+}
+
+// DecodeCode converts the code of a contract back to its source file
+func DecodeCode(lang string, code string) ([]byte, error) {
+	if lang == "wasm" {
+		return base64.StdEncoding.DecodeString(code)
+	}
+	return []byte(code), nil
+}
+
 // ToBytes converts Amount to bytes.
 func (a *Amount) ToBytes() []byte {
 	se := common.NewSimpleEncoder()
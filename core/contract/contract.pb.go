@@ -21,9 +21,12 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Info struct {
-	Lang                 string   `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
-	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Abi                  []*ABI   `protobuf:"bytes,3,rep,name=abi,proto3" json:"abi,omitempty"`
+	Lang    string   `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Version string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Abi     []*ABI   `protobuf:"bytes,3,rep,name=abi,proto3" json:"abi,omitempty"`
+	Events  []*Event `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	// refuse the code if the linter reports any finding
+	Lint                 bool     `protobuf:"varint,5,opt,name=lint,proto3" json:"lint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -75,6 +78,20 @@ func (m *Info) GetAbi() []*ABI {
 	return nil
 }
 
+func (m *Info) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Info) GetLint() bool {
+	if m != nil {
+		return m.Lint
+	}
+	return false
+}
+
 type ABI struct {
 	Name                 string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Args                 []string  `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
@@ -130,6 +147,100 @@ func (m *ABI) GetAmountLimit() []*Amount {
 	return nil
 }
 
+type Event struct {
+	Name                 string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fields               []*EventField `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f74c2661e7246774, []int{2}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Event) GetFields() []*EventField {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+type EventField struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EventField) Reset()         { *m = EventField{} }
+func (m *EventField) String() string { return proto.CompactTextString(m) }
+func (*EventField) ProtoMessage()    {}
+func (*EventField) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f74c2661e7246774, []int{3}
+}
+
+func (m *EventField) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EventField.Unmarshal(m, b)
+}
+func (m *EventField) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EventField.Marshal(b, m, deterministic)
+}
+func (m *EventField) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventField.Merge(m, src)
+}
+func (m *EventField) XXX_Size() int {
+	return xxx_messageInfo_EventField.Size(m)
+}
+func (m *EventField) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventField.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventField proto.InternalMessageInfo
+
+func (m *EventField) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EventField) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
 type Amount struct {
 	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 	Val                  string   `protobuf:"bytes,2,opt,name=val,proto3" json:"val,omitempty"`
@@ -142,7 +253,7 @@ func (m *Amount) Reset()         { *m = Amount{} }
 func (m *Amount) String() string { return proto.CompactTextString(m) }
 func (*Amount) ProtoMessage()    {}
 func (*Amount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f74c2661e7246774, []int{2}
+	return fileDescriptor_f74c2661e7246774, []int{4}
 }
 
 func (m *Amount) XXX_Unmarshal(b []byte) error {
@@ -190,7 +301,7 @@ func (m *Contract) Reset()         { *m = Contract{} }
 func (m *Contract) String() string { return proto.CompactTextString(m) }
 func (*Contract) ProtoMessage()    {}
 func (*Contract) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f74c2661e7246774, []int{3}
+	return fileDescriptor_f74c2661e7246774, []int{5}
 }
 
 func (m *Contract) XXX_Unmarshal(b []byte) error {
@@ -235,6 +346,8 @@ func (m *Contract) GetCode() string {
 func init() {
 	proto.RegisterType((*Info)(nil), "contract.Info")
 	proto.RegisterType((*ABI)(nil), "contract.ABI")
+	proto.RegisterType((*Event)(nil), "contract.Event")
+	proto.RegisterType((*EventField)(nil), "contract.EventField")
 	proto.RegisterType((*Amount)(nil), "contract.Amount")
 	proto.RegisterType((*Contract)(nil), "contract.Contract")
 }
@@ -242,21 +355,25 @@ func init() {
 func init() { proto.RegisterFile("core/contract/contract.proto", fileDescriptor_f74c2661e7246774) }
 
 var fileDescriptor_f74c2661e7246774 = []byte{
-	// 245 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x90, 0xc1, 0x6e, 0xc2, 0x30,
-	0x0c, 0x86, 0x45, 0xd3, 0x31, 0xe6, 0x6a, 0x08, 0x59, 0x3b, 0xe4, 0x30, 0x69, 0x55, 0x4f, 0x9c,
-	0xd8, 0xd4, 0x3d, 0x01, 0x8c, 0x4b, 0xa4, 0x9d, 0x22, 0xed, 0x01, 0x42, 0x17, 0x50, 0x34, 0x1a,
-	0x4f, 0x21, 0xe3, 0xf9, 0x27, 0xa7, 0x29, 0x70, 0xfb, 0xfd, 0xff, 0xce, 0x67, 0xc7, 0xf0, 0xdc,
-	0x51, 0xb0, 0xaf, 0x1d, 0xf9, 0x18, 0x4c, 0x17, 0x2f, 0x62, 0xf5, 0x1b, 0x28, 0x12, 0xce, 0xc6,
-	0xba, 0xf9, 0x82, 0x52, 0xf9, 0x3d, 0x21, 0x42, 0x79, 0x34, 0xfe, 0x20, 0x27, 0xf5, 0x64, 0xf9,
-	0xa0, 0x93, 0x46, 0x09, 0xf7, 0x67, 0x1b, 0x4e, 0x8e, 0xbc, 0x2c, 0x92, 0x3d, 0x96, 0xf8, 0x02,
-	0xc2, 0xec, 0x9c, 0x14, 0xb5, 0x58, 0x56, 0xed, 0xe3, 0xea, 0x42, 0x5f, 0x6f, 0x94, 0xe6, 0xa4,
-	0x31, 0x20, 0xd6, 0x1b, 0xc5, 0x54, 0x6f, 0x7a, 0x3b, 0x52, 0x59, 0xb3, 0x67, 0xc2, 0xe1, 0x24,
-	0x8b, 0x5a, 0xb0, 0xc7, 0x1a, 0x5b, 0xa8, 0x4c, 0x4f, 0x7f, 0x3e, 0x7e, 0xba, 0xde, 0xc5, 0xcc,
-	0x5d, 0xdc, 0x70, 0x53, 0xa8, 0x6f, 0x9b, 0x9a, 0x37, 0x98, 0x0e, 0x36, 0x3e, 0xc1, 0x5d, 0xa4,
-	0x1f, 0xeb, 0xf3, 0x98, 0xa1, 0xc0, 0x05, 0x88, 0xb3, 0x39, 0xe6, 0xcd, 0x59, 0x36, 0x1a, 0x66,
-	0x1f, 0x99, 0x88, 0x73, 0x28, 0xd4, 0x36, 0x3f, 0x28, 0xd4, 0x16, 0x1b, 0x28, 0x9d, 0xdf, 0x53,
-	0x6a, 0xaf, 0xda, 0xf9, 0x75, 0x34, 0x5f, 0x47, 0xa7, 0x8c, 0x37, 0xef, 0xe8, 0xdb, 0x4a, 0x31,
-	0xfc, 0x86, 0xf5, 0x6e, 0x9a, 0x0e, 0xfa, 0xfe, 0x1f, 0x00, 0x00, 0xff, 0xff, 0x37, 0x75, 0x1b,
-	0x8b, 0x70, 0x01, 0x00, 0x00,
+	// 315 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xd1, 0x4e, 0xc2, 0x30,
+	0x14, 0x86, 0xb3, 0x75, 0x4c, 0x3c, 0x8b, 0x48, 0x1a, 0x2e, 0x7a, 0x61, 0xe2, 0xd2, 0x1b, 0xb9,
+	0x30, 0x68, 0xa6, 0x2f, 0x00, 0xa2, 0x49, 0x13, 0xaf, 0xfa, 0x06, 0x65, 0x14, 0xd2, 0x08, 0x2d,
+	0x19, 0x95, 0xc4, 0xd7, 0xf0, 0x89, 0xcd, 0xe9, 0x0a, 0x23, 0x46, 0xef, 0xfe, 0xf3, 0xff, 0xff,
+	0xd9, 0xf9, 0xb2, 0x0d, 0x6e, 0x6a, 0xd7, 0xe8, 0x87, 0xda, 0x59, 0xdf, 0xa8, 0xda, 0x9f, 0xc4,
+	0x64, 0xd7, 0x38, 0xef, 0x68, 0xff, 0x38, 0xf3, 0xef, 0x04, 0x32, 0x61, 0x57, 0x8e, 0x52, 0xc8,
+	0x36, 0xca, 0xae, 0x59, 0x52, 0x26, 0xe3, 0x4b, 0x19, 0x34, 0x65, 0x70, 0x71, 0xd0, 0xcd, 0xde,
+	0x38, 0xcb, 0xd2, 0x60, 0x1f, 0x47, 0x7a, 0x0b, 0x44, 0x2d, 0x0c, 0x23, 0x25, 0x19, 0x17, 0xd5,
+	0xd5, 0xe4, 0xf4, 0xf8, 0xe9, 0x4c, 0x48, 0x4c, 0xe8, 0x1d, 0xe4, 0xfa, 0xa0, 0xad, 0xdf, 0xb3,
+	0x2c, 0x74, 0xae, 0xbb, 0xce, 0x2b, 0xfa, 0x32, 0xc6, 0xe1, 0xae, 0xb1, 0x9e, 0xf5, 0xca, 0x64,
+	0xdc, 0x97, 0x41, 0x73, 0x05, 0x64, 0x3a, 0x13, 0x18, 0x59, 0xb5, 0xd5, 0x47, 0x24, 0xd4, 0xe8,
+	0xa9, 0x66, 0xbd, 0x67, 0x69, 0x49, 0xd0, 0x43, 0x4d, 0x2b, 0x28, 0xd4, 0xd6, 0x7d, 0x5a, 0xff,
+	0x6e, 0xb6, 0xc6, 0x47, 0xa8, 0xe1, 0x19, 0x54, 0x08, 0xe5, 0x79, 0x89, 0x0b, 0xe8, 0x05, 0x8e,
+	0x3f, 0x8f, 0xdc, 0x43, 0xbe, 0x32, 0x7a, 0xb3, 0x6c, 0xcf, 0x14, 0xd5, 0xe8, 0x17, 0xfc, 0x1b,
+	0x86, 0x32, 0x76, 0xf8, 0x33, 0x40, 0xe7, 0xfe, 0x07, 0xed, 0xbf, 0x76, 0x3a, 0xbe, 0xc4, 0xa0,
+	0xf9, 0x23, 0xe4, 0x2d, 0x17, 0x1d, 0x41, 0xcf, 0xbb, 0x0f, 0x6d, 0xe3, 0x4a, 0x3b, 0xd0, 0x21,
+	0x90, 0x83, 0xda, 0xc4, 0x15, 0x94, 0x5c, 0x42, 0xff, 0x25, 0x62, 0xd0, 0x01, 0xa4, 0x62, 0x1e,
+	0x17, 0x52, 0x31, 0xa7, 0x1c, 0x32, 0x63, 0x57, 0x2e, 0xd4, 0x8b, 0x6a, 0xd0, 0xf1, 0xe2, 0xb7,
+	0x95, 0x21, 0x43, 0x8a, 0xda, 0x2d, 0x35, 0x23, 0x2d, 0x05, 0xea, 0x45, 0x1e, 0xfe, 0x87, 0xa7,
+	0x9f, 0x01, 0x00, 0x3b, 0x9e, 0x5a, 0xf9, 0x2f, 0x02, 0x00, 0x00,
 }
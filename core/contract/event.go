@@ -0,0 +1,110 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// types of event fields, they are the same as the types of abi args
+var eventFieldTypes = map[string]bool{
+	"string": true,
+	"number": true,
+	"bool":   true,
+	"json":   true,
+}
+
+// errors of events
+var (
+	ErrEventNotDeclared = errors.New("event not declared in abi")
+	ErrEventInvalid     = errors.New("event should be {\"name\": name, \"data\": {fields}}")
+)
+
+// Event get event declaration from contract with specific name
+func (c *Contract) Event(name string) *Event {
+	for _, e := range c.Info.Events {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func (c *Contract) verifyEvents() error {
+	names := make(map[string]bool)
+	for _, e := range c.Info.Events {
+		if e.Name == "" || names[e.Name] {
+			return fmt.Errorf("event name empty or duplicated: %v", e.Name)
+		}
+		names[e.Name] = true
+		fields := make(map[string]bool)
+		for _, f := range e.Fields {
+			if f.Name == "" || fields[f.Name] {
+				return fmt.Errorf("field name of event %v empty or duplicated: %v", e.Name, f.Name)
+			}
+			fields[f.Name] = true
+			if !eventFieldTypes[f.Type] {
+				return fmt.Errorf("invalid type of field %v.%v: %v", e.Name, f.Name, f.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseEvent checks the posted event with the declarations in abi and returns its name and fields.
+// The values of string fields are returned as they are, others are encoded in json.
+// Contracts which declare no event may post any string, ParseEvent returns empty name for them.
+func (c *Contract) ParseEvent(data string) (string, map[string]string, error) {
+	if c.Info == nil || len(c.Info.Events) == 0 {
+		return "", nil, nil
+	}
+	var content struct {
+		Name string                     `json:"name"`
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(data), &content); err != nil || content.Data == nil {
+		return "", nil, ErrEventInvalid
+	}
+	e := c.Event(content.Name)
+	if e == nil {
+		return "", nil, fmt.Errorf("%v: %v", ErrEventNotDeclared, content.Name)
+	}
+	if len(content.Data) != len(e.Fields) {
+		return "", nil, fmt.Errorf("fields of event %v unmatched, need %v, got %v", e.Name, len(e.Fields), len(content.Data))
+	}
+	fields := make(map[string]string, len(e.Fields))
+	for _, f := range e.Fields {
+		raw, ok := content.Data[f.Name]
+		if !ok {
+			return "", nil, fmt.Errorf("field %v.%v missing", e.Name, f.Name)
+		}
+		v, err := parseEventField(f.Type, raw)
+		if err != nil {
+			return "", nil, fmt.Errorf("error parse %v field %v.%v, %v", f.Type, e.Name, f.Name, err)
+		}
+		fields[f.Name] = v
+	}
+	return e.Name, fields, nil
+}
+
+func parseEventField(typ string, raw json.RawMessage) (string, error) {
+	switch typ {
+	case "string":
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	case "number":
+		var n int64
+		err := json.Unmarshal(raw, &n)
+		return string(raw), err
+	case "bool":
+		var b bool
+		err := json.Unmarshal(raw, &b)
+		return string(raw), err
+	default:
+		var buf bytes.Buffer
+		err := json.Compact(&buf, raw)
+		return buf.String(), err
+	}
+}
@@ -0,0 +1,58 @@
+package contract
+
+import (
+	"testing"
+)
+
+func TestParseEvent(t *testing.T) {
+	c := &Contract{
+		Info: &Info{
+			Lang: "javascript",
+			Events: []*Event{
+				{
+					Name: "transfer",
+					Fields: []*EventField{
+						{Name: "from", Type: "string"},
+						{Name: "amount", Type: "number"},
+						{Name: "memo", Type: "json"},
+					},
+				},
+			},
+		},
+	}
+	if err := c.VerifySelf(); err != nil {
+		t.Fatal(err)
+	}
+	name, fields, err := c.ParseEvent(`{"name": "transfer", "data": {"from": "a", "amount": 10, "memo": {"x": [1, 2]}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "transfer" || fields["from"] != "a" || fields["amount"] != "10" || fields["memo"] != `{"x":[1,2]}` {
+		t.Fatal(name, fields)
+	}
+
+	for _, data := range []string{
+		`hello`,
+		`{"name": "transfer"}`,
+		`{"name": "mint", "data": {}}`,
+		`{"name": "transfer", "data": {"from": "a", "amount": 10}}`,
+		`{"name": "transfer", "data": {"from": "a", "amount": "10", "memo": null}}`,
+		`{"name": "transfer", "data": {"from": 1, "amount": 10, "memo": null}}`,
+		`{"name": "transfer", "data": {"from": "a", "amount": 10, "to": null}}`,
+	} {
+		if _, _, err := c.ParseEvent(data); err == nil {
+			t.Fatal("event should be invalid:", data)
+		}
+	}
+
+	c.Info.Events[0].Fields[0].Type = "int"
+	if err := c.VerifySelf(); err == nil {
+		t.Fatal("invalid field type should be refused")
+	}
+
+	c.Info.Events = nil
+	name, fields, err = c.ParseEvent("hello")
+	if err != nil || name != "" || fields != nil {
+		t.Fatal(name, fields, err)
+	}
+}
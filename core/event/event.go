@@ -36,6 +36,9 @@ type Event struct {
 	Topic Topic
 	Data  string
 	Time  int64
+	// Name and Fields are set if the event is declared in the abi of the contract
+	Name   string
+	Fields map[string]string
 }
 
 // NewEvent generate new event with topic and data
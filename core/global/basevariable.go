@@ -6,7 +6,9 @@ import (
 
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
 )
 
 // TMode type of mode
@@ -47,12 +49,20 @@ type BaseVariableImpl struct {
 
 // New return a BaseVariable instance
 func New(conf *common.Config) (*BaseVariableImpl, error) {
-	blockChain, err := block.NewBlockChain(conf.DB.LdbPath + "BlockChainDB")
+	block.SetForkConfig(conf.Fork)
+	tx.SetForkConfig(conf.Fork)
+	db.UndoDepth = conf.DB.UndoDepth
+	storageType, err := kv.ParseStorageType(conf.DB.Backend)
+	if err != nil {
+		return nil, err
+	}
+
+	blockChain, err := block.NewBlockChainWithStorage(conf.DB.LdbPath+"BlockChainDB", storageType)
 	if err != nil {
 		return nil, fmt.Errorf("new blockchain failed, stop the program. err: %v", err)
 	}
 
-	stateDB, err := db.NewMVCCDB(conf.DB.LdbPath + "StateDB")
+	stateDB, err := db.NewMVCCDBWithStorage(conf.DB.LdbPath+"StateDB", storageType)
 	if err != nil {
 		return nil, fmt.Errorf("new statedb failed, stop the program. err: %v", err)
 	}
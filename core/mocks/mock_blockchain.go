@@ -105,6 +105,45 @@ func (mr *MockChainMockRecorder) GetBlockByNumber(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByNumber", reflect.TypeOf((*MockChain)(nil).GetBlockByNumber), arg0)
 }
 
+// GetBlockHashByTxHash mocks base method
+func (m *MockChain) GetBlockHashByTxHash(arg0 []byte) ([]byte, error) {
+	ret := m.ctrl.Call(m, "GetBlockHashByTxHash", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHashByTxHash indicates an expected call of GetBlockHashByTxHash
+func (mr *MockChainMockRecorder) GetBlockHashByTxHash(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHashByTxHash", reflect.TypeOf((*MockChain)(nil).GetBlockHashByTxHash), arg0)
+}
+
+// GetBlockHeadByHash mocks base method
+func (m *MockChain) GetBlockHeadByHash(arg0 []byte) (*block.Block, error) {
+	ret := m.ctrl.Call(m, "GetBlockHeadByHash", arg0)
+	ret0, _ := ret[0].(*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeadByHash indicates an expected call of GetBlockHeadByHash
+func (mr *MockChainMockRecorder) GetBlockHeadByHash(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeadByHash", reflect.TypeOf((*MockChain)(nil).GetBlockHeadByHash), arg0)
+}
+
+// GetBlockHeadByNumber mocks base method
+func (m *MockChain) GetBlockHeadByNumber(arg0 int64) (*block.Block, error) {
+	ret := m.ctrl.Call(m, "GetBlockHeadByNumber", arg0)
+	ret0, _ := ret[0].(*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockHeadByNumber indicates an expected call of GetBlockHeadByNumber
+func (mr *MockChainMockRecorder) GetBlockHeadByNumber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockHeadByNumber", reflect.TypeOf((*MockChain)(nil).GetBlockHeadByNumber), arg0)
+}
+
 // GetHashByNumber mocks base method
 func (m *MockChain) GetHashByNumber(arg0 int64) ([]byte, error) {
 	ret := m.ctrl.Call(m, "GetHashByNumber", arg0)
@@ -195,6 +234,30 @@ func (mr *MockChainMockRecorder) Length() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Length", reflect.TypeOf((*MockChain)(nil).Length))
 }
 
+// Prune mocks base method
+func (m *MockChain) Prune(arg0 int64) error {
+	ret := m.ctrl.Call(m, "Prune", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune
+func (mr *MockChainMockRecorder) Prune(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockChain)(nil).Prune), arg0)
+}
+
+// PrunedNumber mocks base method
+func (m *MockChain) PrunedNumber() int64 {
+	ret := m.ctrl.Call(m, "PrunedNumber")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// PrunedNumber indicates an expected call of PrunedNumber
+func (mr *MockChainMockRecorder) PrunedNumber() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrunedNumber", reflect.TypeOf((*MockChain)(nil).PrunedNumber))
+}
+
 // Push mocks base method
 func (m *MockChain) Push(arg0 *block.Block) error {
 	ret := m.ctrl.Call(m, "Push", arg0)
@@ -233,6 +296,18 @@ func (mr *MockChainMockRecorder) Top() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Top", reflect.TypeOf((*MockChain)(nil).Top))
 }
 
+// Truncate mocks base method
+func (m *MockChain) Truncate(arg0 int64) error {
+	ret := m.ctrl.Call(m, "Truncate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Truncate indicates an expected call of Truncate
+func (mr *MockChainMockRecorder) Truncate(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Truncate", reflect.TypeOf((*MockChain)(nil).Truncate), arg0)
+}
+
 // TxTotal mocks base method
 func (m *MockChain) TxTotal() int64 {
 	ret := m.ctrl.Call(m, "TxTotal")
@@ -21,14 +21,26 @@ const (
 	maxGasRatio = 10000
 	minGasLimit = 500000
 	maxGasLimit = 200000000
-	txSizeLimit = 65536
+	// migration tx may rewrite the whole storage of a contract, so it has a higher gas limit
+	maxMigrationGasLimit = 2000000000
+	txSizeLimit          = 65536
 )
 
 // values
 var (
 	MaxExpiration = int64(90 * time.Second)
+	// MigrationHeight is the number of the first block in which migration txs have the raised gas limit, 0 means it's not activated.
+	MigrationHeight int64
 )
 
+// SetForkConfig sets the heights of the tx rules.
+func SetForkConfig(c *common.ForkConfig) {
+	MigrationHeight = 0
+	if c != nil {
+		MigrationHeight = c.MigrationHeight
+	}
+}
+
 //go:generate protoc  --go_out=plugins=grpc:. ./core/tx/tx.proto
 
 // ToBytesLevel judges which fields of tx should be written to bytes.
@@ -222,6 +234,20 @@ func (t *Tx) IsDefer() bool {
 	return len(t.ReferredTx) > 0
 }
 
+// IsMigration returns whether the transaction only updates contract code,
+// which may run the migrate function of the new code.
+func (t *Tx) IsMigration() bool {
+	if len(t.Actions) == 0 {
+		return false
+	}
+	for _, action := range t.Actions {
+		if action.Contract != "system.iost" || (action.ActionName != "updateCode" && action.ActionName != "applyUpgrade") {
+			return false
+		}
+	}
+	return true
+}
+
 // CanceledDelaytxHash returns the delay transaction hash that is canceled.
 func (t *Tx) CanceledDelaytxHash() ([]byte, bool) {
 	for _, action := range t.Actions {
@@ -372,8 +398,8 @@ func (t *Tx) CheckSize() error {
 	return nil
 }
 
-// CheckGas checks whether the transaction's gas is valid.
-func (t *Tx) CheckGas() error {
+// CheckGas checks whether the transaction's gas is valid in the block of the number.
+func (t *Tx) CheckGas(number int64) error {
 	ratio := 100
 	if t.GasRatio < minGasRatio || t.GasRatio > maxGasRatio {
 		return fmt.Errorf("gas ratio illegal, should in [%v, %v]", minGasRatio/ratio, maxGasRatio/ratio)
@@ -381,8 +407,12 @@ func (t *Tx) CheckGas() error {
 	if t.GasLimit < minGasLimit {
 		return fmt.Errorf("gas limit illegal, should >= %v", minGasLimit/ratio)
 	}
-	if t.GasLimit > maxGasLimit {
-		return fmt.Errorf("gas limit illegal, should <= %v", maxGasLimit/ratio)
+	limit := int64(maxGasLimit)
+	if MigrationHeight > 0 && number >= MigrationHeight && t.IsMigration() {
+		limit = maxMigrationGasLimit
+	}
+	if t.GasLimit > limit {
+		return fmt.Errorf("gas limit illegal, should <= %v", limit/int64(ratio))
 	}
 	return nil
 }
@@ -169,6 +169,24 @@ func TestTx(t *testing.T) {
 			So(err.Error(), ShouldEqual, "signer error")
 		})
 
+		Convey("check gas", func() {
+			tx := NewTx(actions, nil, 300000000, 100, 1, 0)
+			So(tx.IsMigration(), ShouldBeFalse)
+			So(tx.CheckGas(1), ShouldNotBeNil)
+
+			tx.Actions = []*Action{{Contract: "system.iost", ActionName: "updateCode", Data: "[]"}}
+			So(tx.IsMigration(), ShouldBeTrue)
+			// the raised limit of migration txs is activated at MigrationHeight
+			So(tx.CheckGas(1), ShouldNotBeNil)
+			SetForkConfig(&common.ForkConfig{MigrationHeight: 10})
+			defer SetForkConfig(nil)
+			So(tx.CheckGas(9), ShouldNotBeNil)
+			So(tx.CheckGas(10), ShouldBeNil)
+
+			tx.GasLimit = 3000000000
+			So(tx.CheckGas(10), ShouldNotBeNil)
+		})
+
 	})
 }
 
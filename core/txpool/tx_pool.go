@@ -289,7 +289,7 @@ func (pool *TxPImpl) verifyTx(t *tx.Tx) error {
 	if err := t.CheckSize(); err != nil {
 		return err
 	}
-	if err := t.CheckGas(); err != nil {
+	if err := t.CheckGas(pool.blockCache.Head().Head.Number + 1); err != nil {
 		return err
 	}
 	// Add one second delay for tx created time check
@@ -0,0 +1,164 @@
+// Package vrf implements ECVRF-EDWARDS25519-SHA512-TAI of RFC 9381 on ed25519 keys.
+//
+// The output of a proof is determined by the secret key and the input only,
+// so the prover can't choose among several valid outputs.
+package vrf
+
+import (
+	"crypto/sha512"
+	"errors"
+
+	"filippo.io/edwards25519"
+)
+
+// Sizes of the proof and the output.
+const (
+	ProofSize  = 80
+	OutputSize = 64
+)
+
+const (
+	suite = 0x03
+	cLen  = 16
+)
+
+// errors of vrf
+var (
+	ErrSeckey = errors.New("invalid ed25519 secret key")
+	ErrPubkey = errors.New("invalid ed25519 public key")
+	ErrProof  = errors.New("invalid vrf proof")
+)
+
+// Prove returns the proof of alpha with the ed25519 secret key, which is the 64 byte key or its 32 byte seed.
+func Prove(seckey []byte, alpha []byte) ([]byte, error) {
+	if len(seckey) != 32 && len(seckey) != 64 {
+		return nil, ErrSeckey
+	}
+	h := sha512.Sum512(seckey[:32])
+	x, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		return nil, err
+	}
+	y := new(edwards25519.Point).ScalarBaseMult(x)
+	pk := y.Bytes()
+
+	hp, err := encodeToCurve(pk, alpha)
+	if err != nil {
+		return nil, err
+	}
+	hs := hp.Bytes()
+	gamma := new(edwards25519.Point).ScalarMult(x, hp)
+
+	kh := sha512.New()
+	kh.Write(h[32:])
+	kh.Write(hs)
+	k, err := edwards25519.NewScalar().SetUniformBytes(kh.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	u := new(edwards25519.Point).ScalarBaseMult(k)
+	v := new(edwards25519.Point).ScalarMult(k, hp)
+	c := challenge(y, hp, gamma, u, v)
+	s := edwards25519.NewScalar().MultiplyAdd(c, x, k)
+
+	proof := make([]byte, 0, ProofSize)
+	proof = append(proof, gamma.Bytes()...)
+	proof = append(proof, c.Bytes()[:cLen]...)
+	proof = append(proof, s.Bytes()...)
+	return proof, nil
+}
+
+// Verify verifies the proof of alpha with the ed25519 public key, and returns its output.
+func Verify(pubkey []byte, alpha []byte, proof []byte) ([]byte, error) {
+	y, err := new(edwards25519.Point).SetBytes(pubkey)
+	if err != nil || isSmallOrder(y) {
+		return nil, ErrPubkey
+	}
+	gamma, c, s, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	hp, err := encodeToCurve(pubkey, alpha)
+	if err != nil {
+		return nil, err
+	}
+	nc := edwards25519.NewScalar().Negate(c)
+	u := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(nc, y, s)
+	v := new(edwards25519.Point).VarTimeMultiScalarMult([]*edwards25519.Scalar{s, nc}, []*edwards25519.Point{hp, gamma})
+	if challenge(y, hp, gamma, u, v).Equal(c) != 1 {
+		return nil, ErrProof
+	}
+	return output(gamma), nil
+}
+
+// ProofToHash returns the output of the proof without verifying it.
+func ProofToHash(proof []byte) ([]byte, error) {
+	gamma, _, _, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	return output(gamma), nil
+}
+
+func decodeProof(proof []byte) (gamma *edwards25519.Point, c, s *edwards25519.Scalar, err error) {
+	if len(proof) != ProofSize {
+		return nil, nil, nil, ErrProof
+	}
+	gamma, err = new(edwards25519.Point).SetBytes(proof[:32])
+	if err != nil {
+		return nil, nil, nil, ErrProof
+	}
+	var cb [32]byte
+	copy(cb[:], proof[32:32+cLen])
+	c, err = edwards25519.NewScalar().SetCanonicalBytes(cb[:])
+	if err != nil {
+		return nil, nil, nil, ErrProof
+	}
+	s, err = edwards25519.NewScalar().SetCanonicalBytes(proof[32+cLen:])
+	if err != nil {
+		return nil, nil, nil, ErrProof
+	}
+	return gamma, c, s, nil
+}
+
+// encodeToCurve hashes alpha to a point with the try and increment method, salted by the public key.
+func encodeToCurve(pk []byte, alpha []byte) (*edwards25519.Point, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := sha512.New()
+		h.Write([]byte{suite, 0x01})
+		h.Write(pk)
+		h.Write(alpha)
+		h.Write([]byte{byte(ctr), 0x00})
+		p, err := new(edwards25519.Point).SetBytes(h.Sum(nil)[:32])
+		if err == nil {
+			return p.MultByCofactor(p), nil
+		}
+	}
+	return nil, errors.New("failed to hash to curve")
+}
+
+func challenge(points ...*edwards25519.Point) *edwards25519.Scalar {
+	h := sha512.New()
+	h.Write([]byte{suite, 0x02})
+	for _, p := range points {
+		h.Write(p.Bytes())
+	}
+	h.Write([]byte{0x00})
+	var cb [32]byte
+	copy(cb[:], h.Sum(nil)[:cLen])
+	c, _ := edwards25519.NewScalar().SetCanonicalBytes(cb[:])
+	return c
+}
+
+func output(gamma *edwards25519.Point) []byte {
+	h := sha512.New()
+	h.Write([]byte{suite, 0x03})
+	h.Write(new(edwards25519.Point).MultByCofactor(gamma).Bytes())
+	h.Write([]byte{0x00})
+	return h.Sum(nil)
+}
+
+func isSmallOrder(p *edwards25519.Point) bool {
+	return new(edwards25519.Point).MultByCofactor(p).Equal(edwards25519.NewIdentityPoint()) == 1
+}
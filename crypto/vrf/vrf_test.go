@@ -0,0 +1,88 @@
+package vrf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// test vector of ECVRF-EDWARDS25519-SHA512-TAI in RFC 9381 appendix B.3
+var vectors = []struct {
+	sk, pk, alpha, pi, beta string
+}{
+	{
+		sk:    "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		pk:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		alpha: "",
+		pi:    "8657106690b5526245a92b003bb079ccd1a92130477671f6fc01ad16f26f723f26f8a57ccaed74ee1b190bed1f479d9727d2d0f9b005a6e456a35d4fb0daab1268a1b0db10836d9826a528ca76567805",
+		beta:  "90cf1df3b703cce59e2a35b925d411164068269d7b2d29f3301c03dd757876ff66b71dda49d2de59d03450451af026798e8f81cd2e333de5cdf4f3e140fdd8ae",
+	},
+}
+
+func decode(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		sk := decode(t, v.sk)
+		alpha := decode(t, v.alpha)
+		if pk := ed25519.NewKeyFromSeed(sk).Public().(ed25519.PublicKey); !bytes.Equal(pk, decode(t, v.pk)) {
+			t.Fatalf("wrong public key %x", pk)
+		}
+		proof, err := Prove(sk, alpha)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(proof, decode(t, v.pi)) {
+			t.Fatalf("wrong proof %x", proof)
+		}
+		beta, err := Verify(decode(t, v.pk), alpha, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(beta, decode(t, v.beta)) {
+			t.Fatalf("wrong output %x", beta)
+		}
+		if beta, _ := ProofToHash(proof); !bytes.Equal(beta, decode(t, v.beta)) {
+			t.Fatalf("wrong output %x", beta)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	pk, sk, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := Prove(sk, []byte("alpha"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(pk, []byte("alpha"), proof); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(pk, []byte("other"), proof); err != ErrProof {
+		t.Fatalf("proof of other alpha passed: %v", err)
+	}
+	other, _, _ := ed25519.GenerateKey(nil)
+	if _, err := Verify(other, []byte("alpha"), proof); err != ErrProof {
+		t.Fatalf("proof of other key passed: %v", err)
+	}
+	proof[40] ^= 1
+	if _, err := Verify(pk, []byte("alpha"), proof); err != ErrProof {
+		t.Fatalf("tampered proof passed: %v", err)
+	}
+	if _, err := Verify(pk, []byte("alpha"), proof[:79]); err != ErrProof {
+		t.Fatalf("short proof passed: %v", err)
+	}
+	if _, err := Verify(make([]byte, 32), []byte("alpha"), proof); err != ErrPubkey {
+		t.Fatalf("small order key passed: %v", err)
+	}
+}
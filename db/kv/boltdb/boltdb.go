@@ -0,0 +1,262 @@
+package boltdb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	bucketName = []byte("kv")
+	dbFileName = "bolt.db"
+)
+
+// iterBatchSize is the number of items an iterator loads in one read transaction.
+const iterBatchSize = 256
+
+type op struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// DB is the boltdb database
+type DB struct {
+	db    *bolt.DB
+	path  string
+	batch []op
+}
+
+// NewDB return new boltdb, the data is saved in a file under the path directory
+func NewDB(path string) (*DB, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	file := filepath.Join(path, dbFileName)
+	db, err := bolt.Open(file, 0644, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &DB{
+		db:    db,
+		path:  file,
+		batch: nil,
+	}, nil
+}
+
+// Get return the value of the specify key
+func (d *DB) Get(key []byte) ([]byte, error) {
+	value := []byte{}
+	err := d.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketName).Get(key)
+		if v != nil {
+			value = append(value, v...)
+		}
+		return nil
+	})
+	return value, err
+}
+
+// Has returns whether the specified key exists
+func (d *DB) Has(key []byte) (bool, error) {
+	var has bool
+	err := d.db.View(func(tx *bolt.Tx) error {
+		has = tx.Bucket(bucketName).Get(key) != nil
+		return nil
+	})
+	return has, err
+}
+
+// Put will insert the key-value pair
+func (d *DB) Put(key []byte, value []byte) error {
+	if d.batch == nil {
+		return d.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(bucketName).Put(key, value)
+		})
+	}
+	d.batch = append(d.batch, op{
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+	return nil
+}
+
+// Delete will remove the specify key
+func (d *DB) Delete(key []byte) error {
+	if d.batch == nil {
+		return d.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket(bucketName).Delete(key)
+		})
+	}
+	d.batch = append(d.batch, op{
+		key:    append([]byte{}, key...),
+		delete: true,
+	})
+	return nil
+}
+
+// Keys returns the list of key prefixed with prefix
+func (d *DB) Keys(prefix []byte) ([][]byte, error) {
+	keys := make([][]byte, 0)
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketName).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, append([]byte{}, k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// BeginBatch will start the batch transaction
+func (d *DB) BeginBatch() error {
+	if d.batch != nil {
+		return fmt.Errorf("not support nested batch write")
+	}
+	d.batch = make([]op, 0)
+	return nil
+}
+
+// CommitBatch will commit the batch transaction
+func (d *DB) CommitBatch() error {
+	if d.batch == nil {
+		return fmt.Errorf("no batch write to commit")
+	}
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		for _, o := range d.batch {
+			var err error
+			if o.delete {
+				err = b.Delete(o.key)
+			} else {
+				err = b.Put(o.key, o.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.batch = nil
+	return nil
+}
+
+// Size returns the size of boltdb
+func (d *DB) Size() (int64, error) {
+	fi, err := os.Stat(d.path)
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), nil
+}
+
+// Close will close the database
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// NewIteratorByPrefix returns a new iterator by prefix
+func (d *DB) NewIteratorByPrefix(prefix []byte) interface{} {
+	return &Iter{
+		db:     d.db,
+		prefix: append([]byte{}, prefix...),
+		seek:   append([]byte{}, prefix...),
+		index:  -1,
+	}
+}
+
+// Iter is the iterator for boltdb.
+// It loads items in small read transactions, so that writes are not blocked by an unreleased iterator.
+type Iter struct {
+	db     *bolt.DB
+	prefix []byte
+	seek   []byte
+	keys   [][]byte
+	values [][]byte
+	index  int
+	done   bool
+	err    error
+}
+
+func (i *Iter) load() {
+	i.keys = i.keys[:0]
+	i.values = i.values[:0]
+	i.index = 0
+	i.err = i.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketName).Cursor()
+		for k, v := c.Seek(i.seek); k != nil && bytes.HasPrefix(k, i.prefix); k, v = c.Next() {
+			if len(i.keys) == iterBatchSize {
+				return nil
+			}
+			i.keys = append(i.keys, append([]byte{}, k...))
+			i.values = append(i.values, append([]byte{}, v...))
+		}
+		i.done = true
+		return nil
+	})
+	if len(i.keys) > 0 {
+		// the next key after the last loaded one
+		i.seek = append(append([]byte{}, i.keys[len(i.keys)-1]...), 0)
+	}
+}
+
+// Next do next item of iterator
+func (i *Iter) Next() bool {
+	if i.err != nil {
+		return false
+	}
+	i.index++
+	if i.index < len(i.keys) {
+		return true
+	}
+	if i.done {
+		return false
+	}
+	i.load()
+	return i.err == nil && i.index < len(i.keys)
+}
+
+// Key returns the key of current item
+func (i *Iter) Key() []byte {
+	if i.index < 0 || i.index >= len(i.keys) {
+		return nil
+	}
+	return i.keys[i.index]
+}
+
+// Value returns the value of current item
+func (i *Iter) Value() []byte {
+	if i.index < 0 || i.index >= len(i.values) {
+		return nil
+	}
+	return i.values[i.index]
+}
+
+// Error returns the error of iterator
+func (i *Iter) Error() error {
+	return i.err
+}
+
+// Release will release the iterator
+func (i *Iter) Release() {
+	i.keys = nil
+	i.values = nil
+	i.done = true
+}
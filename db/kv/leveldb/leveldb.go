@@ -5,6 +5,7 @@ import (
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/storage"
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
@@ -26,6 +27,18 @@ func NewDB(path string) (*DB, error) {
 	}, nil
 }
 
+// NewMemDB returns new leveldb kept in memory, the data is lost after closed
+func NewMemDB() (*DB, error) {
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DB{
+		db:    db,
+		batch: nil,
+	}, nil
+}
+
 // Get return the value of the specify key
 func (d *DB) Get(key []byte) ([]byte, error) {
 	value, err := d.db.Get(key, nil)
@@ -1,18 +1,54 @@
 package kv
 
 import (
+	"fmt"
+
+	"github.com/iost-official/go-iost/db/kv/boltdb"
 	"github.com/iost-official/go-iost/db/kv/leveldb"
 )
 
-// StorageType is the type of storage, include leveldb and rocksdb
+// StorageType is the type of storage, include leveldb, boltdb and memory
 type StorageType uint8
 
 // Storage type constant
 const (
 	_ StorageType = iota
 	LevelDBStorage
+	BoltDBStorage
+	MemoryStorage
 )
 
+// copyBatchSize is the number of items written in one batch by Copy.
+const copyBatchSize = 10000
+
+// ParseStorageType returns the storage type of the name, empty name means leveldb.
+func ParseStorageType(name string) (StorageType, error) {
+	switch name {
+	case "", "leveldb":
+		return LevelDBStorage, nil
+	case "boltdb":
+		return BoltDBStorage, nil
+	case "memory":
+		return MemoryStorage, nil
+	default:
+		return 0, fmt.Errorf("unknown storage type: %v", name)
+	}
+}
+
+// String returns the name of the storage type.
+func (t StorageType) String() string {
+	switch t {
+	case LevelDBStorage:
+		return "leveldb"
+	case BoltDBStorage:
+		return "boltdb"
+	case MemoryStorage:
+		return "memory"
+	default:
+		return fmt.Sprintf("StorageType(%d)", uint8(t))
+	}
+}
+
 // StorageBackend is the storage backend interface
 type StorageBackend interface {
 	Get(key []byte) ([]byte, error)
@@ -32,7 +68,7 @@ type Storage struct {
 	StorageBackend
 }
 
-// NewStorage return the storage of the specify type
+// NewStorage return the storage of the specify type, the path is ignored by memory storage
 func NewStorage(path string, t StorageType) (*Storage, error) {
 	switch t {
 	case LevelDBStorage:
@@ -41,13 +77,49 @@ func NewStorage(path string, t StorageType) (*Storage, error) {
 			return nil, err
 		}
 		return &Storage{StorageBackend: sb}, nil
-	default:
-		sb, err := leveldb.NewDB(path)
+	case BoltDBStorage:
+		sb, err := boltdb.NewDB(path)
 		if err != nil {
 			return nil, err
 		}
 		return &Storage{StorageBackend: sb}, nil
+	case MemoryStorage:
+		sb, err := leveldb.NewMemDB()
+		if err != nil {
+			return nil, err
+		}
+		return &Storage{StorageBackend: sb}, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage type: %v", t)
+	}
+}
+
+// Copy copies all the items of src to dst, and returns the number of items copied.
+func Copy(dst *Storage, src *Storage) (int64, error) {
+	iter := src.NewIteratorByPrefix(nil)
+	defer iter.Release()
+	var count int64
+	if err := dst.BeginBatch(); err != nil {
+		return 0, err
+	}
+	for iter.Next() {
+		if err := dst.Put(iter.Key(), iter.Value()); err != nil {
+			return count, err
+		}
+		count++
+		if count%copyBatchSize == 0 {
+			if err := dst.CommitBatch(); err != nil {
+				return count, err
+			}
+			if err := dst.BeginBatch(); err != nil {
+				return count, err
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return count, err
 	}
+	return count, dst.CommitBatch()
 }
 
 // NewIteratorByPrefix returns a new iterator by prefix
@@ -2,6 +2,8 @@ package kv
 
 import (
 	"crypto/rand"
+	"fmt"
+	"os"
 	"os/exec"
 	"reflect"
 	"testing"
@@ -14,6 +16,8 @@ const (
 	DBPATH = "db"
 )
 
+var storageTypes = []StorageType{LevelDBStorage, BoltDBStorage, MemoryStorage}
+
 type StorageTestSuite struct {
 	suite.Suite
 	storage *Storage
@@ -92,6 +96,58 @@ func (suite *StorageTestSuite) TestDelete() {
 	suite.Equal([]byte("value05"), value)
 }
 
+func (suite *StorageTestSuite) TestHas() {
+	has, err := suite.storage.Has([]byte("key01"))
+	suite.Nil(err)
+	suite.True(has)
+	has, err = suite.storage.Has([]byte("key06"))
+	suite.Nil(err)
+	suite.False(has)
+}
+
+func (suite *StorageTestSuite) TestIterator() {
+	iter := suite.storage.NewIteratorByPrefix([]byte("iost"))
+	keys := make([]string, 0)
+	values := make([]string, 0)
+	for iter.Next() {
+		keys = append(keys, string(iter.Key()))
+		values = append(values, string(iter.Value()))
+	}
+	iter.Release()
+	suite.Nil(iter.Error())
+	suite.Equal([]string{"iost01", "iost02", "iost03", "iost04", "iost05"}, keys)
+	suite.Equal([]string{"value06", "value07", "value08", "value09", "value10"}, values)
+
+	iter = suite.storage.NewIteratorByPrefix([]byte("none"))
+	suite.False(iter.Next())
+	iter.Release()
+	suite.Nil(iter.Error())
+
+	suite.storage.BeginBatch()
+	for i := 0; i < 1000; i++ {
+		suite.storage.Put([]byte(fmt.Sprintf("many%04d", i)), []byte("value"))
+	}
+	suite.Nil(suite.storage.CommitBatch())
+	iter = suite.storage.NewIteratorByPrefix([]byte("many"))
+	count := 0
+	for iter.Next() {
+		suite.Equal(fmt.Sprintf("many%04d", count), string(iter.Key()))
+		count++
+	}
+	iter.Release()
+	suite.Nil(iter.Error())
+	suite.Equal(1000, count)
+
+	iter = suite.storage.NewIteratorByPrefix(nil)
+	count = 0
+	for iter.Next() {
+		count++
+	}
+	iter.Release()
+	suite.Nil(iter.Error())
+	suite.Equal(1010, count)
+}
+
 func (suite *StorageTestSuite) TestKeys() {
 	var keys [][]byte
 	var err error
@@ -163,6 +219,9 @@ func (suite *StorageTestSuite) TestBatch() {
 }
 
 func (suite *StorageTestSuite) TestRecover() {
+	if suite.t == MemoryStorage {
+		suite.T().Skip("memory storage is dropped when closed")
+	}
 	var value []byte
 	var err error
 
@@ -207,17 +266,85 @@ func (suite *StorageTestSuite) TestRecover() {
 func (suite *StorageTestSuite) TearDownTest() {
 	err := suite.storage.Close()
 	suite.Nil(err)
-	cmd := exec.Command("rm", "-r", DBPATH)
-	err = cmd.Run()
+	err = os.RemoveAll(DBPATH)
 	suite.Require().Nil(err)
 }
 
 func TestStorageTestSuite(t *testing.T) {
-	suite.Run(t, &StorageTestSuite{t: LevelDBStorage})
+	for _, st := range storageTypes {
+		t.Run(st.String(), func(t *testing.T) {
+			suite.Run(t, &StorageTestSuite{t: st})
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	for _, from := range storageTypes {
+		for _, to := range storageTypes {
+			src, err := NewStorage(DBPATH+"src", from)
+			assert.Nil(t, err)
+			dst, err := NewStorage(DBPATH+"dst", to)
+			assert.Nil(t, err)
+			src.BeginBatch()
+			for i := 0; i < 25000; i++ {
+				src.Put([]byte(fmt.Sprintf("key%05d", i)), []byte(fmt.Sprintf("value%05d", i)))
+			}
+			assert.Nil(t, src.CommitBatch())
+
+			count, err := Copy(dst, src)
+			assert.Nil(t, err)
+			assert.Equal(t, int64(25000), count)
+			value, err := dst.Get([]byte("key12345"))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte("value12345"), value)
+			keys, err := dst.Keys([]byte("key"))
+			assert.Nil(t, err)
+			assert.Equal(t, 25000, len(keys))
+
+			src.Close()
+			dst.Close()
+			os.RemoveAll(DBPATH + "src")
+			os.RemoveAll(DBPATH + "dst")
+		}
+	}
+}
+
+func TestParseStorageType(t *testing.T) {
+	for _, st := range storageTypes {
+		parsed, err := ParseStorageType(st.String())
+		assert.Nil(t, err)
+		assert.Equal(t, st, parsed)
+	}
+	parsed, err := ParseStorageType("")
+	assert.Nil(t, err)
+	assert.Equal(t, LevelDBStorage, parsed)
+	_, err = ParseStorageType("rocksdb")
+	assert.NotNil(t, err)
+	_, err = NewStorage(DBPATH, StorageType(0))
+	assert.NotNil(t, err)
+}
+
+func TestMemoryStorage(t *testing.T) {
+	storage, err := NewStorage(DBPATH, MemoryStorage)
+	assert.Nil(t, err)
+	assert.Nil(t, storage.Put([]byte("key01"), []byte("value01")))
+	value, err := storage.Get([]byte("key01"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value01"), value)
+	_, err = os.Stat(DBPATH)
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, storage.Close())
+
+	storage, err = NewStorage(DBPATH, MemoryStorage)
+	assert.Nil(t, err)
+	value, err = storage.Get([]byte("key01"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{}, value)
+	assert.Nil(t, storage.Close())
 }
 
 func BenchmarkStorage(b *testing.B) {
-	for _, t := range []StorageType{LevelDBStorage} {
+	for _, t := range storageTypes {
 		storage, err := NewStorage(DBPATH, t)
 		if err != nil {
 			b.Fatalf("Failed to new storage: %v", err)
@@ -269,7 +396,7 @@ func BenchmarkStorage(b *testing.B) {
 }
 
 func BenchmarkKeys(b *testing.B) {
-	for _, t := range []StorageType{LevelDBStorage} {
+	for _, t := range storageTypes {
 		storage, err := NewStorage(DBPATH, t)
 		if err != nil {
 			b.Fatalf("Failed to new storage: %v", err)
@@ -198,3 +198,15 @@ func (m *MockMVCCDB) Tag(arg0 string) {
 func (mr *MockMVCCDBMockRecorder) Tag(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tag", reflect.TypeOf((*MockMVCCDB)(nil).Tag), arg0)
 }
+
+// Undo mocks base method
+func (m *MockMVCCDB) Undo() error {
+	ret := m.ctrl.Call(m, "Undo")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Undo indicates an expected call of Undo
+func (mr *MockMVCCDBMockRecorder) Undo() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockMVCCDB)(nil).Undo))
+}
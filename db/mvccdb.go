@@ -1,7 +1,9 @@
 package db
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"sync"
 
 	"github.com/iost-official/go-iost/db/kv"
@@ -18,8 +20,13 @@ const (
 // error of mvccdb
 var (
 	ErrTableNotValid = fmt.Errorf("table name is not valid")
+	ErrNoUndo        = fmt.Errorf("no flush to undo")
 )
 
+// UndoDepth is the number of latest flushes kept in the undo journal, 0 disables the journal, which is the default.
+// It's set by db.undodepth in the config of iserver.
+var UndoDepth int64
+
 // MVCCDB is the interface of mvccdb
 type MVCCDB interface {
 	Get(table string, key string) (string, error)
@@ -34,13 +41,19 @@ type MVCCDB interface {
 	CurrentTag() string
 	Fork() MVCCDB
 	Flush(t string) error
+	Undo() error
 	Size() (int64, error)
 	Close() error
 }
 
 // NewMVCCDB return new mvccdb
 func NewMVCCDB(path string) (MVCCDB, error) {
-	return NewCacheMVCCDB(path, mvcc.MapCache)
+	return NewCacheMVCCDB(path, mvcc.MapCache, kv.LevelDBStorage)
+}
+
+// NewMVCCDBWithStorage returns the mvccdb saved in the storage of type t
+func NewMVCCDBWithStorage(path string, t kv.StorageType) (MVCCDB, error) {
+	return NewCacheMVCCDB(path, mvcc.MapCache, t)
 }
 
 // Item is the value of cache
@@ -146,16 +159,30 @@ func (m *CommitManager) FreeBefore(c *Commit) {
 
 // CacheMVCCDB is the mvcc db with cache
 type CacheMVCCDB struct {
-	head    *Commit
-	rwmu    sync.RWMutex
-	stage   *Commit
-	storage *kv.Storage
-	cm      *CommitManager
+	head      *Commit
+	rwmu      sync.RWMutex
+	stage     *Commit
+	storage   *kv.Storage
+	cm        *CommitManager
+	cacheType mvcc.CacheType
+}
+
+// undoEntry is the value of a key in storage before a flush
+type undoEntry struct {
+	Key   []byte
+	Value []byte
+	Exist bool
+}
+
+// undoLog records what a flush overwrote, so that the flush can be reverted
+type undoLog struct {
+	Tag     []byte
+	Entries []undoEntry
 }
 
 // NewCacheMVCCDB returns new CacheMVCCDB
-func NewCacheMVCCDB(path string, cacheType mvcc.CacheType) (*CacheMVCCDB, error) {
-	storage, err := kv.NewStorage(path, kv.LevelDBStorage)
+func NewCacheMVCCDB(path string, cacheType mvcc.CacheType, storageType kv.StorageType) (*CacheMVCCDB, error) {
+	storage, err := kv.NewStorage(path, storageType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to new storage: %v", err)
 	}
@@ -170,10 +197,11 @@ func NewCacheMVCCDB(path string, cacheType mvcc.CacheType) (*CacheMVCCDB, error)
 	cm.AddTag(head, string(tag))
 	cm.Add(head)
 	mvccdb := &CacheMVCCDB{
-		head:    head,
-		stage:   stage,
-		storage: storage,
-		cm:      cm,
+		head:      head,
+		stage:     stage,
+		storage:   storage,
+		cm:        cm,
+		cacheType: cacheType,
 	}
 	return mvccdb, nil
 }
@@ -350,10 +378,11 @@ func (m *CacheMVCCDB) Fork() MVCCDB {
 	defer m.rwmu.RUnlock()
 
 	mvccdb := &CacheMVCCDB{
-		head:    m.head,
-		stage:   m.head.Fork(),
-		storage: m.storage,
-		cm:      m.cm,
+		head:      m.head,
+		stage:     m.head.Fork(),
+		storage:   m.storage,
+		cm:        m.cm,
+		cacheType: m.cacheType,
 	}
 	return mvccdb
 }
@@ -364,18 +393,29 @@ func (m *CacheMVCCDB) Flush(t string) error {
 	if commit == nil {
 		return fmt.Errorf("not found tag: %v", t)
 	}
+	items := make([]*Item, 0)
+	for _, v := range commit.All([]byte("")) {
+		item, ok := v.(*Item)
+		if !ok {
+			return fmt.Errorf("can't assert Item type")
+		}
+		items = append(items, item)
+	}
+	undo, err := m.undoLog(items)
+	if err != nil {
+		return err
+	}
 	if err := m.storage.BeginBatch(); err != nil {
 		return err
 	}
-	err := m.storage.Put([]byte(string(SEPARATOR)+"tag"), []byte(t))
+	err = m.storage.Put([]byte(string(SEPARATOR)+"tag"), []byte(t))
 	if err != nil {
 		return err
 	}
-	for _, v := range commit.All([]byte("")) {
-		item, ok := v.(*Item)
-		if !ok {
-			return fmt.Errorf("can't assert Item type")
-		}
+	if err := m.putUndoLog(undo); err != nil {
+		return err
+	}
+	for _, item := range items {
 		if item.deleted {
 			err := m.storage.Delete([]byte(item.table + string(SEPARATOR) + item.key))
 			if err != nil {
@@ -395,6 +435,126 @@ func (m *CacheMVCCDB) Flush(t string) error {
 	return nil
 }
 
+func undoKey(seq int64) []byte {
+	return []byte(string(SEPARATOR) + "undo" + string(SEPARATOR) + strconv.FormatInt(seq, 10))
+}
+
+func (m *CacheMVCCDB) undoSeq() (int64, error) {
+	v, err := m.storage.Get([]byte(string(SEPARATOR) + "undo"))
+	if err != nil || len(v) == 0 {
+		return 0, err
+	}
+	return strconv.ParseInt(string(v), 10, 64)
+}
+
+// undoLog returns the values in storage the items will overwrite
+func (m *CacheMVCCDB) undoLog(items []*Item) (*undoLog, error) {
+	if UndoDepth <= 0 {
+		return nil, nil
+	}
+	tag, err := m.storage.Get([]byte(string(SEPARATOR) + "tag"))
+	if err != nil {
+		return nil, err
+	}
+	undo := &undoLog{Tag: tag, Entries: make([]undoEntry, 0, len(items))}
+	for _, item := range items {
+		k := []byte(item.table + string(SEPARATOR) + item.key)
+		exist, err := m.storage.Has(k)
+		if err != nil {
+			return nil, err
+		}
+		e := undoEntry{Key: k, Exist: exist}
+		if exist {
+			if e.Value, err = m.storage.Get(k); err != nil {
+				return nil, err
+			}
+		}
+		undo.Entries = append(undo.Entries, e)
+	}
+	return undo, nil
+}
+
+// putUndoLog appends the undo log to the journal and drops the ones out of UndoDepth
+func (m *CacheMVCCDB) putUndoLog(undo *undoLog) error {
+	if undo == nil {
+		return nil
+	}
+	seq, err := m.undoSeq()
+	if err != nil {
+		return err
+	}
+	seq++
+	b, err := json.Marshal(undo)
+	if err != nil {
+		return err
+	}
+	if err := m.storage.Put(undoKey(seq), b); err != nil {
+		return err
+	}
+	if err := m.storage.Put([]byte(string(SEPARATOR)+"undo"), []byte(strconv.FormatInt(seq, 10))); err != nil {
+		return err
+	}
+	return m.storage.Delete(undoKey(seq - UndoDepth))
+}
+
+// Undo reverts the latest flush in the undo journal, and resets the mvccdb to the tag before it.
+// All the uncommitted states and forks of the mvccdb are dropped, so it should be only used offline.
+func (m *CacheMVCCDB) Undo() error {
+	m.rwmu.Lock()
+	defer m.rwmu.Unlock()
+
+	seq, err := m.undoSeq()
+	if err != nil {
+		return err
+	}
+	if seq <= 0 {
+		return ErrNoUndo
+	}
+	b, err := m.storage.Get(undoKey(seq))
+	if err != nil {
+		return err
+	}
+	if len(b) == 0 {
+		return ErrNoUndo
+	}
+	undo := &undoLog{}
+	if err := json.Unmarshal(b, undo); err != nil {
+		return fmt.Errorf("failed to decode undo log %v: %v", seq, err)
+	}
+	if err := m.storage.BeginBatch(); err != nil {
+		return err
+	}
+	for _, e := range undo.Entries {
+		if e.Exist {
+			err = m.storage.Put(e.Key, e.Value)
+		} else {
+			err = m.storage.Delete(e.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := m.storage.Put([]byte(string(SEPARATOR)+"tag"), undo.Tag); err != nil {
+		return err
+	}
+	if err := m.storage.Delete(undoKey(seq)); err != nil {
+		return err
+	}
+	if err := m.storage.Put([]byte(string(SEPARATOR)+"undo"), []byte(strconv.FormatInt(seq-1, 10))); err != nil {
+		return err
+	}
+	if err := m.storage.CommitBatch(); err != nil {
+		return err
+	}
+
+	m.head = NewCommit(m.cacheType)
+	m.stage = m.head.Fork()
+	m.cm = NewCommitManager()
+	m.cm.AddTag(m.head, string(undo.Tag))
+	m.cm.Add(m.head)
+	return nil
+}
+
 // Size returns the size of mvccdb
 func (m *CacheMVCCDB) Size() (int64, error) {
 	return m.storage.Size()
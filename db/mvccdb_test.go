@@ -278,6 +278,59 @@ func (suite *MVCCDBTestSuite) TestFlushAndCheckout() {
 	suite.Equal("", value)
 }
 
+func (suite *MVCCDBTestSuite) TestUndoDisabled() {
+	suite.mvccdb.Tag("tag01")
+	err := suite.mvccdb.Flush("tag01")
+	suite.Nil(err)
+
+	err = suite.mvccdb.Undo()
+	suite.Equal(ErrNoUndo, err)
+}
+
+func (suite *MVCCDBTestSuite) TestUndo() {
+	var value string
+	UndoDepth = 1000
+	defer func() { UndoDepth = 0 }()
+
+	suite.mvccdb.Tag("tag01")
+	err := suite.mvccdb.Flush("tag01")
+	suite.Nil(err)
+
+	err = suite.mvccdb.Put("table01", "key01", "value11")
+	suite.Nil(err)
+	err = suite.mvccdb.Put("table01", "key06", "value06")
+	suite.Nil(err)
+	err = suite.mvccdb.Del("table01", "key04")
+	suite.Nil(err)
+	suite.mvccdb.Tag("tag02")
+	err = suite.mvccdb.Flush("tag02")
+	suite.Nil(err)
+	suite.Equal("tag02", suite.mvccdb.CurrentTag())
+
+	err = suite.mvccdb.Undo()
+	suite.Nil(err)
+	suite.Equal("tag01", suite.mvccdb.CurrentTag())
+	value, err = suite.mvccdb.Get("table01", "key01")
+	suite.Nil(err)
+	suite.Equal("value01", value)
+	has, err := suite.mvccdb.Has("table01", "key06")
+	suite.Nil(err)
+	suite.False(has)
+	value, err = suite.mvccdb.Get("table01", "key04")
+	suite.Nil(err)
+	suite.Equal("value04", value)
+
+	err = suite.mvccdb.Undo()
+	suite.Nil(err)
+	suite.Equal("", suite.mvccdb.CurrentTag())
+	has, err = suite.mvccdb.Has("table01", "key01")
+	suite.Nil(err)
+	suite.False(has)
+
+	err = suite.mvccdb.Undo()
+	suite.Equal(ErrNoUndo, err)
+}
+
 func (suite *MVCCDBTestSuite) TearDownTest() {
 	err := suite.mvccdb.Close()
 	suite.Nil(err, "Close MVCCDB should not fail")
@@ -0,0 +1,217 @@
+package iserver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/consensus/cverifier"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/verifier"
+)
+
+// maxArchiveBlockSize is the maximum size of a block record in the archive.
+const maxArchiveBlockSize = 64 * 1024 * 1024
+
+// ExportBlocks writes the blocks in [from, to] of the block chain to w.
+// Each block is written as a uvarint length followed by the protobuf encoded blockpb.Block.
+func ExportBlocks(chain block.Chain, from int64, to int64, w io.Writer) (int64, error) {
+	if from < 0 || to < from {
+		return 0, fmt.Errorf("invalid block range [%v, %v]", from, to)
+	}
+	if to >= chain.Length() {
+		return 0, fmt.Errorf("block %v is not in the block chain, length: %v", to, chain.Length())
+	}
+	bw := bufio.NewWriter(w)
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	var count int64
+	for i := from; i <= to; i++ {
+		blk, err := chain.GetBlockByNumber(i)
+		if err != nil {
+			return count, fmt.Errorf("get block %v failed. err: %v", i, err)
+		}
+		b, err := blk.Encode()
+		if err != nil {
+			return count, fmt.Errorf("encode block %v failed. err: %v", i, err)
+		}
+		n := binary.PutUvarint(lenBuf, uint64(len(b)))
+		if _, err := bw.Write(lenBuf[:n]); err != nil {
+			return count, err
+		}
+		if _, err := bw.Write(b); err != nil {
+			return count, err
+		}
+		count++
+		if count%10000 == 0 {
+			ilog.Infof("exported %v blocks, current number: %v", count, i)
+		}
+	}
+	return count, bw.Flush()
+}
+
+// ImportBlocks reads the blocks written by ExportBlocks from r, verifies and applies them to the block chain and the state db.
+// Blocks already in the block chain are skipped. Every block should be produced in the slot of its witness.
+// If skipSign is true, signatures of blocks and txs are not checked, which should only be used with trusted archives.
+func ImportBlocks(bv global.BaseVariable, r io.Reader, skipSign bool) (int64, error) {
+	if err := checkGenesis(bv); err != nil {
+		return 0, err
+	}
+	if err := recoverDB(bv); err != nil {
+		return 0, err
+	}
+	chain := bv.BlockChain()
+	parent, err := chain.Top()
+	if err != nil {
+		return 0, err
+	}
+	schedule, err := newWitnessSchedule(bv)
+	if err != nil {
+		return 0, err
+	}
+
+	br := bufio.NewReader(r)
+	var count int64
+	for {
+		blk, err := readArchiveBlock(br)
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+		if blk.Head.Number <= parent.Head.Number {
+			hash, err := chain.GetHashByNumber(blk.Head.Number)
+			if err != nil || !bytes.Equal(hash, blk.HeadHash()) {
+				return count, fmt.Errorf("block %v conflicts with the block chain", blk.Head.Number)
+			}
+			continue
+		}
+		if err := importBlock(bv, blk, parent, schedule, skipSign); err != nil {
+			return count, fmt.Errorf("import block %v failed. err: %v", blk.Head.Number, err)
+		}
+		parent = blk
+		count++
+		if count%10000 == 0 {
+			ilog.Infof("imported %v blocks, current number: %v", count, blk.Head.Number)
+		}
+	}
+}
+
+func readArchiveBlock(br *bufio.Reader) (*block.Block, error) {
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxArchiveBlockSize {
+		return nil, fmt.Errorf("block record too large: %v", size)
+	}
+	b := make([]byte, size)
+	if _, err := io.ReadFull(br, b); err != nil {
+		return nil, fmt.Errorf("read block record failed. err: %v", err)
+	}
+	var blk block.Block
+	if err := blk.Decode(b); err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
+
+// witnessSchedule follows the witness lists in the state db while the blocks are imported.
+type witnessSchedule struct {
+	blockcache.WitnessList
+}
+
+func newWitnessSchedule(bv global.BaseVariable) (*witnessSchedule, error) {
+	s := &witnessSchedule{}
+	if err := s.UpdatePending(bv.StateDB()); err != nil {
+		return nil, fmt.Errorf("get witness list failed. err: %v", err)
+	}
+	s.LibWitnessHandle()
+	return s, nil
+}
+
+// verify checks that the block is produced in the slot of its witness. The pending list replaces the active list
+// when the rotation becomes irreversible, so a block scheduled by the pending list moves the schedule to it.
+func (s *witnessSchedule) verify(head *block.BlockHead) error {
+	if cverifier.VerifyBlockWitness(head, s.Active()) == nil {
+		return nil
+	}
+	if err := cverifier.VerifyBlockWitness(head, s.Pending()); err != nil {
+		return err
+	}
+	s.LibWitnessHandle()
+	return nil
+}
+
+// update reads the pending list from the state db, which is changed only by the blocks of the vote interval.
+func (s *witnessSchedule) update(head *block.BlockHead, bv global.BaseVariable) error {
+	if head.Number%common.VoteInterval != 0 {
+		return nil
+	}
+	return s.UpdatePending(bv.StateDB())
+}
+
+func importBlock(bv global.BaseVariable, blk *block.Block, parent *block.Block, schedule *witnessSchedule, skipSign bool) error {
+	chain := bv.BlockChain()
+	stateDB := bv.StateDB()
+
+	if err := cverifier.VerifyBlockHead(blk, parent, parent); err != nil {
+		return err
+	}
+	if err := schedule.verify(blk.Head); err != nil {
+		return err
+	}
+	if len(blk.Txs) != len(blk.Receipts) {
+		return fmt.Errorf("txs and receipts not match")
+	}
+	if !skipSign {
+		if err := cverifier.VerifyBlockSign(blk.Head, blk.Sign); err != nil {
+			return err
+		}
+	}
+	for i, t := range blk.Txs {
+		if i == 0 {
+			// base tx
+			continue
+		}
+		if !skipSign {
+			if err := t.VerifySelf(); err != nil {
+				return err
+			}
+		}
+		if t.IsDefer() {
+			referredTx, err := chain.GetTx(t.ReferredTx)
+			if err != nil {
+				return fmt.Errorf("get referred tx error, %v", err)
+			}
+			if err := t.VerifyDefer(referredTx); err != nil {
+				return err
+			}
+		}
+	}
+
+	v := verifier.Verifier{}
+	err := v.Verify(blk, parent, stateDB, &verifier.Config{
+		Mode:        0,
+		Timeout:     common.SlotLength / 3 * time.Second,
+		TxTimeLimit: time.Millisecond * 100,
+	})
+	if err != nil {
+		return fmt.Errorf("verify block with VM failed. err: %v", err)
+	}
+	stateDB.Tag(string(blk.HeadHash()))
+	if err := chain.Push(blk); err != nil {
+		return err
+	}
+	if err := stateDB.Flush(string(blk.HeadHash())); err != nil {
+		return err
+	}
+	return schedule.update(blk.Head, bv)
+}
@@ -0,0 +1,152 @@
+package iserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/core/txpool"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/ilog"
+	"github.com/iost-official/go-iost/verifier"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testConfig(path string) *common.Config {
+	conf := common.NewConfig("../config/iserver.yml")
+	conf.Genesis = "../config/genesis"
+	conf.DB.LdbPath = path
+	return conf
+}
+
+func newTestBv(t *testing.T, path string) *global.BaseVariableImpl {
+	bv, err := global.New(testConfig(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkGenesis(bv); err != nil {
+		t.Fatal(err)
+	}
+	return bv
+}
+
+func closeTestBv(bv *global.BaseVariableImpl, path string) {
+	bv.StateDB().Close()
+	bv.BlockChain().Close()
+	os.RemoveAll(path)
+}
+
+// genBlock produces and commits the block after the top of the block chain as the witness of acc.
+func genBlock(t *testing.T, bv global.BaseVariable, acc *account.KeyPair) *block.Block {
+	parent, err := bv.BlockChain().Top()
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, _ := json.Marshal(&verifier.Info{Mode: 0})
+	blk := &block.Block{
+		Head: &block.BlockHead{
+			Version:    block.V0,
+			ParentHash: parent.HeadHash(),
+			Info:       info,
+			Number:     parent.Head.Number + 1,
+			Witness:    acc.ReadablePubkey(),
+			Time:       parent.Head.Time + common.SlotLength*1e9,
+		},
+		Txs:      []*tx.Tx{},
+		Receipts: []*tx.TxReceipt{},
+	}
+	stateDB := bv.StateDB()
+	stateDB.Checkout(string(parent.HeadHash()))
+	v := verifier.Verifier{}
+	_, _, err = v.Gen(blk, parent, stateDB, txpool.NewSortedTxMap(), &verifier.Config{
+		Mode:        0,
+		Timeout:     time.Second,
+		TxTimeLimit: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk.Head.TxMerkleHash = blk.CalculateTxMerkleHash()
+	blk.Head.TxReceiptMerkleHash = blk.CalculateTxReceiptMerkleHash()
+	if err := blk.CalculateHeadHash(); err != nil {
+		t.Fatal(err)
+	}
+	blk.Sign = acc.Sign(blk.HeadHash())
+	stateDB.Tag(string(blk.HeadHash()))
+	if err := bv.BlockChain().Push(blk); err != nil {
+		t.Fatal(err)
+	}
+	if err := stateDB.Flush(string(blk.HeadHash())); err != nil {
+		t.Fatal(err)
+	}
+	return blk
+}
+
+func TestArchive(t *testing.T) {
+	ilog.Stop()
+	conf := testConfig("")
+	witness, err := account.NewKeyPair(common.Base58Decode(conf.ACC.SecKey), crypto.NewAlgorithm(conf.ACC.Algorithm))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Test of block archive", t, func() {
+		src := newTestBv(t, "ArchiveSrc/")
+		defer closeTestBv(src, "ArchiveSrc/")
+		dst := newTestBv(t, "ArchiveDst/")
+		defer closeTestBv(dst, "ArchiveDst/")
+
+		Convey("export and import blocks", func() {
+			for i := 0; i < 3; i++ {
+				genBlock(t, src, witness)
+			}
+			var buf bytes.Buffer
+			count, err := ExportBlocks(src.BlockChain(), 0, 3, &buf)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 4)
+
+			count, err = ImportBlocks(dst, bytes.NewReader(buf.Bytes()), false)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 3)
+			So(dst.BlockChain().Length(), ShouldEqual, 4)
+			for i := int64(0); i < 4; i++ {
+				hash, err := dst.BlockChain().GetHashByNumber(i)
+				So(err, ShouldBeNil)
+				srcHash, _ := src.BlockChain().GetHashByNumber(i)
+				So(hash, ShouldResemble, srcHash)
+			}
+			So(dst.StateDB().CurrentTag(), ShouldEqual, src.StateDB().CurrentTag())
+
+			count, err = ImportBlocks(dst, bytes.NewReader(buf.Bytes()), false)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 0)
+		})
+
+		Convey("block out of the witness schedule", func() {
+			other, err := account.NewKeyPair(nil, crypto.Ed25519)
+			So(err, ShouldBeNil)
+			blk := genBlock(t, src, witness)
+			blk.Head.Witness = other.ReadablePubkey()
+			So(blk.CalculateHeadHash(), ShouldBeNil)
+			blk.Sign = other.Sign(blk.HeadHash())
+			b, err := blk.Encode()
+			So(err, ShouldBeNil)
+			var buf bytes.Buffer
+			buf.Write(proto.EncodeVarint(uint64(len(b))))
+			buf.Write(b)
+
+			count, err := ImportBlocks(dst, &buf, false)
+			So(err.Error(), ShouldContainSubstring, "wrong witness")
+			So(count, ShouldEqual, 0)
+			So(dst.BlockChain().Length(), ShouldEqual, 1)
+		})
+	})
+}
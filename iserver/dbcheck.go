@@ -0,0 +1,171 @@
+package iserver
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/ilog"
+)
+
+// DBCheckResult is the result of CheckDB.
+type DBCheckResult struct {
+	Length      int64    // length of the block chain
+	Consistent  int64    // number of the last consistent block, -1 if the genesis block is broken
+	StateNumber int64    // number of the block tagged by the state db, -1 if it is not in the block chain
+	WALEntries  int      // number of entries in the block cache WAL, -1 if it can't be read
+	WALValid    int      // number of the leading entries in the block cache WAL linked to the consistent block chain
+	Issues      []string // problems found in the database
+}
+
+// OK returns whether the database is consistent.
+func (r *DBCheckResult) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// CheckDB checks the block hash chain linkage, the tx and receipt merkle hashes of every block,
+// whether the state db is tagged with the top block of the block chain, and whether the blocks in the block cache WAL
+// link to the consistent block chain. Txs and receipts of pruned blocks are not checked.
+func CheckDB(bv global.BaseVariable) *DBCheckResult {
+	chain := bv.BlockChain()
+	tag := []byte(bv.StateDB().CurrentTag())
+	r := &DBCheckResult{
+		Length:      chain.Length(),
+		Consistent:  -1,
+		StateNumber: -1,
+		Issues:      make([]string, 0),
+	}
+	var parentHash []byte
+	for i := int64(0); i < r.Length; i++ {
+		issue := checkBlock(chain, i, parentHash)
+		if issue != "" {
+			r.Issues = append(r.Issues, issue)
+			break
+		}
+		hash, _ := chain.GetHashByNumber(i)
+		if bytes.Equal(hash, tag) {
+			r.StateNumber = i
+		}
+		parentHash = hash
+		r.Consistent = i
+		if (i+1)%10000 == 0 {
+			ilog.Infof("checked %v blocks", i+1)
+		}
+	}
+	if r.StateNumber < 0 {
+		r.Issues = append(r.Issues, fmt.Sprintf("state db tag %v is not in the block chain", common.Base58Encode(tag)))
+	} else if r.StateNumber != r.Length-1 {
+		r.Issues = append(r.Issues, fmt.Sprintf("state db is at block %v, but the top of the block chain is %v", r.StateNumber, r.Length-1))
+	}
+	total, valid, err := blockcache.CheckWAL(bv.Config(), chain, r.Consistent)
+	r.WALEntries, r.WALValid = total, valid
+	if err != nil {
+		r.WALEntries = -1
+		r.Issues = append(r.Issues, fmt.Sprintf("block cache WAL can't be read: %v", err))
+	} else if valid < total {
+		r.Issues = append(r.Issues, fmt.Sprintf("block cache WAL: only the first %v of %v entries link to the consistent block chain", valid, total))
+	}
+	return r
+}
+
+func checkBlock(chain block.Chain, number int64, parentHash []byte) string {
+	hash, err := chain.GetHashByNumber(number)
+	if err != nil {
+		return fmt.Sprintf("block %v: %v", number, err)
+	}
+	blk, err := chain.GetBlockByNumber(number)
+	pruned := err == block.ErrPruned
+	if pruned {
+		blk, err = chain.GetBlockHeadByNumber(number)
+	}
+	if err != nil {
+		return fmt.Sprintf("block %v: %v", number, err)
+	}
+	if !bytes.Equal(blk.HeadHash(), hash) {
+		return fmt.Sprintf("block %v: hash %v doesn't match the number index", number, common.Base58Encode(blk.HeadHash()))
+	}
+	if blk.Head.Number != number {
+		return fmt.Sprintf("block %v: wrong number %v in block head", number, blk.Head.Number)
+	}
+	if number > 0 && !bytes.Equal(blk.Head.ParentHash, parentHash) {
+		return fmt.Sprintf("block %v: parent hash doesn't match block %v", number, number-1)
+	}
+	if pruned {
+		return ""
+	}
+	if len(blk.Txs) != len(blk.Receipts) {
+		return fmt.Sprintf("block %v: %v txs but %v receipts", number, len(blk.Txs), len(blk.Receipts))
+	}
+	for i, t := range blk.Txs {
+		if !bytes.Equal(blk.Receipts[i].TxHash, t.Hash()) {
+			return fmt.Sprintf("block %v: tx %v has no receipt", number, common.Base58Encode(t.Hash()))
+		}
+	}
+	if !bytes.Equal(blk.CalculateTxMerkleHash(), blk.Head.TxMerkleHash) {
+		return fmt.Sprintf("block %v: wrong tx merkle hash", number)
+	}
+	if !bytes.Equal(blk.CalculateTxReceiptMerkleHash(), blk.Head.TxReceiptMerkleHash) {
+		return fmt.Sprintf("block %v: wrong tx receipt merkle hash", number)
+	}
+	return ""
+}
+
+// RepairDB rolls the state db back with its undo journal to a block no higher than the last consistent block found by CheckDB,
+// truncates the block chain to the last consistent block, and replays the blocks in between.
+// The block cache WAL is truncated to its valid leading entries.
+// The database must not be opened by others.
+func RepairDB(conf *common.Config, r *DBCheckResult) error {
+	if r.Consistent < 0 {
+		return fmt.Errorf("genesis block is broken, the database can't be repaired")
+	}
+	bv, err := global.New(conf)
+	if err != nil {
+		return err
+	}
+	defer bv.StateDB().Close()
+	defer bv.BlockChain().Close()
+
+	if err := rollbackState(bv, r.Consistent); err != nil {
+		return err
+	}
+	if r.Consistent < bv.BlockChain().Length()-1 {
+		ilog.Infof("truncating the block chain to block %v", r.Consistent)
+		if err := bv.BlockChain().Truncate(r.Consistent + 1); err != nil {
+			return err
+		}
+	}
+	if err := recoverDB(bv); err != nil {
+		return err
+	}
+	if r.WALEntries < 0 || r.WALValid < r.WALEntries {
+		ilog.Infof("truncating the block cache WAL to %v entries", r.WALValid)
+		return blockcache.TruncateWAL(conf, r.WALValid)
+	}
+	return nil
+}
+
+// rollbackState undoes the flushes of the state db until it is tagged with a block no higher than top in the block chain.
+func rollbackState(bv global.BaseVariable, top int64) error {
+	chain := bv.BlockChain()
+	stateDB := bv.StateDB()
+	for {
+		tag := []byte(stateDB.CurrentTag())
+		if head, err := chain.GetBlockHeadByHash(tag); err == nil && head.Head.Number <= top {
+			if hash, err := chain.GetHashByNumber(head.Head.Number); err == nil && bytes.Equal(hash, tag) {
+				return nil
+			}
+		}
+		ilog.Infof("rolling back the state db from %v", common.Base58Encode(tag))
+		err := stateDB.Undo()
+		if err == db.ErrNoUndo {
+			return fmt.Errorf("state db can't be rolled back to block %v or lower, the undo journal is exhausted", top)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package iserver
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/block"
+	"github.com/iost-official/go-iost/core/blockcache"
+	"github.com/iost-official/go-iost/core/global"
+	"github.com/iost-official/go-iost/crypto"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/wal"
+	"github.com/iost-official/go-iost/ilog"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// writeWAL writes the blocks into the block cache WAL as the block cache links them.
+func writeWAL(t *testing.T, conf *common.Config, blks ...*block.Block) {
+	w, err := wal.Create(conf.DB.LdbPath+"./BlockCacheWAL", []byte("block_cache_wal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	for _, blk := range blks {
+		b, err := blk.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := proto.Marshal(&blockcache.BlockCacheRaw{BlockBytes: b, WitnessList: &blockcache.WitnessList{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := proto.Marshal(&blockcache.BcMessage{Data: raw, Type: blockcache.BcMessageType_LinkType})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.SaveSingle(wal.Entry{Data: data}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// newUndoTestBv opens the databases of conf with the undo journal enabled.
+func newUndoTestBv(t *testing.T, conf *common.Config) *global.BaseVariableImpl {
+	bv, err := global.New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkGenesis(bv); err != nil {
+		t.Fatal(err)
+	}
+	return bv
+}
+
+func TestDBCheck(t *testing.T) {
+	ilog.Stop()
+	path := "DBCheck/"
+	conf := testConfig(path)
+	conf.DB.UndoDepth = 1000
+	witness, err := account.NewKeyPair(common.Base58Decode(conf.ACC.SecKey), crypto.NewAlgorithm(conf.ACC.Algorithm))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Test of db check", t, func() {
+		bv := newUndoTestBv(t, conf)
+		defer func() { closeTestBv(bv, path) }()
+
+		Convey("consistent database", func() {
+			genBlock(t, bv, witness)
+			r := CheckDB(bv)
+			So(r.OK(), ShouldBeTrue)
+			So(r.Length, ShouldEqual, 2)
+			So(r.Consistent, ShouldEqual, 1)
+			So(r.StateNumber, ShouldEqual, 1)
+			So(r.WALEntries, ShouldEqual, 0)
+		})
+
+		Convey("repair state db ahead of the block chain and a broken WAL", func() {
+			var blks []*block.Block
+			for i := 0; i < 3; i++ {
+				blks = append(blks, genBlock(t, bv, witness))
+			}
+			orphan := *blks[2]
+			orphan.Head = &block.BlockHead{}
+			*orphan.Head = *blks[2].Head
+			orphan.Head.ParentHash = []byte("not a block")
+			So(orphan.CalculateHeadHash(), ShouldBeNil)
+			writeWAL(t, conf, blks[2], &orphan)
+			So(bv.BlockChain().Truncate(3), ShouldBeNil)
+
+			r := CheckDB(bv)
+			So(r.OK(), ShouldBeFalse)
+			So(r.Consistent, ShouldEqual, 2)
+			So(r.StateNumber, ShouldEqual, -1)
+			So(r.WALEntries, ShouldEqual, 2)
+			So(r.WALValid, ShouldEqual, 1)
+
+			bv.StateDB().Close()
+			bv.BlockChain().Close()
+			So(RepairDB(conf, r), ShouldBeNil)
+			bv = newUndoTestBv(t, conf)
+			So(bv.StateDB().CurrentTag(), ShouldEqual, string(blks[1].HeadHash()))
+			r = CheckDB(bv)
+			So(r.OK(), ShouldBeTrue)
+			So(r.Length, ShouldEqual, 3)
+			So(r.WALEntries, ShouldEqual, 1)
+		})
+
+		Convey("undo journal exhausted", func() {
+			genBlock(t, bv, witness)
+			depth := db.UndoDepth
+			db.UndoDepth = 1
+			defer func() { db.UndoDepth = depth }()
+			genBlock(t, bv, witness)
+			genBlock(t, bv, witness)
+			So(bv.BlockChain().Truncate(2), ShouldBeNil)
+
+			r := CheckDB(bv)
+			So(r.Consistent, ShouldEqual, 1)
+			bv.StateDB().Close()
+			bv.BlockChain().Close()
+			err := RepairDB(conf, r)
+			So(err.Error(), ShouldContainSubstring, "undo journal is exhausted")
+			bv = newUndoTestBv(t, conf)
+		})
+	})
+}
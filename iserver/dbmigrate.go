@@ -0,0 +1,40 @@
+package iserver
+
+import (
+	"path/filepath"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/ilog"
+)
+
+var migratedDBs = []string{"BlockChainDB", "StateDB"}
+
+// MigrateDB copies the block chain db and the state db of conf to the storage of type to under the out directory.
+// The block cache WAL is not copied, the node restarts from the top of the block chain.
+// The database must not be opened by others.
+func MigrateDB(conf *common.Config, to kv.StorageType, out string) error {
+	from, err := kv.ParseStorageType(conf.DB.Backend)
+	if err != nil {
+		return err
+	}
+	for _, name := range migratedDBs {
+		src, err := kv.NewStorage(conf.DB.LdbPath+name, from)
+		if err != nil {
+			return err
+		}
+		dst, err := kv.NewStorage(filepath.Join(out, name), to)
+		if err != nil {
+			src.Close()
+			return err
+		}
+		count, err := kv.Copy(dst, src)
+		src.Close()
+		dst.Close()
+		if err != nil {
+			return err
+		}
+		ilog.Infof("migrated %v items of %v from %v to %v", count, name, from, to)
+	}
+	return nil
+}
@@ -53,7 +53,7 @@ func recoverDB(bv global.BaseVariable) error {
 	hash := stateDB.CurrentTag()
 	var parent *block.Block
 	if hash != "" {
-		blk, err := blockChain.GetBlockByHash([]byte(hash))
+		blk, err := blockChain.GetBlockHeadByHash([]byte(hash))
 		if err != nil {
 			return fmt.Errorf("statedb doesn't coincides with blockchaindb. err: %v", err)
 		}
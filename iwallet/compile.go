@@ -43,6 +43,8 @@ func generateABI(codePath string) (string, error) {
 		fmt.Printf("Please make sure node.js has been installed and `npm install` has been executed inside %v\n", contractPath)
 		return "", err
 	}
+	// show the findings of the linter
+	fmt.Print(string(output))
 
 	return codePath + ".abi", nil
 }
@@ -52,6 +54,8 @@ var compileCmd = &cobra.Command{
 	Use:   "compile",
 	Short: "Generate contract abi",
 	Long: `Generate abi from contract javascript code
+	It also warns about non-deterministic code such as Date, Math.random and float math,
+	set "lint": true in the abi to make the chain refuse the code with such warnings.
 	example:iwallet compile ./example.js
 	`,
 
@@ -2,6 +2,7 @@
 
 const esprima = require('esprima/dist/esprima.js');
 const escodegen = require('escodegen/escodegen.js');
+const lint = require('./lint.js');
 
 const lang = "javascript";
 const version = "1.0.0";
@@ -59,6 +60,35 @@ function genAbi(def, lastPos, comments) {
     return abi;
 }
 
+// events are declared in comments like
+// /**
+//  * @event transfer
+//  * @field {string} from
+//  * @field {number} amount
+//  */
+function genEventArr(comments) {
+    let eventArr = [];
+    for (let comment of comments) {
+        let res = comment.value.match(/@event\s+([a-zA-Z_$][0-9a-zA-Z_$]*)/);
+        if (res === null) {
+            continue;
+        }
+        let e = {
+            "name": res[1],
+            "fields": []
+        };
+        let reg = /@field\s*{([a-zA-Z]+)}\s*([a-zA-Z_$][0-9a-zA-Z_$]*)/g;
+        while ((res = reg.exec(comment.value)) !== null) {
+            e.fields.push({
+                "name": res[2],
+                "type": res[1]
+            });
+        }
+        eventArr.push(e);
+    }
+    return eventArr;
+}
+
 function genAbiArr(stat, comments) {
     let abiArr = [];
     if (!isClassDecl(stat) || stat.body.type !== "ClassBody") {
@@ -217,6 +247,10 @@ function processContract(source) {
     abi["lang"] = lang;
     abi["version"] = version;
     abi["abi"] = abiArr;
+    let eventArr = genEventArr(ast.comments);
+    if (eventArr.length > 0) {
+        abi["events"] = eventArr;
+    }
     let abiStr = JSON.stringify(abi, null, 4);
 
     return [newSource, abiStr]
@@ -233,6 +267,9 @@ fs.readFile(file, 'utf8', function(err, contents) {
     }
     //console.log('before calling process, len = ' + contents.length);
     let [newSource, abi] = processContract(contents);
+    for (const f of JSON.parse(lint(contents))) {
+        console.log("warning: " + file + ":" + f.line + ":" + f.column + " " + f.rule + " " + f.message);
+    }
     //console.log('after calling process, newSource len = ' + newSource.length + ", abi len = " + abi.length);
 
     //fs.writeFile(file + ".after", newSource, function(err) {
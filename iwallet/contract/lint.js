@@ -0,0 +1,21 @@
+'use strict';
+
+// the linter is shared with the chain, which runs vm/v8vm/v8/libjs/lint.js with esprima in global scope
+global.esprima = require('esprima/dist/esprima.js');
+const lint = require('../../vm/v8vm/v8/libjs/lint.js');
+
+module.exports = lint;
+
+if (require.main === module) {
+    let fs = require('fs');
+
+    let file = process.argv[2];
+    fs.readFile(file, 'utf8', function(err, contents) {
+        if (contents === undefined) {
+            throw new Error("invalid file content. Is " + file + " exists?")
+        }
+        for (const f of JSON.parse(lint(contents))) {
+            console.log(file + ":" + f.line + ":" + f.column + " " + f.rule + " " + f.message);
+        }
+    });
+}
@@ -227,6 +227,19 @@ func (s *SDK) getAccountInfo(id string) (*rpcpb.Account, error) {
 	}
 	return value, nil
 }
+
+// getVesting return vesting schedules of token granted to the account
+func (s *SDK) getVesting(account string, token string) (*rpcpb.GetVestingResponse, error) {
+	conn, err := grpc.Dial(s.server, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := rpcpb.NewApiServiceClient(conn)
+	req := &rpcpb.GetTokenBalanceRequest{Account: account, Token: token, ByLongestChain: s.useLongestChain}
+	return client.GetVesting(context.Background(), req)
+}
+
 func (s *SDK) getGetBlockByNum(num int64, complete bool) (*rpcpb.BlockResponse, error) {
 	conn, err := grpc.Dial(s.server, grpc.WithInsecure())
 	if err != nil {
@@ -268,6 +281,17 @@ func (s *SDK) GetTxReceiptByTxHash(txHashStr string) (*rpcpb.TxReceipt, error) {
 	return client.GetTxReceiptByTxHash(context.Background(), &rpcpb.TxHashRequest{Hash: txHashStr})
 }
 
+// TraceTransaction ...
+func (s *SDK) TraceTransaction(txHashStr string) (*rpcpb.TraceTransactionResponse, error) {
+	conn, err := grpc.Dial(s.server, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := rpcpb.NewApiServiceClient(conn)
+	return client.TraceTransaction(context.Background(), &rpcpb.TxHashRequest{Hash: txHashStr})
+}
+
 func (s *SDK) sendTx(stx *rpcpb.TransactionRequest) (string, error) {
 	fmt.Println("sending tx")
 	if sdk.verbose {
@@ -472,14 +496,14 @@ func (s *SDK) CreateNewAccount(newID string, ownerKey string, activeKey string,
 	return txHash, nil
 }
 
-// PublishContract converts contract js code to transaction. If 'send', also send it to chain.
+// PublishContract converts contract code to transaction. If 'send', also send it to chain.
 func (s *SDK) PublishContract(codePath string, abiPath string, conID string, update bool, updateID string) (stx *rpcpb.TransactionRequest, txHash string, err error) {
 	fd, err := readFile(codePath)
 	if err != nil {
 		fmt.Println("Read source code file failed: ", err.Error())
 		return nil, "", err
 	}
-	code := string(fd)
+	src := fd
 
 	fd, err = readFile(abiPath)
 	if err != nil {
@@ -494,7 +518,7 @@ func (s *SDK) PublishContract(codePath string, abiPath string, conID string, upd
 	}
 	c := &contract.Contract{
 		ID:   conID,
-		Code: code,
+		Code: contract.EncodeCode(info.Lang, src),
 		Info: info,
 	}
 	methodName := "setCode"
@@ -0,0 +1,77 @@
+package iwallet
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/iost-official/go-iost/contracttest"
+	"github.com/spf13/cobra"
+)
+
+var testContractPath string
+
+// testCmd represents the test command
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "run contract tests locally",
+	Long: `run contract test specs in an in-memory chain started from the genesis block, no node is needed
+	example:iwallet test --genesis_contract ./config/genesis/contract ./token_test.json`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if len(args) < 1 {
+			fmt.Println(`Error: test spec file not given`)
+			return
+		}
+		if testContractPath == "" {
+			fmt.Println(`Error: genesis contract path not given`)
+			return
+		}
+		failed := 0
+		for _, path := range args {
+			if !runSpec(path) {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%v of %v specs failed", failed, len(args))
+		}
+		return nil
+	},
+}
+
+func runSpec(path string) bool {
+	fmt.Printf("=== %v\n", path)
+	spec, err := contracttest.LoadSpec(path)
+	if err != nil {
+		fmt.Println(err.Error())
+		return false
+	}
+	h, err := contracttest.New(&contracttest.Config{ContractPath: testContractPath})
+	if err != nil {
+		fmt.Printf("start harness failed: %v\n", err)
+		return false
+	}
+	defer h.Close()
+	results, err := spec.Run(h, filepath.Dir(path))
+	if err != nil {
+		fmt.Printf("prepare failed: %v\n", err)
+		return false
+	}
+	for i, r := range results {
+		name := r.Step.Name
+		if name == "" {
+			name = fmt.Sprintf("step %v", i)
+		}
+		if r.Err != nil {
+			fmt.Printf("--- FAIL: %v\n    %v\n", name, r.Err)
+			return false
+		}
+		fmt.Printf("--- PASS: %v\n", name)
+	}
+	fmt.Println("PASS")
+	return true
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+	testCmd.Flags().StringVarP(&testContractPath, "genesis_contract", "", "", "directory of the genesis contracts, i.e. config/genesis/contract of the source tree")
+}
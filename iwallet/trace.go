@@ -0,0 +1,61 @@
+package iwallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iost-official/go-iost/rpc/pb"
+	"github.com/spf13/cobra"
+)
+
+var traceTree bool
+
+// traceCmd represents the trace command
+var traceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "trace transaction",
+	Long:  `re-execute the transaction of a block after the last irreversible block on the node and print its contract call tree, storage accesses and receipts, the node must enable rpc.tracetx`,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		if len(args) < 1 {
+			fmt.Println(`Error: transaction hash not given`)
+			return
+		}
+		trace, err := sdk.TraceTransaction(args[0])
+		if err != nil {
+			fmt.Println(err.Error())
+			return
+		}
+		if !traceTree {
+			fmt.Println(marshalTextString(trace))
+			return nil
+		}
+		fmt.Printf("block %v, status %v %v\n", trace.BlockNumber, trace.Receipt.StatusCode, trace.Receipt.Message)
+		printCallFrames(trace.Calls, 0)
+		return nil
+	},
+}
+
+func printCallFrames(frames []*rpcpb.CallFrame, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, f := range frames {
+		fmt.Printf("%v%v.%v %v gas: %v ram: %v\n", indent, f.Contract, f.Api, f.Args, f.Gas, f.Ram)
+		for _, s := range f.Storage {
+			fmt.Printf("%v  %v %v %v %v -> %v\n", indent, s.Op, s.Key, s.Field, s.OldValue, s.NewValue)
+		}
+		for _, r := range f.Receipts {
+			fmt.Printf("%v  receipt %v %v\n", indent, r.FuncName, r.Content)
+		}
+		for _, e := range f.Events {
+			fmt.Printf("%v  event %v\n", indent, e)
+		}
+		printCallFrames(f.Calls, depth+1)
+		if f.Error != "" {
+			fmt.Printf("%v  error: %v\n", indent, f.Error)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(traceCmd)
+	traceCmd.Flags().BoolVarP(&traceTree, "tree", "", false, "print the call tree in text instead of json")
+}
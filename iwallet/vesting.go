@@ -0,0 +1,42 @@
+// Copyright © 2018 NAME HERE <EMAIL ADDRESS>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iwallet
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// vestingCmd prints the vesting schedules of an account
+var vestingCmd = &cobra.Command{
+	Use:   "vesting account token",
+	Short: "Print vesting schedules of the account",
+	Long:  `Print vesting schedules of the token granted to the account, vested token can be claimed by "iwallet call token.iost claim '["token", "account", id]'"`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		info, err := sdk.getVesting(args[0], args[1])
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Println(marshalTextString(info))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(vestingCmd)
+}
@@ -52,6 +52,8 @@ const (
 	SyncBlockResponse
 	SyncHeight
 	PublishTx
+	SyncBlockHeadRequest
+	SyncBlockHeadResponse
 
 	UrgentMessage = 1
 	NormalMessage = 2
@@ -81,6 +83,10 @@ func (m MessageType) String() string {
 		return "PublishTx"
 	case NewBlockHash:
 		return "NewBlockHash"
+	case SyncBlockHeadRequest:
+		return "SyncBlockHeadRequest"
+	case SyncBlockHeadResponse:
+		return "SyncBlockHeadResponse"
 	default:
 		return "unknown_type:" + strconv.Itoa(int(m))
 	}
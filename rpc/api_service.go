@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -28,6 +29,9 @@ import (
 	"github.com/iost-official/go-iost/vm/host"
 )
 
+// maxToken721Limit is the max number of token ids returned by GetToken721Supply
+const maxToken721Limit = 1000
+
 //go:generate mockgen -destination mock_rpc/mock_api.go -package main github.com/iost-official/go-iost/rpc/pb ApiServiceServer
 
 // APIService implements all rpc APIs.
@@ -38,7 +42,8 @@ type APIService struct {
 	blockchain block.Chain
 	bv         global.BaseVariable
 
-	quitCh chan struct{}
+	traceCh chan struct{}
+	quitCh  chan struct{}
 }
 
 // NewAPIService returns a new APIService instance.
@@ -49,6 +54,7 @@ func NewAPIService(tp txpool.TxPool, bcache blockcache.BlockCache, bv global.Bas
 		blockchain: bv.BlockChain(),
 		bc:         bcache,
 		bv:         bv,
+		traceCh:    make(chan struct{}, 1),
 		quitCh:     quitCh,
 	}
 }
@@ -125,10 +131,16 @@ func (as *APIService) GetTxByHash(ctx context.Context, req *rpcpb.TxHashRequest)
 		if err != nil {
 			status = rpcpb.TransactionResponse_IRREVERSIBLE
 			t, err = as.blockchain.GetTx(txHashBytes)
+			if err == block.ErrPruned {
+				return nil, errors.New("tx has been pruned")
+			}
 			if err != nil {
 				return nil, errors.New("tx not found")
 			}
 			txReceipt, err = as.blockchain.GetReceiptByTxHash(txHashBytes)
+			if err == block.ErrPruned {
+				return nil, errors.New("txreceipt has been pruned")
+			}
 			if err != nil {
 				return nil, errors.New("txreceipt not found")
 			}
@@ -145,6 +157,9 @@ func (as *APIService) GetTxByHash(ctx context.Context, req *rpcpb.TxHashRequest)
 func (as *APIService) GetTxReceiptByTxHash(ctx context.Context, req *rpcpb.TxHashRequest) (*rpcpb.TxReceipt, error) {
 	txHashBytes := common.Base58Decode(req.GetHash())
 	receipt, err := as.blockchain.GetReceiptByTxHash(txHashBytes)
+	if err == block.ErrPruned {
+		return nil, errors.New("txreceipt has been pruned")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -163,6 +178,10 @@ func (as *APIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByH
 	if err != nil {
 		status = rpcpb.BlockResponse_IRREVERSIBLE
 		blk, err = as.blockchain.GetBlockByHash(hashBytes)
+		if err == block.ErrPruned {
+			// only the head of a pruned block is kept
+			blk, err = as.blockchain.GetBlockHeadByHash(hashBytes)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -185,6 +204,10 @@ func (as *APIService) GetBlockByNumber(ctx context.Context, req *rpcpb.GetBlockB
 	if err != nil {
 		status = rpcpb.BlockResponse_IRREVERSIBLE
 		blk, err = as.blockchain.GetBlockByNumber(number)
+		if err == block.ErrPruned {
+			// only the head of a pruned block is kept
+			blk, err = as.blockchain.GetBlockHeadByNumber(number)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -278,6 +301,69 @@ func (as *APIService) GetTokenBalance(ctx context.Context, req *rpcpb.GetTokenBa
 	}, nil
 }
 
+// GetTokenInfo returns information of an specific token.
+func (as *APIService) GetTokenInfo(ctx context.Context, req *rpcpb.GetTokenInfoRequest) (*rpcpb.TokenInfo, error) {
+	dbVisitor := as.getStateDBVisitor(req.ByLongestChain)
+	info := dbVisitor.TokenInfo(req.GetSymbol())
+	if info == nil {
+		return nil, errors.New("token not found")
+	}
+	return &rpcpb.TokenInfo{
+		Symbol:                info.Symbol,
+		FullName:              info.FullName,
+		Issuer:                info.Issuer,
+		TotalSupplyFloat:      info.TotalSupply.ToFloat(),
+		CurrentSupplyFloat:    info.CurrentSupply.ToFloat(),
+		Decimal:               int32(info.Decimal),
+		CanTransfer:           info.CanTransfer,
+		OnlyIssuerCanTransfer: info.OnlyIssuerCanTransfer,
+		Url:                   info.URL,
+		Logo:                  info.Logo,
+		Paused:                info.Paused,
+		CanPause:              info.CanPause,
+		CanBlacklist:          info.CanBlacklist,
+		CanUpdateMetadata:     info.CanUpdateMetadata,
+		CanChangeIssuer:       info.CanChangeIssuer,
+	}, nil
+}
+
+// GetVesting returns vesting schedules of an specific token granted to the account.
+func (as *APIService) GetVesting(ctx context.Context, req *rpcpb.GetTokenBalanceRequest) (*rpcpb.GetVestingResponse, error) {
+	dbVisitor := as.getStateDBVisitor(req.ByLongestChain)
+	acc, _ := host.ReadAuth(dbVisitor, req.GetAccount())
+	if acc == nil {
+		return nil, errors.New("account not found")
+	}
+	var blkTime int64
+	if req.GetByLongestChain() {
+		blkTime = as.bc.Head().Head.Time
+	} else {
+		blkTime = as.bc.LinkedRoot().Head.Time
+	}
+	decimal := dbVisitor.Decimal(req.GetToken())
+	toFloat := func(v int64) float64 {
+		return (&common.Fixed{Value: v, Decimal: decimal}).ToFloat()
+	}
+	schedules := make([]*rpcpb.VestingSchedule, 0)
+	for _, v := range dbVisitor.Vesting(req.GetToken(), req.GetAccount()) {
+		schedules = append(schedules, &rpcpb.VestingSchedule{
+			Id:        v.ID,
+			Grantor:   v.Grantor,
+			Amount:    toFloat(v.Amount),
+			Claimed:   toFloat(v.Claimed),
+			Claimable: toFloat(v.Vested(blkTime) - v.Claimed),
+			Start:     v.Start,
+			Cliff:     v.Cliff,
+			Duration:  v.Duration,
+			Interval:  v.Interval,
+			Revocable: v.Revocable,
+		})
+	}
+	return &rpcpb.GetVestingResponse{
+		Schedules: schedules,
+	}, nil
+}
+
 // GetToken721Balance returns balance of account of an specific token721 token.
 func (as *APIService) GetToken721Balance(ctx context.Context, req *rpcpb.GetTokenBalanceRequest) (*rpcpb.GetToken721BalanceResponse, error) {
 	dbVisitor := as.getStateDBVisitor(req.ByLongestChain)
@@ -312,6 +398,27 @@ func (as *APIService) GetToken721Owner(ctx context.Context, req *rpcpb.GetToken7
 	}, err
 }
 
+// GetToken721Approved returns the account approved to transfer an specific token721 token.
+func (as *APIService) GetToken721Approved(ctx context.Context, req *rpcpb.GetToken721InfoRequest) (*rpcpb.GetToken721ApprovedResponse, error) {
+	dbVisitor := as.getStateDBVisitor(req.ByLongestChain)
+	approved, err := dbVisitor.Token721Approved(req.GetToken(), req.GetTokenId())
+	return &rpcpb.GetToken721ApprovedResponse{
+		Approved: approved,
+	}, err
+}
+
+// GetToken721Supply returns supply and ids of tokens of an specific token721 token.
+func (as *APIService) GetToken721Supply(ctx context.Context, req *rpcpb.GetToken721SupplyRequest) (*rpcpb.GetToken721SupplyResponse, error) {
+	if req.GetOffset() < 0 || req.GetLimit() <= 0 || req.GetLimit() > maxToken721Limit {
+		return nil, fmt.Errorf("invalid offset or limit, limit should be in (0, %v]", maxToken721Limit)
+	}
+	dbVisitor := as.getStateDBVisitor(req.ByLongestChain)
+	return &rpcpb.GetToken721SupplyResponse{
+		Supply:   dbVisitor.Token721Supply(req.GetToken()),
+		TokenIDs: dbVisitor.Token721TokenIDs(req.GetToken(), int(req.GetOffset()), int(req.GetLimit())),
+	}, nil
+}
+
 // GetContract returns contract information corresponding to the given contract ID.
 func (as *APIService) GetContract(ctx context.Context, req *rpcpb.GetContractRequest) (*rpcpb.Contract, error) {
 	dbVisitor := as.getStateDBVisitor(req.ByLongestChain)
@@ -443,6 +550,66 @@ func (as *APIService) ExecTransaction(ctx context.Context, req *rpcpb.Transactio
 	return toPbTxReceipt(receipt), nil
 }
 
+// TraceTransaction re-executes the transaction on the state of its parent block and returns its call tree.
+// Only the states of the blocks in the block cache are kept, so it only traces txs in the blocks above the last
+// irreversible block (LinkedRoot) and returns an error for older ones.
+// It re-executes the whole block, so it is disabled unless rpc.tracetx is set, and one trace runs at a time.
+func (as *APIService) TraceTransaction(ctx context.Context, req *rpcpb.TxHashRequest) (*rpcpb.TraceTransactionResponse, error) {
+	if !as.bv.Config().RPC.TraceTx {
+		return nil, errors.New("trace transaction is disabled, set rpc.tracetx to enable it")
+	}
+	select {
+	case as.traceCh <- struct{}{}:
+		defer func() { <-as.traceCh }()
+	default:
+		return nil, errors.New("another transaction is being traced, try again later")
+	}
+	txHashBytes := common.Base58Decode(req.GetHash())
+	blk, err := as.getReversibleBlockByTxHash(txHashBytes)
+	if err != nil {
+		return nil, err
+	}
+	stateDB := as.bv.StateDB().Fork()
+	if !stateDB.Checkout(string(blk.Head.ParentHash)) {
+		return nil, fmt.Errorf("block %v is irreversible, only txs after the last irreversible block %v can be traced", blk.Head.Number, as.bc.LinkedRoot().Head.Number)
+	}
+	v := verifier.Verifier{}
+	receipt, tracer, err := v.Trace(blk, stateDB, txHashBytes, &verifier.Config{
+		Mode:        0,
+		Timeout:     common.SlotLength / 3 * time.Second,
+		TxTimeLimit: cverifier.TxExecTimeLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("trace transaction failed: %v", err)
+	}
+	return &rpcpb.TraceTransactionResponse{
+		Receipt:     toPbTxReceipt(receipt),
+		Calls:       toPbCallFrames(tracer.Calls),
+		BlockNumber: blk.Head.Number,
+	}, nil
+}
+
+// getReversibleBlockByTxHash returns the block of the tx above the last irreversible block.
+func (as *APIService) getReversibleBlockByTxHash(hash []byte) (*block.Block, error) {
+	root := as.bc.LinkedRoot()
+	for node := as.bc.Head(); node != nil && node != root; node = node.GetParent() {
+		for _, t := range node.Block.Txs {
+			if bytes.Equal(t.Hash(), hash) {
+				return node.Block, nil
+			}
+		}
+	}
+	blockHash, err := as.blockchain.GetBlockHashByTxHash(hash)
+	if err != nil {
+		return nil, errors.New("tx not found")
+	}
+	head, err := as.blockchain.GetBlockHeadByHash(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("tx is in an irreversible block, only txs after the last irreversible block %v can be traced", root.Head.Number)
+	}
+	return nil, fmt.Errorf("tx is in irreversible block %v, only txs after the last irreversible block %v can be traced", head.Head.Number, root.Head.Number)
+}
+
 // Subscribe used for event.
 func (as *APIService) Subscribe(req *rpcpb.SubscribeRequest, res rpcpb.ApiService_SubscribeServer) error {
 
@@ -473,9 +640,11 @@ func (as *APIService) Subscribe(req *rpcpb.SubscribeRequest, res rpcpb.ApiServic
 			return res.Context().Err()
 		case ev := <-ch:
 			e := &rpcpb.Event{
-				Topic: rpcpb.Event_Topic(ev.Topic),
-				Data:  ev.Data,
-				Time:  ev.Time,
+				Topic:  rpcpb.Event_Topic(ev.Topic),
+				Data:   ev.Data,
+				Time:   ev.Time,
+				Name:   ev.Name,
+				Fields: ev.Fields,
 			}
 			err := res.Send(&rpcpb.SubscribeResponse{Event: e})
 			if err != nil {
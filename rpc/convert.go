@@ -10,6 +10,7 @@ import (
 	"github.com/iost-official/go-iost/crypto"
 	"github.com/iost-official/go-iost/rpc/pb"
 	"github.com/iost-official/go-iost/verifier"
+	"github.com/iost-official/go-iost/vm/host"
 )
 
 func toPbAction(a *tx.Action) *rpcpb.Action {
@@ -41,6 +42,41 @@ func toPbTxReceipt(tr *tx.TxReceipt) *rpcpb.TxReceipt {
 	return ret
 }
 
+func toPbCallFrames(frames []*host.CallFrame) []*rpcpb.CallFrame {
+	ret := make([]*rpcpb.CallFrame, 0, len(frames))
+	for _, f := range frames {
+		pf := &rpcpb.CallFrame{
+			Contract: f.Contract,
+			Api:      f.API,
+			Args:     f.Args,
+			Gas:      f.Gas,
+			Ram:      f.RAM,
+			Error:    f.Error,
+			Calls:    toPbCallFrames(f.Calls),
+		}
+		for _, s := range f.Storage {
+			pf.Storage = append(pf.Storage, &rpcpb.StorageAccess{
+				Op:       s.Op,
+				Key:      s.Key,
+				Field:    s.Field,
+				OldValue: s.Old,
+				NewValue: s.New,
+			})
+		}
+		for _, r := range f.Receipts {
+			pf.Receipts = append(pf.Receipts, &rpcpb.TxReceipt_Receipt{
+				FuncName: r.FuncName,
+				Content:  r.Content,
+			})
+		}
+		for _, e := range f.Events {
+			pf.Events = append(pf.Events, e.Content)
+		}
+		ret = append(ret, pf)
+	}
+	return ret
+}
+
 func toPbAmountLimit(a *contract.Amount) *rpcpb.AmountLimit {
 	return &rpcpb.AmountLimit{
 		Token: a.Token,
@@ -83,6 +119,10 @@ func toPbBlock(blk *block.Block, complete bool) *rpcpb.Block {
 		GasUsage:            float64(blk.CalculateGasUsage()) / 100,
 		TxCount:             int64(len(blk.Txs)),
 	}
+	if blk.Txs == nil {
+		// head of a pruned block
+		ret.TxCount = int64(len(blk.TxHashes))
+	}
 	var info verifier.Info
 	json.Unmarshal(blk.Head.Info, &info)
 	ret.Info = &rpcpb.Block_Info{
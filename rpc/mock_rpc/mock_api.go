@@ -177,6 +177,19 @@ func (mr *MockApiServiceServerMockRecorder) GetRAMInfo(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRAMInfo", reflect.TypeOf((*MockApiServiceServer)(nil).GetRAMInfo), arg0, arg1)
 }
 
+// GetToken721Approved mocks base method
+func (m *MockApiServiceServer) GetToken721Approved(arg0 context.Context, arg1 *pb.GetToken721InfoRequest) (*pb.GetToken721ApprovedResponse, error) {
+	ret := m.ctrl.Call(m, "GetToken721Approved", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetToken721ApprovedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken721Approved indicates an expected call of GetToken721Approved
+func (mr *MockApiServiceServerMockRecorder) GetToken721Approved(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken721Approved", reflect.TypeOf((*MockApiServiceServer)(nil).GetToken721Approved), arg0, arg1)
+}
+
 // GetToken721Balance mocks base method
 func (m *MockApiServiceServer) GetToken721Balance(arg0 context.Context, arg1 *pb.GetTokenBalanceRequest) (*pb.GetToken721BalanceResponse, error) {
 	ret := m.ctrl.Call(m, "GetToken721Balance", arg0, arg1)
@@ -216,6 +229,19 @@ func (mr *MockApiServiceServerMockRecorder) GetToken721Owner(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken721Owner", reflect.TypeOf((*MockApiServiceServer)(nil).GetToken721Owner), arg0, arg1)
 }
 
+// GetToken721Supply mocks base method
+func (m *MockApiServiceServer) GetToken721Supply(arg0 context.Context, arg1 *pb.GetToken721SupplyRequest) (*pb.GetToken721SupplyResponse, error) {
+	ret := m.ctrl.Call(m, "GetToken721Supply", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetToken721SupplyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetToken721Supply indicates an expected call of GetToken721Supply
+func (mr *MockApiServiceServerMockRecorder) GetToken721Supply(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetToken721Supply", reflect.TypeOf((*MockApiServiceServer)(nil).GetToken721Supply), arg0, arg1)
+}
+
 // GetTokenBalance mocks base method
 func (m *MockApiServiceServer) GetTokenBalance(arg0 context.Context, arg1 *pb.GetTokenBalanceRequest) (*pb.GetTokenBalanceResponse, error) {
 	ret := m.ctrl.Call(m, "GetTokenBalance", arg0, arg1)
@@ -229,6 +255,19 @@ func (mr *MockApiServiceServerMockRecorder) GetTokenBalance(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenBalance", reflect.TypeOf((*MockApiServiceServer)(nil).GetTokenBalance), arg0, arg1)
 }
 
+// GetTokenInfo mocks base method
+func (m *MockApiServiceServer) GetTokenInfo(arg0 context.Context, arg1 *pb.GetTokenInfoRequest) (*pb.TokenInfo, error) {
+	ret := m.ctrl.Call(m, "GetTokenInfo", arg0, arg1)
+	ret0, _ := ret[0].(*pb.TokenInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenInfo indicates an expected call of GetTokenInfo
+func (mr *MockApiServiceServerMockRecorder) GetTokenInfo(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenInfo", reflect.TypeOf((*MockApiServiceServer)(nil).GetTokenInfo), arg0, arg1)
+}
+
 // GetTxByHash mocks base method
 func (m *MockApiServiceServer) GetTxByHash(arg0 context.Context, arg1 *pb.TxHashRequest) (*pb.TransactionResponse, error) {
 	ret := m.ctrl.Call(m, "GetTxByHash", arg0, arg1)
@@ -255,6 +294,19 @@ func (mr *MockApiServiceServerMockRecorder) GetTxReceiptByTxHash(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTxReceiptByTxHash", reflect.TypeOf((*MockApiServiceServer)(nil).GetTxReceiptByTxHash), arg0, arg1)
 }
 
+// GetVesting mocks base method
+func (m *MockApiServiceServer) GetVesting(arg0 context.Context, arg1 *pb.GetTokenBalanceRequest) (*pb.GetVestingResponse, error) {
+	ret := m.ctrl.Call(m, "GetVesting", arg0, arg1)
+	ret0, _ := ret[0].(*pb.GetVestingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVesting indicates an expected call of GetVesting
+func (mr *MockApiServiceServerMockRecorder) GetVesting(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVesting", reflect.TypeOf((*MockApiServiceServer)(nil).GetVesting), arg0, arg1)
+}
+
 // SendTransaction mocks base method
 func (m *MockApiServiceServer) SendTransaction(arg0 context.Context, arg1 *pb.TransactionRequest) (*pb.SendTransactionResponse, error) {
 	ret := m.ctrl.Call(m, "SendTransaction", arg0, arg1)
@@ -279,3 +331,16 @@ func (m *MockApiServiceServer) Subscribe(arg0 *pb.SubscribeRequest, arg1 pb.ApiS
 func (mr *MockApiServiceServerMockRecorder) Subscribe(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockApiServiceServer)(nil).Subscribe), arg0, arg1)
 }
+
+// TraceTransaction mocks base method
+func (m *MockApiServiceServer) TraceTransaction(arg0 context.Context, arg1 *pb.TxHashRequest) (*pb.TraceTransactionResponse, error) {
+	ret := m.ctrl.Call(m, "TraceTransaction", arg0, arg1)
+	ret0, _ := ret[0].(*pb.TraceTransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TraceTransaction indicates an expected call of TraceTransaction
+func (mr *MockApiServiceServerMockRecorder) TraceTransaction(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TraceTransaction", reflect.TypeOf((*MockApiServiceServer)(nil).TraceTransaction), arg0, arg1)
+}
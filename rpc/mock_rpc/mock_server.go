@@ -254,6 +254,21 @@ func newMockAPI() rpcpb.ApiServiceServer {
 			{Amount: 111.2, Time: 2343242},
 		},
 	}, nil)
+	api.EXPECT().GetTokenInfo(gomock.Any(), gomock.Any()).AnyTimes().Return(&rpcpb.TokenInfo{
+		Symbol:             "iost",
+		FullName:           "iost",
+		Issuer:             "token.iost",
+		TotalSupplyFloat:   21000000000,
+		CurrentSupplyFloat: 20000000000,
+		Decimal:            8,
+		CanTransfer:        true,
+	}, nil)
+	api.EXPECT().GetVesting(gomock.Any(), gomock.Any()).AnyTimes().Return(&rpcpb.GetVestingResponse{
+		Schedules: []*rpcpb.VestingSchedule{
+			{Id: 3, Grantor: "issuer", Amount: 1000, Claimed: 200, Claimable: 50, Start: 1543200000000000000,
+				Cliff: 2592000000000000, Duration: 31536000000000000, Interval: 86400000000000, Revocable: true},
+		},
+	}, nil)
 	api.EXPECT().GetToken721Balance(gomock.Any(), gomock.Any()).AnyTimes().Return(&rpcpb.GetToken721BalanceResponse{
 		Balance:  2,
 		TokenIDs: []string{"2", "0"},
@@ -264,6 +279,13 @@ func newMockAPI() rpcpb.ApiServiceServer {
 	api.EXPECT().GetToken721Owner(gomock.Any(), gomock.Any()).AnyTimes().Return(&rpcpb.GetToken721OwnerResponse{
 		Owner: "myaccount",
 	}, nil)
+	api.EXPECT().GetToken721Approved(gomock.Any(), gomock.Any()).AnyTimes().Return(&rpcpb.GetToken721ApprovedResponse{
+		Approved: "otheraccount",
+	}, nil)
+	api.EXPECT().GetToken721Supply(gomock.Any(), gomock.Any()).AnyTimes().Return(&rpcpb.GetToken721SupplyResponse{
+		Supply:   2,
+		TokenIDs: []string{"0", "2"},
+	}, nil)
 
 	api.EXPECT().GetContract(gomock.Any(), gomock.Any()).AnyTimes().Return(&rpcpb.Contract{
 		Id:       "Contract12312131",
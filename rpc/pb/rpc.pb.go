@@ -195,7 +195,7 @@ func (x Event_Topic) String() string {
 }
 
 func (Event_Topic) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{35, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{42, 0}
 }
 
 // The message defines an empty request.
@@ -1506,7 +1506,7 @@ func (m *TxHashRequest) GetHash() string {
 type GetBlockByHashRequest struct {
 	// block hash
 	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	// complete means whether including the full transactions and transaction receipts
+	// complete means whether including the full transactions and transaction receipts, which are not kept for pruned blocks
 	Complete             bool     `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1556,7 +1556,7 @@ func (m *GetBlockByHashRequest) GetComplete() bool {
 type GetBlockByNumberRequest struct {
 	// block number
 	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
-	// complete means whether including the full transactions and transaction receipts
+	// complete means whether including the full transactions and transaction receipts, which are not kept for pruned blocks
 	Complete             bool     `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2770,6 +2770,386 @@ func (m *GetTokenBalanceRequest) GetByLongestChain() bool {
 	return false
 }
 
+// The message defines get token info request.
+type GetTokenInfoRequest struct {
+	// token symbol
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// get data by longest chain's head block or last irreversible block
+	ByLongestChain       bool     `protobuf:"varint,2,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTokenInfoRequest) Reset()         { *m = GetTokenInfoRequest{} }
+func (m *GetTokenInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTokenInfoRequest) ProtoMessage()    {}
+func (*GetTokenInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{31}
+}
+
+func (m *GetTokenInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTokenInfoRequest.Unmarshal(m, b)
+}
+func (m *GetTokenInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTokenInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTokenInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTokenInfoRequest.Merge(m, src)
+}
+func (m *GetTokenInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTokenInfoRequest.Size(m)
+}
+func (m *GetTokenInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTokenInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTokenInfoRequest proto.InternalMessageInfo
+
+func (m *GetTokenInfoRequest) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *GetTokenInfoRequest) GetByLongestChain() bool {
+	if m != nil {
+		return m.ByLongestChain
+	}
+	return false
+}
+
+// The message defines token information.
+type TokenInfo struct {
+	// token symbol
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// token full name
+	FullName string `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	// token issuer
+	Issuer string `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// total supply
+	TotalSupplyFloat float64 `protobuf:"fixed64,4,opt,name=total_supply_float,json=totalSupplyFloat,proto3" json:"total_supply_float,omitempty"`
+	// current supply
+	CurrentSupplyFloat float64 `protobuf:"fixed64,5,opt,name=current_supply_float,json=currentSupplyFloat,proto3" json:"current_supply_float,omitempty"`
+	// token decimal
+	Decimal int32 `protobuf:"varint,6,opt,name=decimal,proto3" json:"decimal,omitempty"`
+	// whether the token can be transferred
+	CanTransfer bool `protobuf:"varint,7,opt,name=can_transfer,json=canTransfer,proto3" json:"can_transfer,omitempty"`
+	// whether only the issuer can transfer the token
+	OnlyIssuerCanTransfer bool `protobuf:"varint,8,opt,name=only_issuer_can_transfer,json=onlyIssuerCanTransfer,proto3" json:"only_issuer_can_transfer,omitempty"`
+	// url of the token
+	Url string `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	// hash of the token logo
+	Logo string `protobuf:"bytes,10,opt,name=logo,proto3" json:"logo,omitempty"`
+	// whether the token is paused
+	Paused bool `protobuf:"varint,11,opt,name=paused,proto3" json:"paused,omitempty"`
+	// whether the issuer can pause the token
+	CanPause bool `protobuf:"varint,12,opt,name=can_pause,json=canPause,proto3" json:"can_pause,omitempty"`
+	// whether the issuer can blacklist accounts
+	CanBlacklist bool `protobuf:"varint,13,opt,name=can_blacklist,json=canBlacklist,proto3" json:"can_blacklist,omitempty"`
+	// whether the issuer can update the metadata
+	CanUpdateMetadata bool `protobuf:"varint,14,opt,name=can_update_metadata,json=canUpdateMetadata,proto3" json:"can_update_metadata,omitempty"`
+	// whether the issuer can be changed
+	CanChangeIssuer      bool     `protobuf:"varint,15,opt,name=can_change_issuer,json=canChangeIssuer,proto3" json:"can_change_issuer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenInfo) Reset()         { *m = TokenInfo{} }
+func (m *TokenInfo) String() string { return proto.CompactTextString(m) }
+func (*TokenInfo) ProtoMessage()    {}
+func (*TokenInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{32}
+}
+
+func (m *TokenInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenInfo.Unmarshal(m, b)
+}
+func (m *TokenInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenInfo.Marshal(b, m, deterministic)
+}
+func (m *TokenInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenInfo.Merge(m, src)
+}
+func (m *TokenInfo) XXX_Size() int {
+	return xxx_messageInfo_TokenInfo.Size(m)
+}
+func (m *TokenInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenInfo proto.InternalMessageInfo
+
+func (m *TokenInfo) GetSymbol() string {
+	if m != nil {
+		return m.Symbol
+	}
+	return ""
+}
+
+func (m *TokenInfo) GetFullName() string {
+	if m != nil {
+		return m.FullName
+	}
+	return ""
+}
+
+func (m *TokenInfo) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+func (m *TokenInfo) GetTotalSupplyFloat() float64 {
+	if m != nil {
+		return m.TotalSupplyFloat
+	}
+	return 0
+}
+
+func (m *TokenInfo) GetCurrentSupplyFloat() float64 {
+	if m != nil {
+		return m.CurrentSupplyFloat
+	}
+	return 0
+}
+
+func (m *TokenInfo) GetDecimal() int32 {
+	if m != nil {
+		return m.Decimal
+	}
+	return 0
+}
+
+func (m *TokenInfo) GetCanTransfer() bool {
+	if m != nil {
+		return m.CanTransfer
+	}
+	return false
+}
+
+func (m *TokenInfo) GetOnlyIssuerCanTransfer() bool {
+	if m != nil {
+		return m.OnlyIssuerCanTransfer
+	}
+	return false
+}
+
+func (m *TokenInfo) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *TokenInfo) GetLogo() string {
+	if m != nil {
+		return m.Logo
+	}
+	return ""
+}
+
+func (m *TokenInfo) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *TokenInfo) GetCanPause() bool {
+	if m != nil {
+		return m.CanPause
+	}
+	return false
+}
+
+func (m *TokenInfo) GetCanBlacklist() bool {
+	if m != nil {
+		return m.CanBlacklist
+	}
+	return false
+}
+
+func (m *TokenInfo) GetCanUpdateMetadata() bool {
+	if m != nil {
+		return m.CanUpdateMetadata
+	}
+	return false
+}
+
+func (m *TokenInfo) GetCanChangeIssuer() bool {
+	if m != nil {
+		return m.CanChangeIssuer
+	}
+	return false
+}
+
+// The message defines vesting schedule struct.
+type VestingSchedule struct {
+	// id of the schedule
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// account which granted the token
+	Grantor string `protobuf:"bytes,2,opt,name=grantor,proto3" json:"grantor,omitempty"`
+	// total amount of the schedule
+	Amount float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// amount claimed by the holder
+	Claimed float64 `protobuf:"fixed64,4,opt,name=claimed,proto3" json:"claimed,omitempty"`
+	// amount vested and not claimed yet
+	Claimable float64 `protobuf:"fixed64,5,opt,name=claimable,proto3" json:"claimable,omitempty"`
+	// start time in nanoseconds
+	Start int64 `protobuf:"varint,6,opt,name=start,proto3" json:"start,omitempty"`
+	// nothing is vested before start + cliff, in nanoseconds
+	Cliff int64 `protobuf:"varint,7,opt,name=cliff,proto3" json:"cliff,omitempty"`
+	// the amount is vested linearly during the duration, in nanoseconds
+	Duration int64 `protobuf:"varint,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	// the amount is vested once per interval, in nanoseconds
+	Interval int64 `protobuf:"varint,9,opt,name=interval,proto3" json:"interval,omitempty"`
+	// whether the grantor can revoke the schedule
+	Revocable            bool     `protobuf:"varint,10,opt,name=revocable,proto3" json:"revocable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VestingSchedule) Reset()         { *m = VestingSchedule{} }
+func (m *VestingSchedule) String() string { return proto.CompactTextString(m) }
+func (*VestingSchedule) ProtoMessage()    {}
+func (*VestingSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{33}
+}
+
+func (m *VestingSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VestingSchedule.Unmarshal(m, b)
+}
+func (m *VestingSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VestingSchedule.Marshal(b, m, deterministic)
+}
+func (m *VestingSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VestingSchedule.Merge(m, src)
+}
+func (m *VestingSchedule) XXX_Size() int {
+	return xxx_messageInfo_VestingSchedule.Size(m)
+}
+func (m *VestingSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_VestingSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VestingSchedule proto.InternalMessageInfo
+
+func (m *VestingSchedule) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *VestingSchedule) GetGrantor() string {
+	if m != nil {
+		return m.Grantor
+	}
+	return ""
+}
+
+func (m *VestingSchedule) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *VestingSchedule) GetClaimed() float64 {
+	if m != nil {
+		return m.Claimed
+	}
+	return 0
+}
+
+func (m *VestingSchedule) GetClaimable() float64 {
+	if m != nil {
+		return m.Claimable
+	}
+	return 0
+}
+
+func (m *VestingSchedule) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *VestingSchedule) GetCliff() int64 {
+	if m != nil {
+		return m.Cliff
+	}
+	return 0
+}
+
+func (m *VestingSchedule) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *VestingSchedule) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *VestingSchedule) GetRevocable() bool {
+	if m != nil {
+		return m.Revocable
+	}
+	return false
+}
+
+// The message defines get vesting response.
+type GetVestingResponse struct {
+	// vesting schedules
+	Schedules            []*VestingSchedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetVestingResponse) Reset()         { *m = GetVestingResponse{} }
+func (m *GetVestingResponse) String() string { return proto.CompactTextString(m) }
+func (*GetVestingResponse) ProtoMessage()    {}
+func (*GetVestingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{34}
+}
+
+func (m *GetVestingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetVestingResponse.Unmarshal(m, b)
+}
+func (m *GetVestingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetVestingResponse.Marshal(b, m, deterministic)
+}
+func (m *GetVestingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetVestingResponse.Merge(m, src)
+}
+func (m *GetVestingResponse) XXX_Size() int {
+	return xxx_messageInfo_GetVestingResponse.Size(m)
+}
+func (m *GetVestingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetVestingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetVestingResponse proto.InternalMessageInfo
+
+func (m *GetVestingResponse) GetSchedules() []*VestingSchedule {
+	if m != nil {
+		return m.Schedules
+	}
+	return nil
+}
+
 // The message defines get token721 balance response.
 type GetToken721BalanceResponse struct {
 	// token balance
@@ -2785,7 +3165,7 @@ func (m *GetToken721BalanceResponse) Reset()         { *m = GetToken721BalanceRe
 func (m *GetToken721BalanceResponse) String() string { return proto.CompactTextString(m) }
 func (*GetToken721BalanceResponse) ProtoMessage()    {}
 func (*GetToken721BalanceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{31}
+	return fileDescriptor_1b773bf3e696f610, []int{35}
 }
 
 func (m *GetToken721BalanceResponse) XXX_Unmarshal(b []byte) error {
@@ -2837,7 +3217,7 @@ func (m *GetToken721InfoRequest) Reset()         { *m = GetToken721InfoRequest{}
 func (m *GetToken721InfoRequest) String() string { return proto.CompactTextString(m) }
 func (*GetToken721InfoRequest) ProtoMessage()    {}
 func (*GetToken721InfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{32}
+	return fileDescriptor_1b773bf3e696f610, []int{36}
 }
 
 func (m *GetToken721InfoRequest) XXX_Unmarshal(b []byte) error {
@@ -2892,7 +3272,7 @@ func (m *GetToken721MetadataResponse) Reset()         { *m = GetToken721Metadata
 func (m *GetToken721MetadataResponse) String() string { return proto.CompactTextString(m) }
 func (*GetToken721MetadataResponse) ProtoMessage()    {}
 func (*GetToken721MetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{33}
+	return fileDescriptor_1b773bf3e696f610, []int{37}
 }
 
 func (m *GetToken721MetadataResponse) XXX_Unmarshal(b []byte) error {
@@ -2933,7 +3313,7 @@ func (m *GetToken721OwnerResponse) Reset()         { *m = GetToken721OwnerRespon
 func (m *GetToken721OwnerResponse) String() string { return proto.CompactTextString(m) }
 func (*GetToken721OwnerResponse) ProtoMessage()    {}
 func (*GetToken721OwnerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{34}
+	return fileDescriptor_1b773bf3e696f610, []int{38}
 }
 
 func (m *GetToken721OwnerResponse) XXX_Unmarshal(b []byte) error {
@@ -2961,6 +3341,165 @@ func (m *GetToken721OwnerResponse) GetOwner() string {
 	return ""
 }
 
+// The message defines get token721 approved response.
+type GetToken721ApprovedResponse struct {
+	// the approved account, it's empty if there is none
+	Approved             string   `protobuf:"bytes,1,opt,name=approved,proto3" json:"approved,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetToken721ApprovedResponse) Reset()         { *m = GetToken721ApprovedResponse{} }
+func (m *GetToken721ApprovedResponse) String() string { return proto.CompactTextString(m) }
+func (*GetToken721ApprovedResponse) ProtoMessage()    {}
+func (*GetToken721ApprovedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{39}
+}
+
+func (m *GetToken721ApprovedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetToken721ApprovedResponse.Unmarshal(m, b)
+}
+func (m *GetToken721ApprovedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetToken721ApprovedResponse.Marshal(b, m, deterministic)
+}
+func (m *GetToken721ApprovedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetToken721ApprovedResponse.Merge(m, src)
+}
+func (m *GetToken721ApprovedResponse) XXX_Size() int {
+	return xxx_messageInfo_GetToken721ApprovedResponse.Size(m)
+}
+func (m *GetToken721ApprovedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetToken721ApprovedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetToken721ApprovedResponse proto.InternalMessageInfo
+
+func (m *GetToken721ApprovedResponse) GetApproved() string {
+	if m != nil {
+		return m.Approved
+	}
+	return ""
+}
+
+// The message defines get token721 supply request.
+type GetToken721SupplyRequest struct {
+	// the token name
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// offset of the token ids
+	Offset int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// max number of the token ids, at most 1000
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// get data by longest chain's head block or last irreversible block
+	ByLongestChain       bool     `protobuf:"varint,4,opt,name=by_longest_chain,json=byLongestChain,proto3" json:"by_longest_chain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetToken721SupplyRequest) Reset()         { *m = GetToken721SupplyRequest{} }
+func (m *GetToken721SupplyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetToken721SupplyRequest) ProtoMessage()    {}
+func (*GetToken721SupplyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{40}
+}
+
+func (m *GetToken721SupplyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetToken721SupplyRequest.Unmarshal(m, b)
+}
+func (m *GetToken721SupplyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetToken721SupplyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetToken721SupplyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetToken721SupplyRequest.Merge(m, src)
+}
+func (m *GetToken721SupplyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetToken721SupplyRequest.Size(m)
+}
+func (m *GetToken721SupplyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetToken721SupplyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetToken721SupplyRequest proto.InternalMessageInfo
+
+func (m *GetToken721SupplyRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *GetToken721SupplyRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *GetToken721SupplyRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetToken721SupplyRequest) GetByLongestChain() bool {
+	if m != nil {
+		return m.ByLongestChain
+	}
+	return false
+}
+
+// The message defines get token721 supply response.
+type GetToken721SupplyResponse struct {
+	// number of tokens issued and not burned
+	Supply int64 `protobuf:"varint,1,opt,name=supply,proto3" json:"supply,omitempty"`
+	// ids of tokens
+	TokenIDs             []string `protobuf:"bytes,2,rep,name=tokenIDs,proto3" json:"tokenIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetToken721SupplyResponse) Reset()         { *m = GetToken721SupplyResponse{} }
+func (m *GetToken721SupplyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetToken721SupplyResponse) ProtoMessage()    {}
+func (*GetToken721SupplyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{41}
+}
+
+func (m *GetToken721SupplyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetToken721SupplyResponse.Unmarshal(m, b)
+}
+func (m *GetToken721SupplyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetToken721SupplyResponse.Marshal(b, m, deterministic)
+}
+func (m *GetToken721SupplyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetToken721SupplyResponse.Merge(m, src)
+}
+func (m *GetToken721SupplyResponse) XXX_Size() int {
+	return xxx_messageInfo_GetToken721SupplyResponse.Size(m)
+}
+func (m *GetToken721SupplyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetToken721SupplyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetToken721SupplyResponse proto.InternalMessageInfo
+
+func (m *GetToken721SupplyResponse) GetSupply() int64 {
+	if m != nil {
+		return m.Supply
+	}
+	return 0
+}
+
+func (m *GetToken721SupplyResponse) GetTokenIDs() []string {
+	if m != nil {
+		return m.TokenIDs
+	}
+	return nil
+}
+
 // The message defines event struct.
 type Event struct {
 	// event topic
@@ -2968,17 +3507,21 @@ type Event struct {
 	// event data
 	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	// event time
-	Time                 int64    `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Time int64 `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	// event name, set if the event is declared in the contract abi
+	Name string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	// event fields, string fields are as they are and others are encoded in json
+	Fields               map[string]string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Event) Reset()         { *m = Event{} }
 func (m *Event) String() string { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()    {}
 func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{35}
+	return fileDescriptor_1b773bf3e696f610, []int{42}
 }
 
 func (m *Event) XXX_Unmarshal(b []byte) error {
@@ -3017,7 +3560,21 @@ func (m *Event) GetTime() int64 {
 	if m != nil {
 		return m.Time
 	}
-	return 0
+	return 0
+}
+
+func (m *Event) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Event) GetFields() map[string]string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
 }
 
 // The message defines subscribe request.
@@ -3033,7 +3590,7 @@ func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
 func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
 func (*SubscribeRequest) ProtoMessage()    {}
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{36}
+	return fileDescriptor_1b773bf3e696f610, []int{43}
 }
 
 func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
@@ -3080,7 +3637,7 @@ func (m *SubscribeRequest_Filter) Reset()         { *m = SubscribeRequest_Filter
 func (m *SubscribeRequest_Filter) String() string { return proto.CompactTextString(m) }
 func (*SubscribeRequest_Filter) ProtoMessage()    {}
 func (*SubscribeRequest_Filter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{36, 0}
+	return fileDescriptor_1b773bf3e696f610, []int{43, 0}
 }
 
 func (m *SubscribeRequest_Filter) XXX_Unmarshal(b []byte) error {
@@ -3120,7 +3677,7 @@ func (m *SubscribeResponse) Reset()         { *m = SubscribeResponse{} }
 func (m *SubscribeResponse) String() string { return proto.CompactTextString(m) }
 func (*SubscribeResponse) ProtoMessage()    {}
 func (*SubscribeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1b773bf3e696f610, []int{37}
+	return fileDescriptor_1b773bf3e696f610, []int{44}
 }
 
 func (m *SubscribeResponse) XXX_Unmarshal(b []byte) error {
@@ -3148,6 +3705,264 @@ func (m *SubscribeResponse) GetEvent() *Event {
 	return nil
 }
 
+// The message defines a storage access in a call frame.
+type StorageAccess struct {
+	// operation, one of get, has, keys, put and del
+	Op string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	// storage key
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// map field
+	Field string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	// value before the operation
+	OldValue string `protobuf:"bytes,4,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	// value after the operation
+	NewValue             string   `protobuf:"bytes,5,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageAccess) Reset()         { *m = StorageAccess{} }
+func (m *StorageAccess) String() string { return proto.CompactTextString(m) }
+func (*StorageAccess) ProtoMessage()    {}
+func (*StorageAccess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{45}
+}
+
+func (m *StorageAccess) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageAccess.Unmarshal(m, b)
+}
+func (m *StorageAccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageAccess.Marshal(b, m, deterministic)
+}
+func (m *StorageAccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageAccess.Merge(m, src)
+}
+func (m *StorageAccess) XXX_Size() int {
+	return xxx_messageInfo_StorageAccess.Size(m)
+}
+func (m *StorageAccess) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageAccess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageAccess proto.InternalMessageInfo
+
+func (m *StorageAccess) GetOp() string {
+	if m != nil {
+		return m.Op
+	}
+	return ""
+}
+
+func (m *StorageAccess) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StorageAccess) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *StorageAccess) GetOldValue() string {
+	if m != nil {
+		return m.OldValue
+	}
+	return ""
+}
+
+func (m *StorageAccess) GetNewValue() string {
+	if m != nil {
+		return m.NewValue
+	}
+	return ""
+}
+
+// The message defines a contract call of a traced transaction.
+type CallFrame struct {
+	// contract name
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	// api name
+	Api string `protobuf:"bytes,2,opt,name=api,proto3" json:"api,omitempty"`
+	// arguments
+	Args string `protobuf:"bytes,3,opt,name=args,proto3" json:"args,omitempty"`
+	// gas usage, without gas ratio
+	Gas int64 `protobuf:"varint,4,opt,name=gas,proto3" json:"gas,omitempty"`
+	// ram usage
+	Ram int64 `protobuf:"varint,5,opt,name=ram,proto3" json:"ram,omitempty"`
+	// storage accesses
+	Storage []*StorageAccess `protobuf:"bytes,6,rep,name=storage,proto3" json:"storage,omitempty"`
+	// receipts
+	Receipts []*TxReceipt_Receipt `protobuf:"bytes,7,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	// events
+	Events []string `protobuf:"bytes,8,rep,name=events,proto3" json:"events,omitempty"`
+	// error message, empty if the call succeeds
+	Error string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	// nested calls
+	Calls                []*CallFrame `protobuf:"bytes,10,rep,name=calls,proto3" json:"calls,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *CallFrame) Reset()         { *m = CallFrame{} }
+func (m *CallFrame) String() string { return proto.CompactTextString(m) }
+func (*CallFrame) ProtoMessage()    {}
+func (*CallFrame) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{46}
+}
+
+func (m *CallFrame) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CallFrame.Unmarshal(m, b)
+}
+func (m *CallFrame) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CallFrame.Marshal(b, m, deterministic)
+}
+func (m *CallFrame) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CallFrame.Merge(m, src)
+}
+func (m *CallFrame) XXX_Size() int {
+	return xxx_messageInfo_CallFrame.Size(m)
+}
+func (m *CallFrame) XXX_DiscardUnknown() {
+	xxx_messageInfo_CallFrame.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CallFrame proto.InternalMessageInfo
+
+func (m *CallFrame) GetContract() string {
+	if m != nil {
+		return m.Contract
+	}
+	return ""
+}
+
+func (m *CallFrame) GetApi() string {
+	if m != nil {
+		return m.Api
+	}
+	return ""
+}
+
+func (m *CallFrame) GetArgs() string {
+	if m != nil {
+		return m.Args
+	}
+	return ""
+}
+
+func (m *CallFrame) GetGas() int64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *CallFrame) GetRam() int64 {
+	if m != nil {
+		return m.Ram
+	}
+	return 0
+}
+
+func (m *CallFrame) GetStorage() []*StorageAccess {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+func (m *CallFrame) GetReceipts() []*TxReceipt_Receipt {
+	if m != nil {
+		return m.Receipts
+	}
+	return nil
+}
+
+func (m *CallFrame) GetEvents() []string {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *CallFrame) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CallFrame) GetCalls() []*CallFrame {
+	if m != nil {
+		return m.Calls
+	}
+	return nil
+}
+
+// The message defines trace transaction response.
+type TraceTransactionResponse struct {
+	// transaction receipt
+	Receipt *TxReceipt `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// contract calls of the actions
+	Calls []*CallFrame `protobuf:"bytes,2,rep,name=calls,proto3" json:"calls,omitempty"`
+	// block number
+	BlockNumber          int64    `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TraceTransactionResponse) Reset()         { *m = TraceTransactionResponse{} }
+func (m *TraceTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*TraceTransactionResponse) ProtoMessage()    {}
+func (*TraceTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b773bf3e696f610, []int{47}
+}
+
+func (m *TraceTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceTransactionResponse.Unmarshal(m, b)
+}
+func (m *TraceTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *TraceTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceTransactionResponse.Merge(m, src)
+}
+func (m *TraceTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_TraceTransactionResponse.Size(m)
+}
+func (m *TraceTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceTransactionResponse proto.InternalMessageInfo
+
+func (m *TraceTransactionResponse) GetReceipt() *TxReceipt {
+	if m != nil {
+		return m.Receipt
+	}
+	return nil
+}
+
+func (m *TraceTransactionResponse) GetCalls() []*CallFrame {
+	if m != nil {
+		return m.Calls
+	}
+	return nil
+}
+
+func (m *TraceTransactionResponse) GetBlockNumber() int64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("rpcpb.TxReceipt_StatusCode", TxReceipt_StatusCode_name, TxReceipt_StatusCode_value)
 	proto.RegisterEnum("rpcpb.TransactionResponse_Status", TransactionResponse_Status_name, TransactionResponse_Status_value)
@@ -3197,221 +4012,282 @@ func init() {
 	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
 	proto.RegisterType((*GetTokenBalanceResponse)(nil), "rpcpb.GetTokenBalanceResponse")
 	proto.RegisterType((*GetTokenBalanceRequest)(nil), "rpcpb.GetTokenBalanceRequest")
+	proto.RegisterType((*GetTokenInfoRequest)(nil), "rpcpb.GetTokenInfoRequest")
+	proto.RegisterType((*TokenInfo)(nil), "rpcpb.TokenInfo")
+	proto.RegisterType((*VestingSchedule)(nil), "rpcpb.VestingSchedule")
+	proto.RegisterType((*GetVestingResponse)(nil), "rpcpb.GetVestingResponse")
 	proto.RegisterType((*GetToken721BalanceResponse)(nil), "rpcpb.GetToken721BalanceResponse")
 	proto.RegisterType((*GetToken721InfoRequest)(nil), "rpcpb.GetToken721InfoRequest")
 	proto.RegisterType((*GetToken721MetadataResponse)(nil), "rpcpb.GetToken721MetadataResponse")
 	proto.RegisterType((*GetToken721OwnerResponse)(nil), "rpcpb.GetToken721OwnerResponse")
+	proto.RegisterType((*GetToken721ApprovedResponse)(nil), "rpcpb.GetToken721ApprovedResponse")
+	proto.RegisterType((*GetToken721SupplyRequest)(nil), "rpcpb.GetToken721SupplyRequest")
+	proto.RegisterType((*GetToken721SupplyResponse)(nil), "rpcpb.GetToken721SupplyResponse")
 	proto.RegisterType((*Event)(nil), "rpcpb.Event")
+	proto.RegisterMapType((map[string]string)(nil), "rpcpb.Event.FieldsEntry")
 	proto.RegisterType((*SubscribeRequest)(nil), "rpcpb.SubscribeRequest")
 	proto.RegisterType((*SubscribeRequest_Filter)(nil), "rpcpb.SubscribeRequest.Filter")
 	proto.RegisterType((*SubscribeResponse)(nil), "rpcpb.SubscribeResponse")
+	proto.RegisterType((*StorageAccess)(nil), "rpcpb.StorageAccess")
+	proto.RegisterType((*CallFrame)(nil), "rpcpb.CallFrame")
+	proto.RegisterType((*TraceTransactionResponse)(nil), "rpcpb.TraceTransactionResponse")
 }
 
 func init() { proto.RegisterFile("rpc/pb/rpc.proto", fileDescriptor_1b773bf3e696f610) }
 
 var fileDescriptor_1b773bf3e696f610 = []byte{
-	// 3205 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x39, 0x4d, 0x73, 0x1b, 0xc7,
-	0xb1, 0x5a, 0x80, 0xf8, 0x6a, 0x80, 0xe0, 0x6a, 0x48, 0x53, 0x10, 0xf4, 0xbd, 0x96, 0xad, 0x8f,
-	0xb2, 0x09, 0x89, 0xb2, 0x24, 0x4b, 0xb6, 0xdf, 0x33, 0x48, 0x41, 0x34, 0x4b, 0x12, 0x48, 0x2f,
-	0x40, 0xfb, 0xb9, 0xea, 0x55, 0xed, 0x5b, 0x00, 0x43, 0x70, 0x9f, 0x80, 0x5d, 0x64, 0x77, 0x21,
-	0x81, 0x61, 0xe9, 0x90, 0x1c, 0x72, 0x4a, 0xc5, 0xe5, 0xf2, 0x25, 0x55, 0xc9, 0x25, 0xd7, 0xfc,
-	0x81, 0xa4, 0x2a, 0xd7, 0xfc, 0x83, 0x54, 0xe5, 0x9a, 0x43, 0xf2, 0x0f, 0xfc, 0x07, 0x52, 0xd3,
-	0x33, 0xb3, 0x5f, 0x58, 0x48, 0xcc, 0x09, 0xdb, 0x3d, 0x3d, 0xdd, 0x3d, 0xd3, 0xdf, 0x03, 0x50,
-	0xdd, 0x49, 0xbf, 0x31, 0xe9, 0x35, 0xdc, 0x49, 0x7f, 0x63, 0xe2, 0x3a, 0xbe, 0x43, 0x72, 0xee,
-	0xa4, 0x3f, 0xe9, 0xd5, 0x2f, 0x0e, 0x1d, 0x67, 0x38, 0xa2, 0x0d, 0x73, 0x62, 0x35, 0x4c, 0xdb,
-	0x76, 0x7c, 0xd3, 0xb7, 0x1c, 0xdb, 0xe3, 0x44, 0x5a, 0x15, 0x2a, 0xad, 0xf1, 0xc4, 0x3f, 0xd6,
-	0xe9, 0xcf, 0xa6, 0xd4, 0xf3, 0xb5, 0x0d, 0x28, 0xee, 0x53, 0xea, 0xee, 0xda, 0x87, 0x0e, 0xa9,
-	0x42, 0xc6, 0x1a, 0xd4, 0x94, 0xab, 0xca, 0xcd, 0x92, 0x9e, 0xb1, 0x06, 0x84, 0xc0, 0x92, 0x39,
-	0x18, 0xb8, 0xb5, 0x0c, 0x62, 0xf0, 0x5b, 0xfb, 0x7f, 0x28, 0xb7, 0xa9, 0xff, 0xda, 0x71, 0x5f,
-	0xa6, 0x6e, 0xb9, 0x04, 0x30, 0xa1, 0xd4, 0x35, 0xfa, 0xce, 0xd4, 0xf6, 0x71, 0x63, 0x4e, 0x2f,
-	0x31, 0xcc, 0x36, 0x43, 0x90, 0x8f, 0x00, 0x01, 0xc3, 0xb2, 0x0f, 0x9d, 0x5a, 0xf6, 0x6a, 0xf6,
-	0x66, 0x79, 0x73, 0x65, 0x03, 0xd5, 0xde, 0x90, 0x5a, 0xe8, 0xc5, 0x89, 0xf8, 0xd2, 0xfe, 0xa8,
-	0xc0, 0x8a, 0xde, 0x7c, 0x81, 0x58, 0xea, 0x4d, 0x1c, 0xdb, 0xa3, 0xe4, 0x3c, 0x14, 0xa7, 0x1e,
-	0x1d, 0x18, 0xae, 0x39, 0x46, 0xb1, 0x59, 0xbd, 0xc0, 0x60, 0xdd, 0x1c, 0x93, 0xf7, 0x61, 0xd9,
-	0x7c, 0x65, 0x5a, 0x23, 0xb3, 0x37, 0xa2, 0xb8, 0x9e, 0xc1, 0xf5, 0x4a, 0x80, 0x64, 0x44, 0x17,
-	0xa0, 0xe4, 0x3b, 0xbe, 0x39, 0x42, 0x82, 0x2c, 0x12, 0x14, 0x11, 0xc1, 0x16, 0x2f, 0x01, 0x78,
-	0x74, 0x34, 0x32, 0x26, 0xae, 0xd5, 0xa7, 0xb5, 0xa5, 0xab, 0xca, 0x4d, 0x45, 0x2f, 0x31, 0xcc,
-	0x3e, 0x43, 0xb0, 0xbd, 0xbd, 0xe9, 0xb1, 0x58, 0xcd, 0xe1, 0x6a, 0xb1, 0x37, 0x3d, 0xc6, 0x45,
-	0xed, 0x37, 0x0a, 0xa8, 0x6d, 0x67, 0x40, 0x63, 0xda, 0x5e, 0x02, 0xe8, 0x4d, 0xad, 0xd1, 0xc0,
-	0xf0, 0xad, 0x31, 0x15, 0xd7, 0x54, 0x42, 0x4c, 0xd7, 0x1a, 0xe3, 0x61, 0x86, 0x96, 0x6f, 0x1c,
-	0x99, 0xde, 0x91, 0xb8, 0xe4, 0xc2, 0xd0, 0xf2, 0xbf, 0x32, 0xbd, 0x23, 0x76, 0xf7, 0x63, 0x67,
-	0x40, 0x51, 0xc5, 0x92, 0x8e, 0xdf, 0xe4, 0x23, 0x28, 0xd8, 0xfc, 0xee, 0x51, 0xb7, 0xf2, 0x26,
-	0x11, 0x77, 0x17, 0xb1, 0x88, 0x2e, 0x49, 0xb4, 0x47, 0x50, 0x6e, 0x8e, 0xd9, 0xad, 0x3f, 0xb7,
-	0xc6, 0x96, 0x4f, 0xd6, 0x20, 0xe7, 0x3b, 0x2f, 0xa9, 0x2d, 0xb4, 0xe0, 0x00, 0xc3, 0xbe, 0x32,
-	0x47, 0x53, 0x2a, 0xc4, 0x73, 0x40, 0xfb, 0x0e, 0xf2, 0xcd, 0x3e, 0xf3, 0x1a, 0x52, 0x87, 0x62,
-	0xdf, 0xb1, 0x7d, 0xd7, 0xec, 0xfb, 0x62, 0x63, 0x00, 0x93, 0x2b, 0x50, 0x36, 0x91, 0xca, 0xb0,
-	0xcd, 0xb1, 0xe4, 0x00, 0x1c, 0xd5, 0x36, 0xc7, 0x94, 0x9d, 0x61, 0x60, 0xfa, 0xa6, 0x3c, 0x03,
-	0xfb, 0xd6, 0xfe, 0xb1, 0x04, 0xa5, 0xee, 0x4c, 0xa7, 0x7d, 0x6a, 0x4d, 0x7c, 0x72, 0x0e, 0x0a,
-	0xfe, 0x8c, 0x9f, 0x9f, 0x73, 0xcf, 0xfb, 0x33, 0x3c, 0xfe, 0x05, 0x28, 0x0d, 0x4d, 0xcf, 0x98,
-	0x7a, 0xe6, 0x90, 0x73, 0x56, 0xf4, 0xe2, 0xd0, 0xf4, 0x0e, 0x18, 0x4c, 0x3e, 0x83, 0x92, 0x6b,
-	0x8e, 0xc5, 0x22, 0xf7, 0xa2, 0xcb, 0xe2, 0x26, 0x02, 0xd6, 0x1b, 0xba, 0x39, 0x46, 0xea, 0x96,
-	0xed, 0xbb, 0xc7, 0x7a, 0xd1, 0x15, 0x20, 0xf9, 0x1c, 0xca, 0x9e, 0x6f, 0xfa, 0x53, 0xcf, 0xe8,
-	0xb3, 0xfb, 0x65, 0x17, 0x59, 0xdd, 0xbc, 0x30, 0xb7, 0xbd, 0x83, 0x34, 0xdb, 0xce, 0x80, 0xea,
-	0xe0, 0x05, 0xdf, 0xa4, 0x06, 0x85, 0x31, 0xf5, 0x50, 0x70, 0x8e, 0x1b, 0x4c, 0x80, 0x6c, 0xc5,
-	0xa5, 0xfe, 0xd4, 0xb5, 0xbd, 0x5a, 0xfe, 0x6a, 0x96, 0xad, 0x08, 0x90, 0x7c, 0x02, 0x45, 0x97,
-	0x73, 0xf5, 0x6a, 0x05, 0xd4, 0xb6, 0x36, 0xaf, 0x2d, 0xff, 0xd5, 0x03, 0xca, 0xfa, 0x67, 0xb0,
-	0x1c, 0x3b, 0x02, 0x51, 0x21, 0xfb, 0x92, 0x1e, 0x8b, 0x7b, 0x62, 0x9f, 0x71, 0xe3, 0x65, 0x85,
-	0xf1, 0x1e, 0x67, 0x3e, 0x55, 0xea, 0x5f, 0x42, 0x41, 0x5e, 0xf1, 0x05, 0x28, 0x1d, 0x4e, 0xed,
-	0x3e, 0xb7, 0x91, 0x30, 0x21, 0x43, 0xa0, 0x85, 0x6a, 0x50, 0x60, 0xe6, 0xa4, 0x22, 0x56, 0x4b,
-	0xba, 0x04, 0xb5, 0x3f, 0x29, 0x00, 0xe1, 0x1d, 0x90, 0x32, 0x14, 0x3a, 0x07, 0xdb, 0xdb, 0xad,
-	0x4e, 0x47, 0x3d, 0x43, 0x56, 0xa0, 0xbc, 0xd3, 0xec, 0x18, 0xfa, 0x41, 0xdb, 0xd8, 0x3b, 0xe8,
-	0xaa, 0x0a, 0x59, 0x07, 0xb2, 0xd5, 0x7c, 0xde, 0x6c, 0x6f, 0xb7, 0x8c, 0xf6, 0x5e, 0xd7, 0x68,
-	0xb5, 0xf7, 0x0e, 0x76, 0xbe, 0x52, 0x33, 0x64, 0x15, 0x56, 0xbe, 0xd5, 0xf7, 0xda, 0x3b, 0xc6,
-	0x7e, 0x53, 0x6f, 0xbe, 0x68, 0x75, 0x5b, 0xba, 0x9a, 0x25, 0x67, 0x61, 0x59, 0x3f, 0x68, 0x77,
-	0x77, 0x5f, 0xb4, 0x8c, 0x96, 0xae, 0xef, 0xe9, 0xea, 0x12, 0xe3, 0xce, 0x60, 0xc6, 0x2c, 0x17,
-	0x6e, 0xea, 0xfe, 0x8f, 0xf1, 0x74, 0x4f, 0x7f, 0xd1, 0xec, 0xaa, 0x79, 0x26, 0xe1, 0xc9, 0xc1,
-	0xfe, 0xf3, 0xdd, 0xed, 0x66, 0xb7, 0x65, 0x74, 0x5a, 0x5d, 0x63, 0x7b, 0xef, 0x49, 0x4b, 0x2d,
-	0x30, 0x66, 0x07, 0xed, 0x67, 0xed, 0xbd, 0x6f, 0xdb, 0x82, 0x59, 0x51, 0xfb, 0x3e, 0x0b, 0xe5,
-	0xae, 0x6b, 0xda, 0x1e, 0xf7, 0x44, 0xe6, 0x85, 0x11, 0x07, 0xc3, 0x6f, 0x86, 0xc3, 0x88, 0xe4,
-	0x17, 0x87, 0xdf, 0xe4, 0x32, 0x00, 0x9d, 0x4d, 0x2c, 0x17, 0xd3, 0xa5, 0x48, 0x0d, 0x11, 0x8c,
-	0x74, 0x49, 0x84, 0x44, 0x6e, 0x60, 0x2e, 0xa9, 0x33, 0x58, 0x2e, 0x8e, 0x58, 0xa8, 0xc9, 0xd4,
-	0x30, 0x34, 0xbd, 0x20, 0xf4, 0x06, 0x74, 0x64, 0x1e, 0xd7, 0xf2, 0xdc, 0x4e, 0x08, 0x90, 0x1b,
-	0x50, 0xe0, 0x1a, 0x4a, 0xaf, 0x58, 0x16, 0x5e, 0xc1, 0x43, 0x4f, 0x97, 0xab, 0xcc, 0x48, 0x9e,
-	0x35, 0xb4, 0xa9, 0xeb, 0xd5, 0x8a, 0xdc, 0xb3, 0x04, 0x48, 0x2e, 0x42, 0x69, 0x32, 0xed, 0x8d,
-	0x2c, 0xef, 0x88, 0xba, 0xb5, 0x12, 0xcf, 0x2e, 0x01, 0x82, 0xc5, 0xa7, 0x4b, 0x0f, 0xa9, 0xeb,
-	0xd2, 0x81, 0xe1, 0xcf, 0x6a, 0xc0, 0xe3, 0x53, 0xa2, 0xba, 0x33, 0x72, 0x1f, 0x2a, 0x26, 0x66,
-	0x08, 0xa1, 0x77, 0x19, 0xd5, 0x90, 0x49, 0x25, 0x92, 0x3c, 0xf4, 0xb2, 0x19, 0xc9, 0x24, 0x0d,
-	0x00, 0x7f, 0x66, 0x08, 0x47, 0xad, 0x55, 0x30, 0x13, 0xa9, 0x49, 0x8f, 0xd6, 0x4b, 0xbe, 0xfc,
-	0xd4, 0xfe, 0xa2, 0xc0, 0x6a, 0xc4, 0x22, 0x41, 0x76, 0x7c, 0x04, 0x79, 0x1e, 0x5a, 0x68, 0x9b,
-	0xea, 0xe6, 0x35, 0xc9, 0x64, 0x9e, 0x56, 0xc4, 0xa3, 0x2e, 0x36, 0x90, 0x4f, 0xa0, 0xec, 0x87,
-	0x54, 0x68, 0xc7, 0x50, 0xf3, 0xe8, 0xfe, 0x28, 0x99, 0x76, 0x0f, 0xf2, 0x9c, 0x0f, 0xf3, 0xb8,
-	0xfd, 0x56, 0xfb, 0xc9, 0x6e, 0x7b, 0x47, 0x3d, 0x43, 0x00, 0xf2, 0xfb, 0xcd, 0xed, 0x67, 0xad,
-	0x27, 0xaa, 0x42, 0x54, 0xa8, 0xec, 0xea, 0x7a, 0xeb, 0x9b, 0x96, 0xde, 0xd9, 0xdd, 0x7a, 0xde,
-	0x52, 0x33, 0xda, 0x9f, 0x15, 0x28, 0x75, 0xac, 0xa1, 0x6d, 0xfa, 0x53, 0x97, 0x92, 0x4f, 0xa1,
-	0x64, 0x8e, 0x86, 0x8e, 0x6b, 0xf9, 0x47, 0x63, 0xa1, 0x76, 0x5d, 0x88, 0x0d, 0x88, 0x36, 0x9a,
-	0x92, 0x42, 0x0f, 0x89, 0x99, 0xb1, 0x3c, 0x49, 0x81, 0x0a, 0x57, 0xf4, 0x10, 0x81, 0x85, 0x93,
-	0x59, 0xae, 0x6f, 0xb0, 0x20, 0xcf, 0xf2, 0x65, 0x8e, 0x79, 0x46, 0x8f, 0xb5, 0x4f, 0xa0, 0x14,
-	0x30, 0x65, 0xca, 0x0b, 0xa7, 0x57, 0xcf, 0x90, 0x65, 0x28, 0x75, 0x5a, 0xdb, 0xfb, 0x9b, 0xf7,
-	0x1f, 0x3c, 0xbb, 0xab, 0x2a, 0x6c, 0xad, 0xf5, 0x64, 0xf3, 0xfe, 0xfd, 0xbb, 0x8f, 0xd4, 0x8c,
-	0xf6, 0xbb, 0x2c, 0x90, 0xd8, 0x65, 0x62, 0xcd, 0x0f, 0xbc, 0x5f, 0x59, 0xe8, 0xfd, 0x99, 0xb7,
-	0x7b, 0x7f, 0xf6, 0x6d, 0xde, 0xbf, 0xb4, 0xc8, 0xfb, 0x73, 0x0b, 0xbc, 0x3f, 0xff, 0x56, 0xef,
-	0x4f, 0x3a, 0x69, 0xe1, 0x74, 0x4e, 0xba, 0x38, 0x68, 0xee, 0x00, 0x04, 0xd7, 0xee, 0xd5, 0x4a,
-	0xc8, 0x4e, 0x4d, 0x9a, 0x50, 0x8f, 0xd0, 0xc4, 0xc3, 0x0c, 0x92, 0x61, 0xf6, 0x10, 0xaa, 0x01,
-	0x60, 0x78, 0xd6, 0xd0, 0x13, 0x71, 0x34, 0xcf, 0x73, 0x39, 0xa0, 0xeb, 0x58, 0x43, 0x4f, 0xfb,
-	0x67, 0x16, 0x72, 0x5b, 0x23, 0xa7, 0xff, 0x32, 0x35, 0x45, 0xd5, 0xa0, 0xf0, 0x8a, 0xba, 0x5e,
-	0x68, 0x0d, 0x09, 0xb2, 0xb8, 0x9e, 0x98, 0x2e, 0xb5, 0x45, 0xe3, 0xc0, 0xab, 0x2b, 0x70, 0x14,
-	0x16, 0xcf, 0xeb, 0x50, 0xf5, 0x67, 0xc6, 0x98, 0xba, 0x2f, 0x47, 0x94, 0xd3, 0x2c, 0x21, 0x4d,
-	0xc5, 0x9f, 0xbd, 0x40, 0x24, 0x52, 0xdd, 0x83, 0xf5, 0x30, 0x8c, 0x63, 0xd4, 0xbc, 0xb2, 0xad,
-	0x06, 0x01, 0x1c, 0xd9, 0xb4, 0x0e, 0x79, 0x7b, 0x3a, 0xee, 0x51, 0x57, 0xe4, 0x32, 0x01, 0x31,
-	0x6d, 0x5f, 0x5b, 0xbe, 0x4d, 0x3d, 0x96, 0xcc, 0xb0, 0x90, 0x08, 0x30, 0x70, 0xb6, 0x62, 0xc4,
-	0xd9, 0x62, 0xd5, 0xbd, 0x94, 0xa8, 0xee, 0xe7, 0xa1, 0xe8, 0xcf, 0x44, 0x03, 0x09, 0xfc, 0xe4,
-	0xfe, 0x8c, 0xb7, 0x8f, 0x1f, 0xc0, 0x12, 0x76, 0x8e, 0x65, 0x0c, 0xf7, 0xb3, 0xe2, 0x82, 0xf1,
-	0x0e, 0x37, 0xb0, 0xf9, 0xc1, 0x65, 0xf2, 0x00, 0x2a, 0x91, 0xa8, 0xf7, 0x6a, 0x95, 0x98, 0xcb,
-	0x44, 0x03, 0x22, 0x46, 0x57, 0xef, 0xc0, 0x12, 0x36, 0xb5, 0xb2, 0xf7, 0x52, 0xb0, 0x7d, 0xe5,
-	0xbd, 0xd7, 0x3a, 0xe4, 0xfd, 0x23, 0x97, 0x9a, 0x03, 0xd1, 0xd4, 0x0a, 0x88, 0x19, 0xa3, 0x67,
-	0xfa, 0xfd, 0x23, 0xc3, 0xb2, 0x07, 0x74, 0x86, 0xdd, 0x48, 0x4e, 0x07, 0x44, 0xed, 0x32, 0x8c,
-	0xf6, 0x83, 0x02, 0xcb, 0xa8, 0x61, 0x90, 0xf6, 0xee, 0x25, 0xd2, 0xde, 0x85, 0xe8, 0x39, 0x16,
-	0x25, 0x3c, 0x0d, 0x72, 0x3d, 0xb6, 0x2e, 0x52, 0x5d, 0x25, 0xb6, 0x87, 0x2f, 0x69, 0x37, 0xd2,
-	0xd3, 0x5b, 0x32, 0xa5, 0x29, 0xda, 0xaf, 0x32, 0x70, 0x76, 0xfb, 0xc8, 0xb4, 0xec, 0x64, 0x6b,
-	0x6d, 0x53, 0x3f, 0xda, 0x28, 0xb0, 0x5e, 0x12, 0xfb, 0x84, 0x5b, 0xa0, 0xe2, 0xf8, 0xd0, 0x77,
-	0x46, 0x46, 0xd4, 0x2b, 0x4b, 0xfa, 0x8a, 0xc4, 0x7f, 0x23, 0xbc, 0xf3, 0x12, 0xc0, 0x11, 0x35,
-	0x07, 0x06, 0xd7, 0x96, 0x97, 0xd1, 0x12, 0xc3, 0x70, 0x57, 0xff, 0x10, 0x56, 0xc2, 0xe5, 0xa8,
-	0x73, 0x2e, 0x07, 0x34, 0xb2, 0x01, 0x1c, 0x59, 0x3d, 0xc1, 0x85, 0x67, 0x8e, 0xe2, 0xc8, 0xea,
-	0x71, 0x26, 0xd7, 0xa1, 0x1a, 0x2c, 0x72, 0x1e, 0x79, 0xee, 0xe0, 0x92, 0x02, 0x59, 0x5c, 0x83,
-	0x8a, 0x70, 0x42, 0x63, 0x64, 0x79, 0x3c, 0x73, 0x94, 0xf4, 0xb2, 0xc0, 0x3d, 0xb7, 0x3c, 0x5f,
-	0x7b, 0x1f, 0x96, 0xbb, 0xd8, 0x70, 0x46, 0x52, 0x63, 0x32, 0x12, 0xb5, 0x1d, 0x78, 0x6f, 0x87,
-	0xfa, 0xc8, 0x77, 0xeb, 0xf8, 0x1d, 0xc4, 0xbc, 0x61, 0x1e, 0x4f, 0x46, 0xd4, 0xe7, 0x49, 0xbe,
-	0xa8, 0x07, 0xb0, 0xf6, 0x02, 0xce, 0x85, 0x8c, 0xda, 0x18, 0x38, 0x92, 0x55, 0x18, 0x57, 0x4a,
-	0x2c, 0xae, 0xde, 0xc6, 0xee, 0x33, 0x58, 0x7e, 0xea, 0x3a, 0x3f, 0xa7, 0xf6, 0x96, 0x39, 0x32,
-	0xed, 0x3e, 0xfa, 0x28, 0x4f, 0x81, 0xc8, 0x44, 0xd1, 0x05, 0x94, 0xd6, 0xed, 0x68, 0x87, 0xa0,
-	0xee, 0x88, 0xf4, 0x1d, 0x38, 0xc0, 0x4d, 0x50, 0x47, 0xce, 0x6b, 0xea, 0xf9, 0x46, 0x98, 0xea,
-	0x39, 0xa7, 0x2a, 0xc7, 0xcb, 0x1d, 0x8c, 0x72, 0x4c, 0x07, 0x96, 0x69, 0x47, 0x28, 0x79, 0x97,
-	0x5e, 0xe5, 0x78, 0x49, 0xa9, 0xfd, 0xb5, 0x04, 0x85, 0x66, 0xbf, 0x2f, 0xf5, 0x88, 0x38, 0x17,
-	0x7e, 0xb3, 0xc4, 0xd1, 0xe3, 0xea, 0x0b, 0x06, 0x12, 0x24, 0x77, 0x81, 0xe5, 0x04, 0x39, 0x2a,
-	0x32, 0xa7, 0x5f, 0x0f, 0x4a, 0x04, 0xf2, 0xdb, 0xd8, 0x31, 0x3d, 0x3e, 0xf2, 0x0c, 0xf9, 0x07,
-	0xdb, 0xc2, 0x06, 0x03, 0xdc, 0xb2, 0x94, 0xba, 0x45, 0x8e, 0x93, 0x05, 0xd7, 0x1c, 0xe3, 0x96,
-	0x26, 0x94, 0x27, 0xd4, 0x1d, 0x5b, 0x9e, 0x87, 0xa9, 0x22, 0x87, 0xa9, 0xe2, 0x4a, 0x62, 0xd7,
-	0x7e, 0x48, 0xc1, 0xc7, 0x89, 0xe8, 0x1e, 0xb2, 0x09, 0xf9, 0xa1, 0xeb, 0x4c, 0x27, 0xb2, 0x92,
-	0xd5, 0x93, 0x6a, 0xe2, 0x22, 0xdf, 0x28, 0x28, 0xc9, 0x17, 0xb0, 0x72, 0x88, 0xb6, 0x33, 0xc4,
-	0x71, 0x65, 0x13, 0xb8, 0x26, 0x36, 0xc7, 0x2c, 0xab, 0x57, 0x0f, 0xa3, 0xa0, 0x57, 0xff, 0x2f,
-	0x80, 0xfd, 0x11, 0x1d, 0x0c, 0x71, 0xda, 0x64, 0x77, 0x38, 0x41, 0xc8, 0x95, 0x71, 0x2b, 0xc0,
-	0x88, 0x47, 0x64, 0xa2, 0x1e, 0x51, 0xff, 0x49, 0x81, 0x82, 0xb8, 0x3d, 0x36, 0x36, 0xf7, 0xa7,
-	0x2e, 0xd6, 0x13, 0x1c, 0x84, 0x85, 0xc9, 0x2b, 0x02, 0xd9, 0x65, 0x38, 0x96, 0x00, 0x30, 0x55,
-	0x1e, 0x52, 0x17, 0xc7, 0xeb, 0xa1, 0xe9, 0x09, 0x96, 0x2b, 0x51, 0xfc, 0x8e, 0xe9, 0x61, 0x27,
-	0x83, 0xe2, 0x91, 0x88, 0xb7, 0x0a, 0x25, 0x8e, 0x61, 0xcb, 0x1f, 0x40, 0xd5, 0xb2, 0xfb, 0x2e,
-	0x35, 0x3d, 0x6a, 0x78, 0x13, 0x4a, 0x07, 0xa2, 0x61, 0x58, 0x96, 0xd8, 0x0e, 0x43, 0xb2, 0xae,
-	0x21, 0xda, 0x4c, 0x73, 0x80, 0x7c, 0x0e, 0x15, 0xce, 0x69, 0xc0, 0x8d, 0xcc, 0x2f, 0xfc, 0x7c,
-	0xd2, 0x5c, 0xc1, 0xd5, 0xe8, 0x65, 0x41, 0xce, 0x80, 0xfa, 0xd7, 0x50, 0x10, 0xf6, 0x67, 0x25,
-	0x3d, 0x78, 0x16, 0x10, 0x21, 0x17, 0x22, 0x98, 0xa3, 0x4e, 0x3d, 0x3a, 0x90, 0x01, 0xc3, 0xbe,
-	0xf9, 0xfc, 0xcc, 0xae, 0x87, 0xa7, 0x34, 0x0e, 0xd4, 0x6d, 0x58, 0xda, 0xf5, 0xe9, 0x78, 0xee,
-	0x1d, 0xe4, 0x32, 0x94, 0x2d, 0x8f, 0xb5, 0x72, 0xc6, 0xc4, 0xb4, 0x5c, 0x11, 0xba, 0x25, 0xcb,
-	0x7b, 0x46, 0x8f, 0xf7, 0x4d, 0x0b, 0x0d, 0xf3, 0x9a, 0x5a, 0xc3, 0x23, 0x5f, 0xb0, 0x13, 0x10,
-	0x6b, 0xc3, 0x42, 0xd7, 0x12, 0x99, 0x31, 0x82, 0xa9, 0x3f, 0x85, 0x1c, 0xba, 0x53, 0x6a, 0x2c,
-	0xdd, 0x82, 0x9c, 0xe5, 0xd3, 0x31, 0xb3, 0x0c, 0xbb, 0x96, 0xd5, 0xc4, 0xb5, 0x30, 0x45, 0x75,
-	0x4e, 0x51, 0xff, 0x85, 0x02, 0x10, 0x7a, 0x75, 0x2a, 0xb7, 0xf5, 0xc0, 0xad, 0x33, 0x98, 0x38,
-	0xa5, 0xeb, 0x06, 0x52, 0xb2, 0xef, 0x92, 0xc2, 0x6e, 0x99, 0x95, 0x49, 0xef, 0xc8, 0x19, 0x71,
-	0x33, 0x67, 0xf5, 0x10, 0x51, 0xff, 0x0e, 0xd4, 0x64, 0x60, 0xa5, 0x0c, 0xb9, 0x8d, 0xe8, 0x90,
-	0x9b, 0x62, 0xeb, 0x80, 0x43, 0x74, 0xfe, 0xdd, 0x83, 0x72, 0x24, 0xea, 0x52, 0xb8, 0xde, 0x8e,
-	0x73, 0x5d, 0x4b, 0x0b, 0xd9, 0x08, 0x43, 0xed, 0x6b, 0x38, 0xbb, 0x43, 0x7d, 0xb1, 0x1c, 0xc9,
-	0xff, 0x73, 0xb7, 0x76, 0x13, 0xd4, 0xde, 0xb1, 0x31, 0x72, 0xec, 0x21, 0xcb, 0xa3, 0x7d, 0x56,
-	0x64, 0x85, 0xf5, 0xab, 0xbd, 0xe3, 0xe7, 0x1c, 0x8d, 0xa5, 0x57, 0xfb, 0x49, 0x81, 0xe2, 0xb6,
-	0x7c, 0x4b, 0x49, 0x79, 0x7a, 0xc3, 0xe7, 0x09, 0xf1, 0xf4, 0xc6, 0xbe, 0x59, 0x2d, 0x18, 0x99,
-	0xf6, 0x70, 0xca, 0x5f, 0x3d, 0x70, 0x90, 0x97, 0x70, 0xb4, 0x5b, 0xe4, 0x4e, 0x13, 0x74, 0x8b,
-	0x37, 0x60, 0xc9, 0xec, 0x59, 0x32, 0xb3, 0x49, 0x6b, 0x49, 0xc1, 0x1b, 0xcd, 0xad, 0x5d, 0x1d,
-	0x09, 0xea, 0x03, 0xc8, 0x36, 0xb7, 0x76, 0x53, 0x0f, 0x45, 0x60, 0xc9, 0x74, 0x87, 0xd2, 0x11,
-	0xf0, 0x7b, 0xae, 0x2f, 0xcf, 0x9e, 0xaa, 0x2f, 0xd7, 0xda, 0x40, 0x76, 0xa8, 0x2f, 0xc5, 0xcb,
-	0x9b, 0x4c, 0x1e, 0xff, 0xf4, 0xb7, 0xf8, 0x06, 0xce, 0x47, 0xf8, 0x75, 0x7c, 0xc7, 0x35, 0x87,
-	0x74, 0x11, 0x5b, 0xe1, 0x07, 0x99, 0xd8, 0x13, 0xca, 0xa1, 0x45, 0x47, 0x03, 0x71, 0xa1, 0x1c,
-	0x48, 0x15, 0xbf, 0x94, 0x2a, 0xfe, 0x0e, 0xd4, 0xd3, 0xc4, 0x8b, 0x82, 0x2a, 0x1f, 0xc0, 0x94,
-	0xc8, 0x03, 0x98, 0x07, 0x57, 0xe6, 0x77, 0x3c, 0x65, 0x62, 0xbd, 0x45, 0x6a, 0xaf, 0x43, 0x1e,
-	0xf5, 0xf2, 0x84, 0xe6, 0x02, 0x4a, 0x55, 0x33, 0x9b, 0xaa, 0xe6, 0x03, 0xb8, 0xba, 0x58, 0xe8,
-	0x5b, 0x94, 0xfd, 0x18, 0xce, 0x75, 0xa8, 0x3d, 0x48, 0x1b, 0xde, 0xd3, 0x3a, 0x25, 0x17, 0x1b,
-	0x9c, 0xae, 0xf3, 0x32, 0xac, 0x5c, 0x92, 0x3c, 0x52, 0xe7, 0x95, 0x78, 0x9d, 0x4f, 0x29, 0x85,
-	0x99, 0xd3, 0x97, 0x42, 0xcd, 0x85, 0xf5, 0x39, 0x99, 0xfc, 0x1a, 0x6b, 0x6c, 0xc4, 0xec, 0x07,
-	0xfd, 0x50, 0x49, 0x97, 0x60, 0xf8, 0x16, 0x9a, 0x89, 0xbe, 0x85, 0x9e, 0xfe, 0x3a, 0x75, 0xb4,
-	0x3a, 0xca, 0x7c, 0xb8, 0x79, 0xf7, 0x1d, 0x47, 0xcd, 0x86, 0x47, 0xad, 0x43, 0x11, 0x45, 0xed,
-	0x3e, 0x91, 0xb1, 0x14, 0xc0, 0x9a, 0x17, 0x9e, 0xe3, 0xe1, 0xe6, 0x5d, 0xde, 0x98, 0xf3, 0x73,
-	0xa4, 0xbf, 0xdc, 0x9e, 0x17, 0xbc, 0x0c, 0x6b, 0x20, 0xdf, 0xee, 0x38, 0xaf, 0xc1, 0x7f, 0x70,
-	0x90, 0x47, 0x70, 0x21, 0x22, 0xf4, 0x05, 0xf5, 0x4d, 0x66, 0xf6, 0xe0, 0x24, 0x75, 0x28, 0x8e,
-	0x05, 0x4e, 0x3e, 0x1d, 0x4a, 0x58, 0xbb, 0x03, 0xb5, 0xc8, 0xd6, 0xbd, 0xd7, 0x36, 0x6b, 0x66,
-	0xc5, 0xbe, 0x35, 0xc8, 0x39, 0x0c, 0x21, 0x35, 0x46, 0x40, 0xfb, 0xb5, 0x02, 0xb9, 0xd6, 0x2b,
-	0x6a, 0xfb, 0xe4, 0x26, 0x3b, 0xd1, 0xc4, 0xea, 0x8b, 0x01, 0x48, 0x26, 0x0d, 0x5c, 0xdc, 0xe8,
-	0xb2, 0x15, 0x9d, 0x13, 0x04, 0x4e, 0x99, 0x09, 0x9d, 0x32, 0x68, 0x67, 0xb3, 0x91, 0x76, 0xf6,
-	0x2e, 0xe4, 0x70, 0x1f, 0x59, 0x03, 0x75, 0x7b, 0xaf, 0xdd, 0xd5, 0x9b, 0xdb, 0x5d, 0x43, 0x6f,
-	0x6d, 0xb7, 0x76, 0xf7, 0xbb, 0xea, 0x19, 0x42, 0xa0, 0x1a, 0x60, 0x5b, 0xdf, 0xb4, 0xda, 0x5d,
-	0x55, 0xd1, 0xfe, 0xa0, 0x80, 0xda, 0x99, 0xf6, 0xbc, 0xbe, 0x6b, 0xf5, 0x02, 0x9f, 0xb9, 0x0d,
-	0x79, 0x14, 0xcc, 0x66, 0xb3, 0xec, 0x02, 0xd5, 0x04, 0x05, 0x79, 0xc0, 0xc2, 0x72, 0xe4, 0x53,
-	0x57, 0x14, 0x11, 0xf9, 0x06, 0x9d, 0x64, 0xba, 0xf1, 0x14, 0xa9, 0x74, 0x41, 0x5d, 0xbf, 0x05,
-	0x79, 0x8e, 0x61, 0xc3, 0xa3, 0x7c, 0x4d, 0x37, 0x82, 0x88, 0x07, 0x89, 0xda, 0x1d, 0x68, 0x0f,
-	0xe1, 0x6c, 0x84, 0x9b, 0xb8, 0x5d, 0x0d, 0x72, 0x94, 0xa9, 0x83, 0xf4, 0xe1, 0x28, 0x88, 0x2a,
-	0xea, 0x7c, 0x69, 0xf3, 0xef, 0x2a, 0x40, 0x73, 0x62, 0x75, 0xa8, 0xfb, 0xca, 0xea, 0x53, 0xf2,
-	0x35, 0x94, 0x77, 0xa8, 0x2f, 0xff, 0x9e, 0x20, 0xb2, 0x0a, 0x44, 0xff, 0x09, 0xaa, 0x9f, 0x93,
-	0x7f, 0x26, 0x24, 0xfe, 0xc4, 0xd0, 0xd6, 0x7e, 0xf9, 0xb7, 0x7f, 0xfd, 0x98, 0xa9, 0x92, 0x4a,
-	0x63, 0x18, 0xe1, 0xd1, 0x85, 0x0a, 0x4b, 0x29, 0x72, 0x8a, 0x4c, 0xe7, 0x29, 0x1f, 0xba, 0xe7,
-	0x86, 0x4d, 0xed, 0x3d, 0x64, 0xba, 0x42, 0x96, 0x19, 0xd3, 0x90, 0x4b, 0x1b, 0x60, 0x87, 0xfa,
-	0xb2, 0x4b, 0x4b, 0xe5, 0x29, 0x5b, 0xfa, 0xc4, 0x3f, 0x43, 0xda, 0x2a, 0x72, 0x5c, 0x26, 0x65,
-	0xc6, 0x51, 0x72, 0xf8, 0x5f, 0x3c, 0x78, 0x77, 0xc6, 0x07, 0x37, 0xb2, 0x16, 0x3c, 0x53, 0x46,
-	0xe6, 0xb8, 0x7a, 0x7d, 0xf1, 0xbb, 0xa3, 0x76, 0x01, 0xb9, 0xbe, 0x47, 0x56, 0x19, 0x57, 0xc9,
-	0xa7, 0x71, 0xc2, 0xd2, 0xdd, 0x1b, 0x32, 0x80, 0x35, 0xe4, 0x2e, 0xde, 0x49, 0xb6, 0x8e, 0x39,
-	0xdf, 0x05, 0x62, 0xe6, 0xde, 0x48, 0xb5, 0xeb, 0xc8, 0xfc, 0x32, 0xb9, 0xc8, 0x99, 0x27, 0xd8,
-	0x48, 0x29, 0x0e, 0x54, 0xe3, 0xf3, 0x27, 0xb9, 0x28, 0x38, 0xa5, 0x8e, 0xa5, 0xf5, 0xb5, 0xb4,
-	0xf7, 0x04, 0xed, 0x16, 0xca, 0x7a, 0x9f, 0x5c, 0x63, 0xb2, 0x22, 0xbb, 0x84, 0x94, 0xc6, 0x89,
-	0x9c, 0x2b, 0xdf, 0x90, 0xd7, 0xa0, 0x26, 0xe7, 0x54, 0x72, 0x79, 0x4e, 0x64, 0x6c, 0x80, 0x5d,
-	0x20, 0xf4, 0x63, 0x14, 0x7a, 0x83, 0x7c, 0x10, 0x11, 0xca, 0xf7, 0x35, 0x4e, 0xf8, 0x84, 0x1b,
-	0x13, 0x4c, 0xd1, 0xfa, 0x72, 0x5c, 0xac, 0x85, 0x22, 0xe3, 0x8d, 0x57, 0xbd, 0x1a, 0x6f, 0xd7,
-	0xe2, 0x62, 0x04, 0xb2, 0x71, 0xc2, 0x5a, 0x97, 0x37, 0x8d, 0x93, 0x64, 0x2a, 0x7c, 0x43, 0xbe,
-	0x57, 0x60, 0x25, 0x51, 0x33, 0xc8, 0xa5, 0x50, 0x58, 0x4a, 0x2d, 0xa9, 0x5f, 0x5e, 0xb4, 0x2c,
-	0x0e, 0xfa, 0x05, 0x6a, 0xf0, 0x90, 0xdc, 0x47, 0x4b, 0x46, 0x28, 0x1a, 0x27, 0xa2, 0xe8, 0xbc,
-	0x69, 0x9c, 0x60, 0x7e, 0x4e, 0xd5, 0xe8, 0xb7, 0x0a, 0xb6, 0x45, 0x89, 0x8a, 0xf2, 0x2e, 0xa5,
-	0xae, 0x25, 0x96, 0xe7, 0x6b, 0x91, 0xf6, 0x25, 0xea, 0xf5, 0x98, 0x7c, 0x1a, 0xe8, 0x15, 0x12,
-	0x9d, 0x4e, 0xb5, 0xdf, 0x2b, 0xb0, 0x9a, 0x52, 0x23, 0xe6, 0x74, 0x8b, 0x17, 0xad, 0xba, 0x36,
-	0xbf, 0x9c, 0x2c, 0x2f, 0xda, 0x16, 0x2a, 0xf7, 0x39, 0x79, 0x1c, 0x55, 0x4e, 0x52, 0x85, 0x3a,
-	0xc9, 0x32, 0x97, 0xaa, 0xde, 0x8f, 0x0a, 0x3a, 0x6b, 0xac, 0x0e, 0xbd, 0x4b, 0xb7, 0x2b, 0xf3,
-	0xcb, 0xb1, 0xfa, 0xa5, 0xfd, 0x37, 0x2a, 0xf6, 0x88, 0x3c, 0x8c, 0x2a, 0x86, 0x24, 0xa7, 0xd4,
-	0x8a, 0xe7, 0xdb, 0xe0, 0xb9, 0xe4, 0xad, 0xf9, 0x36, 0xf9, 0x0c, 0x13, 0xcf, 0xb7, 0x01, 0x8f,
-	0x21, 0xb2, 0x0c, 0x06, 0x86, 0xf3, 0xe1, 0x19, 0x12, 0xcd, 0x74, 0x7d, 0x25, 0xd1, 0xe3, 0x6b,
-	0x1f, 0x21, 0xc3, 0x0f, 0xc9, 0x75, 0xcc, 0xb5, 0x02, 0xdb, 0x38, 0x59, 0xa0, 0xfb, 0x71, 0xac,
-	0x43, 0x17, 0xbd, 0x22, 0xb9, 0x3a, 0x2f, 0x2f, 0xde, 0x6c, 0x47, 0xbd, 0x71, 0x41, 0x3f, 0xac,
-	0x5d, 0x46, 0x45, 0x6a, 0xda, 0x6a, 0x54, 0x11, 0x41, 0xf4, 0x58, 0xb9, 0x4d, 0x7e, 0x50, 0xb0,
-	0xa9, 0x48, 0xed, 0x53, 0xc9, 0x87, 0x0b, 0xf9, 0xc7, 0xba, 0xe7, 0xfa, 0x8d, 0x77, 0xd2, 0x09,
-	0x6d, 0x44, 0xf6, 0xd5, 0xce, 0xa7, 0x68, 0xc3, 0x49, 0x99, 0x4e, 0xff, 0x07, 0x2b, 0x89, 0x16,
-	0x38, 0xb8, 0xfb, 0xf9, 0xbf, 0x56, 0x82, 0x3c, 0xb1, 0xa0, 0x6b, 0xd6, 0x08, 0xca, 0xac, 0x68,
-	0x85, 0x86, 0xc7, 0x28, 0x66, 0x4c, 0x82, 0x0e, 0x2b, 0xad, 0x19, 0xed, 0x9f, 0x52, 0xc2, 0x7c,
-	0x15, 0x09, 0x79, 0x52, 0xc6, 0x06, 0x79, 0x7e, 0x0b, 0xa5, 0xa0, 0x71, 0x20, 0xe7, 0x16, 0x34,
-	0x26, 0x41, 0x79, 0x9e, 0xeb, 0x31, 0x64, 0x79, 0xd6, 0xa0, 0xe1, 0xc9, 0xb5, 0xc7, 0xca, 0xed,
-	0x3b, 0x4a, 0x2f, 0x8f, 0xef, 0xbd, 0xf7, 0xfe, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x17, 0xa6, 0x8c,
-	0x21, 0x7f, 0x22, 0x00, 0x00,
+	// 4009 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x3a, 0x5d, 0x6f, 0x1b, 0x49,
+	0x72, 0x3b, 0xa4, 0x28, 0x92, 0x45, 0x7d, 0xd0, 0x2d, 0xd9, 0xa6, 0xe9, 0xf5, 0xd7, 0xec, 0x87,
+	0x3f, 0xb2, 0x27, 0xda, 0xda, 0xf5, 0x7a, 0xed, 0xdd, 0x4b, 0x8e, 0x92, 0x69, 0x9d, 0x62, 0x9b,
+	0xd2, 0x8e, 0x68, 0x6f, 0x0e, 0x08, 0x30, 0x69, 0x0e, 0x5b, 0xa3, 0x89, 0x87, 0x33, 0xcc, 0xcc,
+	0xd0, 0x96, 0x22, 0x18, 0xc8, 0xe5, 0x21, 0x08, 0x02, 0x5c, 0x0e, 0x87, 0x7b, 0x09, 0x90, 0xbc,
+	0xe4, 0x35, 0x40, 0x9e, 0x13, 0x20, 0xaf, 0xf7, 0x0f, 0xf2, 0x03, 0xf2, 0x90, 0xfc, 0x83, 0xfb,
+	0x03, 0x41, 0x57, 0x77, 0xcf, 0x17, 0x87, 0xb2, 0xf6, 0x9e, 0x34, 0xf5, 0xd1, 0x55, 0xd5, 0xd5,
+	0xd5, 0x55, 0xd5, 0x45, 0x41, 0x33, 0x98, 0x58, 0x9d, 0xc9, 0xb0, 0x13, 0x4c, 0xac, 0x8d, 0x49,
+	0xe0, 0x47, 0x3e, 0xa9, 0x04, 0x13, 0x6b, 0x32, 0x6c, 0x7f, 0x6c, 0xfb, 0xbe, 0xed, 0xb2, 0x0e,
+	0x9d, 0x38, 0x1d, 0xea, 0x79, 0x7e, 0x44, 0x23, 0xc7, 0xf7, 0x42, 0xc1, 0xa4, 0xaf, 0xc0, 0x52,
+	0x6f, 0x3c, 0x89, 0x4e, 0x0c, 0xf6, 0x57, 0x53, 0x16, 0x46, 0xfa, 0x06, 0xd4, 0xf6, 0x19, 0x0b,
+	0x76, 0xbd, 0x43, 0x9f, 0xac, 0x40, 0xc9, 0x19, 0xb5, 0xb4, 0x9b, 0xda, 0x9d, 0xba, 0x51, 0x72,
+	0x46, 0x84, 0xc0, 0x02, 0x1d, 0x8d, 0x82, 0x56, 0x09, 0x31, 0xf8, 0xad, 0xff, 0x25, 0x34, 0xfa,
+	0x2c, 0x7a, 0xe7, 0x07, 0x6f, 0x0a, 0x97, 0x5c, 0x03, 0x98, 0x30, 0x16, 0x98, 0x96, 0x3f, 0xf5,
+	0x22, 0x5c, 0x58, 0x31, 0xea, 0x1c, 0xb3, 0xcd, 0x11, 0xe4, 0x0b, 0x40, 0xc0, 0x74, 0xbc, 0x43,
+	0xbf, 0x55, 0xbe, 0x59, 0xbe, 0xd3, 0xd8, 0x5c, 0xdd, 0x40, 0xb3, 0x37, 0x94, 0x15, 0x46, 0x6d,
+	0x22, 0xbf, 0xf4, 0x7f, 0xd3, 0x60, 0xd5, 0xe8, 0xbe, 0x44, 0x2c, 0x0b, 0x27, 0xbe, 0x17, 0x32,
+	0x72, 0x05, 0x6a, 0xd3, 0x90, 0x8d, 0xcc, 0x80, 0x8e, 0x51, 0x6d, 0xd9, 0xa8, 0x72, 0xd8, 0xa0,
+	0x63, 0xf2, 0x09, 0x2c, 0xd3, 0xb7, 0xd4, 0x71, 0xe9, 0xd0, 0x65, 0x48, 0x2f, 0x21, 0x7d, 0x29,
+	0x46, 0x72, 0xa6, 0xab, 0x50, 0x8f, 0xfc, 0x88, 0xba, 0xc8, 0x50, 0x46, 0x86, 0x1a, 0x22, 0x38,
+	0xf1, 0x1a, 0x40, 0xc8, 0x5c, 0xd7, 0x9c, 0x04, 0x8e, 0xc5, 0x5a, 0x0b, 0x37, 0xb5, 0x3b, 0x9a,
+	0x51, 0xe7, 0x98, 0x7d, 0x8e, 0xe0, 0x6b, 0x87, 0xd3, 0x13, 0x49, 0xad, 0x20, 0xb5, 0x36, 0x9c,
+	0x9e, 0x20, 0x51, 0xff, 0x47, 0x0d, 0x9a, 0x7d, 0x7f, 0xc4, 0x32, 0xd6, 0x5e, 0x03, 0x18, 0x4e,
+	0x1d, 0x77, 0x64, 0x46, 0xce, 0x98, 0x49, 0x37, 0xd5, 0x11, 0x33, 0x70, 0xc6, 0xb8, 0x19, 0xdb,
+	0x89, 0xcc, 0x23, 0x1a, 0x1e, 0x49, 0x27, 0x57, 0x6d, 0x27, 0xfa, 0x39, 0x0d, 0x8f, 0xb8, 0xef,
+	0xc7, 0xfe, 0x88, 0xa1, 0x89, 0x75, 0x03, 0xbf, 0xc9, 0x17, 0x50, 0xf5, 0x84, 0xef, 0xd1, 0xb6,
+	0xc6, 0x26, 0x91, 0xbe, 0x4b, 0x9d, 0x88, 0xa1, 0x58, 0xf4, 0xc7, 0xd0, 0xe8, 0x8e, 0xb9, 0xd7,
+	0x5f, 0x38, 0x63, 0x27, 0x22, 0xeb, 0x50, 0x89, 0xfc, 0x37, 0xcc, 0x93, 0x56, 0x08, 0x80, 0x63,
+	0xdf, 0x52, 0x77, 0xca, 0xa4, 0x7a, 0x01, 0xe8, 0xbf, 0x80, 0xc5, 0xae, 0xc5, 0xa3, 0x86, 0xb4,
+	0xa1, 0x66, 0xf9, 0x5e, 0x14, 0x50, 0x2b, 0x92, 0x0b, 0x63, 0x98, 0xdc, 0x80, 0x06, 0x45, 0x2e,
+	0xd3, 0xa3, 0x63, 0x25, 0x01, 0x04, 0xaa, 0x4f, 0xc7, 0x8c, 0xef, 0x61, 0x44, 0x23, 0xaa, 0xf6,
+	0xc0, 0xbf, 0xf5, 0xff, 0x59, 0x80, 0xfa, 0xe0, 0xd8, 0x60, 0x16, 0x73, 0x26, 0x11, 0xb9, 0x0c,
+	0xd5, 0xe8, 0x58, 0xec, 0x5f, 0x48, 0x5f, 0x8c, 0x8e, 0x71, 0xfb, 0x57, 0xa1, 0x6e, 0xd3, 0xd0,
+	0x9c, 0x86, 0xd4, 0x16, 0x92, 0x35, 0xa3, 0x66, 0xd3, 0xf0, 0x15, 0x87, 0xc9, 0xb7, 0x50, 0x0f,
+	0xe8, 0x58, 0x12, 0x45, 0x14, 0x5d, 0x97, 0x9e, 0x88, 0x45, 0x6f, 0x18, 0x74, 0x8c, 0xdc, 0x3d,
+	0x2f, 0x0a, 0x4e, 0x8c, 0x5a, 0x20, 0x41, 0xf2, 0x1d, 0x34, 0xc2, 0x88, 0x46, 0xd3, 0xd0, 0xb4,
+	0xb8, 0x7f, 0xb9, 0x23, 0x57, 0x36, 0xaf, 0xce, 0x2c, 0x3f, 0x40, 0x9e, 0x6d, 0x7f, 0xc4, 0x0c,
+	0x08, 0xe3, 0x6f, 0xd2, 0x82, 0xea, 0x98, 0x85, 0xa8, 0xb8, 0x22, 0x0e, 0x4c, 0x82, 0x9c, 0x12,
+	0xb0, 0x68, 0x1a, 0x78, 0x61, 0x6b, 0xf1, 0x66, 0x99, 0x53, 0x24, 0x48, 0xbe, 0x82, 0x5a, 0x20,
+	0xa4, 0x86, 0xad, 0x2a, 0x5a, 0xdb, 0x9a, 0xb5, 0x56, 0xfc, 0x35, 0x62, 0xce, 0xf6, 0xb7, 0xb0,
+	0x9c, 0xd9, 0x02, 0x69, 0x42, 0xf9, 0x0d, 0x3b, 0x91, 0x7e, 0xe2, 0x9f, 0xd9, 0xc3, 0x2b, 0xcb,
+	0xc3, 0x7b, 0x52, 0xfa, 0x46, 0x6b, 0xff, 0x0c, 0xaa, 0xca, 0xc5, 0x57, 0xa1, 0x7e, 0x38, 0xf5,
+	0x2c, 0x71, 0x46, 0xf2, 0x08, 0x39, 0x02, 0x4f, 0xa8, 0x05, 0x55, 0x7e, 0x9c, 0x4c, 0xde, 0xd5,
+	0xba, 0xa1, 0x40, 0xfd, 0x3f, 0x34, 0x80, 0xc4, 0x07, 0xa4, 0x01, 0xd5, 0x83, 0x57, 0xdb, 0xdb,
+	0xbd, 0x83, 0x83, 0xe6, 0x47, 0x64, 0x15, 0x1a, 0x3b, 0xdd, 0x03, 0xd3, 0x78, 0xd5, 0x37, 0xf7,
+	0x5e, 0x0d, 0x9a, 0x1a, 0xb9, 0x04, 0x64, 0xab, 0xfb, 0xa2, 0xdb, 0xdf, 0xee, 0x99, 0xfd, 0xbd,
+	0x81, 0xd9, 0xeb, 0xef, 0xbd, 0xda, 0xf9, 0x79, 0xb3, 0x44, 0xd6, 0x60, 0xf5, 0x07, 0x63, 0xaf,
+	0xbf, 0x63, 0xee, 0x77, 0x8d, 0xee, 0xcb, 0xde, 0xa0, 0x67, 0x34, 0xcb, 0xe4, 0x02, 0x2c, 0x1b,
+	0xaf, 0xfa, 0x83, 0xdd, 0x97, 0x3d, 0xb3, 0x67, 0x18, 0x7b, 0x46, 0x73, 0x81, 0x4b, 0xe7, 0x30,
+	0x17, 0x56, 0x49, 0x16, 0x0d, 0xfe, 0xcc, 0x7c, 0xb6, 0x67, 0xbc, 0xec, 0x0e, 0x9a, 0x8b, 0x5c,
+	0xc3, 0xd3, 0x57, 0xfb, 0x2f, 0x76, 0xb7, 0xbb, 0x83, 0x9e, 0x79, 0xd0, 0x1b, 0x98, 0xdb, 0x7b,
+	0x4f, 0x7b, 0xcd, 0x2a, 0x17, 0xf6, 0xaa, 0xff, 0xbc, 0xbf, 0xf7, 0x43, 0x5f, 0x0a, 0xab, 0xe9,
+	0xbf, 0x2e, 0x43, 0x63, 0x10, 0x50, 0x2f, 0x14, 0x91, 0xc8, 0xa3, 0x30, 0x15, 0x60, 0xf8, 0xcd,
+	0x71, 0x78, 0x23, 0x85, 0xe3, 0xf0, 0x9b, 0x5c, 0x07, 0x60, 0xc7, 0x13, 0x27, 0xc0, 0x74, 0x29,
+	0x53, 0x43, 0x0a, 0xa3, 0x42, 0x12, 0xa1, 0xd6, 0x42, 0x1c, 0x92, 0x06, 0x87, 0x15, 0xd1, 0xe5,
+	0x57, 0x4d, 0xa5, 0x06, 0x9b, 0x86, 0xf1, 0xd5, 0x1b, 0x31, 0x97, 0x9e, 0xb4, 0x16, 0xc5, 0x39,
+	0x21, 0x40, 0x6e, 0x43, 0x55, 0x58, 0xa8, 0xa2, 0x62, 0x59, 0x46, 0x85, 0xb8, 0x7a, 0x86, 0xa2,
+	0xf2, 0x43, 0x0a, 0x1d, 0xdb, 0x63, 0x41, 0xd8, 0xaa, 0x89, 0xc8, 0x92, 0x20, 0xf9, 0x18, 0xea,
+	0x93, 0xe9, 0xd0, 0x75, 0xc2, 0x23, 0x16, 0xb4, 0xea, 0x22, 0xbb, 0xc4, 0x08, 0x7e, 0x3f, 0x03,
+	0x76, 0xc8, 0x82, 0x80, 0x8d, 0xcc, 0xe8, 0xb8, 0x05, 0xe2, 0x7e, 0x2a, 0xd4, 0xe0, 0x98, 0x3c,
+	0x84, 0x25, 0x8a, 0x19, 0x42, 0xda, 0xdd, 0xb8, 0x59, 0x4e, 0x25, 0x95, 0x54, 0xf2, 0x30, 0x1a,
+	0x34, 0x01, 0x48, 0x07, 0x20, 0x3a, 0x36, 0x65, 0xa0, 0xb6, 0x96, 0x30, 0x13, 0x35, 0xf3, 0x11,
+	0x6d, 0xd4, 0x23, 0xf5, 0xa9, 0xff, 0x97, 0x06, 0x6b, 0xa9, 0x13, 0x89, 0xb3, 0xe3, 0x63, 0x58,
+	0x14, 0x57, 0x0b, 0xcf, 0x66, 0x65, 0xf3, 0x96, 0x12, 0x32, 0xcb, 0x2b, 0xef, 0xa3, 0x21, 0x17,
+	0x90, 0xaf, 0xa0, 0x11, 0x25, 0x5c, 0x78, 0x8e, 0x89, 0xe5, 0xe9, 0xf5, 0x69, 0x36, 0xfd, 0x4b,
+	0x58, 0x14, 0x72, 0x78, 0xc4, 0xed, 0xf7, 0xfa, 0x4f, 0x77, 0xfb, 0x3b, 0xcd, 0x8f, 0x08, 0xc0,
+	0xe2, 0x7e, 0x77, 0xfb, 0x79, 0xef, 0x69, 0x53, 0x23, 0x4d, 0x58, 0xda, 0x35, 0x8c, 0xde, 0xeb,
+	0x9e, 0x71, 0xb0, 0xbb, 0xf5, 0xa2, 0xd7, 0x2c, 0xe9, 0xff, 0xa9, 0x41, 0xfd, 0xc0, 0xb1, 0x3d,
+	0x1a, 0x4d, 0x03, 0x46, 0xbe, 0x81, 0x3a, 0x75, 0x6d, 0x3f, 0x70, 0xa2, 0xa3, 0xb1, 0x34, 0xbb,
+	0x2d, 0xd5, 0xc6, 0x4c, 0x1b, 0x5d, 0xc5, 0x61, 0x24, 0xcc, 0xfc, 0xb0, 0x42, 0xc5, 0x81, 0x06,
+	0x2f, 0x19, 0x09, 0x02, 0x0b, 0x27, 0x3f, 0x39, 0xcb, 0xe4, 0x97, 0xbc, 0x2c, 0xc8, 0x02, 0xf3,
+	0x9c, 0x9d, 0xe8, 0x5f, 0x41, 0x3d, 0x16, 0xca, 0x8d, 0x97, 0x41, 0xdf, 0xfc, 0x88, 0x2c, 0x43,
+	0xfd, 0xa0, 0xb7, 0xbd, 0xbf, 0xf9, 0xf0, 0xeb, 0xe7, 0x0f, 0x9a, 0x1a, 0xa7, 0xf5, 0x9e, 0x6e,
+	0x3e, 0x7c, 0xf8, 0xe0, 0x71, 0xb3, 0xa4, 0xff, 0x73, 0x19, 0x48, 0xc6, 0x99, 0x58, 0xf3, 0xe3,
+	0xe8, 0xd7, 0xe6, 0x46, 0x7f, 0xe9, 0xec, 0xe8, 0x2f, 0x9f, 0x15, 0xfd, 0x0b, 0xf3, 0xa2, 0xbf,
+	0x32, 0x27, 0xfa, 0x17, 0xcf, 0x8c, 0xfe, 0x7c, 0x90, 0x56, 0xcf, 0x17, 0xa4, 0xf3, 0x2f, 0xcd,
+	0x7d, 0x80, 0xd8, 0xed, 0x61, 0xab, 0x7e, 0xb3, 0x9c, 0x0a, 0xdf, 0xf8, 0x08, 0x8d, 0x14, 0x4f,
+	0xf6, 0x9a, 0x41, 0xfe, 0x9a, 0x3d, 0x82, 0x95, 0x18, 0x30, 0x43, 0xc7, 0x0e, 0x5b, 0x8d, 0x39,
+	0x32, 0x97, 0x63, 0xbe, 0x03, 0xc7, 0x0e, 0xf5, 0xff, 0x2d, 0x43, 0x65, 0xcb, 0xf5, 0xad, 0x37,
+	0x85, 0x29, 0xaa, 0x05, 0xd5, 0xb7, 0x2c, 0x08, 0x93, 0xd3, 0x50, 0x20, 0xbf, 0xd7, 0x13, 0x1a,
+	0x30, 0x4f, 0x36, 0x0e, 0xa2, 0xba, 0x82, 0x40, 0x61, 0xf1, 0xfc, 0x14, 0x56, 0xa2, 0x63, 0x73,
+	0xcc, 0x82, 0x37, 0x2e, 0x13, 0x3c, 0x0b, 0xc8, 0xb3, 0x14, 0x1d, 0xbf, 0x44, 0x24, 0x72, 0x7d,
+	0x09, 0x97, 0x92, 0x6b, 0x9c, 0xe1, 0x16, 0x95, 0x6d, 0x2d, 0xbe, 0xc0, 0xa9, 0x45, 0x97, 0x60,
+	0xd1, 0x9b, 0x8e, 0x87, 0x2c, 0x90, 0xb9, 0x4c, 0x42, 0xdc, 0xda, 0x77, 0x4e, 0xe4, 0xb1, 0x90,
+	0x27, 0x33, 0x2c, 0x24, 0x12, 0x8c, 0x83, 0xad, 0x96, 0x0a, 0xb6, 0x4c, 0x75, 0xaf, 0xe7, 0xaa,
+	0xfb, 0x15, 0xa8, 0x45, 0xc7, 0xb2, 0x81, 0x04, 0xb1, 0xf3, 0xe8, 0x58, 0xb4, 0x8f, 0x9f, 0xc1,
+	0x02, 0x76, 0x8e, 0x0d, 0xbc, 0xee, 0x17, 0xa4, 0x83, 0xd1, 0x87, 0x1b, 0xd8, 0xfc, 0x20, 0x99,
+	0x7c, 0x0d, 0x4b, 0xa9, 0x5b, 0x1f, 0xb6, 0x96, 0x32, 0x21, 0x93, 0xbe, 0x10, 0x19, 0xbe, 0xf6,
+	0x01, 0x2c, 0x70, 0x29, 0x71, 0xef, 0xa5, 0x61, 0xfb, 0x8a, 0xdf, 0x7c, 0xe3, 0xd1, 0x51, 0xc0,
+	0xe8, 0x48, 0x36, 0xb5, 0x12, 0xe2, 0x87, 0x31, 0xa4, 0x91, 0x75, 0x64, 0x3a, 0xde, 0x88, 0x1d,
+	0x63, 0x37, 0x52, 0x31, 0x00, 0x51, 0xbb, 0x1c, 0xa3, 0xff, 0x46, 0x83, 0x65, 0xb4, 0x30, 0x4e,
+	0x7b, 0x5f, 0xe6, 0xd2, 0xde, 0xd5, 0xf4, 0x3e, 0xe6, 0x25, 0x3c, 0x1d, 0x2a, 0x43, 0x4e, 0x97,
+	0xa9, 0x6e, 0x29, 0xb3, 0x46, 0x90, 0xf4, 0xdb, 0xc5, 0xe9, 0x2d, 0x9f, 0xd2, 0x34, 0xfd, 0xef,
+	0x4a, 0x70, 0x61, 0xfb, 0x88, 0x3a, 0x5e, 0xbe, 0xb5, 0xf6, 0x58, 0x94, 0x6e, 0x14, 0x78, 0x2f,
+	0x89, 0x7d, 0xc2, 0x5d, 0x68, 0xe2, 0xf3, 0xc1, 0xf2, 0x5d, 0x33, 0x1d, 0x95, 0x75, 0x63, 0x55,
+	0xe1, 0x5f, 0x0b, 0x34, 0x4f, 0x64, 0x47, 0x8c, 0x8e, 0x4c, 0x61, 0xad, 0x28, 0xa3, 0x75, 0x8e,
+	0x11, 0xa1, 0xfe, 0x39, 0xac, 0x26, 0xe4, 0x74, 0x70, 0x2e, 0xc7, 0x3c, 0xaa, 0x01, 0x74, 0x9d,
+	0xa1, 0x94, 0x22, 0x32, 0x47, 0xcd, 0x75, 0x86, 0x42, 0xc8, 0xa7, 0xb0, 0x12, 0x13, 0x85, 0x8c,
+	0x45, 0x11, 0xe0, 0x8a, 0x03, 0x45, 0xdc, 0x82, 0x25, 0x19, 0x84, 0xa6, 0xeb, 0x84, 0x22, 0x73,
+	0xd4, 0x8d, 0x86, 0xc4, 0xbd, 0x70, 0xc2, 0x48, 0xff, 0x04, 0x96, 0x07, 0xd8, 0x70, 0xa6, 0x52,
+	0x63, 0xfe, 0x26, 0xea, 0x3b, 0x70, 0x71, 0x87, 0x45, 0x28, 0x77, 0xeb, 0xe4, 0x03, 0xcc, 0xa2,
+	0x61, 0x1e, 0x4f, 0x5c, 0x16, 0x89, 0x24, 0x5f, 0x33, 0x62, 0x58, 0x7f, 0x09, 0x97, 0x13, 0x41,
+	0x7d, 0xbc, 0x38, 0x4a, 0x54, 0x72, 0xaf, 0xb4, 0xcc, 0xbd, 0x3a, 0x4b, 0xdc, 0xb7, 0xb0, 0xfc,
+	0x2c, 0xf0, 0xff, 0x9a, 0x79, 0x5b, 0xd4, 0xa5, 0x9e, 0x85, 0x31, 0x2a, 0x52, 0x20, 0x0a, 0xd1,
+	0x0c, 0x09, 0x15, 0x75, 0x3b, 0xfa, 0x21, 0x34, 0x77, 0x64, 0xfa, 0x8e, 0x03, 0xe0, 0x0e, 0x34,
+	0x5d, 0xff, 0x1d, 0x0b, 0x23, 0x33, 0x49, 0xf5, 0x42, 0xd2, 0x8a, 0xc0, 0xab, 0x15, 0x9c, 0x73,
+	0xcc, 0x46, 0x0e, 0xf5, 0x52, 0x9c, 0xa2, 0x4b, 0x5f, 0x11, 0x78, 0xc5, 0xa9, 0xff, 0xae, 0x0e,
+	0xd5, 0xae, 0x65, 0x29, 0x3b, 0x52, 0xc1, 0x85, 0xdf, 0x3c, 0x71, 0x0c, 0x85, 0xf9, 0x52, 0x80,
+	0x02, 0xc9, 0x03, 0xe0, 0x39, 0x41, 0x3d, 0x15, 0x79, 0xd0, 0x5f, 0x8a, 0x4b, 0x04, 0xca, 0xdb,
+	0xd8, 0xa1, 0xa1, 0x78, 0xf2, 0xd8, 0xe2, 0x83, 0x2f, 0xe1, 0x0f, 0x03, 0x5c, 0xb2, 0x50, 0xb8,
+	0x44, 0x3d, 0x27, 0xab, 0x01, 0x1d, 0xe3, 0x92, 0x2e, 0x34, 0x26, 0x2c, 0x18, 0x3b, 0x61, 0x88,
+	0xa9, 0xa2, 0x82, 0xa9, 0xe2, 0x46, 0x6e, 0xd5, 0x7e, 0xc2, 0x21, 0x9e, 0x13, 0xe9, 0x35, 0x64,
+	0x13, 0x16, 0xed, 0xc0, 0x9f, 0x4e, 0x54, 0x25, 0x6b, 0xe7, 0xcd, 0x44, 0xa2, 0x58, 0x28, 0x39,
+	0xc9, 0x4f, 0x61, 0xf5, 0x10, 0xcf, 0xce, 0x94, 0xdb, 0x55, 0x4d, 0xe0, 0xba, 0x5c, 0x9c, 0x39,
+	0x59, 0x63, 0xe5, 0x30, 0x0d, 0x86, 0xed, 0x3f, 0x06, 0xd8, 0x77, 0xd9, 0xc8, 0xc6, 0xd7, 0x26,
+	0xf7, 0xe1, 0x04, 0xa1, 0x40, 0xdd, 0x5b, 0x09, 0xa6, 0x22, 0xa2, 0x94, 0x8e, 0x88, 0xf6, 0xef,
+	0x35, 0xa8, 0x4a, 0xef, 0xf1, 0x67, 0xb3, 0x35, 0x0d, 0xb0, 0x9e, 0xe0, 0x43, 0x58, 0x1e, 0xf9,
+	0x92, 0x44, 0x0e, 0x38, 0x8e, 0x27, 0x00, 0x4c, 0x95, 0x87, 0x2c, 0xc0, 0xe7, 0xb5, 0x4d, 0x43,
+	0x29, 0x72, 0x35, 0x8d, 0xdf, 0xa1, 0x21, 0x76, 0x32, 0xa8, 0x1e, 0x99, 0x44, 0xab, 0x50, 0x17,
+	0x18, 0x4e, 0xfe, 0x0c, 0x56, 0x1c, 0xcf, 0x0a, 0x18, 0x0d, 0x99, 0x19, 0x4e, 0x18, 0x1b, 0xc9,
+	0x86, 0x61, 0x59, 0x61, 0x0f, 0x38, 0x92, 0x77, 0x0d, 0xe9, 0x66, 0x5a, 0x00, 0xe4, 0x3b, 0x58,
+	0x12, 0x92, 0x46, 0xe2, 0x90, 0x85, 0xc3, 0xaf, 0xe4, 0x8f, 0x2b, 0x76, 0x8d, 0xd1, 0x90, 0xec,
+	0x1c, 0x68, 0x7f, 0x0f, 0x55, 0x79, 0xfe, 0xbc, 0xa4, 0xc7, 0x63, 0x01, 0x79, 0xe5, 0x12, 0x04,
+	0x0f, 0x54, 0x3e, 0x54, 0x50, 0x17, 0x66, 0x1a, 0x0a, 0x83, 0x84, 0x7b, 0x44, 0x4a, 0x13, 0x40,
+	0xdb, 0x83, 0x85, 0xdd, 0x88, 0x8d, 0x67, 0xe6, 0x20, 0xd7, 0xa1, 0xe1, 0x84, 0xbc, 0x95, 0x33,
+	0x27, 0xd4, 0x09, 0xe4, 0xd5, 0xad, 0x3b, 0xe1, 0x73, 0x76, 0xb2, 0x4f, 0x1d, 0x3c, 0x98, 0x77,
+	0xcc, 0xb1, 0x8f, 0x22, 0x29, 0x4e, 0x42, 0xbc, 0x0d, 0x4b, 0x42, 0x4b, 0x66, 0xc6, 0x14, 0xa6,
+	0xfd, 0x0c, 0x2a, 0x18, 0x4e, 0x85, 0x77, 0xe9, 0x2e, 0x54, 0x9c, 0x88, 0x8d, 0xf9, 0xc9, 0x70,
+	0xb7, 0xac, 0xe5, 0xdc, 0xc2, 0x0d, 0x35, 0x04, 0x47, 0xfb, 0x97, 0x1a, 0x40, 0x12, 0xd5, 0x85,
+	0xd2, 0x2e, 0xc5, 0x61, 0x5d, 0xc2, 0xc4, 0x29, 0xa1, 0x44, 0x4b, 0xf9, 0x43, 0x5a, 0xb8, 0x97,
+	0x79, 0x99, 0x0c, 0x8f, 0x7c, 0x57, 0x1c, 0x73, 0xd9, 0x48, 0x10, 0xed, 0x5f, 0x40, 0x33, 0x7f,
+	0xb1, 0x0a, 0x1e, 0xb9, 0x9d, 0xf4, 0x23, 0xb7, 0xe0, 0xac, 0x63, 0x09, 0xe9, 0xf7, 0xef, 0x1e,
+	0x34, 0x52, 0xb7, 0xae, 0x40, 0xea, 0xbd, 0xac, 0xd4, 0xf5, 0xa2, 0x2b, 0x9b, 0x12, 0xa8, 0x7f,
+	0x0f, 0x17, 0x76, 0x58, 0x24, 0xc9, 0xa9, 0xfc, 0x3f, 0xe3, 0xb5, 0x3b, 0xd0, 0x1c, 0x9e, 0x98,
+	0xae, 0xef, 0xd9, 0x3c, 0x8f, 0x5a, 0xbc, 0xc8, 0xca, 0xd3, 0x5f, 0x19, 0x9e, 0xbc, 0x10, 0x68,
+	0x2c, 0xbd, 0xfa, 0xef, 0x35, 0xa8, 0x6d, 0xab, 0x59, 0x4a, 0xc1, 0xe8, 0x0d, 0xc7, 0x13, 0x72,
+	0xf4, 0xc6, 0xbf, 0x79, 0x2d, 0x70, 0xa9, 0x67, 0x4f, 0xc5, 0xd4, 0x83, 0xe3, 0x63, 0x38, 0xdd,
+	0x2d, 0x8a, 0xa0, 0x51, 0x20, 0xb9, 0x0d, 0x0b, 0x74, 0xe8, 0xa8, 0xcc, 0xa6, 0x4e, 0x4b, 0x29,
+	0xde, 0xe8, 0x6e, 0xed, 0x1a, 0xc8, 0xd0, 0x1e, 0x41, 0xb9, 0xbb, 0xb5, 0x5b, 0xb8, 0x29, 0x3e,
+	0x08, 0x0c, 0x6c, 0x15, 0x08, 0xf8, 0x3d, 0xd3, 0x97, 0x97, 0xcf, 0xd5, 0x97, 0xeb, 0x7d, 0x20,
+	0x3b, 0x2c, 0x52, 0xea, 0x95, 0x27, 0xf3, 0xdb, 0x3f, 0xbf, 0x17, 0xdf, 0xc3, 0x95, 0x94, 0xbc,
+	0x83, 0xc8, 0x0f, 0xa8, 0xcd, 0xe6, 0x89, 0x95, 0x71, 0x50, 0xca, 0x8c, 0x50, 0x0e, 0x1d, 0xe6,
+	0x8e, 0xa4, 0x43, 0x05, 0x50, 0xa8, 0x7e, 0xa1, 0x50, 0xfd, 0x7d, 0x68, 0x17, 0xa9, 0x97, 0x05,
+	0x55, 0x0d, 0xc0, 0xb4, 0xd4, 0x00, 0x2c, 0x84, 0x1b, 0xb3, 0x2b, 0x9e, 0x71, 0xb5, 0xe1, 0x3c,
+	0xb3, 0x2f, 0xc1, 0x22, 0xda, 0x15, 0x4a, 0xcb, 0x25, 0x54, 0x68, 0x66, 0xb9, 0xd0, 0xcc, 0xaf,
+	0xe1, 0xe6, 0x7c, 0xa5, 0x67, 0x18, 0xfb, 0x13, 0xb8, 0x7c, 0xc0, 0xbc, 0x51, 0xd1, 0xe3, 0xbd,
+	0xa8, 0x53, 0x0a, 0xb0, 0xc1, 0x19, 0xf8, 0x6f, 0xe2, 0x52, 0x15, 0xb3, 0xa7, 0xea, 0xbc, 0x96,
+	0xad, 0xf3, 0x05, 0xa5, 0xb0, 0x74, 0xfe, 0x52, 0xa8, 0x07, 0x70, 0x69, 0x46, 0xa7, 0x70, 0x63,
+	0x8b, 0x3f, 0x31, 0xad, 0xb8, 0x1f, 0xaa, 0x1b, 0x0a, 0x4c, 0x66, 0xa1, 0xa5, 0xf4, 0x2c, 0xf4,
+	0xfc, 0xee, 0xfc, 0x01, 0xd6, 0x94, 0x4e, 0xd1, 0x41, 0xc7, 0x4d, 0x5c, 0x78, 0x32, 0x1e, 0xfa,
+	0xae, 0x1a, 0x66, 0x0a, 0xe8, 0x47, 0x44, 0xf3, 0x3f, 0xf0, 0xe9, 0xa8, 0x12, 0x3b, 0x57, 0x1e,
+	0x8e, 0xf4, 0x5c, 0x37, 0x3d, 0x76, 0xad, 0x71, 0x44, 0x5f, 0xa6, 0x6d, 0x27, 0x0c, 0xa7, 0x2c,
+	0x90, 0x21, 0x2d, 0x21, 0xf2, 0x05, 0x10, 0x31, 0xf8, 0x0e, 0xa7, 0x93, 0x89, 0x7b, 0x62, 0x1e,
+	0xba, 0x3e, 0x55, 0x8f, 0xf5, 0x26, 0x52, 0x0e, 0x90, 0xf0, 0x8c, 0xe3, 0xc9, 0x7d, 0x58, 0x57,
+	0x4d, 0x41, 0x86, 0x5f, 0x54, 0x63, 0x22, 0x69, 0xe9, 0x15, 0x2d, 0xa8, 0x8e, 0x98, 0xe5, 0x8c,
+	0xa9, 0x8b, 0xcd, 0x78, 0xc5, 0x50, 0x20, 0xef, 0xc3, 0x2d, 0xea, 0x99, 0xaa, 0x4f, 0xc0, 0x07,
+	0x62, 0xcd, 0x68, 0x58, 0xd4, 0x1b, 0x48, 0x14, 0x79, 0x04, 0x2d, 0xdf, 0x73, 0x4f, 0x4c, 0x61,
+	0xab, 0x99, 0x61, 0xaf, 0x21, 0xfb, 0x45, 0x4e, 0xdf, 0x45, 0xf2, 0x76, 0x6a, 0x61, 0x13, 0xca,
+	0xd3, 0xc0, 0x95, 0xb3, 0x2f, 0xfe, 0xc9, 0xe3, 0xd2, 0xf5, 0x6d, 0x5f, 0xbe, 0xd3, 0xf1, 0x9b,
+	0xfb, 0x64, 0x42, 0xb1, 0xa2, 0x37, 0x50, 0x98, 0x84, 0xb8, 0x23, 0xb9, 0x2a, 0x84, 0x5a, 0x4b,
+	0xb2, 0xbd, 0xa6, 0xde, 0x3e, 0x87, 0xb1, 0x2f, 0xa2, 0x9e, 0x39, 0x74, 0xa9, 0xf5, 0x06, 0xdf,
+	0x0f, 0xcb, 0xc8, 0xc0, 0xf7, 0xb2, 0xa5, 0x70, 0x64, 0x03, 0xd6, 0x38, 0xd3, 0x74, 0x32, 0xa2,
+	0x11, 0x33, 0xc7, 0x2c, 0xa2, 0x78, 0x87, 0x56, 0x90, 0xf5, 0x82, 0x45, 0xbd, 0x57, 0x48, 0x79,
+	0x29, 0x09, 0xe4, 0x1e, 0x70, 0x24, 0x8f, 0x01, 0xcf, 0x66, 0x72, 0xbb, 0xad, 0x55, 0xe4, 0x5e,
+	0xb5, 0xa8, 0xb7, 0x8d, 0x78, 0xb1, 0x4d, 0xfd, 0x57, 0x25, 0x58, 0x7d, 0xcd, 0xc2, 0xc8, 0xf1,
+	0xec, 0x03, 0xeb, 0x88, 0x8d, 0xa6, 0x2e, 0x4b, 0xa5, 0x86, 0x32, 0xa6, 0x86, 0x16, 0x54, 0xed,
+	0x80, 0x7a, 0x91, 0x1f, 0xc4, 0x3f, 0x20, 0x08, 0x30, 0xd5, 0xfa, 0x95, 0x33, 0x8f, 0x01, 0x3e,
+	0xf2, 0x75, 0xa9, 0x33, 0x8e, 0x1b, 0x2f, 0x05, 0xf2, 0x6a, 0x8d, 0x9f, 0xd8, 0x13, 0x89, 0x83,
+	0x4e, 0x10, 0xfc, 0xce, 0x84, 0x11, 0x0d, 0x22, 0x35, 0xc4, 0x44, 0x80, 0x63, 0x2d, 0xd7, 0x39,
+	0x3c, 0xc4, 0x43, 0x2d, 0x1b, 0x02, 0xe0, 0x95, 0x6a, 0x34, 0x95, 0xa3, 0x24, 0xf1, 0xee, 0x8f,
+	0x61, 0x4e, 0x73, 0xbc, 0x88, 0x05, 0x6f, 0xa9, 0x38, 0xb6, 0xb2, 0x11, 0xc3, 0xdc, 0x82, 0x80,
+	0xbd, 0xf5, 0x2d, 0xb4, 0x00, 0x44, 0xcf, 0x14, 0x23, 0xf4, 0x3f, 0xc5, 0xd2, 0x21, 0x3d, 0x12,
+	0x27, 0x96, 0xaf, 0xa0, 0x1e, 0x4a, 0xef, 0xf0, 0x07, 0x75, 0x39, 0xd5, 0xf4, 0xe7, 0x9c, 0x67,
+	0x24, 0x8c, 0xba, 0x81, 0x79, 0x1b, 0xaf, 0xda, 0xa3, 0xcd, 0x07, 0x1f, 0x48, 0x56, 0xe5, 0x24,
+	0x59, 0xb5, 0xa1, 0x86, 0xc9, 0x62, 0xf7, 0xa9, 0xaa, 0x86, 0x31, 0xac, 0x87, 0x49, 0x26, 0x7a,
+	0xb4, 0xf9, 0x20, 0x9d, 0x18, 0x8a, 0x7f, 0x7b, 0xb9, 0x22, 0x65, 0x99, 0xce, 0x48, 0x1d, 0x9e,
+	0x90, 0x35, 0xfa, 0x11, 0xa9, 0xe8, 0x31, 0x5c, 0x4d, 0x29, 0x55, 0x71, 0x16, 0xef, 0xa4, 0x0d,
+	0xb5, 0x38, 0x28, 0xe5, 0xf0, 0x5f, 0xc1, 0xfa, 0x7d, 0x68, 0xa5, 0x96, 0xee, 0xbd, 0xf3, 0x58,
+	0x10, 0xaf, 0x5b, 0x87, 0x8a, 0xcf, 0x11, 0xca, 0x62, 0x04, 0x72, 0xca, 0xba, 0x93, 0x49, 0xe0,
+	0xbf, 0x65, 0xa3, 0xb4, 0x32, 0x2a, 0x71, 0x4a, 0x99, 0x82, 0xf5, 0xbf, 0xd7, 0x32, 0xda, 0x44,
+	0xe6, 0x38, 0xdb, 0x3f, 0x97, 0x60, 0xd1, 0x3f, 0x3c, 0x0c, 0x99, 0xfa, 0x1d, 0x51, 0x42, 0xc9,
+	0xd3, 0xa0, 0x8c, 0x68, 0x01, 0xfc, 0x88, 0x9a, 0xbd, 0x07, 0x57, 0x0a, 0x2c, 0x91, 0x7b, 0xe0,
+	0x39, 0x17, 0x31, 0xea, 0x21, 0x2e, 0xa0, 0x33, 0x0f, 0xfe, 0x57, 0x25, 0xa8, 0xf4, 0xde, 0x32,
+	0x8f, 0x1b, 0x51, 0x89, 0xfc, 0x89, 0x63, 0xc9, 0xc9, 0x8e, 0xea, 0x86, 0x90, 0xb8, 0x31, 0xe0,
+	0x14, 0x43, 0x30, 0xc4, 0xd5, 0xb6, 0x94, 0x54, 0xdb, 0xf8, 0x9d, 0x5e, 0x4e, 0x8d, 0xca, 0x54,
+	0x3b, 0xb6, 0x90, 0x6a, 0xc7, 0xee, 0xc7, 0xfd, 0x40, 0x25, 0xf3, 0x73, 0x92, 0x50, 0x23, 0xca,
+	0xba, 0x7c, 0x6e, 0x0a, 0xbe, 0xf6, 0x63, 0x68, 0xa4, 0xd0, 0x1f, 0xfa, 0x29, 0xa9, 0x9e, 0xee,
+	0x7c, 0x1f, 0x40, 0x05, 0x0d, 0x27, 0xeb, 0xd0, 0xdc, 0xde, 0xeb, 0x0f, 0x8c, 0xee, 0xf6, 0xc0,
+	0x34, 0x7a, 0xdb, 0xbd, 0xdd, 0xfd, 0x41, 0xf3, 0x23, 0x42, 0x60, 0x25, 0xc6, 0xf6, 0x5e, 0xf7,
+	0xfa, 0x83, 0xa6, 0xa6, 0xff, 0xab, 0x06, 0xcd, 0x83, 0xe9, 0x30, 0xb4, 0x02, 0x67, 0x18, 0x57,
+	0xe3, 0x7b, 0xb0, 0x88, 0x3b, 0x17, 0x97, 0xb4, 0xd8, 0x37, 0x92, 0x83, 0x7c, 0xcd, 0x37, 0xe8,
+	0x46, 0x2c, 0x90, 0xed, 0xb9, 0xfa, 0x75, 0x2f, 0x2f, 0x74, 0xe3, 0x19, 0x72, 0x19, 0x92, 0xbb,
+	0x7d, 0x17, 0x16, 0x05, 0x86, 0x8f, 0xe5, 0xd4, 0xef, 0x94, 0x66, 0xdc, 0x4b, 0x81, 0x42, 0xed,
+	0x8e, 0xf4, 0x47, 0x70, 0x21, 0x25, 0x4d, 0x1e, 0xbe, 0x0e, 0x15, 0xc6, 0xcd, 0x69, 0x69, 0x99,
+	0x21, 0x1b, 0x9a, 0x68, 0x08, 0x92, 0xfe, 0x4b, 0x0d, 0x96, 0x65, 0x03, 0xd5, 0xb5, 0x2c, 0x3e,
+	0xe1, 0x5c, 0x81, 0x92, 0x3f, 0x51, 0xed, 0x9a, 0x3f, 0x39, 0x77, 0x97, 0x79, 0x15, 0xea, 0xbe,
+	0x3b, 0x32, 0x85, 0xdf, 0xc5, 0xf9, 0xd6, 0x7c, 0x77, 0xf4, 0x9a, 0xc3, 0x9c, 0xe8, 0xb1, 0x77,
+	0x92, 0x28, 0x06, 0xb2, 0x35, 0x8f, 0xbd, 0x43, 0xa2, 0xfe, 0xef, 0x25, 0xa8, 0x6f, 0x53, 0xd7,
+	0x7d, 0x16, 0xf0, 0x70, 0x38, 0xeb, 0x37, 0xda, 0x26, 0x94, 0xe9, 0xc4, 0x51, 0xb6, 0xd0, 0x89,
+	0x13, 0xf7, 0xf2, 0xf2, 0x47, 0x59, 0xfe, 0xcd, 0xb9, 0xf8, 0x5b, 0x5d, 0xbc, 0xd0, 0xf8, 0x27,
+	0xc7, 0xf0, 0x1f, 0xc8, 0xc5, 0xe0, 0x8d, 0x7f, 0x92, 0x0d, 0xa8, 0x86, 0x62, 0xdb, 0xad, 0xc5,
+	0x4c, 0x7b, 0x96, 0x71, 0x86, 0xa1, 0x98, 0xfe, 0xb0, 0x5f, 0x3d, 0xf9, 0xf5, 0x43, 0x37, 0xab,
+	0xa9, 0xbd, 0x84, 0xb8, 0x07, 0x59, 0x10, 0xf8, 0xea, 0x57, 0x2e, 0x01, 0x90, 0xcf, 0xa1, 0x62,
+	0x51, 0xd7, 0x0d, 0x5b, 0x90, 0x99, 0xb8, 0xc7, 0xae, 0x31, 0x04, 0x99, 0xcf, 0x60, 0x5b, 0x83,
+	0x80, 0x5a, 0xac, 0xa8, 0x91, 0xbd, 0x07, 0x55, 0xa9, 0x5e, 0x1e, 0xfb, 0xec, 0x6f, 0x59, 0x8a,
+	0x21, 0x51, 0x58, 0x3a, 0x53, 0x21, 0x6f, 0x79, 0xc4, 0x70, 0x52, 0x0e, 0xf5, 0xc4, 0x8d, 0x6e,
+	0x20, 0x4e, 0x0c, 0xfe, 0x36, 0x7f, 0x77, 0x11, 0xa0, 0x3b, 0x71, 0x0e, 0x58, 0xf0, 0xd6, 0xb1,
+	0x18, 0xf9, 0x1e, 0x1a, 0x3b, 0x2c, 0x52, 0xff, 0x40, 0x40, 0xd4, 0x3b, 0x2d, 0xfd, 0xbf, 0x1a,
+	0xed, 0xcb, 0x12, 0x99, 0xff, 0x37, 0x03, 0x7d, 0xfd, 0x6f, 0xff, 0xfb, 0xff, 0x7e, 0x5b, 0x5a,
+	0x21, 0x4b, 0x1d, 0x3b, 0x25, 0x63, 0x00, 0x4b, 0x3b, 0x4c, 0xe4, 0xbc, 0xf9, 0x32, 0xd5, 0xa1,
+	0xcc, 0x8c, 0x83, 0xf5, 0x8b, 0x28, 0x74, 0x95, 0x2c, 0x73, 0xa1, 0x89, 0x94, 0x3e, 0xc0, 0x0e,
+	0x8b, 0xd4, 0x1c, 0xa5, 0x50, 0xa6, 0xaa, 0xbf, 0xb9, 0xff, 0xdd, 0xd0, 0xd7, 0x50, 0xe2, 0x32,
+	0x69, 0x70, 0x89, 0x4a, 0xc2, 0x9f, 0xe3, 0xc6, 0x07, 0xc7, 0x62, 0xb4, 0x4a, 0xd6, 0x63, 0xe7,
+	0xa7, 0x26, 0xad, 0xed, 0xf6, 0xfc, 0x5f, 0x06, 0xf5, 0xab, 0x28, 0xf5, 0x22, 0x59, 0xeb, 0xd8,
+	0x89, 0x9c, 0xce, 0x29, 0x7f, 0x90, 0xbc, 0x27, 0x23, 0x58, 0x47, 0xe9, 0xf2, 0x24, 0xb7, 0x4e,
+	0x06, 0xc7, 0x67, 0xa8, 0x99, 0x39, 0x79, 0xfd, 0x53, 0x14, 0x7e, 0x9d, 0x7c, 0x2c, 0x84, 0xe7,
+	0xc4, 0x28, 0x2d, 0x3e, 0xac, 0x64, 0x27, 0xc4, 0xe4, 0x63, 0x29, 0xa9, 0x70, 0x70, 0xdc, 0x5e,
+	0x2f, 0x9a, 0xf8, 0xeb, 0x77, 0x51, 0xd7, 0x27, 0xe4, 0x16, 0xd7, 0x95, 0x5a, 0x25, 0xb5, 0x74,
+	0x4e, 0xd5, 0xe4, 0xf7, 0x3d, 0x79, 0x07, 0xcd, 0xfc, 0x24, 0x99, 0x5c, 0x9f, 0x51, 0x99, 0x19,
+	0x31, 0xcf, 0x51, 0xfa, 0x13, 0x54, 0x7a, 0x9b, 0x7c, 0xd6, 0xb1, 0x73, 0xeb, 0x3a, 0xa7, 0x22,
+	0x78, 0x33, 0x8a, 0x19, 0x40, 0x32, 0x07, 0x21, 0xad, 0x44, 0x65, 0x76, 0x34, 0xd2, 0x5e, 0xc9,
+	0x0e, 0x54, 0xb2, 0x6a, 0x24, 0xb2, 0x73, 0xca, 0xab, 0xd9, 0xfb, 0xce, 0x69, 0xbe, 0x6e, 0xbf,
+	0x27, 0xbf, 0xd6, 0x60, 0x35, 0xf7, 0xaa, 0x23, 0xd7, 0x12, 0x65, 0x05, 0xaf, 0xbd, 0xf6, 0xf5,
+	0x79, 0x64, 0xb9, 0xd1, 0x9f, 0xa2, 0x05, 0x8f, 0xc8, 0xc3, 0x8e, 0x9d, 0xe5, 0xe8, 0x9c, 0xca,
+	0x67, 0xe1, 0xfb, 0xce, 0x29, 0x96, 0xf4, 0x42, 0x8b, 0x7c, 0xbc, 0x4c, 0xc9, 0xdb, 0xac, 0x9d,
+	0x53, 0x97, 0x6a, 0xf7, 0x92, 0x30, 0x52, 0x04, 0xfd, 0x01, 0x2a, 0xff, 0x23, 0x72, 0x37, 0x56,
+	0xce, 0xd1, 0x9d, 0x53, 0xf1, 0xa0, 0x2b, 0x54, 0xf8, 0x37, 0x1a, 0xba, 0x5a, 0xf6, 0xb0, 0x1f,
+	0xda, 0xfd, 0x95, 0x84, 0x9c, 0x6b, 0x90, 0xf5, 0x6f, 0x50, 0xf7, 0x26, 0xb9, 0xdf, 0xb1, 0x63,
+	0xe2, 0xf9, 0xf6, 0xfc, 0x4f, 0x1a, 0x10, 0xa5, 0x2f, 0xe9, 0x92, 0x3f, 0x64, 0xca, 0xad, 0x1c,
+	0x79, 0xb6, 0xbf, 0xd6, 0x7f, 0x86, 0x26, 0x3d, 0x21, 0xdf, 0x74, 0xec, 0x19, 0xa6, 0xf3, 0x99,
+	0xf6, 0x2f, 0x1a, 0xac, 0x15, 0xf4, 0xbd, 0x33, 0xb6, 0x65, 0x1b, 0xf1, 0xb6, 0x3e, 0x4b, 0xce,
+	0xb7, 0xcc, 0xfa, 0x16, 0x1a, 0xf7, 0x1d, 0x79, 0xd2, 0xb1, 0x67, 0xb9, 0x12, 0x9b, 0x54, 0xeb,
+	0x5e, 0x68, 0xde, 0x6f, 0x35, 0xbc, 0xa0, 0x99, 0xde, 0xfa, 0x43, 0xb6, 0xdd, 0x98, 0x25, 0x67,
+	0x7a, 0x72, 0xfd, 0x4f, 0xd0, 0xb0, 0xc7, 0xe4, 0x51, 0xc7, 0xce, 0xb1, 0x9c, 0xd3, 0xaa, 0x9c,
+	0xd3, 0x54, 0xff, 0xfe, 0x07, 0x38, 0x2d, 0xdf, 0xfa, 0x17, 0x3b, 0x4d, 0x71, 0x9d, 0xd3, 0xbc,
+	0x29, 0xce, 0x58, 0xb3, 0x7d, 0x39, 0x29, 0xf0, 0x4a, 0xe6, 0xed, 0xd0, 0xbe, 0x39, 0x9f, 0x41,
+	0xda, 0x76, 0x0d, 0x6d, 0xbb, 0xac, 0x93, 0x8e, 0x9d, 0xe7, 0x79, 0xa2, 0xdd, 0x93, 0x95, 0x37,
+	0xfe, 0x69, 0xeb, 0xcc, 0xca, 0x9b, 0xff, 0xc9, 0x2c, 0x5b, 0x79, 0x63, 0x19, 0x36, 0x34, 0x52,
+	0xe3, 0x36, 0x92, 0xba, 0x9c, 0xb9, 0xc1, 0x67, 0x7b, 0x35, 0x37, 0x8f, 0xd5, 0xbf, 0x40, 0x81,
+	0x9f, 0x93, 0x4f, 0xb1, 0xea, 0x4a, 0x6c, 0xe7, 0x74, 0x8e, 0xcb, 0x4e, 0x32, 0xd3, 0x54, 0xd9,
+	0x89, 0x91, 0x9b, 0xb3, 0xfa, 0xb2, 0x83, 0xd1, 0xf6, 0xad, 0x33, 0x38, 0xe4, 0xce, 0xae, 0xa3,
+	0x21, 0x2d, 0x7d, 0xad, 0x63, 0xcf, 0x30, 0x71, 0xb7, 0xfd, 0x46, 0x3c, 0xe8, 0x0a, 0x67, 0x8a,
+	0xe4, 0xf3, 0xb9, 0xf2, 0x33, 0x93, 0xce, 0xf6, 0xed, 0x0f, 0xf2, 0x49, 0x6b, 0x64, 0x1d, 0xd6,
+	0xaf, 0x74, 0xec, 0x39, 0xac, 0xdc, 0xa6, 0xbf, 0x80, 0xd5, 0xdc, 0xb8, 0x32, 0xf6, 0xfd, 0xec,
+	0xbf, 0xc1, 0xc4, 0x15, 0x63, 0xce, 0x84, 0x53, 0x27, 0xa8, 0x73, 0x49, 0xaf, 0x76, 0x42, 0xce,
+	0x71, 0xcc, 0x35, 0x18, 0xb0, 0xda, 0x3b, 0x66, 0xd6, 0x39, 0x35, 0xcc, 0xf6, 0x13, 0x89, 0x4c,
+	0xc6, 0xc5, 0xa0, 0xcc, 0x21, 0x34, 0xf3, 0xcd, 0xe9, 0x9c, 0xfe, 0xe4, 0x46, 0xa2, 0xaa, 0xb0,
+	0x97, 0xd5, 0x2f, 0xa3, 0xf8, 0x0b, 0x64, 0xb5, 0x13, 0x21, 0xcb, 0xb1, 0xea, 0x50, 0x7e, 0x80,
+	0x7a, 0xfc, 0xdc, 0x21, 0x97, 0xe7, 0x3c, 0xa7, 0xda, 0xad, 0x59, 0x42, 0xb6, 0x19, 0xd4, 0xa1,
+	0x13, 0x2a, 0xda, 0x13, 0xed, 0xde, 0x7d, 0x6d, 0xb8, 0x88, 0xbf, 0xff, 0x7f, 0xf9, 0xff, 0x03,
+	0x00, 0x62, 0x20, 0x0e, 0xfa, 0x8f, 0x2c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3444,12 +4320,20 @@ type ApiServiceClient interface {
 	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error)
 	// get token balance
 	GetTokenBalance(ctx context.Context, in *GetTokenBalanceRequest, opts ...grpc.CallOption) (*GetTokenBalanceResponse, error)
+	// get token info
+	GetTokenInfo(ctx context.Context, in *GetTokenInfoRequest, opts ...grpc.CallOption) (*TokenInfo, error)
+	// get vesting schedules of token
+	GetVesting(ctx context.Context, in *GetTokenBalanceRequest, opts ...grpc.CallOption) (*GetVestingResponse, error)
 	// get token721 balance
 	GetToken721Balance(ctx context.Context, in *GetTokenBalanceRequest, opts ...grpc.CallOption) (*GetToken721BalanceResponse, error)
 	// get token721 metadata
 	GetToken721Metadata(ctx context.Context, in *GetToken721InfoRequest, opts ...grpc.CallOption) (*GetToken721MetadataResponse, error)
 	// get token721 owner
 	GetToken721Owner(ctx context.Context, in *GetToken721InfoRequest, opts ...grpc.CallOption) (*GetToken721OwnerResponse, error)
+	// get the account approved to transfer token721
+	GetToken721Approved(ctx context.Context, in *GetToken721InfoRequest, opts ...grpc.CallOption) (*GetToken721ApprovedResponse, error)
+	// get token721 supply and ids of tokens by pages
+	GetToken721Supply(ctx context.Context, in *GetToken721SupplyRequest, opts ...grpc.CallOption) (*GetToken721SupplyResponse, error)
 	// get gas ratio infomation
 	GetGasRatio(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GasRatioResponse, error)
 	// get contract
@@ -3462,6 +4346,8 @@ type ApiServiceClient interface {
 	SendTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
 	// execute transaction
 	ExecTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TxReceipt, error)
+	// trace transaction by re-executing it on the state of its block
+	TraceTransaction(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*TraceTransactionResponse, error)
 	// subscribe an event
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error)
 }
@@ -3555,6 +4441,24 @@ func (c *apiServiceClient) GetTokenBalance(ctx context.Context, in *GetTokenBala
 	return out, nil
 }
 
+func (c *apiServiceClient) GetTokenInfo(ctx context.Context, in *GetTokenInfoRequest, opts ...grpc.CallOption) (*TokenInfo, error) {
+	out := new(TokenInfo)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetTokenInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetVesting(ctx context.Context, in *GetTokenBalanceRequest, opts ...grpc.CallOption) (*GetVestingResponse, error) {
+	out := new(GetVestingResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetVesting", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetToken721Balance(ctx context.Context, in *GetTokenBalanceRequest, opts ...grpc.CallOption) (*GetToken721BalanceResponse, error) {
 	out := new(GetToken721BalanceResponse)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetToken721Balance", in, out, opts...)
@@ -3582,6 +4486,24 @@ func (c *apiServiceClient) GetToken721Owner(ctx context.Context, in *GetToken721
 	return out, nil
 }
 
+func (c *apiServiceClient) GetToken721Approved(ctx context.Context, in *GetToken721InfoRequest, opts ...grpc.CallOption) (*GetToken721ApprovedResponse, error) {
+	out := new(GetToken721ApprovedResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetToken721Approved", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetToken721Supply(ctx context.Context, in *GetToken721SupplyRequest, opts ...grpc.CallOption) (*GetToken721SupplyResponse, error) {
+	out := new(GetToken721SupplyResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetToken721Supply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) GetGasRatio(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*GasRatioResponse, error) {
 	out := new(GasRatioResponse)
 	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetGasRatio", in, out, opts...)
@@ -3636,6 +4558,15 @@ func (c *apiServiceClient) ExecTransaction(ctx context.Context, in *TransactionR
 	return out, nil
 }
 
+func (c *apiServiceClient) TraceTransaction(ctx context.Context, in *TxHashRequest, opts ...grpc.CallOption) (*TraceTransactionResponse, error) {
+	out := new(TraceTransactionResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/TraceTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_ApiService_serviceDesc.Streams[0], "/rpcpb.ApiService/Subscribe", opts...)
 	if err != nil {
@@ -3688,12 +4619,20 @@ type ApiServiceServer interface {
 	GetAccount(context.Context, *GetAccountRequest) (*Account, error)
 	// get token balance
 	GetTokenBalance(context.Context, *GetTokenBalanceRequest) (*GetTokenBalanceResponse, error)
+	// get token info
+	GetTokenInfo(context.Context, *GetTokenInfoRequest) (*TokenInfo, error)
+	// get vesting schedules of token
+	GetVesting(context.Context, *GetTokenBalanceRequest) (*GetVestingResponse, error)
 	// get token721 balance
 	GetToken721Balance(context.Context, *GetTokenBalanceRequest) (*GetToken721BalanceResponse, error)
 	// get token721 metadata
 	GetToken721Metadata(context.Context, *GetToken721InfoRequest) (*GetToken721MetadataResponse, error)
 	// get token721 owner
 	GetToken721Owner(context.Context, *GetToken721InfoRequest) (*GetToken721OwnerResponse, error)
+	// get the account approved to transfer token721
+	GetToken721Approved(context.Context, *GetToken721InfoRequest) (*GetToken721ApprovedResponse, error)
+	// get token721 supply and ids of tokens by pages
+	GetToken721Supply(context.Context, *GetToken721SupplyRequest) (*GetToken721SupplyResponse, error)
 	// get gas ratio infomation
 	GetGasRatio(context.Context, *EmptyRequest) (*GasRatioResponse, error)
 	// get contract
@@ -3706,6 +4645,8 @@ type ApiServiceServer interface {
 	SendTransaction(context.Context, *TransactionRequest) (*SendTransactionResponse, error)
 	// execute transaction
 	ExecTransaction(context.Context, *TransactionRequest) (*TxReceipt, error)
+	// trace transaction by re-executing it on the state of its block
+	TraceTransaction(context.Context, *TxHashRequest) (*TraceTransactionResponse, error)
 	// subscribe an event
 	Subscribe(*SubscribeRequest, ApiService_SubscribeServer) error
 }
@@ -3876,6 +4817,42 @@ func _ApiService_GetTokenBalance_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_GetTokenInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTokenInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTokenInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTokenInfo(ctx, req.(*GetTokenInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetVesting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetVesting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetVesting",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetVesting(ctx, req.(*GetTokenBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_GetToken721Balance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTokenBalanceRequest)
 	if err := dec(in); err != nil {
@@ -3930,6 +4907,42 @@ func _ApiService_GetToken721Owner_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_GetToken721Approved_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetToken721InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetToken721Approved(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetToken721Approved",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetToken721Approved(ctx, req.(*GetToken721InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetToken721Supply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetToken721SupplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetToken721Supply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetToken721Supply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetToken721Supply(ctx, req.(*GetToken721SupplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_GetGasRatio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EmptyRequest)
 	if err := dec(in); err != nil {
@@ -4038,6 +5051,24 @@ func _ApiService_ExecTransaction_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApiService_TraceTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).TraceTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/TraceTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).TraceTransaction(ctx, req.(*TxHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApiService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -4099,6 +5130,14 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTokenBalance",
 			Handler:    _ApiService_GetTokenBalance_Handler,
 		},
+		{
+			MethodName: "GetTokenInfo",
+			Handler:    _ApiService_GetTokenInfo_Handler,
+		},
+		{
+			MethodName: "GetVesting",
+			Handler:    _ApiService_GetVesting_Handler,
+		},
 		{
 			MethodName: "GetToken721Balance",
 			Handler:    _ApiService_GetToken721Balance_Handler,
@@ -4111,6 +5150,14 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetToken721Owner",
 			Handler:    _ApiService_GetToken721Owner_Handler,
 		},
+		{
+			MethodName: "GetToken721Approved",
+			Handler:    _ApiService_GetToken721Approved_Handler,
+		},
+		{
+			MethodName: "GetToken721Supply",
+			Handler:    _ApiService_GetToken721Supply_Handler,
+		},
 		{
 			MethodName: "GetGasRatio",
 			Handler:    _ApiService_GetGasRatio_Handler,
@@ -4135,6 +5182,10 @@ var _ApiService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExecTransaction",
 			Handler:    _ApiService_ExecTransaction_Handler,
 		},
+		{
+			MethodName: "TraceTransaction",
+			Handler:    _ApiService_TraceTransaction_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3,6 +3,8 @@ package integration
 import (
 	"testing"
 
+	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/verifier"
 	"github.com/iost-official/go-iost/vm/database"
@@ -113,3 +115,159 @@ func TestAccountInfo(t *testing.T) {
 
 	})
 }
+
+func TestAccountRecovery(t *testing.T) {
+	ilog.SetLevel(ilog.LevelInfo)
+	s := verifier.NewSimulator()
+	defer s.Clear()
+	Convey("test of social recovery", t, func() {
+		ca, err := s.Compile("auth.iost", "../../config/genesis/contract/account", "../../config/genesis/contract/account.js")
+		So(err, ShouldBeNil)
+		s.Visitor.SetContract(ca)
+		s.Visitor.SetContract(native.GasABI())
+		s.Visitor.SetContract(native.TokenABI())
+
+		acc := prepareAuth(t, s)
+		for _, a := range []*TestAccount{acc1, acc2, acc3} {
+			s.SetAccount(a.ToAccount())
+		}
+		for _, a := range []*TestAccount{acc, acc1, acc3} {
+			s.SetGas(a.ID, 1e8)
+			s.SetRAM(a.ID, 1000)
+		}
+		newOwner := acc3.KeyPair.ReadablePubkey()
+
+		// guardians sign the tx together
+		guardianCall := func(abi string, args []interface{}, kps ...*account.KeyPair) *tx.TxReceipt {
+			trx := tx.NewTx([]*tx.Action{{
+				Contract:   "auth.iost",
+				ActionName: abi,
+				Data:       array2json(args),
+			}}, nil, s.GasLimit, 100, s.Head.Time+10000000, 0)
+			trx.Time = s.Head.Time
+			stx, err := tx.SignTx(trx, acc1.ID, kps)
+			So(err, ShouldBeNil)
+			r, err := s.RunTx(stx)
+			So(err, ShouldBeNil)
+			return r
+		}
+		initiate := func(kps ...*account.KeyPair) *tx.TxReceipt {
+			return guardianCall("initiateRecovery", []interface{}{acc.ID, newOwner}, kps...)
+		}
+
+		guardians := []interface{}{
+			map[string]interface{}{"id": acc1.ID, "weight": 1},
+			map[string]interface{}{"id": acc2.ID + "@active", "weight": 1},
+		}
+		r, err := s.Call("auth.iost", "setRecovery", array2json([]interface{}{acc.ID, guardians, 3, 86400}), acc.ID, acc.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldContainSubstring, "threshold should be between")
+
+		r, err = s.Call("auth.iost", "setRecovery", array2json([]interface{}{acc.ID, guardians, 2, 86400}), acc.ID, acc.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		So(database.Unmarshal(s.Visitor.MGet("auth.iost-auth", acc.ID)), ShouldContainSubstring, `"recovery":{"name":"recovery","groups":[],"items":[{"id":"`+acc1.ID+`","permission":"active","is_key_pair":false,"weight":1}`)
+
+		r, err = s.Call("auth.iost", "dropPermission", array2json([]interface{}{acc.ID, "recovery"}), acc.ID, acc.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldContainSubstring, "recovery permission should be changed by setRecovery")
+
+		r = initiate(acc1.KeyPair)
+		So(r.Status.Message, ShouldContainSubstring, "guardians not enough")
+
+		r = guardianCall("initiateRecovery", []interface{}{acc.ID, "acc3"}, acc1.KeyPair, acc2.KeyPair)
+		So(r.Status.Message, ShouldContainSubstring, "pubkey invalid")
+		r = guardianCall("initiateRecovery", []interface{}{acc.ID, newOwner + "zzzz"}, acc1.KeyPair, acc2.KeyPair)
+		So(r.Status.Message, ShouldContainSubstring, "pubkey should be 32 or 33 bytes")
+
+		// guardians overwrite or cancel their own request
+		r = guardianCall("initiateRecovery", []interface{}{acc.ID, acc1.KeyPair.ReadablePubkey()}, acc1.KeyPair, acc2.KeyPair)
+		So(r.Status.Message, ShouldEqual, "")
+		r = initiate(acc1.KeyPair, acc2.KeyPair)
+		So(r.Status.Message, ShouldEqual, "")
+		So(database.Unmarshal(s.Visitor.MGet("auth.iost-recoveryRequest", acc.ID)), ShouldContainSubstring, newOwner)
+		r = guardianCall("cancelRecovery", []interface{}{acc.ID}, acc1.KeyPair)
+		So(r.Status.Message, ShouldContainSubstring, "guardians not enough")
+		r = guardianCall("cancelRecovery", []interface{}{acc.ID}, acc1.KeyPair, acc2.KeyPair)
+		So(r.Status.Message, ShouldEqual, "")
+
+		r = initiate(acc1.KeyPair, acc2.KeyPair)
+		So(r.Status.Message, ShouldEqual, "")
+
+		r, err = s.Call("auth.iost", "cancelRecovery", array2json([]interface{}{acc.ID}), acc.ID, acc.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+
+		r = initiate(acc1.KeyPair, acc2.KeyPair)
+		So(r.Status.Message, ShouldEqual, "")
+
+		r, err = s.Call("auth.iost", "finishRecovery", array2json([]interface{}{acc.ID}), acc3.ID, acc3.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldContainSubstring, "recovery can be finished after")
+
+		s.Head.Time += 86400 * 1e9
+		r, err = s.Call("auth.iost", "finishRecovery", array2json([]interface{}{acc.ID}), acc1.ID, acc1.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldContainSubstring, "require auth failed")
+
+		r, err = s.Call("auth.iost", "finishRecovery", array2json([]interface{}{acc.ID}), acc3.ID, acc3.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		a, _ := host.ReadAuth(s.Visitor, acc.ID)
+		So(len(a.Permissions["owner"].Items), ShouldEqual, 1)
+		So(a.Permissions["owner"].Items[0].ID, ShouldEqual, newOwner)
+	})
+}
+
+func TestAccountSession(t *testing.T) {
+	ilog.SetLevel(ilog.LevelInfo)
+	s := verifier.NewSimulator()
+	defer s.Clear()
+	Convey("test of session permission", t, func() {
+		ca, err := s.Compile("auth.iost", "../../config/genesis/contract/account", "../../config/genesis/contract/account.js")
+		So(err, ShouldBeNil)
+		s.Visitor.SetContract(ca)
+		s.Visitor.SetContract(native.GasABI())
+		s.Visitor.SetContract(native.TokenABI())
+
+		acc := prepareAuth(t, s)
+		s.SetAccount(acc1.ToAccount())
+		s.SetGas(acc.ID, 1e8)
+		s.SetRAM(acc.ID, 10000)
+		err = createToken(t, s, acc)
+		So(err, ShouldBeNil)
+
+		config := map[string]interface{}{
+			"scope":        []interface{}{map[string]interface{}{"contract": "token.iost", "abi": "transfer"}},
+			"expiry":       s.Head.Time + 3600*1e9,
+			"spend_limit":  []interface{}{map[string]interface{}{"token": "iost", "amount": "10"}},
+			"spend_period": 86400,
+		}
+		r, err := s.Call("auth.iost", "addSession", array2json([]interface{}{acc.ID, "pay", acc4.KeyPair.ReadablePubkey(), config}), acc.ID, acc.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		So(database.Unmarshal(s.Visitor.MGet("auth.iost-auth", acc.ID)), ShouldContainSubstring, `"scope":[{"contract":"token.iost","abi":"transfer"}]`)
+
+		r, err = s.Call("token.iost", "transfer", array2json([]interface{}{"iost", acc.ID, acc1.ID, "6", ""}), acc.ID, acc4.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		So(s.Visitor.TokenBalance("iost", acc1.ID), ShouldEqual, int64(6e8))
+
+		r, err = s.Call("token.iost", "transfer", array2json([]interface{}{"iost", acc.ID, acc1.ID, "5", ""}), acc.ID, acc4.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldContainSubstring, "exceed spend limit of session")
+
+		_, err = s.Call("token.iost", "create", array2json([]interface{}{"abc", acc.ID, 100, map[string]interface{}{}}), acc.ID, acc4.KeyPair)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "unauthorized publisher")
+
+		s.Head.Time += 3600 * 1e9
+		_, err = s.Call("token.iost", "transfer", array2json([]interface{}{"iost", acc.ID, acc1.ID, "1", ""}), acc.ID, acc4.KeyPair)
+		So(err, ShouldNotBeNil)
+
+		r, err = s.Call("auth.iost", "dropSession", array2json([]interface{}{acc.ID, "pay"}), acc.ID, acc.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		So(s.Visitor.MHas("auth.iost-spent", acc.ID+".pay"), ShouldBeFalse)
+	})
+}
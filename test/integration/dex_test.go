@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/ilog"
+	. "github.com/iost-official/go-iost/verifier"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var dexContractName = "dex.iost"
+
+func dexSetup(t *testing.T) *Simulator {
+	s := NewSimulator()
+	ilog.Stop()
+	createAccountsWithResource(s)
+	prepareToken(t, s, acc0)
+
+	r, err := s.Call("token.iost", "create", fmt.Sprintf(`["%v", "%v", %v, {"decimal": 2}]`, "usdt", acc0.ID, 100000000), acc0.ID, acc0.KeyPair)
+	if err != nil || r.Status.Code != tx.Success {
+		t.Fatal(err, r)
+	}
+	for _, acc := range testAccounts[1:4] {
+		r, err = s.Call("token.iost", "issue", fmt.Sprintf(`["%v", "%v", "%v"]`, "usdt", acc.ID, "100000"), acc0.ID, acc0.KeyPair)
+		if err != nil || r.Status.Code != tx.Success {
+			t.Fatal(err, r)
+		}
+		s.SetRAM(acc.ID, 100000)
+	}
+
+	err = setNonNativeContract(s, dexContractName, "dex.js", ContractPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Visitor.MPut("system.iost-contract_owner", dexContractName, `s`+acc0.ID)
+	s.Visitor.Commit()
+	return s
+}
+
+type dexLevel struct {
+	Price  string `json:"price"`
+	Amount string `json:"amount"`
+}
+
+func dexDepth(s *Simulator, side string) []dexLevel {
+	r, err := s.Call(dexContractName, "get_depth", array2json([]interface{}{"iost", "usdt", side, 200}), acc0.ID, acc0.KeyPair)
+	So(err, ShouldBeNil)
+	So(r.Status.Message, ShouldEqual, "")
+	var rtn []string
+	So(json.Unmarshal([]byte(r.Returns[0]), &rtn), ShouldBeNil)
+	var depth []dexLevel
+	So(json.Unmarshal([]byte(rtn[0]), &depth), ShouldBeNil)
+	return depth
+}
+
+func dexOrder(s *Simulator, acc *TestAccount, side, price, amount string, expiry int64) *tx.TxReceipt {
+	r, err := s.Call(dexContractName, "place_order", array2json([]interface{}{"iost", "usdt", side, price, amount, expiry}), acc.ID, acc.KeyPair)
+	So(err, ShouldBeNil)
+	return r
+}
+
+func TestDex(t *testing.T) {
+	seller, buyer, maker := acc1, acc2, acc3
+	Convey("test of dex", t, func() {
+		s := dexSetup(t)
+		defer s.Clear()
+		iost := s.Visitor.TokenBalance("iost", seller.ID)
+		usdt := s.Visitor.TokenBalance("usdt", buyer.ID)
+
+		Convey("partial fill and dust", func() {
+			So(dexOrder(s, seller, "sell", "2", "10", 0).Status.Message, ShouldEqual, "")
+			So(dexOrder(s, buyer, "buy", "3", "4", 0).Status.Message, ShouldEqual, "")
+			// filled at the price of the maker and the rest locked by the taker is refunded
+			So(s.Visitor.TokenBalance("usdt", buyer.ID), ShouldEqual, usdt-800)
+			So(s.Visitor.TokenBalance("usdt", seller.ID), ShouldEqual, usdt+800)
+			So(dexDepth(s, "sell"), ShouldResemble, []dexLevel{{"2", "6"}})
+
+			// 0.001 iost left at 2 is worth nothing in usdt, the maker is closed instead of crossing the book
+			So(dexOrder(s, buyer, "buy", "2", "5.999", 0).Status.Message, ShouldEqual, "")
+			So(dexDepth(s, "sell"), ShouldBeEmpty)
+			So(dexDepth(s, "buy"), ShouldBeEmpty)
+			So(s.Visitor.TokenBalance("iost", seller.ID), ShouldEqual, iost-9.999*1e8)
+			So(s.Visitor.TokenBalance("iost", dexContractName), ShouldEqual, 0)
+			So(s.Visitor.TokenBalance("usdt", dexContractName), ShouldEqual, 0)
+
+			r := dexOrder(s, seller, "sell", "0.01", "10", 0)
+			So(r.Status.Message, ShouldContainSubstring, "order value should be at least 1 usdt")
+		})
+
+		Convey("expiry", func() {
+			So(dexOrder(s, seller, "sell", "5", "1", s.Head.Time+1e9).Status.Message, ShouldEqual, "")
+			So(dexOrder(s, seller, "sell", "6", "1", s.Head.Time+1e9).Status.Message, ShouldEqual, "")
+			r, err := s.Call(dexContractName, "cancel_expired", `["2"]`, maker.ID, maker.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "order not expired")
+
+			s.Head.Time += 2e9
+			So(dexOrder(s, buyer, "buy", "5", "1", 0).Status.Message, ShouldEqual, "")
+			So(dexDepth(s, "buy"), ShouldResemble, []dexLevel{{"5", "1"}})
+			So(dexDepth(s, "sell"), ShouldResemble, []dexLevel{{"6", "1"}})
+
+			r, err = s.Call(dexContractName, "cancel_expired", `["2"]`, maker.ID, maker.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(dexDepth(s, "sell"), ShouldBeEmpty)
+			So(s.Visitor.TokenBalance("iost", seller.ID), ShouldEqual, iost)
+		})
+
+		Convey("fees", func() {
+			r, err := s.Call(dexContractName, "set_fee", `["", "0.001", "0.002"]`, seller.ID, seller.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "require auth failed")
+			r, err = s.Call(dexContractName, "set_fee", `["", "0.001", "0.002"]`, acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			r, err = s.Call(dexContractName, "set_fee_recipient", array2json([]interface{}{acc4.ID}), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+
+			fee := s.Visitor.TokenBalance("iost", acc4.ID)
+			So(dexOrder(s, seller, "sell", "2", "100", 0).Status.Message, ShouldEqual, "")
+			So(dexOrder(s, buyer, "buy", "2", "100", 0).Status.Message, ShouldEqual, "")
+			// the seller is the maker paying 0.1% of 200 usdt, the buyer is the taker paying 0.2% of 100 iost
+			So(s.Visitor.TokenBalance("usdt", acc4.ID), ShouldEqual, 20)
+			So(s.Visitor.TokenBalance("iost", acc4.ID), ShouldEqual, fee+0.2*1e8)
+			So(s.Visitor.TokenBalance("usdt", seller.ID), ShouldEqual, usdt+20000-20)
+			So(s.Visitor.TokenBalance("iost", buyer.ID), ShouldEqual, iost+99.8*1e8)
+		})
+
+		Convey("depth", func() {
+			s.SetGas(maker.ID, 1e10)
+			for i := 1; i <= 100; i++ {
+				price := fmt.Sprintf("1.%02d", i)
+				if i == 100 {
+					price = "2"
+				}
+				So(dexOrder(s, maker, "buy", price, "1", 0).Status.Message, ShouldEqual, "")
+			}
+			depth := dexDepth(s, "buy")
+			So(len(depth), ShouldEqual, 100)
+			So(depth[0], ShouldResemble, dexLevel{"2", "1"})
+			So(depth[99], ShouldResemble, dexLevel{"1.01", "1"})
+
+			// a full book gives way to a better price by closing its worst level
+			r := dexOrder(s, buyer, "buy", "0.5", "4", 0)
+			So(r.Status.Message, ShouldContainSubstring, "price is worse than the 100 levels in the book")
+			usdt = s.Visitor.TokenBalance("usdt", maker.ID)
+			So(dexOrder(s, buyer, "buy", "1.015", "1", 0).Status.Message, ShouldEqual, "")
+			depth = dexDepth(s, "buy")
+			So(len(depth), ShouldEqual, 100)
+			So(depth[99], ShouldResemble, dexLevel{"1.015", "1"})
+			So(s.Visitor.TokenBalance("usdt", maker.ID), ShouldEqual, usdt+101)
+
+			// a full level gives way to a larger order by closing its smallest order
+			for i := 1; i < 50; i++ {
+				So(dexOrder(s, maker, "buy", "2", "1", 0).Status.Message, ShouldEqual, "")
+			}
+			r = dexOrder(s, buyer, "buy", "2", "1", 0)
+			So(r.Status.Message, ShouldContainSubstring, "too many orders at price 2, max 50")
+			So(dexOrder(s, buyer, "buy", "2", "2", 0).Status.Message, ShouldEqual, "")
+			So(dexDepth(s, "buy")[0], ShouldResemble, dexLevel{"2", "51"})
+
+			// out of matches the rest is refunded instead of crossing the book
+			iost = s.Visitor.TokenBalance("iost", seller.ID)
+			So(dexOrder(s, seller, "sell", "1", "1000", 0).Status.Message, ShouldEqual, "")
+			So(dexDepth(s, "sell"), ShouldBeEmpty)
+			So(dexDepth(s, "buy")[0], ShouldResemble, dexLevel{"2", "31"})
+			So(s.Visitor.TokenBalance("iost", seller.ID), ShouldEqual, iost-20*1e8)
+		})
+	})
+}
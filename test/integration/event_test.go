@@ -0,0 +1,50 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/iost-official/go-iost/core/event"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/ilog"
+	. "github.com/iost-official/go-iost/verifier"
+)
+
+func Test_EventSchema(t *testing.T) {
+	ilog.Stop()
+	Convey("test of event declared in abi", t, func() {
+		s := NewSimulator()
+		defer s.Clear()
+
+		createAccountsWithResource(s)
+		createToken(t, s, acc0)
+
+		ca, err := s.Compile("", "./test_data/event", "./test_data/event.js")
+		So(err, ShouldBeNil)
+		So(len(ca.Info.Events), ShouldEqual, 1)
+		cname, r, err := s.DeployContract(ca, acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Code, ShouldEqual, tx.Success)
+
+		ec := event.GetCollector()
+		ch := ec.Subscribe(1, []event.Topic{event.ContractEvent}, &event.Meta{ContractID: cname})
+		defer ec.Unsubscribe(1, []event.Topic{event.ContractEvent})
+
+		r, err = s.Call(cname, "transfer", `["a", "b", 10]`, acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		select {
+		case e := <-ch:
+			So(e.Name, ShouldEqual, "transfer")
+			So(e.Fields, ShouldResemble, map[string]string{"from": "a", "to": "b", "amount": "10"})
+		case <-time.After(time.Second):
+			t.Fatal("event not received")
+		}
+
+		r, err = s.Call(cname, "bad", `[]`, acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldContainSubstring, "event should be")
+	})
+}
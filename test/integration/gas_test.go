@@ -336,6 +336,42 @@ func TestGas_PledgeunpledgeForOther(t *testing.T) {
 	})
 }
 
+func TestGas_Rent(t *testing.T) {
+	Convey("test pledge of rented gas", t, func() {
+		e, h, code, testAcc, tmpDB := gasTestInit()
+		defer func() {
+			tmpDB.Close()
+			os.RemoveAll("mvcc")
+		}()
+		otherAcc := acc1.ID
+		h.DB().SetTokenBalanceFixed("iost", native.RentContractName, "100")
+		authList := h.Context().Value("auth_contract_list").(map[string]int)
+		authList[native.RentContractName] = 1
+		Convey("Gas can be rented only by users with the min pledge", func() {
+			_, _, err := e.LoadAndCall(h, code, "pledge", native.RentContractName, otherAcc, "50")
+			So(err.Error(), ShouldEqual, "gas can be rented only by users who pledge 10")
+		})
+		_, _, err := e.LoadAndCall(h, code, "pledge", testAcc, otherAcc, "10")
+		So(err, ShouldBeNil)
+		_, _, err = e.LoadAndCall(h, code, "pledge", native.RentContractName, otherAcc, "50")
+		So(err, ShouldBeNil)
+		total, _ := h.GasPledgeTotal(otherAcc)
+		So(total.ToString(), ShouldEqual, "60")
+		Convey("Rented gas is not counted in the min pledge", func() {
+			_, _, err = e.LoadAndCall(h, code, "unpledge", testAcc, otherAcc, "5")
+			So(err.Error(), ShouldEqual, "unpledge to much 60 - 5 less than 60")
+		})
+		Convey("Rented gas can always be unpledged", func() {
+			_, _, err = e.LoadAndCall(h, code, "unpledge", native.RentContractName, otherAcc, "50")
+			So(err, ShouldBeNil)
+			total, _ := h.GasPledgeTotal(otherAcc)
+			So(total.ToString(), ShouldEqual, "10")
+			pledged, _ := h.GasManager.GasPledge(otherAcc, native.RentContractName)
+			So(pledged.IsZero(), ShouldBeTrue)
+		})
+	})
+}
+
 func TestGas_Increase(t *testing.T) {
 	Convey("check gas increase rate", t, func() {
 		s := verifier.NewSimulator()
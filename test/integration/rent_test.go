@@ -0,0 +1,119 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/core/tx"
+	. "github.com/iost-official/go-iost/verifier"
+	"github.com/iost-official/go-iost/vm/native"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var rentContractName = "rent.iost"
+
+func rentSetup(t *testing.T) (*Simulator, *TestAccount) {
+	s, acc := ramSetup(t)
+	s.SetContract(native.SystemABI())
+	s.SetContract(native.GasABI())
+	err := setNonNativeContract(s, rentContractName, "rent.js", ContractPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Head.Number = 0
+	admin := acc1
+	r, err := s.Call(rentContractName, "initAdmin", array2json([]interface{}{admin.ID}), admin.ID, admin.KeyPair)
+	if err != nil {
+		panic(err)
+	}
+	if r.Status.Code != tx.StatusCode(tx.Success) {
+		panic("call failed " + r.String())
+	}
+	s.Head.Number = 1
+	return s, acc
+}
+
+func TestRent(t *testing.T) {
+	renter := acc2
+	var day int64 = 24 * 3600 * 1e9
+	Convey("test of rent", t, func() {
+		s, acc := rentSetup(t)
+		defer s.Clear()
+		r, err := s.Call("token.iost", "transfer", array2json([]interface{}{"iost", acc.ID, renter.ID, "100", ""}), acc.ID, acc.KeyPair)
+		So(err, ShouldEqual, nil)
+		So(r.Status.Message, ShouldEqual, "")
+
+		Convey("rent gas", func() {
+			r, err := s.Call("gas.iost", "pledge", array2json([]interface{}{renter.ID, renter.ID, "10"}), renter.ID, renter.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldEqual, "")
+			r, err = s.Call(rentContractName, "offer", array2json([]interface{}{"gas", acc.ID, "100", "1", 10, "0"}), acc.ID, acc.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(s.Visitor.TokenBalance("iost", rentContractName), ShouldEqual, 100*1e8)
+
+			balance := s.Visitor.TokenBalance("iost", acc.ID)
+			r, err = s.Call(rentContractName, "rent", array2json([]interface{}{"1", renter.ID, 2}), renter.ID, renter.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(s.Visitor.TokenBalance("iost", acc.ID), ShouldEqual, balance+2*1e8)
+			So(s.Visitor.GasPledge(renter.ID, rentContractName).ToString(), ShouldEqual, "100")
+
+			r, err = s.Call(rentContractName, "rent", array2json([]interface{}{"1", acc1.ID, 1}), acc1.ID, acc1.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldContainSubstring, "offer is rented until")
+			r, err = s.Call(rentContractName, "expire", array2json([]interface{}{"1"}), acc1.ID, acc1.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldContainSubstring, "rental ends at")
+
+			// the rental expires in the block base by the scheduled call
+			s.Head.Time += 2 * day
+			r, err = s.RunBlockBase("token.iost", "balanceOf", array2json([]interface{}{"iost", acc.ID}))
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(r.Receipts[len(r.Receipts)-1].Content, ShouldContainSubstring, `"code":0`)
+			So(s.Visitor.GasPledge(renter.ID, rentContractName).IsZero(), ShouldBeTrue)
+			So(s.Visitor.FreezedTokenBalance("iost", rentContractName), ShouldEqual, 100*1e8)
+			r, err = s.Call(rentContractName, "cancelOffer", array2json([]interface{}{"1"}), acc.ID, acc.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldContainSubstring, "unpledged iost is frozen until")
+			r, err = s.Call(rentContractName, "expire", array2json([]interface{}{"1"}), acc1.ID, acc1.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldContainSubstring, "offer is not rented")
+		})
+
+		Convey("rent ram", func() {
+			r, err := s.Call(ramContractName, "buy", array2json([]interface{}{acc.ID, acc.ID, 1000}), acc.ID, acc.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldEqual, "")
+			ownerRAM := s.Visitor.TokenBalance("ram", acc.ID)
+			renterRAM := s.Visitor.TokenBalance("ram", renter.ID)
+			r, err = s.Call(rentContractName, "offer", array2json([]interface{}{"ram", acc.ID, "300", "1", 10, "30"}), acc.ID, acc.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldEqual, "")
+
+			r, err = s.Call(rentContractName, "rent", array2json([]interface{}{"1", renter.ID, 1}), renter.ID, renter.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(s.Visitor.TokenBalance("ram", acc.ID), ShouldEqual, ownerRAM-300)
+			So(s.Visitor.TokenBalance("ram", renter.ID), ShouldEqual, renterRAM+300)
+			So(s.Visitor.TokenBalance("iost", rentContractName), ShouldEqual, 30*1e8)
+
+			// expiring by hand cancels the scheduled call
+			s.Head.Time += day
+			renterIOST := s.Visitor.TokenBalance("iost", renter.ID)
+			r, err = s.Call(rentContractName, "expire", array2json([]interface{}{"1"}), acc1.ID, acc1.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(s.Visitor.TokenBalance("ram", acc.ID), ShouldEqual, ownerRAM)
+			So(s.Visitor.TokenBalance("ram", renter.ID), ShouldEqual, renterRAM)
+			So(s.Visitor.TokenBalance("iost", renter.ID), ShouldEqual, renterIOST+30*1e8)
+			r, err = s.Call(rentContractName, "getOffer", array2json([]interface{}{"1"}), acc.ID, acc.KeyPair)
+			So(err, ShouldEqual, nil)
+			So(r.Status.Message, ShouldContainSubstring, "offer not exists")
+			r, err = s.RunBlockBase("token.iost", "balanceOf", array2json([]interface{}{"iost", acc.ID}))
+			So(err, ShouldEqual, nil)
+			So(len(r.Receipts), ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,19 @@
+/**
+ * @event transfer
+ * @field {string} from
+ * @field {string} to
+ * @field {number} amount
+ */
+class Contract {
+    init() {
+
+    }
+    transfer(from, to, amount) {
+        blockchain.emit("transfer", {from: from, to: to, amount: amount});
+    }
+    bad() {
+        blockchain.event("not declared");
+    }
+}
+
+module.exports = Contract;
@@ -0,0 +1,15 @@
+class Contract {
+    init() {
+
+    }
+    fill(n) {
+        for (let i = 0; i < n; i++) {
+            storage.put("k" + i, "v" + i);
+        }
+    }
+    can_update(data) {
+        return blockchain.requireAuth(blockchain.contractOwner(), "active");
+    }
+}
+
+module.exports = Contract;
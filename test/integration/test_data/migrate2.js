@@ -0,0 +1,33 @@
+class Contract {
+    init() {
+
+    }
+    migrate() {
+        // move the plain keys into the map "data"
+        while (true) {
+            const keys = storage.keys(0, 10);
+            if (keys.length === 0) {
+                break;
+            }
+            for (const k of keys) {
+                storage.mapPut("data", k, storage.get(k));
+                storage.del(k);
+            }
+        }
+    }
+    count() {
+        let n = 0;
+        for (let offset = 0; ; offset += 10) {
+            const fields = storage.mapFields("data", offset, 10);
+            if (fields.length === 0) {
+                return n;
+            }
+            n += fields.length;
+        }
+    }
+    can_update(data) {
+        return blockchain.requireAuth(blockchain.contractOwner(), "active");
+    }
+}
+
+module.exports = Contract;
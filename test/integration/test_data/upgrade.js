@@ -0,0 +1,13 @@
+class Contract {
+    init() {
+
+    }
+    hello() {
+        return "v1";
+    }
+    can_update(data) {
+        return blockchain.requireAuth(blockchain.contractOwner(), "active");
+    }
+}
+
+module.exports = Contract;
@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/ilog"
+	. "github.com/iost-official/go-iost/verifier"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/native"
+)
+
+func Test_UpgradePolicy(t *testing.T) {
+	ilog.Stop()
+	Convey("test of upgrade policy", t, func() {
+		s := NewSimulator()
+		defer s.Clear()
+
+		s.SetContract(native.SystemABI())
+		createAccountsWithResource(s)
+		createToken(t, s, acc0)
+
+		ca, err := s.Compile("", "./test_data/upgrade", "./test_data/upgrade.js")
+		So(err, ShouldBeNil)
+		cname, r, err := s.DeployContract(ca, acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Code, ShouldEqual, tx.Success)
+
+		r, err = s.Call("system.iost", "setUpgradePolicy", fmt.Sprintf(`["%v", {"delay": 60, "approver": "%v"}]`, cname, acc1.ID), acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+
+		ca2, err := s.Compile(cname, "./test_data/upgrade2", "./test_data/upgrade2.js")
+		So(err, ShouldBeNil)
+		code, err := json.Marshal(ca2)
+		So(err, ShouldBeNil)
+		proposeArgs, err := json.Marshal([]string{cname, string(code)})
+		So(err, ShouldBeNil)
+		codeHash := native.CodeHash(ca2.Code)
+
+		Convey("updateCode is refused", func() {
+			args, err := json.Marshal([]string{string(code), ""})
+			So(err, ShouldBeNil)
+			r, err := s.Call("system.iost", "updateCode", string(args), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "upgrade policy")
+		})
+
+		Convey("upgrade after approval and timelock", func() {
+			r, err := s.Call("system.iost", "proposeUpgrade", string(proposeArgs), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			pending := &native.PendingUpgrade{}
+			So(json.Unmarshal([]byte(database.MustUnmarshal(s.Visitor.MGet("system.iost-"+native.UpgradePendingMap, cname)).(database.SerializedJSON)), pending), ShouldBeNil)
+			So(pending.CodeHash, ShouldEqual, codeHash)
+			So(pending.Approved, ShouldBeFalse)
+
+			r, err = s.Call("system.iost", "applyUpgrade", fmt.Sprintf(`["%v"]`, cname), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "not approved")
+
+			r, err = s.Call("system.iost", "approveUpgrade", fmt.Sprintf(`["%v", "%v"]`, cname, codeHash), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "permission")
+			r, err = s.Call("system.iost", "approveUpgrade", fmt.Sprintf(`["%v", "%v"]`, cname, codeHash), acc1.ID, acc1.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+
+			r, err = s.Call("system.iost", "applyUpgrade", fmt.Sprintf(`["%v"]`, cname), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "locked")
+
+			s.Head.Time += 61 * 1e9
+			r, err = s.Call("system.iost", "applyUpgrade", fmt.Sprintf(`["%v"]`, cname), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(s.Visitor.MHas("system.iost-"+native.UpgradePendingMap, cname), ShouldBeFalse)
+
+			r, err = s.Call(cname, "hello", "[]", acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Returns[0], ShouldEqual, `["v2"]`)
+		})
+
+		Convey("cancel upgrade", func() {
+			r, err := s.Call("system.iost", "proposeUpgrade", string(proposeArgs), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			r, err = s.Call("system.iost", "cancelUpgrade", fmt.Sprintf(`["%v"]`, cname), acc1.ID, acc1.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+			So(s.Visitor.MHas("system.iost-"+native.UpgradePendingMap, cname), ShouldBeFalse)
+		})
+
+		Convey("policy can't be loosened by the owner", func() {
+			r, err := s.Call("system.iost", "setUpgradePolicy", fmt.Sprintf(`["%v", {"delay": 0, "approver": "%v"}]`, cname, acc1.ID), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "permission")
+
+			r, err = s.Call("system.iost", "setUpgradePolicy", fmt.Sprintf(`["%v", {"delay": 120, "approver": "%v"}]`, cname, acc1.ID), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldEqual, "")
+		})
+
+		Convey("delay is capped", func() {
+			r, err := s.Call("system.iost", "setUpgradePolicy", fmt.Sprintf(`["%v", {"delay": %v, "approver": "%v"}]`, cname, native.MaxUpgradeDelay+1, acc1.ID), acc0.ID, acc0.KeyPair)
+			So(err, ShouldBeNil)
+			So(r.Status.Message, ShouldContainSubstring, "delay should be between")
+		})
+	})
+}
+
+func Test_Migrate(t *testing.T) {
+	ilog.Stop()
+	Convey("test of storage migration in updateCode", t, func() {
+		s := NewSimulator()
+		defer s.Clear()
+
+		createAccountsWithResource(s)
+		createToken(t, s, acc0)
+
+		ca, err := s.Compile("", "./test_data/migrate", "./test_data/migrate.js")
+		So(err, ShouldBeNil)
+		cname, r, err := s.DeployContract(ca, acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Code, ShouldEqual, tx.Success)
+
+		r, err = s.Call(cname, "fill", "[25]", acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		So(s.Visitor.KeysIndexed(cname), ShouldBeFalse)
+
+		// the keys written before system.iost 1.1.0 are indexed by their owner
+		s.SetContract(native.SystemABI())
+		keys := make([]string, 0)
+		for i := 0; i < 25; i++ {
+			keys = append(keys, fmt.Sprintf("k%v", i))
+		}
+		args, err := json.Marshal([]interface{}{cname, "", keys})
+		So(err, ShouldBeNil)
+		r, err = s.Call("system.iost", "indexKeys", string(args), acc1.ID, acc1.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldContainSubstring, "need owner")
+		r, err = s.Call("system.iost", "indexKeys", string(args), acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		So(s.Visitor.KeysLen(cname), ShouldEqual, 25)
+
+		ca2, err := s.Compile(cname, "./test_data/migrate2", "./test_data/migrate2.js")
+		So(err, ShouldBeNil)
+		code, err := json.Marshal(ca2)
+		So(err, ShouldBeNil)
+		args, err = json.Marshal([]string{string(code), ""})
+		So(err, ShouldBeNil)
+		r, err = s.Call("system.iost", "updateCode", string(args), acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldEqual, "")
+		So(s.Visitor.KeysLen(cname), ShouldEqual, 0)
+
+		r, err = s.Call(cname, "migrate", "[]", acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Status.Message, ShouldContainSubstring, "migrate can only be called by system.iost")
+		So(s.Visitor.MFieldsLen(cname+"-data"), ShouldEqual, 25)
+
+		r, err = s.Call(cname, "count", "[]", acc0.ID, acc0.KeyPair)
+		So(err, ShouldBeNil)
+		So(r.Returns[0], ShouldEqual, "[25]")
+	})
+}
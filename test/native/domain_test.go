@@ -0,0 +1,270 @@
+package native
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/core/tx"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
+	"github.com/iost-official/go-iost/vm/native"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// tokenMonitor calls token.iost for domain.iost, args in json are passed as they are
+type tokenMonitor struct {
+	e *native.Impl
+}
+
+func (m *tokenMonitor) Call(h *host.Host, contractName, api string, jarg string) ([]interface{}, contract.Cost, error) {
+	var args []interface{}
+	if err := json.Unmarshal([]byte(jarg), &args); err != nil {
+		return nil, contract.Cost0(), err
+	}
+	h.Context().Set("contract_name", contractName)
+	h.Context().Set("abi_name", api)
+	return m.e.LoadAndCall(h, native.TokenABI(), api, args...)
+}
+
+func (m *tokenMonitor) Validate(h *host.Host, c *contract.Contract) error {
+	return nil
+}
+
+func (m *tokenMonitor) Compile(h *host.Host, c *contract.Contract) (string, error) {
+	return "", nil
+}
+
+func initDomainVM(t *testing.T) (*native.Impl, *host.Host, *contract.Contract) {
+	db := database.NewDatabaseFromPath(testDataPath + "token.json")
+	vi := database.NewVisitor(100, db)
+	for _, id := range []string{"admin", "issuer0", "user0", "user1"} {
+		vi.MPut("auth.iost-auth", id, database.MustMarshal(`{"id":"`+id+`","permissions":{"active":{"name":"active","groups":[],"items":[{"id":"`+id+`","is_key_pair":true,"weight":1}],"threshold":1},"owner":{"name":"owner","groups":[],"items":[{"id":"`+id+`","is_key_pair":true,"weight":1}],"threshold":1}}}`))
+	}
+
+	ctx := host.NewContext(nil)
+	ctx.Set("gas_ratio", int64(100))
+	ctx.GSet("gas_limit", int64(10000000))
+	ctx.Set("contract_name", "token.iost")
+	ctx.Set("tx_hash", []byte("iamhash"))
+	ctx.Set("auth_list", map[string]int{"issuer0": 1})
+	ctx.Set("auth_contract_list", make(map[string]int))
+	ctx.Set("time", int64(0))
+	ctx.Set("abi_name", "abi")
+	ctx.GSet("receipts", []*tx.Receipt{})
+
+	e := &native.Impl{}
+	e.Init()
+	h := host.NewHost(ctx, vi, &tokenMonitor{e: e}, nil)
+	h.Context().Set("stack_height", 0)
+	h.SetDeadline(time.Now().Add(10 * time.Second))
+
+	_, _, err := e.LoadAndCall(h, native.TokenABI(), "create", "iost", "issuer0", int64(100000), []byte(`{"decimal": 8}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"user0", "user1"} {
+		_, _, err = e.LoadAndCall(h, native.TokenABI(), "issue", "iost", id, "1000")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h.Context().Set("contract_name", "domain.iost")
+	h.Context().Set("auth_list", make(map[string]int))
+	return e, h, native.DomainABI()
+}
+
+func setPublisher(h *host.Host, id string) {
+	h.Context().Set("publisher", id)
+	h.Context().Set("auth_list", map[string]int{id: 1})
+}
+
+func balanceOf(h *host.Host, id string) string {
+	return h.DB().TokenBalanceFixed("iost", id).ToString()
+}
+
+func TestDomain_Expiry(t *testing.T) {
+	e, h, code := initDomainVM(t)
+
+	Convey("Test of domain expiry", t, func() {
+		Reset(func() {
+			e, h, code = initDomainVM(t)
+		})
+
+		Convey("linked url expires after a term", func() {
+			setPublisher(h, "user0")
+			_, _, err := e.LoadAndCall(h, code, "link", "abc_0_de.io", "Contract0")
+			So(err, ShouldBeNil)
+			So(h.URLOwner("abc_0_de.io"), ShouldEqual, "user0")
+			So(h.URLExpiry("abc_0_de.io"), ShouldEqual, native.DomainTerm*1e9)
+
+			h.Context().Set("time", native.DomainTerm*1e9)
+			So(h.URLOwner("abc_0_de.io"), ShouldEqual, "")
+			So(h.ContractID("abc_0_de.io"), ShouldEqual, "")
+			So(h.LinkedContractID("abc_0_de.io"), ShouldEqual, "Contract0")
+			setPublisher(h, "user1")
+			_, _, err = e.LoadAndCall(h, code, "link", "abc_0_de.io", "Contract1")
+			So(err, ShouldBeNil)
+			So(h.URLOwner("abc_0_de.io"), ShouldEqual, "user1")
+			So(h.ContractID("abc_0_de.io"), ShouldEqual, "Contract1")
+			So(h.URLExpiry("abc_0_de.io"), ShouldEqual, 2*native.DomainTerm*1e9)
+		})
+
+		Convey("1.0.0 links urls without expiry", func() {
+			code = native.SystemContractABI("domain.iost", "1.0.0")
+			setPublisher(h, "user0")
+			_, _, err := e.LoadAndCall(h, code, "link", "abc.io", "Contract0")
+			So(err, ShouldBeNil)
+			So(h.URLOwner("abc.io"), ShouldEqual, "user0")
+			So(h.URLExpiry("abc.io"), ShouldEqual, 0)
+			_, _, err = e.LoadAndCall(h, code, "renew", "abc.io")
+			So(err.Error(), ShouldContainSubstring, "invalid api name")
+		})
+
+		Convey("premium url can't be linked", func() {
+			setPublisher(h, "user0")
+			_, _, err := e.LoadAndCall(h, code, "link", "abc.io", "Contract0")
+			So(err.Error(), ShouldEqual, "premium url should be won in auction")
+		})
+
+		Convey("renew in the window", func() {
+			setPublisher(h, "user0")
+			_, _, err := e.LoadAndCall(h, code, "link", "abc_0_de.io", "Contract0")
+			So(err, ShouldBeNil)
+			_, _, err = e.LoadAndCall(h, code, "renew", "abc_0_de.io")
+			So(err.Error(), ShouldContainSubstring, "url can be renewed after")
+
+			h.Context().Set("time", (native.DomainTerm-native.DomainRenewWindow)*1e9)
+			setPublisher(h, "user1")
+			_, _, err = e.LoadAndCall(h, code, "renew", "abc_0_de.io")
+			So(err.Error(), ShouldEqual, "no privilege of claimed url")
+			setPublisher(h, "user0")
+			_, _, err = e.LoadAndCall(h, code, "renew", "abc_0_de.io")
+			So(err, ShouldBeNil)
+			So(h.URLExpiry("abc_0_de.io"), ShouldEqual, 2*native.DomainTerm*1e9)
+		})
+	})
+}
+
+func TestDomain_Auction(t *testing.T) {
+	e, h, code := initDomainVM(t)
+
+	Convey("Test of premium name auction", t, func() {
+		Reset(func() {
+			e, h, code = initDomainVM(t)
+		})
+
+		Convey("bid and settle url", func() {
+			setPublisher(h, "user0")
+			_, _, err := e.LoadAndCall(h, code, "bid", "domain", "abc.io", "user0", "50")
+			So(err.Error(), ShouldEqual, "bid should be at least 100")
+			_, _, err = e.LoadAndCall(h, code, "bid", "domain", "abc.io", "user1", "100")
+			So(err, ShouldEqual, host.ErrPermissionLost)
+			_, _, err = e.LoadAndCall(h, code, "bid", "domain", "abc.io", "user0", "100")
+			So(err, ShouldBeNil)
+			So(balanceOf(h, "user0"), ShouldEqual, "900")
+			So(balanceOf(h, "domain.iost"), ShouldEqual, "100")
+
+			setPublisher(h, "user1")
+			_, _, err = e.LoadAndCall(h, code, "bid", "domain", "abc.io", "user1", "105")
+			So(err.Error(), ShouldEqual, "bid should be 10% higher than 100")
+			_, _, err = e.LoadAndCall(h, code, "bid", "domain", "abc.io", "user1", "110")
+			So(err, ShouldBeNil)
+			So(balanceOf(h, "user0"), ShouldEqual, "1000")
+			So(balanceOf(h, "user1"), ShouldEqual, "890")
+			So(balanceOf(h, "domain.iost"), ShouldEqual, "110")
+
+			_, _, err = e.LoadAndCall(h, code, "settle", "domain", "abc.io")
+			So(err.Error(), ShouldContainSubstring, "auction of abc.io ends at")
+			h.Context().Set("time", native.AuctionDuration*1e9)
+			_, _, err = e.LoadAndCall(h, code, "bid", "domain", "abc.io", "user1", "200")
+			So(err.Error(), ShouldEqual, "auction of abc.io ended, settle it")
+			_, _, err = e.LoadAndCall(h, code, "settle", "domain", "abc.io")
+			So(err, ShouldBeNil)
+			So(h.URLOwner("abc.io"), ShouldEqual, "user1")
+			So(h.URLExpiry("abc.io"), ShouldEqual, (native.AuctionDuration+native.DomainTerm)*1e9)
+			So(balanceOf(h, "domain.iost"), ShouldEqual, "0")
+
+			_, _, err = e.LoadAndCall(h, code, "bid", "domain", "abc.io", "user0", "100")
+			So(err.Error(), ShouldEqual, "url abc.io is owned by user1")
+		})
+
+		Convey("late bid extends the auction", func() {
+			setPublisher(h, "user0")
+			_, _, err := e.LoadAndCall(h, code, "bid", "account", "abcde", "user0", "100")
+			So(err, ShouldBeNil)
+			h.Context().Set("time", native.AuctionDuration*1e9-1)
+			setPublisher(h, "user1")
+			_, _, err = e.LoadAndCall(h, code, "bid", "account", "abcde", "user1", "110")
+			So(err, ShouldBeNil)
+			h.Context().Set("time", native.AuctionDuration*1e9)
+			_, _, err = e.LoadAndCall(h, code, "settle", "account", "abcde")
+			So(err.Error(), ShouldContainSubstring, "auction of abcde ends at")
+
+			h.Context().Set("time", native.AuctionDuration*1e9-1+native.AuctionExtension*1e9)
+			_, _, err = e.LoadAndCall(h, code, "settle", "account", "abcde")
+			So(err, ShouldBeNil)
+			winner, _ := h.GlobalMapGet("domain.iost", "account_winner", "abcde")
+			So(winner, ShouldEqual, "user1")
+			_, _, err = e.LoadAndCall(h, code, "bid", "account", "abcde", "user1", "100")
+			So(err.Error(), ShouldEqual, "account abcde is won by user1")
+		})
+
+		Convey("won account is claimed or auctioned again", func() {
+			setPublisher(h, "user0")
+			_, _, err := e.LoadAndCall(h, code, "bid", "account", "abcde", "user0", "100")
+			So(err, ShouldBeNil)
+			h.Context().Set("time", native.AuctionDuration*1e9)
+			_, _, err = e.LoadAndCall(h, code, "settle", "account", "abcde")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(h, code, "claimAccount", "abcde", "user0")
+			So(err.Error(), ShouldEqual, "only account.iost can claim accounts")
+			h.Context().Set("auth_contract_list", map[string]int{"account.iost": 1})
+			_, _, err = e.LoadAndCall(h, code, "claimAccount", "abcde", "user1")
+			So(err.Error(), ShouldEqual, "account abcde is not won by user1")
+			_, _, err = e.LoadAndCall(h, code, "claimAccount", "abcde", "user0")
+			So(err, ShouldBeNil)
+			ok, _ := h.GlobalMapHas("domain.iost", "account_winner", "abcde")
+			So(ok, ShouldBeFalse)
+
+			h.Context().Set("auth_contract_list", make(map[string]int))
+			_, _, err = e.LoadAndCall(h, code, "bid", "account", "abcdf", "user0", "100")
+			So(err, ShouldBeNil)
+			h.Context().Set("time", 2*native.AuctionDuration*1e9)
+			_, _, err = e.LoadAndCall(h, code, "settle", "account", "abcdf")
+			So(err, ShouldBeNil)
+			setPublisher(h, "user1")
+			_, _, err = e.LoadAndCall(h, code, "bid", "account", "abcdf", "user1", "100")
+			So(err.Error(), ShouldEqual, "account abcdf is won by user0")
+
+			h.Context().Set("time", (2*native.AuctionDuration+native.AccountClaimPeriod)*1e9)
+			_, _, err = e.LoadAndCall(h, code, "bid", "account", "abcdf", "user1", "100")
+			So(err, ShouldBeNil)
+			ok, _ = h.GlobalMapHas("domain.iost", "account_winner", "abcdf")
+			So(ok, ShouldBeFalse)
+			h.Context().Set("auth_contract_list", map[string]int{"account.iost": 1})
+			_, _, err = e.LoadAndCall(h, code, "claimAccount", "abcdf", "user0")
+			So(err.Error(), ShouldEqual, "account abcdf is not won by user0")
+		})
+
+		Convey("set premium names", func() {
+			setPublisher(h, "user0")
+			_, _, err := e.LoadAndCall(h, code, "bid", "account", "abcdef", "user0", "100")
+			So(err.Error(), ShouldEqual, "account abcdef is not premium, sign up or link it directly")
+			_, _, err = e.LoadAndCall(h, code, "setPremium", "account", "abcdef", true)
+			So(err.Error(), ShouldEqual, "only admin can set premium names")
+
+			setPublisher(h, "admin")
+			_, _, err = e.LoadAndCall(h, code, "setPremium", "account", "abcdef", true)
+			So(err, ShouldBeNil)
+			setPublisher(h, "user0")
+			_, _, err = e.LoadAndCall(h, code, "bid", "account", "abcdef", "user0", "100")
+			So(err, ShouldBeNil)
+			_, _, err = e.LoadAndCall(h, code, "bid", "account", "user0", "user0", "100")
+			So(err.Error(), ShouldEqual, "account user0 exists")
+		})
+	})
+}
@@ -44,7 +44,7 @@ func initVM(t *testing.T, conName string, optional ...interface{}) (*native.Impl
 
 	code := &contract.Contract{
 		ID: "system.iost",
-		Info: &contract.Info{Version:"1.0.0"},
+		Info: &contract.Info{Version: native.NativeVersion},
 	}
 
 	e := &native.Impl{}
@@ -293,3 +293,200 @@ func TestToken721_Metadate(t *testing.T) {
 
 	})
 }
+
+func TestToken721_Approve(t *testing.T) {
+	issuer0 := "issuer0"
+	e, host, code := initVM(t, "token721.iost")
+	code.ID = "token721.iost"
+	host.SetDeadline(time.Now().Add(10 * time.Second))
+	authList := host.Context().Value("auth_list").(map[string]int)
+
+	Convey("Test of Token approve", t, func() {
+		Reset(func() {
+			e, host, code = initVM(t, "token721.iost")
+			code.ID = "token721.iost"
+			host.SetDeadline(time.Now().Add(10 * time.Second))
+			authList = host.Context().Value("auth_list").(map[string]int)
+		})
+
+		Convey("approve and transferFrom", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100))
+			So(err, ShouldBeNil)
+			for i := 0; i < 3; i++ {
+				e.LoadAndCall(host, code, "issue", "iost", "issuer0", "{}")
+			}
+
+			_, _, err = e.LoadAndCall(host, code, "approve", "iost", "issuer0", "user0", "1")
+			So(err, ShouldBeNil)
+			rs, _, err := e.LoadAndCall(host, code, "getApproved", "iost", "1")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "user0")
+
+			delete(authList, issuer0)
+			authList["user0"] = 1
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user0", "issuer0", "user1", "2")
+			So(err.Error(), ShouldContainSubstring, "is not approved")
+
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user0", "issuer0", "user1", "1")
+			So(err, ShouldBeNil)
+			owner, err := host.DB().Token721Owner("iost", "1")
+			So(err, ShouldBeNil)
+			So(owner, ShouldEqual, "user1")
+			So(host.DB().Token721Balance("iost", "issuer0"), ShouldEqual, 2)
+
+			// approval is cleared after transfer
+			rs, _, err = e.LoadAndCall(host, code, "getApproved", "iost", "1")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "")
+		})
+
+		Convey("setApprovalForAll", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100))
+			So(err, ShouldBeNil)
+			for i := 0; i < 3; i++ {
+				e.LoadAndCall(host, code, "issue", "iost", "issuer0", "{}")
+			}
+
+			_, _, err = e.LoadAndCall(host, code, "setApprovalForAll", "iost", "issuer0", "user0", true)
+			So(err, ShouldBeNil)
+			rs, _, err := e.LoadAndCall(host, code, "isApprovedForAll", "iost", "issuer0", "user0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, true)
+
+			delete(authList, issuer0)
+			authList["user0"] = 1
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user0", "issuer0", "user1", "0")
+			So(err, ShouldBeNil)
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user0", "issuer0", "user1", "2")
+			So(err, ShouldBeNil)
+			So(host.DB().Token721Balance("iost", "user1"), ShouldEqual, 2)
+
+			authList[issuer0] = 1
+			_, _, err = e.LoadAndCall(host, code, "setApprovalForAll", "iost", "issuer0", "user0", false)
+			So(err, ShouldBeNil)
+			So(host.DB().Token721IsApprovedForAll("iost", "issuer0", "user0"), ShouldBeFalse)
+		})
+	})
+}
+
+func TestToken721_Burn(t *testing.T) {
+	issuer0 := "issuer0"
+	e, host, code := initVM(t, "token721.iost")
+	code.ID = "token721.iost"
+	host.SetDeadline(time.Now().Add(10 * time.Second))
+	authList := host.Context().Value("auth_list").(map[string]int)
+
+	Convey("Test of Token burn", t, func() {
+		Reset(func() {
+			e, host, code = initVM(t, "token721.iost")
+			code.ID = "token721.iost"
+			host.SetDeadline(time.Now().Add(10 * time.Second))
+			authList = host.Context().Value("auth_list").(map[string]int)
+		})
+
+		Convey("burn and enumerate", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100))
+			So(err, ShouldBeNil)
+			for i := 0; i < 4; i++ {
+				e.LoadAndCall(host, code, "issue", "iost", "issuer0", "{}")
+			}
+
+			_, _, err = e.LoadAndCall(host, code, "burn", "iost", "issuer0", "1")
+			So(err, ShouldBeNil)
+			_, err = host.DB().Token721Owner("iost", "1")
+			So(err, ShouldNotBeNil)
+			So(host.DB().Token721Balance("iost", "issuer0"), ShouldEqual, 3)
+
+			rs, _, err := e.LoadAndCall(host, code, "totalSupply", "iost")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, int64(3))
+			So(fmt.Sprintf("%v", host.DB().Token721TokenIDs("iost", 0, 10)), ShouldEqual, "[0 3 2]")
+
+			rs, _, err = e.LoadAndCall(host, code, "tokenByIndex", "iost", int64(1))
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "3")
+			_, _, err = e.LoadAndCall(host, code, "tokenByIndex", "iost", int64(3))
+			So(err.Error(), ShouldEqual, "out of range")
+
+			// burned id is not issued again
+			rs, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "{}")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "4")
+		})
+
+		Convey("tokens issued by 1.0.0", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			code.Info.Version = "1.0.0"
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100))
+			So(err, ShouldBeNil)
+			for i := 0; i < 3; i++ {
+				e.LoadAndCall(host, code, "issue", "iost", "issuer0", "{}")
+			}
+
+			code.Info.Version = native.NativeVersion
+			e.LoadAndCall(host, code, "issue", "iost", "issuer0", "{}")
+			_, _, err = e.LoadAndCall(host, code, "burn", "iost", "issuer0", "0")
+			So(err, ShouldBeNil)
+			rs, _, err := e.LoadAndCall(host, code, "totalSupply", "iost")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, int64(3))
+			So(host.DB().Token721Supply("iost"), ShouldEqual, 3)
+			_, _, err = e.LoadAndCall(host, code, "tokenByIndex", "iost", int64(0))
+			So(err.Error(), ShouldContainSubstring, "0 of 3 tokens are indexed")
+
+			_, _, err = e.LoadAndCall(host, code, "indexTokens", "iost", int64(0), int64(2))
+			So(err, ShouldBeNil)
+			_, _, err = e.LoadAndCall(host, code, "tokenByIndex", "iost", int64(0))
+			So(err.Error(), ShouldContainSubstring, "1 of 3 tokens are indexed")
+			_, _, err = e.LoadAndCall(host, code, "indexTokens", "iost", int64(2), int64(1000))
+			So(err, ShouldBeNil)
+			So(fmt.Sprintf("%v", host.DB().Token721TokenIDs("iost", 0, 10)), ShouldEqual, "[1 2 3]")
+			rs, _, err = e.LoadAndCall(host, code, "tokenByIndex", "iost", int64(2))
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "3")
+		})
+
+		Convey("burn without auth", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100))
+			So(err, ShouldBeNil)
+			e.LoadAndCall(host, code, "issue", "iost", "issuer0", "{}")
+
+			authList["user0"] = 1
+			_, _, err = e.LoadAndCall(host, code, "burn", "iost", "user0", "0")
+			So(err.Error(), ShouldContainSubstring, "error token owner isn't from")
+
+			delete(authList, issuer0)
+			_, _, err = e.LoadAndCall(host, code, "burn", "iost", "issuer0", "0")
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+		})
+
+		Convey("update metadata", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100))
+			So(err, ShouldBeNil)
+			e.LoadAndCall(host, code, "issue", "iost", "issuer0", "{}")
+			_, _, err = e.LoadAndCall(host, code, "transfer", "iost", "issuer0", "user0", "0")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "updateMetadata", "iost", "0", `{"hp": 99}`)
+			So(err, ShouldBeNil)
+			md, err := host.DB().Token721Metadata("iost", "0")
+			So(err, ShouldBeNil)
+			So(md, ShouldEqual, `{"hp": 99}`)
+
+			delete(authList, issuer0)
+			_, _, err = e.LoadAndCall(host, code, "updateMetadata", "iost", "0", `{"hp": 98}`)
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package native
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -40,7 +41,7 @@ func InitVM(t *testing.T, conName string, optional ...interface{}) (*native.Impl
 
 	code := &contract.Contract{
 		ID: "system.iost",
-		Info: &contract.Info{Version:"1.0.0"},
+		Info: &contract.Info{Version: native.NativeVersion},
 	}
 
 	e := &native.Impl{}
@@ -623,3 +624,506 @@ func TestToken_TransferFreeze(t *testing.T) {
 
 	})
 }
+
+func TestToken_Allowance(t *testing.T) {
+	issuer0 := "issuer0"
+	e, host, code := InitVM(t, "token")
+	code.ID = "token.iost"
+	host.Context().Set("contract_name", "token.iost")
+	host.SetDeadline(time.Now().Add(10 * time.Second))
+	authList := host.Context().Value("auth_list").(map[string]int)
+
+	Convey("Test of Token allowance", t, func() {
+
+		Reset(func() {
+			e, host, code = InitVM(t, "token")
+			code.ID = "token.iost"
+			host.Context().Set("contract_name", "token.iost")
+			host.SetDeadline(time.Now().Add(10 * time.Second))
+			authList = host.Context().Value("auth_list").(map[string]int)
+
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100), []byte("{}"))
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "100.0")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("allowance prepare", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100), []byte("{}"))
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "100.0")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("correct transferFrom", func() {
+			_, cost, err := e.LoadAndCall(host, code, "approve", "iost", "issuer0", "user0", "30")
+			So(err, ShouldBeNil)
+			So(cost.ToGas(), ShouldBeGreaterThan, 0)
+
+			rs, _, err := e.LoadAndCall(host, code, "allowance", "iost", "issuer0", "user0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "30")
+
+			delete(authList, issuer0)
+			authList["user0"] = 1
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user0", "issuer0", "user1", "22.3", "")
+			So(err, ShouldBeNil)
+
+			rs, _, err = e.LoadAndCall(host, code, "allowance", "iost", "issuer0", "user0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "7.7")
+
+			rs, _, err = e.LoadAndCall(host, code, "balanceOf", "iost", "issuer0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "77.7")
+
+			rs, _, err = e.LoadAndCall(host, code, "balanceOf", "iost", "user1")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "22.3")
+
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user0", "issuer0", "user1", "7.8", "")
+			So(err.Error(), ShouldStartWith, "allowance not enough")
+
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user0", "issuer0", "user0", "7.7", "")
+			So(err, ShouldBeNil)
+
+			ok, _ := host.MapHas("TAissuer0", "iost:user0")
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("approve without auth", func() {
+			delete(authList, issuer0)
+			_, _, err := e.LoadAndCall(host, code, "approve", "iost", "issuer0", "user0", "30")
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+		})
+
+		Convey("transferFrom without auth", func() {
+			_, _, err := e.LoadAndCall(host, code, "approve", "iost", "issuer0", "user0", "30")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user1", "issuer0", "user1", "1", "")
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+
+			authList["user1"] = 1
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user1", "issuer0", "user1", "1", "")
+			So(err.Error(), ShouldStartWith, "allowance not enough")
+		})
+
+		Convey("approve invalid amount", func() {
+			_, _, err := e.LoadAndCall(host, code, "approve", "iost", "issuer0", "user0", "-1")
+			So(err.Error(), ShouldEqual, "invalid amount")
+
+			_, _, err = e.LoadAndCall(host, code, "approve", "iost", "issuer0", "issuer0", "1")
+			So(err.Error(), ShouldEqual, "approve to self")
+		})
+
+		Convey("revoke", func() {
+			_, _, err := e.LoadAndCall(host, code, "approve", "iost", "issuer0", "user0", "30")
+			So(err, ShouldBeNil)
+
+			delete(authList, issuer0)
+			_, _, err = e.LoadAndCall(host, code, "revoke", "iost", "issuer0", "user0")
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+
+			authList["user0"] = 1
+			_, _, err = e.LoadAndCall(host, code, "revoke", "iost", "issuer0", "user0")
+			So(err, ShouldBeNil)
+
+			rs, _, err := e.LoadAndCall(host, code, "allowance", "iost", "issuer0", "user0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "0")
+
+			_, _, err = e.LoadAndCall(host, code, "transferFrom", "iost", "user0", "issuer0", "user0", "1", "")
+			So(err.Error(), ShouldStartWith, "allowance not enough")
+		})
+	})
+}
+
+func TestToken_Controls(t *testing.T) {
+	issuer0 := "issuer0"
+	e, host, code := InitVM(t, "token")
+	code.ID = "token.iost"
+	host.Context().Set("contract_name", "token.iost")
+	host.SetDeadline(time.Now().Add(10 * time.Second))
+	authList := host.Context().Value("auth_list").(map[string]int)
+	config := []byte(`{"canPause": true, "canBlacklist": true, "canUpdateMetadata": true, "canChangeIssuer": true, "url": "https://iost.io"}`)
+
+	Convey("Test of Token controls", t, func() {
+
+		Reset(func() {
+			e, host, code = InitVM(t, "token")
+			code.ID = "token.iost"
+			host.Context().Set("contract_name", "token.iost")
+			host.SetDeadline(time.Now().Add(10 * time.Second))
+			authList = host.Context().Value("auth_list").(map[string]int)
+
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(1000), config)
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "100.0")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("controls prepare", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(1000), config)
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "100.0")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("controls disabled", func() {
+			_, _, err := e.LoadAndCall(host, code, "create", "iost1", "issuer0", int64(100), []byte("{}"))
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "pause", "iost1")
+			So(err.Error(), ShouldEqual, "token control disabled: canPause")
+
+			_, _, err = e.LoadAndCall(host, code, "blacklist", "iost1", "user0")
+			So(err.Error(), ShouldEqual, "token control disabled: canBlacklist")
+		})
+
+		Convey("pause", func() {
+			_, _, err := e.LoadAndCall(host, code, "pause", "iost")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "transfer", "iost", "issuer0", "user0", "1", "")
+			So(err.Error(), ShouldEqual, "token paused")
+
+			_, _, err = e.LoadAndCall(host, code, "unpause", "iost")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "transfer", "iost", "issuer0", "user0", "1", "")
+			So(err, ShouldBeNil)
+
+			delete(authList, issuer0)
+			_, _, err = e.LoadAndCall(host, code, "pause", "iost")
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+		})
+
+		Convey("blacklist", func() {
+			_, _, err := e.LoadAndCall(host, code, "blacklist", "iost", "user0")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "transfer", "iost", "issuer0", "user0", "1", "")
+			So(err.Error(), ShouldEqual, "account user0 is blacklisted")
+
+			_, _, err = e.LoadAndCall(host, code, "transfer", "iost", "issuer0", "user1", "1", "")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "unblacklist", "iost", "user0")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "transfer", "iost", "issuer0", "user0", "1", "")
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "blacklist", "iost", "issuer0")
+			So(err.Error(), ShouldEqual, "can't blacklist issuer")
+		})
+
+		Convey("update metadata", func() {
+			_, _, err := e.LoadAndCall(host, code, "updateMetadata", "iost", []byte(`{"fullName": "IOST", "logo": "QmHash"}`))
+			So(err, ShouldBeNil)
+
+			info := host.DB().TokenInfo("iost")
+			So(info.FullName, ShouldEqual, "IOST")
+			So(info.URL, ShouldEqual, "https://iost.io")
+			So(info.Logo, ShouldEqual, "QmHash")
+			So(info.CanPause, ShouldBeTrue)
+
+			_, _, err = e.LoadAndCall(host, code, "updateMetadata", "iost", []byte(`{"issuer": "user0"}`))
+			So(err.Error(), ShouldEqual, "invalid metadata field issuer")
+		})
+
+		Convey("change issuer", func() {
+			_, _, err := e.LoadAndCall(host, code, "changeIssuer", "iost", "user0")
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+
+			authList["user0"] = 1
+			_, _, err = e.LoadAndCall(host, code, "changeIssuer", "iost", "user0")
+			So(err, ShouldBeNil)
+
+			delete(authList, issuer0)
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "user0", "1")
+			So(err, ShouldBeNil)
+			So(host.DB().TokenInfo("iost").Issuer, ShouldEqual, "user0")
+		})
+
+		Convey("1.0.0 ignores controls and keeps the cost of transfer", func() {
+			_, cost0, err := e.LoadAndCall(host, code, "transfer", "iost", "issuer0", "user0", "1", "")
+			So(err, ShouldBeNil)
+
+			code.Info.Version = "1.0.0"
+			_, _, err = e.LoadAndCall(host, code, "create", "iost1", "issuer0", int64(100), config)
+			So(err, ShouldBeNil)
+			So(host.DB().MHas("token.iost-TIiost1", "canPause"), ShouldBeFalse)
+
+			_, _, err = e.LoadAndCall(host, code, "pause", "iost")
+			So(err.Error(), ShouldContainSubstring, "invalid api name")
+			_, cost1, err := e.LoadAndCall(host, code, "transfer", "iost", "issuer0", "user0", "1", "")
+			So(err, ShouldBeNil)
+			So(cost1.ToGas(), ShouldBeLessThan, cost0.ToGas())
+		})
+	})
+}
+
+func TestToken_BatchTransfer(t *testing.T) {
+	issuer0 := "issuer0"
+	e, host, code := InitVM(t, "token")
+	code.ID = "token.iost"
+	host.Context().Set("contract_name", "token.iost")
+	host.SetDeadline(time.Now().Add(10 * time.Second))
+	authList := host.Context().Value("auth_list").(map[string]int)
+
+	Convey("Test of Token batchTransfer", t, func() {
+
+		Reset(func() {
+			e, host, code = InitVM(t, "token")
+			code.ID = "token.iost"
+			host.Context().Set("contract_name", "token.iost")
+			host.SetDeadline(time.Now().Add(10 * time.Second))
+			authList = host.Context().Value("auth_list").(map[string]int)
+
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100), []byte("{}"))
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "100.0")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("batchTransfer prepare", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100), []byte("{}"))
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "100.0")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("correct batchTransfer", func() {
+			transfers := []byte(`[["user0", "10", "memo0"], ["user1", "2.5", ""], ["user0", "1"], ["issuer0", "5"]]`)
+			_, cost, err := e.LoadAndCall(host, code, "batchTransfer", "iost", "issuer0", transfers)
+			So(err, ShouldBeNil)
+			So(cost.ToGas(), ShouldBeGreaterThan, 0)
+
+			rs, _, err := e.LoadAndCall(host, code, "balanceOf", "iost", "issuer0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "86.5")
+
+			rs, _, err = e.LoadAndCall(host, code, "balanceOf", "iost", "user0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "11")
+
+			rs, _, err = e.LoadAndCall(host, code, "balanceOf", "iost", "user1")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "2.5")
+
+			receipts := host.Context().GValue("receipts").([]*tx.Receipt)
+			So(len(receipts), ShouldBeGreaterThanOrEqualTo, 3)
+			receipts = receipts[len(receipts)-3:]
+			So(receipts[0].Content, ShouldEqual, `["iost","issuer0","user0","10","memo0"]`)
+			So(receipts[1].Content, ShouldEqual, `["iost","issuer0","user1","2.5",""]`)
+			So(receipts[2].Content, ShouldEqual, `["iost","issuer0","user0","1",""]`)
+		})
+
+		Convey("batchTransfer cost grows with transfers", func() {
+			_, cost1, err := e.LoadAndCall(host, code, "batchTransfer", "iost", "issuer0", []byte(`[["user0", "1"]]`))
+			So(err, ShouldBeNil)
+			_, cost2, err := e.LoadAndCall(host, code, "batchTransfer", "iost", "issuer0", []byte(`[["user0", "1"], ["user1", "1"]]`))
+			So(err, ShouldBeNil)
+			So(cost2.ToGas(), ShouldBeGreaterThan, cost1.ToGas())
+		})
+
+		Convey("batchTransfer balance not enough", func() {
+			_, _, err := e.LoadAndCall(host, code, "batchTransfer", "iost", "issuer0", []byte(`[["user0", "60"], ["user1", "50"]]`))
+			So(err.Error(), ShouldEqual, "balance not enough 100 < 110")
+
+			rs, _, err := e.LoadAndCall(host, code, "balanceOf", "iost", "user0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "0")
+		})
+
+		Convey("batchTransfer without auth", func() {
+			delete(authList, issuer0)
+			_, _, err := e.LoadAndCall(host, code, "batchTransfer", "iost", "issuer0", []byte(`[["user0", "1"]]`))
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+		})
+
+		Convey("batchTransfer invalid transfers", func() {
+			_, _, err := e.LoadAndCall(host, code, "batchTransfer", "iost", "issuer0", []byte(`[]`))
+			So(err.Error(), ShouldStartWith, "transfers count should be between")
+
+			_, _, err = e.LoadAndCall(host, code, "batchTransfer", "iost", "issuer0", []byte(`[["user0"]]`))
+			So(err.Error(), ShouldStartWith, "invalid transfer 0")
+
+			_, _, err = e.LoadAndCall(host, code, "batchTransfer", "iost", "issuer0", []byte(`[["user0", "1"], ["user1", "-1"]]`))
+			So(err.Error(), ShouldEqual, "invalid amount")
+		})
+	})
+}
+
+func TestToken_Vesting(t *testing.T) {
+	issuer0 := "issuer0"
+	e, host, code := InitVM(t, "token")
+	code.ID = "token.iost"
+	host.Context().Set("contract_name", "token.iost")
+	host.SetDeadline(time.Now().Add(10 * time.Second))
+	authList := host.Context().Value("auth_list").(map[string]int)
+	now := int64(time.Now().Unix()) * 1e9
+	config := func(revocable bool) []byte {
+		return []byte(fmt.Sprintf(`{"start": %v, "cliff": 10, "duration": 100, "interval": 10, "revocable": %v}`, now, revocable))
+	}
+
+	Convey("Test of Token vesting", t, func() {
+
+		Reset(func() {
+			e, host, code = InitVM(t, "token")
+			code.ID = "token.iost"
+			host.Context().Set("contract_name", "token.iost")
+			host.SetDeadline(time.Now().Add(10 * time.Second))
+			authList = host.Context().Value("auth_list").(map[string]int)
+
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			host.Context().Set("time", now)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100), []byte("{}"))
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "100.0")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("vesting prepare", func() {
+			authList[issuer0] = 1
+			host.Context().Set("auth_list", authList)
+			host.Context().Set("time", now)
+			_, _, err := e.LoadAndCall(host, code, "create", "iost", "issuer0", int64(100), []byte("{}"))
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "issue", "iost", "issuer0", "100.0")
+			So(err, ShouldBeNil)
+		})
+
+		Convey("correct vest and claim", func() {
+			rs, cost, err := e.LoadAndCall(host, code, "vest", "iost", "issuer0", "user0", "60", config(false))
+			So(err, ShouldBeNil)
+			So(cost.ToGas(), ShouldBeGreaterThan, 0)
+			So(rs[0], ShouldEqual, "0")
+
+			rs, _, err = e.LoadAndCall(host, code, "balanceOf", "iost", "issuer0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "40")
+
+			authList["user0"] = 1
+			host.Context().Set("time", now+5)
+			rs, _, err = e.LoadAndCall(host, code, "claim", "iost", "user0", int64(0))
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "0")
+
+			host.Context().Set("time", now+25)
+			rs, _, err = e.LoadAndCall(host, code, "claim", "iost", "user0", int64(0))
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "12")
+
+			vesting := host.DB().Vesting("iost", "user0")
+			So(len(vesting), ShouldEqual, 1)
+			So(vesting[0].Claimed, ShouldEqual, 12*1e8)
+
+			host.Context().Set("time", now+100)
+			rs, _, err = e.LoadAndCall(host, code, "claim", "iost", "user0", int64(0))
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "48")
+
+			rs, _, err = e.LoadAndCall(host, code, "balanceOf", "iost", "user0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "60")
+			So(len(host.DB().Vesting("iost", "user0")), ShouldEqual, 0)
+		})
+
+		Convey("claim a schedule among many", func() {
+			_, _, err := e.LoadAndCall(host, code, "vest", "iost", "issuer0", "user0", "60", config(false))
+			So(err, ShouldBeNil)
+			authList["user0"] = 1
+			host.Context().Set("time", now+25)
+			_, _, err = e.LoadAndCall(host, code, "claim", "iost", "user0", int64(0))
+			So(err, ShouldBeNil)
+			host.Context().Set("time", now+35)
+			_, cost, err := e.LoadAndCall(host, code, "claim", "iost", "user0", int64(0))
+			So(err, ShouldBeNil)
+
+			host.Context().Set("time", now)
+			for i := 0; i < 20; i++ {
+				_, _, err = e.LoadAndCall(host, code, "vest", "iost", "issuer0", "user0", "0.01", config(false))
+				So(err, ShouldBeNil)
+			}
+			So(len(host.DB().Vesting("iost", "user0")), ShouldEqual, 21)
+
+			host.Context().Set("time", now+45)
+			rs, cost0, err := e.LoadAndCall(host, code, "claim", "iost", "user0", int64(0))
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "6")
+			So(cost0.ToGas(), ShouldEqual, cost.ToGas())
+
+			_, _, err = e.LoadAndCall(host, code, "claim", "iost", "user0", int64(21))
+			So(err.Error(), ShouldEqual, "vesting 21 of user0 not exists")
+		})
+
+		Convey("revoke vesting", func() {
+			_, _, err := e.LoadAndCall(host, code, "vest", "iost", "issuer0", "user0", "60", config(false))
+			So(err, ShouldBeNil)
+			_, _, err = e.LoadAndCall(host, code, "vest", "iost", "issuer0", "user0", "20", config(true))
+			So(err, ShouldBeNil)
+
+			_, _, err = e.LoadAndCall(host, code, "revokeVesting", "iost", "user0", int64(0))
+			So(err.Error(), ShouldEqual, "vesting 0 is not revocable")
+
+			delete(authList, issuer0)
+			_, _, err = e.LoadAndCall(host, code, "revokeVesting", "iost", "user0", int64(1))
+			So(err.Error(), ShouldEqual, "transaction has no permission")
+
+			authList[issuer0] = 1
+			host.Context().Set("time", now+55)
+			_, _, err = e.LoadAndCall(host, code, "revokeVesting", "iost", "user0", int64(1))
+			So(err, ShouldBeNil)
+
+			rs, _, err := e.LoadAndCall(host, code, "balanceOf", "iost", "issuer0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "30")
+
+			rs, _, err = e.LoadAndCall(host, code, "balanceOf", "iost", "user0")
+			So(err, ShouldBeNil)
+			So(rs[0], ShouldEqual, "10")
+
+			vesting := host.DB().Vesting("iost", "user0")
+			So(len(vesting), ShouldEqual, 1)
+			So(vesting[0].ID, ShouldEqual, 0)
+		})
+
+		Convey("vest invalid config", func() {
+			_, _, err := e.LoadAndCall(host, code, "vest", "iost", "issuer0", "user0", "10", []byte(`{"duration": 100, "cliff": 200}`))
+			So(err.Error(), ShouldStartWith, "invalid vesting cliff")
+
+			_, _, err = e.LoadAndCall(host, code, "vest", "iost", "issuer0", "user0", "10", []byte(`{"duration": 0}`))
+			So(err.Error(), ShouldStartWith, "invalid vesting start")
+
+			_, _, err = e.LoadAndCall(host, code, "vest", "iost", "issuer0", "issuer0", "10", config(false))
+			So(err.Error(), ShouldEqual, "vest to self")
+
+			_, _, err = e.LoadAndCall(host, code, "vest", "iost", "issuer0", "user0", "101", config(false))
+			So(err.Error(), ShouldStartWith, "balance not enough")
+		})
+	})
+}
@@ -7,6 +7,18 @@ class crypto1 {
         return IOSTCrypto.sha3(msg);
     }
 
+    sha256(msg) {
+        return IOSTCrypto.sha256(msg);
+    }
+
+    verify(algo, msg, sig, pubkey) {
+        return IOSTCrypto.verify(algo, msg, sig, pubkey);
+    }
+
+    parsePubkey(pubkey) {
+        return JSON.parse(IOSTCrypto.parsePubkey(pubkey)).algorithm;
+    }
+
 }
 
 module.exports = crypto1;
\ No newline at end of file
@@ -1,6 +1,7 @@
 package v8vm
 
 import (
+	"crypto/sha256"
 	"io/ioutil"
 	"strings"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	. "github.com/golang/mock/gomock"
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/crypto"
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/vm/database"
 	"github.com/iost-official/go-iost/vm/host"
@@ -721,6 +723,47 @@ func TestEngine_Sha3(t *testing.T) {
 	}
 }
 
+func TestEngine_Verify(t *testing.T) {
+	host, code := MyInit(t, "crypto1")
+	testStr := "hello world"
+	rs, _, err := vmPool.LoadAndCall(host, code, "sha256", testStr)
+	if err != nil {
+		t.Fatalf("LoadAndCall sha256 error: %v", err)
+	}
+	digest := sha256.Sum256([]byte(testStr))
+	if rs[0] != common.Base58Encode(digest[:]) {
+		t.Fatalf("LoadAndCall sha256 invalid result")
+	}
+
+	seckey := crypto.Ed25519.GenSeckey()
+	msg := common.Sha3([]byte(testStr))
+	sig := crypto.Ed25519.Sign(msg, seckey)
+	rs, _, err = vmPool.LoadAndCall(host, code, "verify", "ed25519", common.Base58Encode(msg),
+		common.Base58Encode(sig), common.Base58Encode(crypto.Ed25519.GetPubkey(seckey)))
+	if err != nil {
+		t.Fatalf("LoadAndCall verify error: %v", err)
+	}
+	if rs[0] != "true" {
+		t.Fatalf("LoadAndCall verify invalid result: %v", rs[0])
+	}
+}
+
+func TestEngine_ParsePubkey(t *testing.T) {
+	host, code := MyInit(t, "crypto1")
+	pubkey := crypto.Secp256k1.GetPubkey(crypto.Secp256k1.GenSeckey())
+	rs, _, err := vmPool.LoadAndCall(host, code, "parsePubkey", common.Base58Encode(pubkey))
+	if err != nil {
+		t.Fatalf("LoadAndCall parsePubkey error: %v", err)
+	}
+	if rs[0] != "secp256k1" {
+		t.Fatalf("LoadAndCall parsePubkey invalid result: %v", rs[0])
+	}
+	_, _, err = vmPool.LoadAndCall(host, code, "parsePubkey", common.Base58Encode([]byte("abc")))
+	if err == nil || !strings.Contains(err.Error(), "invalid public key") {
+		t.Fatalf("LoadAndCall parsePubkey should fail: %v", err)
+	}
+}
+
 func TestEngine_ArrayOfFrom(t *testing.T) {
 	host, code := MyInit(t, "arrayfunc")
 	_, _, err := vmPool.LoadAndCall(host, code, "from")
@@ -839,3 +882,41 @@ func TestEngine_JSON(t *testing.T) {
 		So(rtn[0], ShouldEqual, `{"a":{"b":{"c":""}}} {"a":{"b":{"c":""}}}`)
 	})
 }
+
+func TestEngine_Lint(t *testing.T) {
+	Convey("test lint", t, func() {
+		code := &contract.Contract{
+			ID: "lint",
+			Code: `class Contract {
+    init() {}
+    now() {
+        return Date.now();
+    }
+}
+module.exports = Contract;`,
+			Info: &contract.Info{
+				Lang:    "javascript",
+				Version: "1.0.0",
+				Abi:     []*contract.ABI{{Name: "now"}},
+			},
+		}
+		So(vmPool.Validate(code), ShouldBeNil)
+
+		code.Info.Lint = true
+		err := vmPool.Validate(code)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "4:15 no-date")
+
+		code.Code = strings.Replace(code.Code, "Date.now()", `JSON.parse(blockchain.blockInfo()).time`, 1)
+		So(vmPool.Validate(code), ShouldBeNil)
+
+		code.Code = strings.Replace(code.Code, `JSON.parse(blockchain.blockInfo()).time`, `JSON.parse(blockchain.blockInfo()).time / 1000`, 1)
+		err = vmPool.Validate(code)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "4:15 no-float")
+
+		code.Code = strings.Replace(code.Code, `JSON.parse(blockchain.blockInfo()).time / 1000`,
+			`new BigNumber(JSON.parse(blockchain.blockInfo()).time) / new BigNumber(1000)`, 1)
+		So(vmPool.Validate(code), ShouldBeNil)
+	})
+}
@@ -222,6 +222,35 @@ func (s *Simulator) RunTx(stx *tx.Tx) (*tx.TxReceipt, error) {
 	return r, nil
 }
 
+// RunBlockBase run tx as the block base tx, which runs the calls scheduled by contracts due at the head as well
+func (s *Simulator) RunBlockBase(contractName, abi, args string) (*tx.TxReceipt, error) {
+	var isolator vm.Isolator
+	trx := tx.NewTx([]*tx.Action{{
+		Contract:   contractName,
+		ActionName: abi,
+		Data:       args,
+	}}, nil, s.GasLimit, 100, s.Head.Time+10000000, 0)
+	trx.Time = s.Head.Time
+	trx.Publisher = "base.iost"
+
+	err := isolator.Prepare(s.Head, s.Visitor, s.Logger)
+	if err != nil {
+		return &tx.TxReceipt{}, err
+	}
+	isolator.TriggerBlockBaseMode()
+	err = isolator.PrepareTx(trx, time.Second)
+	if err != nil {
+		return &tx.TxReceipt{}, fmt.Errorf("prepare tx error: %v", err)
+	}
+	r, err := isolator.Run()
+	if err != nil {
+		return &tx.TxReceipt{}, err
+	}
+	isolator.Commit()
+	isolator.ClearTx()
+	return r, nil
+}
+
 // Clear mvccdb
 func (s *Simulator) Clear() {
 	s.Mvcc.Close()
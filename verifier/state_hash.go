@@ -0,0 +1,131 @@
+package verifier
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/vm/database"
+)
+
+type stateValue struct {
+	table string
+	key   string
+	value string
+	exist bool
+}
+
+func (v *stateValue) equal(o *stateValue) bool {
+	return v.exist == o.exist && v.value == o.value
+}
+
+// stateRecorder records the state changes of a block made through it.
+// Only the difference between the state before and after the block is hashed,
+// so the state hash does not depend on the order or the number of the writes.
+type stateRecorder struct {
+	database.IMultiValue
+	mu        sync.Mutex
+	origins   map[string]*stateValue
+	committed map[string]*stateValue
+	pending   map[string]*stateValue
+}
+
+func newStateRecorder(db database.IMultiValue) *stateRecorder {
+	return &stateRecorder{
+		IMultiValue: db,
+		origins:     make(map[string]*stateValue),
+		committed:   make(map[string]*stateValue),
+		pending:     make(map[string]*stateValue),
+	}
+}
+
+func (r *stateRecorder) record(table string, key string, value string, exist bool) error {
+	k := table + "/" + key
+	if _, ok := r.origins[k]; !ok {
+		has, err := r.IMultiValue.Has(table, key)
+		if err != nil {
+			return err
+		}
+		v, err := r.IMultiValue.Get(table, key)
+		if err != nil {
+			return err
+		}
+		r.origins[k] = &stateValue{table: table, key: key, value: v, exist: has}
+	}
+	r.pending[k] = &stateValue{table: table, key: key, value: value, exist: exist}
+	return nil
+}
+
+// Put puts the key-value pair and records it
+func (r *stateRecorder) Put(table string, key string, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(table, key, value, true); err != nil {
+		return err
+	}
+	return r.IMultiValue.Put(table, key, value)
+}
+
+// Del deletes the key and records it
+func (r *stateRecorder) Del(table string, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(table, key, "", false); err != nil {
+		return err
+	}
+	return r.IMultiValue.Del(table, key)
+}
+
+// Commit commits the recorded changes
+func (r *stateRecorder) Commit() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, v := range r.pending {
+		r.committed[k] = v
+	}
+	r.pending = make(map[string]*stateValue)
+	r.IMultiValue.Commit()
+}
+
+// Rollback drops the changes after the last commit
+func (r *stateRecorder) Rollback() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = make(map[string]*stateValue)
+	r.IMultiValue.Rollback()
+}
+
+// Hash returns the state hash chained from the parent state hash.
+func (r *stateRecorder) Hash(parent []byte) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changes := make(map[string]*stateValue, len(r.committed)+len(r.pending))
+	for k, v := range r.committed {
+		changes[k] = v
+	}
+	for k, v := range r.pending {
+		changes[k] = v
+	}
+	keys := make([]string, 0, len(changes))
+	for k, v := range changes {
+		if !v.equal(r.origins[k]) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	se := common.NewSimpleEncoder()
+	se.WriteBytes(parent)
+	for _, k := range keys {
+		v := changes[k]
+		se.WriteString(v.table)
+		se.WriteString(v.key)
+		if v.exist {
+			se.WriteByte(1)
+		} else {
+			se.WriteByte(0)
+		}
+		se.WriteString(v.value)
+	}
+	return common.Sha3(se.Bytes())
+}
@@ -0,0 +1,57 @@
+package verifier
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/iost-official/go-iost/db"
+)
+
+func TestStateRecorder(t *testing.T) {
+	mvccdb, err := db.NewMVCCDB("statehash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll("statehash")
+	defer mvccdb.Close()
+	mvccdb.Put("state", "a", "1")
+	mvccdb.Put("state", "b", "2")
+	mvccdb.Commit()
+
+	r1 := newStateRecorder(mvccdb.Fork())
+	r1.Put("state", "a", "3")
+	r1.Put("state", "c", "4")
+	r1.Del("state", "b")
+	r1.Put("state", "d", "5")
+	r1.Rollback()
+	r1.Put("state", "a", "4")
+	r1.Commit()
+	r1.Put("state", "c", "4")
+	r1.Del("state", "b")
+
+	// same changes in another order, with writes that don't change the state
+	r2 := newStateRecorder(mvccdb.Fork())
+	r2.Del("state", "b")
+	r2.Put("state", "c", "4")
+	r2.Put("state", "e", "6")
+	r2.Del("state", "e")
+	r2.Put("state", "a", "1")
+	r2.Put("state", "a", "4")
+
+	parent := []byte("parent")
+	h1 := r1.Hash(parent)
+	if !bytes.Equal(h1, r2.Hash(parent)) {
+		t.Fatal("state hash should only depend on state changes")
+	}
+	if bytes.Equal(h1, r1.Hash([]byte("other"))) {
+		t.Fatal("state hash should depend on parent")
+	}
+	r2.Put("state", "a", "5")
+	if bytes.Equal(h1, r2.Hash(parent)) {
+		t.Fatal("state hash should change with state")
+	}
+	if v, _ := r1.Get("state", "a"); v != "4" {
+		t.Fatalf("expect 4, got %v", v)
+	}
+}
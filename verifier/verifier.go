@@ -1,6 +1,7 @@
 package verifier
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,12 +15,15 @@ import (
 	"github.com/iost-official/go-iost/ilog"
 	"github.com/iost-official/go-iost/vm"
 	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
 )
 
 // values
 var (
 	ErrExpiredTx    = errors.New("expired tx")
 	ErrNotArrivedTx = errors.New("not arrived tx")
+	ErrStateHash    = errors.New("wrong state hash")
+	ErrHeadVersion  = errors.New("block head version is lower than required")
 )
 
 // Verifier ..
@@ -36,9 +40,10 @@ type Config struct {
 
 // Info info in block
 type Info struct {
-	Mode   int   `json:"mode"`
-	Thread int   `json:"thread"`
-	Batch  []int `json:"batch"`
+	Mode   int    `json:"mode"`
+	Thread int    `json:"thread"`
+	Batch  []int  `json:"batch"`
+	Proof  []byte `json:"proof,omitempty"`
 }
 
 //var ParallelMask int64 = 1 // 0000 0001
@@ -87,8 +92,55 @@ func (v *Verifier) Try(bh *block.BlockHead, db database.IMultiValue, t *tx.Tx, l
 	return r, err
 }
 
+// Trace re-executes the txs of blk on the state of its parent block until the tx of txHash,
+// and returns the receipt and the call tree of that tx. The db is changed, so a forked db should be used.
+func (v *Verifier) Trace(blk *block.Block, db database.IMultiValue, txHash []byte, c *Config) (*tx.TxReceipt, *host.Tracer, error) {
+	isolator := vm.Isolator{}
+	vi := database.NewVisitor(100, db)
+	err := isolator.Prepare(blk.Head, vi, getLogger(false))
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, t := range blk.Txs {
+		isolator.ClearTx()
+		if i == 0 {
+			isolator.TriggerBlockBaseMode()
+		}
+		var tracer *host.Tracer
+		if bytes.Equal(t.Hash(), txHash) {
+			tracer = host.NewTracer()
+			isolator.SetTracer(tracer)
+		}
+		err = isolator.PrepareTx(t, c.TxTimeLimit)
+		if err != nil {
+			return nil, nil, err
+		}
+		r, err := isolator.Run()
+		if err != nil {
+			return nil, nil, err
+		}
+		if i > 0 {
+			r, err = isolator.PayCost()
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		if tracer != nil {
+			isolator.SetTracer(nil)
+			return r, tracer, nil
+		}
+		isolator.Commit()
+	}
+	return nil, nil, fmt.Errorf("tx %v not found in block %v", common.Base58Encode(txHash), blk.Head.Number)
+}
+
 // Gen gen block
 func (v *Verifier) Gen(blk *block.Block, parent *block.Block, db database.IMultiValue, iter *txpool.SortedTxMap, c *Config) (droplist []*tx.Tx, errs []error, err error) {
+	var recorder *stateRecorder
+	if blk.Head.Version >= block.V1 {
+		recorder = newStateRecorder(db)
+		db = recorder
+	}
 	isolator := &vm.Isolator{}
 	baseTx, err := NewBaseTx(blk, parent)
 	if err != nil {
@@ -106,16 +158,26 @@ func (v *Verifier) Gen(blk *block.Block, parent *block.Block, db database.IMulti
 		err = baseGen(blk, db, pi, isolator, c)
 		droplist, errs = pi.List()
 		pi.Close()
-		return
 	case 1:
 		batcher := NewBatcher()
 		err = batchGen(blk, db, pi, batcher, c)
 		droplist, errs = pi.List()
 		pi.Close()
-		return
+	default:
+		pi.Close()
+		return []*tx.Tx{}, []error{}, fmt.Errorf("mode unexpected: %v", c.Mode)
+	}
+	if err == nil && recorder != nil {
+		blk.Head.StateHash = recorder.Hash(parentStateHash(parent))
+	}
+	return
+}
+
+func parentStateHash(parent *block.Block) []byte {
+	if parent == nil {
+		return nil
 	}
-	pi.Close()
-	return []*tx.Tx{}, []error{}, fmt.Errorf("mode unexpected: %v", c.Mode)
+	return parent.Head.StateHash
 }
 
 func blockBaseExec(blk *block.Block, db database.IMultiValue, isolator *vm.Isolator, t *tx.Tx, c *Config) (tr *tx.TxReceipt, err error) {
@@ -142,7 +204,8 @@ func blockBaseExec(blk *block.Block, db database.IMultiValue, isolator *vm.Isola
 // nolint:gocyclo
 func baseGen(blk *block.Block, db database.IMultiValue, provider Provider, isolator *vm.Isolator, c *Config) (err error) {
 	info := Info{
-		Mode: 0,
+		Mode:  0,
+		Proof: blk.Head.Proof(),
 	}
 	var tn time.Time
 	to := time.Now().Add(c.Timeout)
@@ -235,6 +298,7 @@ func batchGen(blk *block.Block, db database.IMultiValue, provider Provider, batc
 		Mode:   1,
 		Thread: c.Thread,
 		Batch:  make([]int, 0),
+		Proof:  blk.Head.Proof(),
 	}
 	tn := time.Now()
 	to := time.Now().Add(c.Timeout)
@@ -271,8 +335,25 @@ func getLogger(enableContractLog bool) *ilog.Logger {
 	return ilog.DefaultLogger()
 }
 
-// Verify verify block generated by Verifier
+// Verify verify block generated by Verifier, the state hash is checked since block head V1
 func (v *Verifier) Verify(blk *block.Block, parent *block.Block, db database.IMultiValue, c *Config) error {
+	if blk.Head.Version < block.HeadVersion(blk.Head.Number) {
+		return ErrHeadVersion
+	}
+	if blk.Head.Version < block.V1 {
+		return verifyBlock(blk, parent, db, c)
+	}
+	recorder := newStateRecorder(db)
+	if err := verifyBlock(blk, parent, recorder, c); err != nil {
+		return err
+	}
+	if !bytes.Equal(recorder.Hash(parentStateHash(parent)), blk.Head.StateHash) {
+		return ErrStateHash
+	}
+	return nil
+}
+
+func verifyBlock(blk *block.Block, parent *block.Block, db database.IMultiValue, c *Config) error {
 	ri := blk.Head.Info
 	var info Info
 	err := json.Unmarshal(ri, &info)
@@ -6,6 +6,7 @@ import (
 
 	"os"
 
+	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/block"
 	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/core/txpool"
@@ -59,4 +60,16 @@ func TestVerifier_Gen(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+
+	block.SetForkConfig(&common.ForkConfig{V1Height: 1})
+	defer block.SetForkConfig(nil)
+	blk.Head.Number = 1
+	err = v.Verify(&blk, nil, mvccdb, &Config{
+		Mode:        0,
+		Timeout:     time.Second,
+		TxTimeLimit: time.Millisecond * 100,
+	})
+	if err != ErrHeadVersion {
+		t.Fatal(err)
+	}
 }
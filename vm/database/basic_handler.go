@@ -8,9 +8,16 @@ type BasicHandler struct {
 	db database
 }
 
+func (m *BasicHandler) index() *keyIndex {
+	return &keyIndex{db: m.db, prefix: BasicIndexPrefix}
+}
+
 // Put put to k-v
 func (m *BasicHandler) Put(key, value string) {
 	//fmt.Println("basic put,", key, value)
+	if owner, k, ok := splitOwner(key); ok && indexMarked(m.db, owner) && m.index().enabled(owner) && !m.Has(key) {
+		m.index().add(owner, k)
+	}
 	m.db.Put(BasicPrefix+key, value)
 }
 
@@ -27,5 +34,46 @@ func (m *BasicHandler) Has(key string) bool {
 
 // Del del key, if key is nil do nothing
 func (m *BasicHandler) Del(key string) {
+	if owner, k, ok := splitOwner(key); ok && indexMarked(m.db, owner) {
+		m.index().remove(owner, k)
+	}
 	m.db.Del(BasicPrefix + key)
 }
+
+// KeysIndexed returns whether the keys of the contract are indexed
+func (m *BasicHandler) KeysIndexed(contractName string) bool {
+	return indexMarked(m.db, contractName) && m.index().enabled(contractName)
+}
+
+// KeyIndexed returns whether key is in the index of its contract
+func (m *BasicHandler) KeyIndexed(key string) bool {
+	owner, k, ok := splitOwner(key)
+	return ok && indexMarked(m.db, owner) && m.index().has(owner, k)
+}
+
+// EnableKeys starts indexing the keys of the contract, the keys written before should be added by IndexKey
+func (m *BasicHandler) EnableKeys(contractName string) {
+	markIndex(m.db, contractName)
+	m.index().enable(contractName)
+}
+
+// IndexKey adds an existing key to the index of its contract, returns whether it's added
+func (m *BasicHandler) IndexKey(key string) bool {
+	owner, k, ok := splitOwner(key)
+	if !ok || !indexMarked(m.db, owner) || !m.index().enabled(owner) || !m.Has(key) || m.index().has(owner, k) {
+		return false
+	}
+	m.index().add(owner, k)
+	return true
+}
+
+// Keys lists the keys of the contract from offset, at most limit keys.
+// The order is stable until a key is deleted, which moves the last key to its position.
+func (m *BasicHandler) Keys(contractName string, offset, limit int) []string {
+	return m.index().list(contractName, offset, limit)
+}
+
+// KeysLen returns the number of keys of the contract
+func (m *BasicHandler) KeysLen(contractName string) int {
+	return m.index().count(contractName)
+}
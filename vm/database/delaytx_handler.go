@@ -1,7 +1,16 @@
 package database
 
+import (
+	"encoding/json"
+	"strconv"
+)
+
 const (
-	delaytxPrefix = "t-"
+	delaytxPrefix       = "t-"
+	scheduledCallPrefix = "tc-"
+	scheduledSlotPrefix = "ts-"
+	scheduledNextKey    = "tn"
+	scheduledIDKey      = "ti"
 )
 
 // DelaytxHandler handler of delay tx
@@ -9,6 +18,23 @@ type DelaytxHandler struct {
 	db database
 }
 
+// ScheduledCall is a call of a contract api scheduled by the contract, it's run in the block base from Time.
+// The calls are queued in the slots of the seconds, a call is in the slot of the first second not before Time.
+type ScheduledCall struct {
+	ID       string `json:"id"`
+	Contract string `json:"contract"`
+	API      string `json:"api"`
+	Args     string `json:"args"`
+	Time     int64  `json:"time"`
+	GasLimit int64  `json:"gas_limit"`
+	Payer    string `json:"payer"`
+}
+
+// Slot returns the slot of the call.
+func (c *ScheduledCall) Slot() int64 {
+	return (c.Time + 1e9 - 1) / 1e9
+}
+
 func (m *DelaytxHandler) delaytxKey(txHash string) string {
 	return delaytxPrefix + txHash
 }
@@ -32,3 +58,73 @@ func (m *DelaytxHandler) HasDelaytx(txHash string) bool {
 func (m *DelaytxHandler) DelDelaytx(txHash string) {
 	m.db.Del(m.delaytxKey(txHash))
 }
+
+// NewScheduledCallID returns the id of a new scheduled call.
+func (m *DelaytxHandler) NewScheduledCallID() string {
+	id, _ := strconv.ParseInt(m.db.Get(scheduledIDKey), 10, 64)
+	m.db.Put(scheduledIDKey, strconv.FormatInt(id+1, 10))
+	return strconv.FormatInt(id, 10)
+}
+
+// ScheduledCall returns the scheduled call of the id, nil if it doesn't exist.
+func (m *DelaytxHandler) ScheduledCall(id string) *ScheduledCall {
+	v := m.db.Get(scheduledCallPrefix + id)
+	if v == NilPrefix {
+		return nil
+	}
+	var c ScheduledCall
+	if err := json.Unmarshal([]byte(v), &c); err != nil {
+		return nil
+	}
+	return &c
+}
+
+// StoreScheduledCall stores the call and adds it to the end of its slot, returns the length of the stored call.
+func (m *DelaytxHandler) StoreScheduledCall(c *ScheduledCall) int {
+	data, _ := json.Marshal(c)
+	m.db.Put(scheduledCallPrefix+c.ID, string(data))
+	m.SetScheduledSlot(c.Slot(), append(m.ScheduledSlot(c.Slot()), c.ID))
+	return len(data)
+}
+
+// DelScheduledCall deletes the call, the caller should remove it from its slot, returns the length of the deleted call.
+func (m *DelaytxHandler) DelScheduledCall(id string) int {
+	v := m.db.Get(scheduledCallPrefix + id)
+	if v == NilPrefix {
+		return 0
+	}
+	m.db.Del(scheduledCallPrefix + id)
+	return len(v)
+}
+
+// ScheduledSlot returns the ids of the calls in the slot.
+func (m *DelaytxHandler) ScheduledSlot(slot int64) []string {
+	v := m.db.Get(scheduledSlotPrefix + strconv.FormatInt(slot, 10))
+	ids := make([]string, 0)
+	if v != NilPrefix {
+		json.Unmarshal([]byte(v), &ids) // nolint: errcheck
+	}
+	return ids
+}
+
+// SetScheduledSlot sets the ids of the calls in the slot, the slot is deleted if it's empty.
+func (m *DelaytxHandler) SetScheduledSlot(slot int64, ids []string) {
+	key := scheduledSlotPrefix + strconv.FormatInt(slot, 10)
+	if len(ids) == 0 {
+		m.db.Del(key)
+		return
+	}
+	data, _ := json.Marshal(ids)
+	m.db.Put(key, string(data))
+}
+
+// NextScheduledSlot returns the first slot not run, 0 if no call has been scheduled.
+func (m *DelaytxHandler) NextScheduledSlot() int64 {
+	next, _ := strconv.ParseInt(m.db.Get(scheduledNextKey), 10, 64)
+	return next
+}
+
+// SetNextScheduledSlot sets the first slot not run.
+func (m *DelaytxHandler) SetNextScheduledSlot(slot int64) {
+	m.db.Put(scheduledNextKey, strconv.FormatInt(slot, 10))
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"strconv"
+	"strings"
+)
+
+// prefixes of the key indexes
+const (
+	BasicIndexPrefix  = "ib-"
+	MapIndexPrefix    = "im-"
+	IndexMarkerPrefix = "ix-"
+)
+
+// keyIndex is the list of the keys of an owner, it's used to iterate the keys by pages.
+// The keys are kept in a dense array, removing a key moves the last key to its position.
+// An owner has no index until it's enabled, the keys written before that are added one by one.
+//
+//	<prefix><owner>              number of keys, it exists once the index is enabled
+//	<prefix><owner>-<n>          the n-th key
+//	<prefix><owner>@<key>        position of key
+//
+// A contract with any index enabled has the marker ix-<contract>, the writes of other contracts skip the index.
+type keyIndex struct {
+	db     database
+	prefix string
+}
+
+func (i *keyIndex) count(owner string) int {
+	v := i.db.Get(i.prefix + owner)
+	if v == NilPrefix {
+		return 0
+	}
+	n, _ := strconv.Atoi(v)
+	return n
+}
+
+func (i *keyIndex) enabled(owner string) bool {
+	return i.db.Has(i.prefix + owner)
+}
+
+func (i *keyIndex) enable(owner string) {
+	if !i.enabled(owner) {
+		i.db.Put(i.prefix+owner, "0")
+	}
+}
+
+func (i *keyIndex) has(owner, key string) bool {
+	return i.db.Has(i.position(owner, key))
+}
+
+func (i *keyIndex) slot(owner string, n int) string {
+	return i.prefix + owner + Separator + strconv.Itoa(n)
+}
+
+func (i *keyIndex) position(owner, key string) string {
+	return i.prefix + owner + ApplicationSeparator + key
+}
+
+// add appends key to the index, the caller should make sure that key isn't in the index
+func (i *keyIndex) add(owner, key string) {
+	n := i.count(owner)
+	i.db.Put(i.slot(owner, n), key)
+	i.db.Put(i.position(owner, key), strconv.Itoa(n))
+	i.db.Put(i.prefix+owner, strconv.Itoa(n+1))
+}
+
+func (i *keyIndex) remove(owner, key string) {
+	pk := i.position(owner, key)
+	v := i.db.Get(pk)
+	if v == NilPrefix {
+		return
+	}
+	pos, _ := strconv.Atoi(v)
+	last := i.count(owner) - 1
+	if pos != last {
+		lastKey := i.db.Get(i.slot(owner, last))
+		i.db.Put(i.slot(owner, pos), lastKey)
+		i.db.Put(i.position(owner, lastKey), strconv.Itoa(pos))
+	}
+	i.db.Del(i.slot(owner, last))
+	i.db.Del(pk)
+	i.db.Put(i.prefix+owner, strconv.Itoa(last))
+}
+
+func (i *keyIndex) list(owner string, offset, limit int) []string {
+	n := i.count(owner)
+	keys := make([]string, 0)
+	for j := offset; j < n && j < offset+limit; j++ {
+		keys = append(keys, i.db.Get(i.slot(owner, j)))
+	}
+	return keys
+}
+
+// indexMarked returns whether any index of the contract is enabled
+func indexMarked(db database, contractName string) bool {
+	return db.Has(IndexMarkerPrefix + contractName)
+}
+
+func markIndex(db database, contractName string) {
+	if !indexMarked(db, contractName) {
+		db.Put(IndexMarkerPrefix+contractName, "1")
+	}
+}
+
+// splitOwner splits the key of a contract into the contract and the key in the contract
+func splitOwner(key string) (owner, k string, ok bool) {
+	idx := strings.Index(key, Separator)
+	if idx <= 0 {
+		return "", "", false
+	}
+	return key[:idx], key[idx+1:], true
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+)
+
+func TestKeyIndex(t *testing.T) {
+	mvccdb, err := db.NewMVCCDBWithStorage("", kv.MemoryStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mvccdb.Close()
+	v := NewVisitor(100, mvccdb)
+
+	v.Put("Contractabc-old", "sold")
+	v.EnableKeys("Contractabc")
+	v.EnableKeys("Contractdef")
+	for _, k := range []string{"a", "b", "c", "d"} {
+		v.Put("Contractabc-"+k, "s"+k)
+	}
+	v.Put("Contractabc-a", "snew")
+	v.Put("Contractdef-a", "sa")
+	v.Commit()
+
+	if n := v.KeysLen("Contractabc"); n != 4 || v.KeyIndexed("Contractabc-old") {
+		t.Fatal(n)
+	}
+	if !v.IndexKey("Contractabc-old") || v.IndexKey("Contractabc-old") || v.IndexKey("Contractabc-x") {
+		t.Fatal("index old key")
+	}
+	v.Del("Contractabc-old")
+	if keys := v.Keys("Contractabc", 1, 2); !reflect.DeepEqual(keys, []string{"b", "c"}) {
+		t.Fatal(keys)
+	}
+	if keys := v.Keys("Contractabc", 3, 10); !reflect.DeepEqual(keys, []string{"d"}) {
+		t.Fatal(keys)
+	}
+
+	v.Del("Contractabc-b")
+	v.Del("Contractabc-x")
+	keys := v.Keys("Contractabc", 0, 10)
+	if !reflect.DeepEqual(keys, []string{"a", "d", "c"}) {
+		t.Fatal(keys)
+	}
+	for _, k := range keys {
+		v.Del("Contractabc-" + k)
+	}
+	if n := v.KeysLen("Contractabc"); n != 0 || !v.KeysIndexed("Contractabc") {
+		t.Fatal(n)
+	}
+	v.Put("Contractxyz-a", "sa")
+	if v.KeysIndexed("Contractxyz") || v.KeysLen("Contractxyz") != 0 || v.MFieldsIndexed("Contractxyz-m") {
+		t.Fatal("keys of Contractxyz shouldn't be indexed")
+	}
+	if keys := v.Keys("Contractdef", 0, 10); !reflect.DeepEqual(keys, []string{"a"}) {
+		t.Fatal(keys)
+	}
+
+	for i := 0; i < 300; i++ {
+		v.MPut("Contractabc-m", string(rune('A'+i%26))+string(rune('a'+i/26)), "s")
+	}
+	if !v.MFieldsIndexed("Contractabc-m") || v.MFieldsIndexed("Contractxyz-m") {
+		t.Fatal("fields of Contractabc-m should be indexed")
+	}
+	v.MDel("Contractabc-m", "Aa")
+	if n := v.MFieldsLen("Contractabc-m"); n != 299 {
+		t.Fatal(n)
+	}
+	fields := make([]string, 0)
+	for i := 0; i < 299; i += 100 {
+		fields = append(fields, v.MFields("Contractabc-m", i, 100)...)
+	}
+	sort.Strings(fields)
+	if len(fields) != 299 || fields[0] != "Ab" {
+		t.Fatal(len(fields), fields[0])
+	}
+
+	v.MPut("Contractxyz-m", "a", "s")
+	v.MPut("Contractxyz-m", "b", "s")
+	v.EnableMFields("Contractxyz-m")
+	v.MPut("Contractxyz-m", "c", "s")
+	if !v.IndexMField("Contractxyz-m", "a") || v.IndexMField("Contractxyz-m", "c") || v.IndexMField("Contractxyz-m", "x") {
+		t.Fatal("index old field")
+	}
+	if fields := v.MFields("Contractxyz-m", 0, 10); !reflect.DeepEqual(fields, []string{"c", "a"}) || v.MFieldIndexed("Contractxyz-m", "b") {
+		t.Fatal(fields)
+	}
+	if v.KeysIndexed("Contractxyz") || !indexMarked(v.BasicHandler.db, "Contractxyz") || indexMarked(v.BasicHandler.db, "Contractuvw") {
+		t.Fatal("index marker of Contractxyz")
+	}
+}
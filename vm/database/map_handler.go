@@ -21,14 +21,22 @@ const ApplicationSeparator = "@"
 // MPut put value in kfv storage o(1)
 func (m *MapHandler) MPut(key, field, value string) {
 	//fmt.Println("map put,", key, field, value)
-	m.addField(key, field)
+	if !m.MHas(key, field) {
+		indexed := m.MFieldsIndexed(key)
+		m.addField(key, field)
+		if indexed {
+			m.index().enable(key)
+			m.index().add(key, field)
+		}
+	}
 	m.db.Put(MapPrefix+key+Separator+field, value)
 }
 
+func (m *MapHandler) index() *keyIndex {
+	return &keyIndex{db: m.db, prefix: MapIndexPrefix}
+}
+
 func (m *MapHandler) addField(key, field string) {
-	if m.MHas(key, field) {
-		return
-	}
 	s := m.db.Get(MapPrefix + key)
 	if s == "n" {
 		m.db.Put(MapPrefix+key, ApplicationSeparator+field)
@@ -77,4 +85,53 @@ func (m *MapHandler) MDel(key, field string) {
 	}
 	m.db.Del(MapPrefix + key + Separator + field)
 	m.delField(key, field)
+	if owner, _, ok := splitOwner(key); ok && indexMarked(m.db, owner) {
+		m.index().remove(key, field)
+	}
+}
+
+// MFieldsIndexed returns whether the fields of map are indexed,
+// a new map of a contract whose keys are indexed is indexed from its first field.
+func (m *MapHandler) MFieldsIndexed(key string) bool {
+	owner, _, ok := splitOwner(key)
+	if !ok || !indexMarked(m.db, owner) {
+		return false
+	}
+	if m.index().enabled(key) {
+		return true
+	}
+	return (&keyIndex{db: m.db, prefix: BasicIndexPrefix}).enabled(owner) && !m.db.Has(MapPrefix+key)
+}
+
+// MFieldIndexed returns whether field is in the index of map
+func (m *MapHandler) MFieldIndexed(key, field string) bool {
+	return m.index().has(key, field)
+}
+
+// EnableMFields starts indexing the fields of map, the fields written before should be added by IndexMField
+func (m *MapHandler) EnableMFields(key string) {
+	if owner, _, ok := splitOwner(key); ok {
+		markIndex(m.db, owner)
+	}
+	m.index().enable(key)
+}
+
+// IndexMField adds an existing field to the index of map, returns whether it's added
+func (m *MapHandler) IndexMField(key, field string) bool {
+	if !m.MHas(key, field) || !m.index().enabled(key) || m.index().has(key, field) {
+		return false
+	}
+	m.index().add(key, field)
+	return true
+}
+
+// MFields lists the fields of map from offset, at most limit fields. Unlike MKeys, it isn't limited by the number of fields.
+// The order is stable until a field is deleted, which moves the last field to its position.
+func (m *MapHandler) MFields(key string, offset, limit int) []string {
+	return m.index().list(key, offset, limit)
+}
+
+// MFieldsLen returns the number of fields of map
+func (m *MapHandler) MFieldsLen(key string) int {
+	return m.index().count(key)
 }
@@ -25,6 +25,15 @@ func (m *Token721Handler) metadataKey(tokenName, acc, tokenID string) string {
 func (m *Token721Handler) ownerKey(tokenName, tokenID string) string {
 	return "m-" + Token721ContractName + "-" + "T721I" + tokenName + "-" + tokenID
 }
+func (m *Token721Handler) approvalKey(tokenName, tokenID string) string {
+	return "m-" + Token721ContractName + "-" + "T721A" + tokenName + "-" + tokenID
+}
+func (m *Token721Handler) operatorKey(tokenName, owner, operator string) string {
+	return "m-" + Token721ContractName + "-" + "T721O" + tokenName + "#" + owner + "-" + operator
+}
+func (m *Token721Handler) supplyMapKey(tokenName string) string {
+	return Token721ContractName + "-" + "T721S" + tokenName
+}
 
 // Token721Balance get token balance of acc
 func (m *Token721Handler) Token721Balance(tokenName, acc string) int64 {
@@ -69,3 +78,32 @@ func (m *Token721Handler) Token721Owner(tokenName, tokenID string) (string, erro
 	}
 	return owner, nil
 }
+
+// Token721Approved get the account approved to transfer tokenID, it's empty if there is none
+func (m *Token721Handler) Token721Approved(tokenName, tokenID string) (string, error) {
+	if _, err := m.Token721Owner(tokenName, tokenID); err != nil {
+		return "", err
+	}
+	approved, _ := Unmarshal(m.db.Get(m.approvalKey(tokenName, tokenID))).(string)
+	return approved, nil
+}
+
+// Token721IsApprovedForAll returns whether operator can transfer all tokens of owner
+func (m *Token721Handler) Token721IsApprovedForAll(tokenName, owner, operator string) bool {
+	return m.db.Has(m.operatorKey(tokenName, owner, operator))
+}
+
+// Token721Supply get the number of tokens issued and not burned
+func (m *Token721Handler) Token721Supply(tokenName string) int64 {
+	// the counters of issued and burned tokens are in the info map with the owners
+	issued, _ := Unmarshal(m.db.Get(m.ownerKey(tokenName, "supply"))).(int64)
+	burned, _ := Unmarshal(m.db.Get(m.ownerKey(tokenName, "burned"))).(int64)
+	return issued - burned
+}
+
+// Token721TokenIDs lists ids of tokens issued and not burned from offset, at most limit ids.
+// The tokens issued before token721.iost 1.1.0 are listed after the issuer calls indexTokens.
+func (m *Token721Handler) Token721TokenIDs(tokenName string, offset, limit int) []string {
+	mh := &MapHandler{db: m.db}
+	return mh.MFields(m.supplyMapKey(tokenName), offset, limit)
+}
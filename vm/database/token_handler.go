@@ -3,6 +3,8 @@ package database
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
+
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/ilog"
 )
@@ -10,6 +12,8 @@ import (
 // TokenContractName name of basic token contract
 const TokenContractName = "token.iost"
 
+const vestingPage = 1000
+
 // TokenHandler easy to get balance of token.iost
 type TokenHandler struct {
 	db database
@@ -27,6 +31,34 @@ type FreezeItemFixed struct {
 	Ftime  int64
 }
 
+// VestingItem is a vesting schedule granted to the holder. Amount is released every Interval
+// linearly from Start to Start+Duration, and nothing is released before Start+Cliff.
+type VestingItem struct {
+	ID        int64
+	Grantor   string
+	Amount    int64
+	Claimed   int64
+	Start     int64
+	Cliff     int64
+	Duration  int64
+	Interval  int64
+	Revocable bool
+}
+
+// Vested returns the amount released at time t, including the claimed amount
+func (v *VestingItem) Vested(t int64) int64 {
+	if t < v.Start+v.Cliff {
+		return 0
+	}
+	elapsed := t - v.Start
+	if elapsed >= v.Duration {
+		return v.Amount
+	}
+	elapsed -= elapsed % v.Interval
+	vested := new(big.Int).Mul(big.NewInt(v.Amount), big.NewInt(elapsed))
+	return vested.Div(vested, big.NewInt(v.Duration)).Int64()
+}
+
 func (m *TokenHandler) balanceKey(tokenName, acc string) string {
 	return "m-" + TokenContractName + "-" + "TB" + acc + "-" + tokenName
 }
@@ -35,6 +67,14 @@ func (m *TokenHandler) freezedBalanceKey(tokenName, acc string) string {
 	return "m-" + TokenContractName + "-" + "TF" + acc + "-" + tokenName
 }
 
+func (m *TokenHandler) vestingMapKey(tokenName, acc string) string {
+	return TokenContractName + "-" + "TV" + tokenName + "#" + acc
+}
+
+func (m *TokenHandler) infoKey(tokenName, field string) string {
+	return "m-" + TokenContractName + "-" + "TI" + tokenName + "-" + field
+}
+
 func (m *TokenHandler) decimalKey(tokenName string) string {
 	key := "m-" + TokenContractName + "-" + "TI" + tokenName + "-" + "decimal"
 	return key
@@ -134,3 +174,86 @@ func (m *TokenHandler) Decimal(tokenName string) int {
 	}
 	return int(decimal)
 }
+
+// TokenInfo is the information of token in token.iost
+type TokenInfo struct {
+	Symbol                string
+	FullName              string
+	Issuer                string
+	TotalSupply           *common.Fixed
+	CurrentSupply         *common.Fixed
+	Decimal               int
+	CanTransfer           bool
+	OnlyIssuerCanTransfer bool
+	URL                   string
+	Logo                  string
+	Paused                bool
+	CanPause              bool
+	CanBlacklist          bool
+	CanUpdateMetadata     bool
+	CanChangeIssuer       bool
+}
+
+// TokenInfo get token info, it returns nil if the token doesn't exist
+func (m *TokenHandler) TokenInfo(tokenName string) *TokenInfo {
+	issuer, ok := Unmarshal(m.db.Get(m.infoKey(tokenName, "issuer"))).(string)
+	if !ok {
+		return nil
+	}
+	str := func(field string) string {
+		v, _ := Unmarshal(m.db.Get(m.infoKey(tokenName, field))).(string)
+		return v
+	}
+	flag := func(field string) bool {
+		v, _ := Unmarshal(m.db.Get(m.infoKey(tokenName, field))).(bool)
+		return v
+	}
+	fixed := func(field string, decimal int) *common.Fixed {
+		v, _ := Unmarshal(m.db.Get(m.infoKey(tokenName, field))).(int64)
+		return &common.Fixed{Value: v, Decimal: decimal}
+	}
+	decimal := m.Decimal(tokenName)
+	return &TokenInfo{
+		Symbol:                tokenName,
+		FullName:              str("fullName"),
+		Issuer:                issuer,
+		TotalSupply:           fixed("totalSupply", decimal),
+		CurrentSupply:         fixed("supply", decimal),
+		Decimal:               decimal,
+		CanTransfer:           flag("canTransfer"),
+		OnlyIssuerCanTransfer: flag("onlyIssuerCanTransfer"),
+		URL:                   str("url"),
+		Logo:                  str("logo"),
+		Paused:                flag("paused"),
+		CanPause:              flag("canPause"),
+		CanBlacklist:          flag("canBlacklist"),
+		CanUpdateMetadata:     flag("canUpdateMetadata"),
+		CanChangeIssuer:       flag("canChangeIssuer"),
+	}
+}
+
+// Vesting get vesting schedules of token granted to acc
+func (m *TokenHandler) Vesting(tokenName, acc string) []VestingItem {
+	vestingList := make([]VestingItem, 0)
+	mh := &MapHandler{db: m.db}
+	key := m.vestingMapKey(tokenName, acc)
+	for offset := 0; ; offset += vestingPage {
+		ids := mh.MFields(key, offset, vestingPage)
+		for _, id := range ids {
+			vestingJSON, ok := Unmarshal(mh.MGet(key, id)).(SerializedJSON)
+			if !ok {
+				continue
+			}
+			var v VestingItem
+			err := json.Unmarshal([]byte(vestingJSON), &v)
+			if err != nil {
+				ilog.Errorf("vesting is invalid json %v %v", string(vestingJSON), err)
+				continue
+			}
+			vestingList = append(vestingList, v)
+		}
+		if len(ids) < vestingPage {
+			return vestingList
+		}
+	}
+}
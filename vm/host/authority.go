@@ -2,13 +2,20 @@ package host
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/core/tx"
 	"github.com/iost-official/go-iost/vm/database"
 )
 
+// sessionSpentKey is the map of tokens spent by session permissions, it's in the storage of auth.iost
+const sessionSpentKey = "auth.iost" + database.Separator + "spent"
+
 // Authority module of ...
 type Authority struct {
 	h *Host
@@ -38,7 +45,148 @@ func (h *Authority) RequireAuth(id, p string) (bool, contract.Cost) {
 	authMap := authList.(map[string]int)
 	reenterMap := make(map[string]int)
 
-	return Auth(h.h.db, id, p, authMap, reenterMap)
+	c := &authChecker{
+		vi:      h.h.db,
+		auth:    authMap,
+		reenter: reenterMap,
+	}
+	// session permissions are usable only if their spending can be recorded
+	sessionList, ok := h.h.ctx.Value("session_list").(map[string]bool)
+	if ok {
+		c.time, _ = h.h.ctx.Value("time").(int64)
+		c.inScope = h.inScope
+	}
+	ans, cost := c.check(id, p)
+	if ans {
+		for _, s := range c.sessions {
+			sessionList[s] = true
+		}
+	}
+	return ans, cost
+}
+
+// inScope checks the scope of a session permission with the current contract,
+// out of contracts it's checked with all actions of the tx
+func (h *Authority) inScope(p *account.Permission) bool {
+	if name, ok := h.h.ctx.Value("contract_name").(string); ok {
+		abi, _ := h.h.ctx.Value("abi_name").(string)
+		return p.InScope(name, abi)
+	}
+	actions, _ := h.h.ctx.Value("actions").([]*tx.Action)
+	if len(actions) == 0 {
+		return false
+	}
+	for _, a := range actions {
+		cid := a.Contract
+		if h.h.IsDomain(cid) {
+			if id := h.h.ContractID(cid); id != "" {
+				cid = id
+			}
+		}
+		if !p.InScope(cid, a.ActionName) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckSessionSpend checks the tokens spent by the accounts authorized with session permissions
+// and records them in the spend limit of the sessions. spent is the amount of tokens by account.
+// Gas isn't a token, a session publishing txs spends the gas of its account without limit.
+func (h *Authority) CheckSessionSpend(spent map[string]map[string]*common.Fixed) (contract.Cost, error) {
+	cost := contract.Cost0()
+	sessionList, _ := h.h.ctx.Value("session_list").(map[string]bool)
+	if len(sessionList) == 0 || len(spent) == 0 {
+		return cost, nil
+	}
+	now, _ := h.h.ctx.Value("time").(int64)
+	sessions := make([]string, 0, len(sessionList))
+	for s := range sessionList {
+		sessions = append(sessions, s)
+	}
+	sort.Strings(sessions)
+	for _, s := range sessions {
+		ss := strings.Split(s, "@")
+		tokens, ok := spent[ss[0]]
+		if !ok {
+			continue
+		}
+		a, c := ReadAuth(h.h.db, ss[0])
+		cost.AddAssign(c)
+		cost.AddAssign(CommonOpCost(len(tokens)))
+		var p *account.Permission
+		if a != nil {
+			p = a.Permissions[ss[1]]
+		}
+		if p == nil || !p.IsSession() {
+			return cost, fmt.Errorf("session %v not found", s)
+		}
+
+		field := ss[0] + "." + ss[1]
+		record := h.readSessionSpent(field)
+		if record.Start == 0 || p.SpendPeriod > 0 && now >= record.Start+p.SpendPeriod*1e9 {
+			record = &SessionSpent{Start: now, Spent: make(map[string]string)}
+		}
+		names := make([]string, 0, len(tokens))
+		for token := range tokens {
+			names = append(names, token)
+		}
+		sort.Strings(names)
+		for _, token := range names {
+			decimal := h.h.db.Decimal(token)
+			limit := p.SpendLimitOf(token)
+			if limit == "" {
+				return cost, fmt.Errorf("token %v can't be spent by session %v", token, s)
+			}
+			l, err := common.NewFixed(limit, decimal)
+			if err != nil {
+				return cost, err
+			}
+			used, err := common.NewFixed("0", decimal)
+			if v, ok := record.Spent[token]; ok {
+				used, err = common.NewFixed(v, decimal)
+			}
+			if err != nil {
+				return cost, err
+			}
+			used = used.Add(tokens[token])
+			if used.Value > l.Value {
+				return cost, fmt.Errorf("token %v exceed spend limit of session %v. need %v, limit %v",
+					token, s, used.ToString(), limit)
+			}
+			record.Spent[token] = used.ToString()
+		}
+		h.writeSessionSpent(field, record, ss[0])
+	}
+	return cost, nil
+}
+
+// SessionSpent the tokens spent by a session permission since Start
+type SessionSpent struct {
+	Start int64             `json:"start"`
+	Spent map[string]string `json:"spent"`
+}
+
+func (h *Authority) readSessionSpent(field string) *SessionSpent {
+	record := &SessionSpent{Spent: make(map[string]string)}
+	v, ok := database.MustUnmarshal(h.h.db.MGet(sessionSpentKey, field)).(string)
+	if !ok {
+		return record
+	}
+	if err := json.Unmarshal([]byte(v), record); err != nil {
+		panic(err)
+	}
+	return record
+}
+
+func (h *Authority) writeSessionSpent(field string, record *SessionSpent, payer string) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		panic(err)
+	}
+	sv := database.MustMarshal(string(b), payer)
+	h.h.payRAMForMap(sessionSpentKey, field, sv, h.h.db.MGet(sessionSpentKey, field), payer)
+	h.h.db.MPut(sessionSpentKey, field, sv)
 }
 
 // IsContract to judge the id is contract format
@@ -67,24 +215,74 @@ func ReadAuth(vi *database.Visitor, id string) (*account.Account, contract.Cost)
 }
 
 // Auth check auth
-func Auth(vi *database.Visitor, id, permission string, auth, reenter map[string]int) (bool, contract.Cost) { // nolint
-	if _, ok := reenter[id+"@"+permission]; ok {
+func Auth(vi *database.Visitor, id, permission string, auth, reenter map[string]int) (bool, contract.Cost) {
+	c := &authChecker{
+		vi:      vi,
+		auth:    auth,
+		reenter: reenter,
+	}
+	return c.check(id, permission)
+}
+
+// authChecker checks the permissions of accounts with the signatures in auth
+type authChecker struct {
+	vi      *database.Visitor
+	auth    map[string]int
+	reenter map[string]int
+
+	time int64
+	// inScope checks the scope of session permissions, they are not usable if it's nil
+	inScope func(p *account.Permission) bool
+	// sessions are the session permissions used, as id@permission
+	sessions []string
+}
+
+func (c *authChecker) check(id, permission string) (bool, contract.Cost) { // nolint
+	if _, ok := c.reenter[id+"@"+permission]; ok {
 		return false, CommonErrorCost(1)
 	}
-	reenter[id+"@"+permission] = 1
+	c.reenter[id+"@"+permission] = 1
 
-	a, c := ReadAuth(vi, id)
+	a, cost := ReadAuth(c.vi, id)
 
 	if a == nil {
-		return false, c
+		return false, cost
 	}
 
 	p, ok := a.Permissions[permission]
 	if !ok {
 		if permission == "owner" || permission == "active" {
-			return false, c
+			return false, cost
 		}
-		return Auth(vi, id, "active", auth, reenter)
+		return c.check(id, "active")
+	}
+
+	if c.grant(a, p, &cost) {
+		return true, cost
+	}
+	if permission == "active" {
+		ok, c2 := c.check(id, "owner")
+		cost.AddAssign(c2)
+		if !ok {
+			ok = c.checkSessions(a, &cost)
+		}
+		return ok, cost
+	} else if permission == "owner" {
+		return false, cost
+	} else {
+		ok, c2 := c.check(id, "active")
+		cost.AddAssign(c2)
+		return ok, cost
+	}
+}
+
+// grant returns whether the signatures reach the threshold of p
+func (c *authChecker) grant(a *account.Account, p *account.Permission, cost *contract.Cost) bool {
+	if p.IsExpired(c.time) {
+		return false
+	}
+	if p.IsSession() && (c.inScope == nil || !c.inScope(p)) {
+		return false
 	}
 
 	u := p.Items
@@ -99,36 +297,54 @@ func Auth(vi *database.Visitor, id, permission string, auth, reenter map[string]
 	var weight int
 	for _, user := range u {
 		if user.IsKeyPair {
-			if _, ok := auth[user.ID]; ok {
+			if _, ok := c.auth[user.ID]; ok {
 				weight += user.Weight
 				if weight >= p.Threshold {
-					return true, c
+					return c.granted(a, p)
 				}
 			}
 		} else {
-			ok, cost := Auth(vi, user.ID, user.Permission, auth, reenter)
-			c.AddAssign(cost)
+			ok, c2 := c.check(user.ID, user.Permission)
+			cost.AddAssign(c2)
 			if ok {
 				weight += user.Weight
 				if weight >= p.Threshold {
-					return true, c
+					return c.granted(a, p)
 				}
 			}
 		}
 	}
 
 	if weight >= p.Threshold {
-		return true, c
+		return c.granted(a, p)
 	}
-	if permission == "active" {
-		ok, c2 := Auth(vi, id, "owner", auth, reenter)
-		c.AddAssign(c2)
-		return ok, c
-	} else if permission == "owner" {
-		return false, c
-	} else {
-		ok, c2 := Auth(vi, id, "active", auth, reenter)
-		c.AddAssign(c2)
-		return ok, c
+	return false
+}
+
+func (c *authChecker) granted(a *account.Account, p *account.Permission) bool {
+	if p.IsSession() {
+		c.sessions = append(c.sessions, a.ID+"@"+p.Name)
 	}
+	return true
+}
+
+// checkSessions checks the session permissions of a in name order, they work as active in their scope
+func (c *authChecker) checkSessions(a *account.Account, cost *contract.Cost) bool {
+	names := make([]string, 0)
+	for name, p := range a.Permissions {
+		if p.IsSession() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := c.reenter[a.ID+"@"+name]; ok {
+			continue
+		}
+		c.reenter[a.ID+"@"+name] = 1
+		if c.grant(a, a.Permissions[name], cost) {
+			return true
+		}
+	}
+	return false
 }
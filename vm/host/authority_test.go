@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"testing"
 
+	. "github.com/golang/mock/gomock"
 	"github.com/iost-official/go-iost/account"
+	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/vm/database"
 )
 
@@ -243,3 +245,118 @@ func TestAuthority_Owner(t *testing.T) {
 		t.Fatal(cost)
 	}
 }
+
+func sessionAccount(t *testing.T) string {
+	ac := account.NewInitAccount("a", "keyowner", "keyactive")
+	ac.Permissions["game"] = &account.Permission{
+		Name:   "game",
+		Groups: []string{},
+		Items: []*account.Item{
+			{
+				ID:        "keysession",
+				IsKeyPair: true,
+				Weight:    1,
+			},
+		},
+		Threshold:   1,
+		Scope:       []*account.Scope{{Contract: "game.iost", ABI: "*"}, {Contract: "token.iost", ABI: "transfer"}},
+		Expiry:      1000,
+		SpendLimit:  []*account.Limit{{Token: "iost", Amount: "10"}},
+		SpendPeriod: 100,
+	}
+	j, err := json.Marshal(ac)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return database.MustMarshal(string(j))
+}
+
+func TestAuthority_Session(t *testing.T) {
+	ctx := NewContext(nil)
+	ctx.Set("contract_name", "game.iost")
+	ctx.Set("abi_name", "play")
+	ctx.Set("time", int64(10))
+	ctx.Set("auth_list", map[string]int{"keysession": 1})
+	sessionList := make(map[string]bool)
+	ctx.Set("session_list", sessionList)
+
+	db, host := myinit(t, ctx)
+	db.EXPECT().Get("state", "m-auth.iost-auth-a").AnyTimes().Return(sessionAccount(t), nil)
+
+	ans, _ := host.RequireAuth("a", "active")
+	if !ans || !sessionList["a@game"] {
+		t.Fatal(ans, sessionList)
+	}
+	ans, _ = host.RequireAuth("a", "transfer")
+	if !ans {
+		t.Fatal(ans)
+	}
+	ans, _ = host.RequireAuth("a", "owner")
+	if ans {
+		t.Fatal(ans)
+	}
+
+	ctx.Set("contract_name", "token.iost")
+	ans, _ = host.RequireAuth("a", "active")
+	if ans {
+		t.Fatal(ans)
+	}
+	ctx.Set("abi_name", "transfer")
+	ans, _ = host.RequireAuth("a", "active")
+	if !ans {
+		t.Fatal(ans)
+	}
+
+	ctx.Set("time", int64(1000))
+	ans, _ = host.RequireAuth("a", "active")
+	if ans {
+		t.Fatal(ans)
+	}
+
+	// session permissions are not usable without the session list
+	ctx.Set("time", int64(10))
+	ctx.Set("session_list", nil)
+	ans, _ = host.RequireAuth("a", "active")
+	if ans {
+		t.Fatal(ans)
+	}
+}
+
+func TestAuthority_CheckSessionSpend(t *testing.T) {
+	ctx := NewContext(nil)
+	ctx.Set("contract_name", "game.iost")
+	ctx.Set("time", int64(10))
+	ctx.Set("session_list", map[string]bool{"a@game": true})
+
+	db, host := myinit(t, ctx)
+	db.EXPECT().Get("state", "m-auth.iost-auth-a").AnyTimes().Return(sessionAccount(t), nil)
+	db.EXPECT().Get("state", "m-token.iost-TIiost-decimal").AnyTimes().Return(database.MustMarshal(int64(8)), nil)
+	db.EXPECT().Get("state", "m-token.iost-TIabc-decimal").AnyTimes().Return(database.MustMarshal(int64(8)), nil)
+	db.EXPECT().Get(Any(), Any()).AnyTimes().Return("n", nil)
+	db.EXPECT().Has(Any(), Any()).AnyTimes().Return(false, nil)
+
+	spend := func(token string, amount int64) error {
+		spent := map[string]map[string]*common.Fixed{
+			"a": {token: &common.Fixed{Value: amount * 1e8, Decimal: 8}},
+		}
+		_, err := host.CheckSessionSpend(spent)
+		return err
+	}
+	if err := spend("iost", 6); err != nil {
+		t.Fatal(err)
+	}
+	if err := spend("iost", 5); err == nil {
+		t.Fatal("spend limit exceeded")
+	}
+	if err := spend("abc", 1); err == nil {
+		t.Fatal("token not in spend limit")
+	}
+	if err := spend("iost", 4); err != nil {
+		t.Fatal(err)
+	}
+	// the spent tokens are reset in the next period
+	ctx.Set("time", int64(10+100*1e9))
+	if err := spend("iost", 10); err != nil {
+		t.Fatal(err)
+	}
+}
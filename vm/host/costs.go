@@ -6,6 +6,7 @@ import "github.com/iost-official/go-iost/core/contract"
 var (
 	Costs = map[string]contract.Cost{
 		"JSCost":       contract.NewCost(0, 0, 30000),
+		"WASMCost":     contract.NewCost(0, 0, 10000),
 		"PutCost":      contract.NewCost(0, 0, 300),
 		"GetCost":      contract.NewCost(0, 0, 300),
 		"DelCost":      contract.NewCost(0, 0, 300),
@@ -16,6 +17,10 @@ var (
 		"CodePrice":    contract.NewCost(0, 0, 1),
 		"OpPrice":      contract.NewCost(0, 0, 1),
 		"ErrPrice":     contract.NewCost(0, 0, 1),
+		"HashCost":     contract.NewCost(0, 0, 100),
+		"HashPrice":    contract.NewCost(0, 0, 1),
+		"VerifyCost":   contract.NewCost(0, 0, 3000),
+		"PubkeyCost":   contract.NewCost(0, 0, 1000),
 	}
 )
 
@@ -44,6 +49,20 @@ func CommonOpCost(layer int) contract.Cost {
 	return Costs["OpPrice"].Multiply(int64(layer * 10))
 }
 
+// HashCost returns cost of hashing a message based on message size
+func HashCost(size int) contract.Cost {
+	cost := Costs["HashCost"]
+	cost.AddAssign(Costs["HashPrice"].Multiply(int64(size)))
+	return cost
+}
+
+// VerifyCost returns cost of verifying a signature based on message size
+func VerifyCost(size int) contract.Cost {
+	cost := Costs["VerifyCost"]
+	cost.AddAssign(Costs["HashPrice"].Multiply(int64(size)))
+	return cost
+}
+
 // DelayTxCost returns cost of a delay transaction.
 func DelayTxCost(dataLen int, payer string) contract.Cost {
 	cost := Costs["PutCost"]
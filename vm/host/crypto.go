@@ -0,0 +1,87 @@
+package host
+
+import (
+	"crypto/sha256"
+
+	"filippo.io/edwards25519"
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/crypto"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+)
+
+// Crypto crypto handler of contracts, provides hash and signature functions
+type Crypto struct {
+	h *Host
+}
+
+// NewCrypto new crypto
+func NewCrypto(h *Host) Crypto {
+	return Crypto{h: h}
+}
+
+// Hash returns the digest of msg by algo, which is one of sha3, sha256, ripemd160 and keccak256
+func (c *Crypto) Hash(algo string, msg []byte) ([]byte, contract.Cost, error) {
+	cost := HashCost(len(msg))
+	switch algo {
+	case "sha3":
+		return common.Sha3(msg), cost, nil
+	case "sha256":
+		d := sha256.Sum256(msg)
+		return d[:], cost, nil
+	case "ripemd160":
+		h := ripemd160.New()
+		h.Write(msg)
+		return h.Sum(nil), cost, nil
+	case "keccak256":
+		h := sha3.NewLegacyKeccak256()
+		h.Write(msg)
+		return h.Sum(nil), cost, nil
+	default:
+		return nil, CommonErrorCost(1), ErrUnknownAlgorithm
+	}
+}
+
+// VerifySignature verifies sig of msg with pubkey by algo, which is ed25519 or secp256k1.
+// The msg of secp256k1 must be a 32 bytes digest.
+func (c *Crypto) VerifySignature(algo string, msg []byte, sig []byte, pubkey []byte) (bool, contract.Cost, error) {
+	cost := VerifyCost(len(msg))
+	switch algo {
+	case "ed25519":
+		if len(pubkey) != ed25519.PublicKeySize || len(sig) != ed25519.SignatureSize {
+			return false, cost, nil
+		}
+		return crypto.Ed25519.Verify(msg, pubkey, sig), cost, nil
+	case "secp256k1":
+		if len(msg) != 32 || len(sig) != 64 || len(pubkey) != 33 {
+			return false, cost, nil
+		}
+		return crypto.Secp256k1.Verify(msg, pubkey, sig), cost, nil
+	default:
+		return false, CommonErrorCost(1), ErrUnknownAlgorithm
+	}
+}
+
+// ParsePubkey decodes a Base58 public key of accounts, it returns the algorithm, ed25519 or secp256k1, and the raw key.
+// The key should be a point of the curve of its algorithm.
+func (c *Crypto) ParsePubkey(pubkey string) (string, []byte, contract.Cost, error) {
+	cost := Costs["PubkeyCost"]
+	raw := common.Base58Decode(pubkey)
+	switch len(raw) {
+	case ed25519.PublicKeySize:
+		if _, err := new(edwards25519.Point).SetBytes(raw); err != nil {
+			return "", nil, cost, ErrInvalidPubkey
+		}
+		return crypto.Ed25519.String(), raw, cost, nil
+	case 33:
+		if x, _ := secp256k1.DecompressPubkey(raw); x == nil {
+			return "", nil, cost, ErrInvalidPubkey
+		}
+		return crypto.Secp256k1.String(), raw, cost, nil
+	default:
+		return "", nil, cost, ErrInvalidPubkey
+	}
+}
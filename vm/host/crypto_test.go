@@ -0,0 +1,90 @@
+package host
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/crypto"
+)
+
+func TestCrypto_Hash(t *testing.T) {
+	host := NewHost(NewContext(nil), nil, nil, nil)
+
+	cases := map[string]string{
+		"sha256":    "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"ripemd160": "8eb208f7e05d987a9b044a8e98c6b087f15a0bfc",
+		"keccak256": "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45",
+		"sha3":      hex.EncodeToString(common.Sha3([]byte("abc"))),
+	}
+	for algo, want := range cases {
+		digest, cost, err := host.Hash(algo, []byte("abc"))
+		if err != nil {
+			t.Fatal(algo, err)
+		}
+		if hex.EncodeToString(digest) != want {
+			t.Fatalf("%v digest %x, want %v", algo, digest, want)
+		}
+		if cost.CPU != HashCost(3).CPU {
+			t.Fatal(algo, cost)
+		}
+	}
+
+	_, _, err := host.Hash("md5", []byte("abc"))
+	if err != ErrUnknownAlgorithm {
+		t.Fatal(err)
+	}
+}
+
+func TestCrypto_VerifySignature(t *testing.T) {
+	host := NewHost(NewContext(nil), nil, nil, nil)
+
+	for _, algo := range []crypto.Algorithm{crypto.Ed25519, crypto.Secp256k1} {
+		msg := common.Sha3([]byte("hello"))
+		seckey := algo.GenSeckey()
+		pubkey := algo.GetPubkey(seckey)
+		sig := algo.Sign(msg, seckey)
+
+		ok, _, err := host.VerifySignature(algo.String(), msg, sig, pubkey)
+		if err != nil || !ok {
+			t.Fatal(algo.String(), ok, err)
+		}
+		ok, _, err = host.VerifySignature(algo.String(), common.Sha3([]byte("world")), sig, pubkey)
+		if err != nil || ok {
+			t.Fatal(algo.String(), ok, err)
+		}
+		ok, _, err = host.VerifySignature(algo.String(), msg, sig[1:], pubkey)
+		if err != nil || ok {
+			t.Fatal(algo.String(), ok, err)
+		}
+	}
+
+	_, _, err := host.VerifySignature("rsa", nil, nil, nil)
+	if err != ErrUnknownAlgorithm {
+		t.Fatal(err)
+	}
+}
+
+func TestCrypto_ParsePubkey(t *testing.T) {
+	host := NewHost(NewContext(nil), nil, nil, nil)
+
+	for _, algo := range []crypto.Algorithm{crypto.Ed25519, crypto.Secp256k1} {
+		pubkey := algo.GetPubkey(algo.GenSeckey())
+		name, raw, _, err := host.ParsePubkey(common.Base58Encode(pubkey))
+		if err != nil || name != algo.String() || !bytes.Equal(raw, pubkey) {
+			t.Fatal(algo.String(), name, raw, err)
+		}
+	}
+
+	// y = 2 isn't on the ed25519 curve, and 0x05 isn't a prefix of compressed secp256k1 keys
+	badEd25519 := make([]byte, 32)
+	badEd25519[0] = 2
+	badSecp256k1 := append([]byte{5}, make([]byte, 32)...)
+	for _, pubkey := range []string{"", "0OIl", common.Base58Encode([]byte("abc")),
+		common.Base58Encode(badEd25519), common.Base58Encode(badSecp256k1)} {
+		if _, _, _, err := host.ParsePubkey(pubkey); err != ErrInvalidPubkey {
+			t.Fatal(pubkey, err)
+		}
+	}
+}
@@ -2,10 +2,16 @@ package host
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/iost-official/go-iost/core/contract"
 	"github.com/iost-official/go-iost/vm/database"
 )
 
+// maxKeysLimit is the max number of keys returned by one page of Keys and MapFields
+const maxKeysLimit = 1000
+
 // DBHandler is an application layer abstraction of our base basic_handler and map_handler.
 // it offers interface which has an interface{} type value and ramPayer semantic
 // it also handles the Marshal and Unmarshal work and determine the cost of each operation
@@ -55,6 +61,7 @@ func (h *DBHandler) Put(key string, value interface{}, ramPayer ...string) (cont
 	sv := h.modifyValue(value, payer)
 
 	h.payRAM(mk, sv, oldV, payer)
+	h.trace("put", mk, "", oldV, sv)
 	h.h.db.Put(mk, sv)
 
 	cost := contract.NewCost(0, 0, int64(len(sv)/10))
@@ -67,7 +74,9 @@ func (h *DBHandler) Put(key string, value interface{}, ramPayer ...string) (cont
 // Get get value of key from db
 func (h *DBHandler) Get(key string) (value interface{}, cost contract.Cost) {
 	mk := h.modifyKey(key)
-	rtn := h.parseValue(h.h.db.Get(mk))
+	v := h.h.db.Get(mk)
+	h.trace("get", mk, "", v, "")
+	rtn := h.parseValue(v)
 	return rtn, Costs["GetCost"]
 }
 
@@ -79,6 +88,9 @@ func (h *DBHandler) Del(key string) (contract.Cost, error) {
 	}
 	mk := h.modifyKey(key)
 	h.releaseRAM(mk)
+	if h.h.tracer != nil {
+		h.trace("del", mk, "", h.h.db.Get(mk), "")
+	}
 	h.h.db.Del(mk)
 	return Costs["DelCost"], nil
 }
@@ -86,7 +98,9 @@ func (h *DBHandler) Del(key string) (contract.Cost, error) {
 // Has if db has key
 func (h *DBHandler) Has(key string) (bool, contract.Cost) {
 	mk := h.modifyKey(key)
-	return h.h.db.Has(mk), Costs["GetCost"]
+	has := h.h.db.Has(mk)
+	h.trace("has", mk, "", strconv.FormatBool(has), "")
+	return has, Costs["GetCost"]
 }
 
 // MapPut put kfv to db
@@ -114,6 +128,7 @@ func (h *DBHandler) MapPut(key, field string, value interface{}, ramPayer ...str
 	sv := h.modifyValue(value, payer)
 
 	h.payRAMForMap(mk, field, sv, oldV, payer)
+	h.trace("put", mk, field, oldV, sv)
 	h.h.db.MPut(mk, field, sv)
 
 	cost := contract.NewCost(0, 0, int64(len(sv)/10))
@@ -126,14 +141,18 @@ func (h *DBHandler) MapPut(key, field string, value interface{}, ramPayer ...str
 // MapGet get value by kf from db
 func (h *DBHandler) MapGet(key, field string) (value interface{}, cost contract.Cost) {
 	mk := h.modifyKey(key)
-	rtn := h.parseValue(h.h.db.MGet(mk, field))
+	v := h.h.db.MGet(mk, field)
+	h.trace("get", mk, field, v, "")
+	rtn := h.parseValue(v)
 	return rtn, Costs["GetCost"]
 }
 
 // MapKeys list keys
 func (h *DBHandler) MapKeys(key string) (fields []string, cost contract.Cost) {
 	mk := h.modifyKey(key)
-	return h.h.db.MKeys(mk), Costs["KeysCost"]
+	fields = h.h.db.MKeys(mk)
+	h.trace("keys", mk, "", strings.Join(fields, ","), "")
+	return fields, Costs["KeysCost"]
 }
 
 // MapDel delete field
@@ -148,6 +167,9 @@ func (h *DBHandler) MapDel(key, field string) (contract.Cost, error) {
 	}
 	mk := h.modifyKey(key)
 	h.releaseRAMForMap(mk, field)
+	if h.h.tracer != nil {
+		h.trace("del", mk, field, h.h.db.MGet(mk, field), "")
+	}
 	h.h.db.MDel(mk, field)
 	return Costs["DelCost"], nil
 }
@@ -155,7 +177,9 @@ func (h *DBHandler) MapDel(key, field string) (contract.Cost, error) {
 // MapHas if has field
 func (h *DBHandler) MapHas(key, field string) (bool, contract.Cost) {
 	mk := h.modifyKey(key)
-	return h.h.db.MHas(mk, field), Costs["GetCost"]
+	has := h.h.db.MHas(mk, field)
+	h.trace("has", mk, field, strconv.FormatBool(has), "")
+	return has, Costs["GetCost"]
 }
 
 // MapLen get length of map
@@ -164,36 +188,140 @@ func (h *DBHandler) MapLen(key string) (int, contract.Cost) {
 	return len(keys), cost
 }
 
+// Keys lists keys of the contract's storage by pages, the cost grows with the number of keys
+func (h *DBHandler) Keys(offset, limit int) ([]string, contract.Cost, error) {
+	if err := checkPage(offset, limit); err != nil {
+		return nil, CommonErrorCost(1), err
+	}
+	contractName := h.h.ctx.Value("contract_name").(string)
+	if !h.h.db.KeysIndexed(contractName) {
+		return nil, Costs["GetCost"], fmt.Errorf("keys of %v aren't indexed, the owner can index them by system.iost indexKeys", contractName)
+	}
+	keys := h.h.db.Keys(contractName, offset, limit)
+	h.trace("keys", contractName, "", strings.Join(keys, ","), "")
+	cost := Costs["KeysCost"]
+	cost.AddAssign(CommonOpCost(len(keys)))
+	return keys, cost, nil
+}
+
+// MapFields lists fields of map by pages, the cost grows with the number of fields
+func (h *DBHandler) MapFields(key string, offset, limit int) ([]string, contract.Cost, error) {
+	if err := checkPage(offset, limit); err != nil {
+		return nil, CommonErrorCost(1), err
+	}
+	mk := h.modifyKey(key)
+	if !h.h.db.MFieldsIndexed(mk) {
+		return nil, Costs["GetCost"], fmt.Errorf("fields of %v aren't indexed, the owner can index them by system.iost indexKeys", key)
+	}
+	fields := h.h.db.MFields(mk, offset, limit)
+	h.trace("keys", mk, "", strings.Join(fields, ","), "")
+	cost := Costs["KeysCost"]
+	cost.AddAssign(CommonOpCost(len(fields)))
+	return fields, cost, nil
+}
+
+// MapFieldsLen returns the number of fields of map, it isn't limited as MapLen
+func (h *DBHandler) MapFieldsLen(key string) (int, contract.Cost) {
+	mk := h.modifyKey(key)
+	l := h.h.db.MFieldsLen(mk)
+	h.trace("len", mk, "", strconv.Itoa(l), "")
+	return l, Costs["GetCost"]
+}
+
+// IndexKeys adds keys of contract con written before its keys are indexed to the index, or the fields of map key if key isn't empty.
+// The index is enabled first, so IndexKeys without keys indexes the keys written after it only.
+// The ram of the index entries is paid by the ram payer of each key.
+func (h *DBHandler) IndexKeys(con, key string, keys []string) (contract.Cost, error) {
+	if len(keys) > maxKeysLimit {
+		return CommonErrorCost(1), fmt.Errorf("too many keys %v, expected at most %v", len(keys), maxKeysLimit)
+	}
+	mk := h.modifyGlobalKey(con, key)
+	if key == "" {
+		h.h.db.EnableKeys(con)
+	} else {
+		if err := IsValidKey(key); err != nil {
+			return CommonErrorCost(1), err
+		}
+		h.h.db.EnableMFields(mk)
+	}
+	cost := Costs["PutCost"]
+	for _, k := range keys {
+		if err := IsValidKey(k); err != nil {
+			return cost, err
+		}
+		cost.AddAssign(Costs["PutCost"])
+		var v string
+		var ram int64
+		if key == "" {
+			if !h.h.db.IndexKey(h.modifyGlobalKey(con, k)) {
+				continue
+			}
+			v, ram = h.h.db.Get(h.modifyGlobalKey(con, k)), keyIndexRAM(con, k)
+		} else {
+			if !h.h.db.IndexMField(mk, k) {
+				continue
+			}
+			v, ram = h.h.db.MGet(mk, k), keyIndexRAM(mk, k)
+		}
+		payer := h.parseValuePayer(v)
+		if payer == "" {
+			payer = con
+		}
+		h.h.AddCacheCost(contract.Cost{Data: ram, DataList: []contract.DataItem{{Payer: payer, Val: ram}}})
+	}
+	return cost, nil
+}
+
+func checkPage(offset, limit int) error {
+	if offset < 0 {
+		return fmt.Errorf("invalid offset %v", offset)
+	}
+	if limit <= 0 || limit > maxKeysLimit {
+		return fmt.Errorf("invalid limit %v, expected (0, %v]", limit, maxKeysLimit)
+	}
+	return nil
+}
+
 // GlobalHas if another contract's db has key
 func (h *DBHandler) GlobalHas(con, key string) (bool, contract.Cost) {
 	mk := h.modifyGlobalKey(con, key)
-	return h.h.db.Has(mk), Costs["GetCost"]
+	has := h.h.db.Has(mk)
+	h.trace("has", mk, "", strconv.FormatBool(has), "")
+	return has, Costs["GetCost"]
 }
 
 // GlobalGet get another contract's data
 func (h *DBHandler) GlobalGet(con, key string) (value interface{}, cost contract.Cost) {
 	mk := h.modifyGlobalKey(con, key)
-	rtn := h.parseValue(h.h.db.Get(mk))
+	v := h.h.db.Get(mk)
+	h.trace("get", mk, "", v, "")
+	rtn := h.parseValue(v)
 	return rtn, Costs["GetCost"]
 }
 
 // GlobalMapHas if another contract's map has field
 func (h *DBHandler) GlobalMapHas(con, key, field string) (bool, contract.Cost) {
 	mk := h.modifyGlobalKey(con, key)
-	return h.h.db.MHas(mk, field), Costs["GetCost"]
+	has := h.h.db.MHas(mk, field)
+	h.trace("has", mk, field, strconv.FormatBool(has), "")
+	return has, Costs["GetCost"]
 }
 
 // GlobalMapGet get another contract's map data
 func (h *DBHandler) GlobalMapGet(con, key, field string) (value interface{}, cost contract.Cost) {
 	mk := h.modifyGlobalKey(con, key)
-	rtn := h.parseValue(h.h.db.MGet(mk, field))
+	v := h.h.db.MGet(mk, field)
+	h.trace("get", mk, field, v, "")
+	rtn := h.parseValue(v)
 	return rtn, Costs["GetCost"]
 }
 
 // GlobalMapKeys get another contract's map keys
 func (h *DBHandler) GlobalMapKeys(con, key string) (keys []string, cost contract.Cost) {
 	mk := h.modifyGlobalKey(con, key)
-	return h.h.db.MKeys(mk), Costs["GetCost"]
+	keys = h.h.db.MKeys(mk)
+	h.trace("keys", mk, "", strings.Join(keys, ","), "")
+	return keys, Costs["GetCost"]
 }
 
 // GlobalMapLen get another contract's map length
@@ -202,6 +330,12 @@ func (h *DBHandler) GlobalMapLen(con, key string) (length int, cost contract.Cos
 	return len(k), cost
 }
 
+func (h *DBHandler) trace(op, key, field, oldV, newV string) {
+	if h.h.tracer != nil {
+		h.h.tracer.storage(op, key, field, oldV, newV)
+	}
+}
+
 func (h *DBHandler) modifyKey(key string) string {
 	contractName, ok := h.h.ctx.Value("contract_name").(string)
 	if !ok {
@@ -231,15 +365,39 @@ func (h *DBHandler) parseValuePayer(value string) string {
 	return extra
 }
 
+// keyIndexRAM is the ram of the two index entries of key, the slot and the position, which is paid with the key
+func keyIndexRAM(owner, key string) int64 {
+	return int64(2*(len(owner)+len(key)) + 16)
+}
+
+// indexRAM returns the index ram of the key k of a contract, a new key is indexed if the keys of its contract are
+func (h *DBHandler) indexRAM(k, oldV string) int64 {
+	con := strings.SplitN(k, database.Separator, 2)[0]
+	if oldV == database.NilPrefix && h.h.db.KeysIndexed(con) || oldV != database.NilPrefix && h.h.db.KeyIndexed(k) {
+		return keyIndexRAM(con, k[len(con)+1:])
+	}
+	return 0
+}
+
+// indexRAMForMap returns the index ram of the field f of map k
+func (h *DBHandler) indexRAMForMap(k, f, oldV string) int64 {
+	if oldV == database.NilPrefix && h.h.db.MFieldsIndexed(k) || oldV != database.NilPrefix && h.h.db.MFieldIndexed(k, f) {
+		return keyIndexRAM(k, f)
+	}
+	return 0
+}
+
 func (h *DBHandler) payRAM(k, v, oldV string, who string) {
-	oLen := int64(len(oldV) + len(k))
-	nLen := int64(len(v) + len(k))
+	idx := h.indexRAM(k, oldV)
+	oLen := int64(len(oldV)+len(k)) + idx
+	nLen := int64(len(v)+len(k)) + idx
 	h.payRAMInner(oldV, oLen, nLen, who)
 }
 
 func (h *DBHandler) payRAMForMap(k, f, v, oldV string, who string) {
-	oLen := int64(len(oldV) + len(k) + 2*len(f))
-	nLen := int64(len(v) + len(k) + 2*len(f))
+	idx := h.indexRAMForMap(k, f, oldV)
+	oLen := int64(len(oldV)+len(k)+2*len(f)) + idx
+	nLen := int64(len(v)+len(k)+2*len(f)) + idx
 	h.payRAMInner(oldV, oLen, nLen, who)
 }
 
@@ -281,12 +439,18 @@ func (h *DBHandler) releaseRAMInner(oldV string, oLen int64) {
 
 func (h *DBHandler) releaseRAM(k string) {
 	v := h.h.db.Get(k)
-	oLen := int64(len(k) + len(v))
+	if v == database.NilPrefix {
+		return
+	}
+	oLen := int64(len(k)+len(v)) + h.indexRAM(k, v)
 	h.releaseRAMInner(v, oLen)
 }
 
 func (h *DBHandler) releaseRAMForMap(k, f string, who ...string) {
 	v := h.h.db.MGet(k, f)
-	oLen := int64(len(k) + 2*len(f) + len(v))
+	if v == database.NilPrefix {
+		return
+	}
+	oLen := int64(len(k)+2*len(f)+len(v)) + h.indexRAMForMap(k, f, v)
 	h.releaseRAMInner(v, oLen)
 }
@@ -8,9 +8,10 @@ import (
 
 // const table name
 const (
-	DNSTable      = "dns_table"
-	DNSRTable     = "dns_revert_table"
-	DNSOwnerTable = "dns_owner_table"
+	DNSTable       = "dns_table"
+	DNSRTable      = "dns_revert_table"
+	DNSOwnerTable  = "dns_owner_table"
+	DNSExpiryTable = "dns_expiry_table"
 )
 
 // DNS dns server handler
@@ -25,8 +26,16 @@ func NewDNS(h *Host) DNS {
 	}
 }
 
-// ContractID find cid from url
+// ContractID find cid from url, an expired url resolves to nothing
 func (d *DNS) ContractID(url string) string {
+	if d.expired(url) {
+		return ""
+	}
+	return d.LinkedContractID(url)
+}
+
+// LinkedContractID find cid linked to url even if the url is expired
+func (d *DNS) LinkedContractID(url string) string {
 	cid, _ := d.h.GlobalMapGet("domain.iost", DNSTable, url)
 	if s, ok := cid.(string); ok {
 		return s
@@ -34,13 +43,40 @@ func (d *DNS) ContractID(url string) string {
 	return ""
 }
 
-// URLOwner find owner of url
+// URLOwner find owner of url, an expired url has no owner
 func (d *DNS) URLOwner(url string) string {
 	owner, _ := d.h.GlobalMapGet("domain.iost", DNSOwnerTable, url)
-	if s, ok := owner.(string); ok {
-		return s
+	s, ok := owner.(string)
+	if !ok || d.expired(url) {
+		return ""
+	}
+	return s
+}
+
+func (d *DNS) expired(url string) bool {
+	expiry := d.URLExpiry(url)
+	if expiry == 0 {
+		return false
+	}
+	now, _ := d.h.ctx.Value("time").(int64)
+	return now >= expiry
+}
+
+// URLExpiry find expiry time of url, 0 means the url never expires
+func (d *DNS) URLExpiry(url string) int64 {
+	expiry, _ := d.h.GlobalMapGet("domain.iost", DNSExpiryTable, url)
+	if t, ok := expiry.(int64); ok {
+		return t
+	}
+	return 0
+}
+
+// SetURLExpiry set expiry time of url
+func (d *DNS) SetURLExpiry(url string, expiry int64) {
+	_, err := d.h.MapPut(DNSExpiryTable, url, expiry)
+	if err != nil {
+		ilog.Errorf("set url expiry mapPut failed. err = %v", err)
 	}
-	return ""
 }
 
 // URLTransfer give url to another id
@@ -86,4 +122,9 @@ func (d *DNS) RemoveLink(url, cid string) {
 	if err0 != nil || err1 != nil || err2 != nil {
 		ilog.Errorf("remove link mapPut failed. err = %v %v %v", err0, err1, err2)
 	}
+	if ok, _ := d.h.MapHas(DNSExpiryTable, url); ok {
+		if _, err := d.h.MapDel(DNSExpiryTable, url); err != nil {
+			ilog.Errorf("remove link mapDel failed. err = %v", err)
+		}
+	}
 }
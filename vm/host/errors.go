@@ -29,7 +29,12 @@ var (
 	ErrTokenNoTransfer           = errors.New("token can't transfer")
 	ErrTokenIssueRefused         = errors.New("token issue refused")
 	ErrMemoTooLarge              = errors.New("memo too large")
+	ErrTokenPaused               = errors.New("token paused")
+	ErrTokenControlDisabled      = errors.New("token control disabled")
 
 	ErrDelaytxNotFound   = errors.New("delaytx not exists")
 	ErrCancelDelayForbid = errors.New("cancel delaytx forbid")
+
+	ErrUnknownAlgorithm = errors.New("unknown algorithm")
+	ErrInvalidPubkey    = errors.New("invalid public key")
 )
@@ -15,10 +15,24 @@ func NewEventPoster(h *Host) EventPoster {
 	return EventPoster{h: h}
 }
 
-// PostEvent post the event
-func (p *EventPoster) PostEvent(data string) contract.Cost {
+// PostEvent post the event, the event should match its declaration if the contract declares events in abi
+func (p *EventPoster) PostEvent(data string) (contract.Cost, error) {
+	contractName := p.h.Context().Value("contract_name").(string)
+	cost := EventCost(len(data))
 	e := event.NewEvent(event.ContractEvent, data)
+	if c := p.h.db.Contract(contractName); c != nil && c.Info != nil && len(c.Info.Events) > 0 {
+		name, fields, err := c.ParseEvent(data)
+		cost.AddAssign(CommonOpCost(len(fields) + 1))
+		if err != nil {
+			return cost, err
+		}
+		e.Name = name
+		e.Fields = fields
+	}
 	event.GetCollector().Post(e,
-		&event.Meta{ContractID: p.h.Context().Value("contract_name").(string)})
-	return EventCost(len(data))
+		&event.Meta{ContractID: contractName})
+	if p.h.tracer != nil {
+		p.h.tracer.event(data)
+	}
+	return cost, nil
 }
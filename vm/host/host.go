@@ -18,8 +18,8 @@ import (
 // Monitor monitor interface
 type Monitor interface {
 	Call(host *Host, contractName, api string, jarg string) (rtn []interface{}, cost contract.Cost, err error)
-	Validate(con *contract.Contract) error
-	Compile(con *contract.Contract) (string, error)
+	Validate(host *Host, con *contract.Contract) error
+	Compile(host *Host, con *contract.Contract) (string, error)
 }
 
 // Host host struct, used as isolate of vm
@@ -32,11 +32,13 @@ type Host struct {
 	DNS
 	Authority
 	GasManager
+	Crypto
 
 	logger  *ilog.Logger
 	ctx     *Context
 	db      *database.Visitor
 	monitor Monitor
+	tracer  *Tracer
 
 	deadline time.Time
 }
@@ -57,6 +59,7 @@ func NewHost(ctx *Context, db *database.Visitor, monitor Monitor, logger *ilog.L
 	h.DNS = NewDNS(h)
 	h.Authority = Authority{h: h}
 	h.GasManager = NewGasManager(h)
+	h.Crypto = NewCrypto(h)
 	return h
 
 }
@@ -116,7 +119,7 @@ func (h *Host) CallWithAuth(contract, api, jarg string) ([]interface{}, contract
 
 func (h *Host) checkAbiValid(c *contract.Contract) (contract.Cost, error) {
 	cost := contract.Cost0()
-	err := h.monitor.Validate(c)
+	err := h.monitor.Validate(h, c)
 	cost.AddAssign(CodeSavageCost(len(c.Code)))
 	return cost, err
 }
@@ -195,7 +198,7 @@ func (h *Host) SetCode(c *contract.Contract, owner string) (contract.Cost, error
 		return cost, err
 	}
 
-	code, err := h.monitor.Compile(c)
+	code, err := h.monitor.Compile(h, c)
 	cost.AddAssign(CodeSavageCost(len(c.Code)))
 	if err != nil {
 		return cost, err
@@ -239,8 +242,6 @@ func (h *Host) UpdateCode(c *contract.Contract, id database.SerializedJSON) (con
 		return Costs["GetCost"], ErrUpdateRefused
 	}
 
-	oldL := len(oc.Encode())
-
 	rtn, cost, err := h.Call(c.ID, "can_update", `["`+string(id)+`"]`)
 
 	if err != nil {
@@ -251,19 +252,34 @@ func (h *Host) UpdateCode(c *contract.Contract, id database.SerializedJSON) (con
 		return cost, ErrUpdateRefused
 	}
 
-	cost0, err := h.checkAbiValid(c)
+	cost0, err := h.UpgradeCode(c)
 	cost.AddAssign(cost0)
+	return cost, err
+}
+
+// UpgradeCode replaces the code of an existing contract without asking the contract, the caller should check the permission
+func (h *Host) UpgradeCode(c *contract.Contract) (contract.Cost, error) {
+	if err := c.VerifySelf(); err != nil {
+		return CommonErrorCost(1), err
+	}
+	oc := h.db.Contract(c.ID)
+	if oc == nil {
+		return Costs["GetCost"], ErrContractNotFound
+	}
+	oldL := len(oc.Encode())
+
+	cost, err := h.checkAbiValid(c)
 	if err != nil {
 		return cost, err
 	}
 
-	cost0, err = h.checkAmountLimitValid(c)
+	cost0, err := h.checkAmountLimitValid(c)
 	cost.AddAssign(cost0)
 	if err != nil {
 		return cost, err
 	}
 
-	code, err := h.monitor.Compile(c)
+	code, err := h.monitor.Compile(h, c)
 	cost.AddAssign(CodeSavageCost(len(c.Code)))
 	if err != nil {
 		return cost, err
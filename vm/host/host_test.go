@@ -55,6 +55,7 @@ func TestHost_Put(t *testing.T) {
 	})
 
 	mock.EXPECT().Get("state", "b-contractName-hello").Return("", nil)
+	mock.EXPECT().Has("state", "ix-contractName").AnyTimes().Return(false, nil)
 
 	_, _ = host.Put("hello", "world")
 	host.FlushCacheCost()
@@ -63,6 +64,29 @@ func TestHost_Put(t *testing.T) {
 	}
 }
 
+func TestHost_PutIndexed(t *testing.T) {
+
+	ctx := NewContext(nil)
+	ctx.Set("commit", "abc")
+	ctx.Set("contract_name", "contractName")
+
+	mock, host := myinit(t, ctx)
+
+	mock.EXPECT().Put(Any(), Any(), Any()).AnyTimes().Return(nil)
+	mock.EXPECT().Get("state", "b-contractName-hello").Return("", nil)
+	mock.EXPECT().Has("state", "b-contractName-hello").Return(false, nil)
+	mock.EXPECT().Has("state", "ix-contractName").AnyTimes().Return(true, nil)
+	mock.EXPECT().Get("state", "ix-contractName").AnyTimes().Return("1", nil)
+	mock.EXPECT().Has("state", "ib-contractName").AnyTimes().Return(true, nil)
+	mock.EXPECT().Get("state", "ib-contractName").Return("0", nil)
+
+	_, _ = host.Put("hello", "world")
+	host.FlushCacheCost()
+	if host.cost["contractName"].Data != 37+keyIndexRAM("contractName", "hello") {
+		t.Fatal(host.cost)
+	}
+}
+
 func TestHost_Put2(t *testing.T) {
 
 	ctx := NewContext(nil)
@@ -76,6 +100,7 @@ func TestHost_Put2(t *testing.T) {
 	})
 
 	mock.EXPECT().Get("state", "b-contractName-hello").Return("sa", nil)
+	mock.EXPECT().Has("state", "ix-contractName").AnyTimes().Return(false, nil)
 
 	_, _ = host.Put("hello", "world")
 	host.FlushCacheCost()
@@ -97,6 +122,7 @@ func TestHost_PutUserSpace(t *testing.T) {
 	})
 
 	mock.EXPECT().Get("state", "b-contractName-hello").Return("sa@abc", nil)
+	mock.EXPECT().Has("state", "ix-contractName").AnyTimes().Return(false, nil)
 
 	_, _ = host.Put("hello", "worldn", "abc")
 	host.FlushCacheCost()
@@ -123,6 +149,7 @@ func TestHost_Del(t *testing.T) {
 
 	mock.EXPECT().Get("state", "b-contractName-hello").Return("sworld@contractName", nil)
 	mock.EXPECT().Get("state", "b-contractName-hello").Return("sworld@contractName", nil)
+	mock.EXPECT().Has("state", "ix-contractName").AnyTimes().Return(false, nil)
 
 	_, _ = host.Del("hello")
 	host.FlushCacheCost()
@@ -177,6 +204,7 @@ func TestHost_MapPut(t *testing.T) {
 	mock.EXPECT().Has("state", "m-contractName-hello-1").Return(false, nil)
 	mock.EXPECT().Get("state", "m-contractName-hello").Return("", nil)
 	mock.EXPECT().Get("state", "m-contractName-hello-1").Return("", nil)
+	mock.EXPECT().Has("state", "ix-contractName").AnyTimes().Return(false, nil)
 
 	tr := watchTime(func() {
 		_, _ = host.MapPut("hello", "1", "world")
@@ -212,6 +240,7 @@ func TestHost_MapPut_Owner(t *testing.T) {
 	mock.EXPECT().Has("state", "m-contractName-hello-1").Return(false, nil)
 	mock.EXPECT().Get("state", "m-contractName-hello").Return("", nil)
 	mock.EXPECT().Get("state", "m-contractName-hello-1").Return("", nil)
+	mock.EXPECT().Has("state", "ix-contractName").AnyTimes().Return(false, nil)
 
 	tr := watchTime(func() {
 		_, _ = host.MapPut("hello", "1", "world", "abc")
@@ -26,6 +26,9 @@ func (h *Info) BlockInfo() (info database.SerializedJSON, cost contract.Cost) {
 	blkInfo["number"] = h.h.ctx.Value("number")
 	blkInfo["witness"] = h.h.ctx.Value("witness")
 	blkInfo["time"] = h.h.ctx.Value("time")
+	if random := h.h.ctx.Value("random"); random != nil {
+		blkInfo["random"] = random
+	}
 
 	bij, err := json.Marshal(blkInfo)
 	if err != nil {
@@ -41,6 +44,12 @@ func (h *Info) BlockTime() (ntime int64, cost contract.Cost) {
 	return ntime, Costs["ContextCost"]
 }
 
+// BlockRandom get the random value of block, in base58. It's empty if the block has no randomness proof
+func (h *Info) BlockRandom() (random string, cost contract.Cost) {
+	random, _ = h.h.ctx.Value("random").(string)
+	return random, Costs["ContextCost"]
+}
+
 // ContractName get block time, in int64
 func (h *Info) ContractName() (name string, cost contract.Cost) {
 	name = h.h.ctx.Value("contract_name").(string)
@@ -0,0 +1,129 @@
+package host
+
+import (
+	"fmt"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/database"
+)
+
+// limits of the calls scheduled by contracts
+const (
+	// MaxScheduledCallDelay is the max delay of a scheduled call in seconds, 3 years
+	MaxScheduledCallDelay = 3 * 365 * 24 * 3600
+	// MaxScheduledCallGas is the max gas limit of a scheduled call
+	MaxScheduledCallGas = 1000000
+	// MaxScheduledCallsPerBlock is the max number of the scheduled calls run in a block, the rest are run in the next blocks
+	MaxScheduledCallsPerBlock = 20
+	// maxScheduledSlotsPerBlock is the max number of the slots checked in a block, it bounds the work after a long halt
+	maxScheduledSlotsPerBlock = 1000
+)
+
+// ScheduleCall schedules a call of api of a contract delay seconds later, it's run in the block base with the
+// authority of the contract. The gas of the call is paid now by the publisher, as well as the ram to store it,
+// which is returned when it's run or cancelled.
+func (h *Host) ScheduleCall(con, api, args string, delay, gasLimit int64) (string, contract.Cost, error) {
+	cost := Costs["GetCost"]
+	c := h.db.Contract(con)
+	if c == nil {
+		return "", cost, ErrContractNotFound
+	}
+	if c.ABI(api) == nil {
+		return "", cost, fmt.Errorf("abi %v not found in %v", api, con)
+	}
+	if delay <= 0 || delay > MaxScheduledCallDelay {
+		return "", cost, fmt.Errorf("delay should be in (0, %v] seconds", MaxScheduledCallDelay)
+	}
+	if gasLimit <= 0 || gasLimit > MaxScheduledCallGas {
+		return "", cost, fmt.Errorf("gas limit should be in (0, %v]", MaxScheduledCallGas)
+	}
+
+	ntime, cost0 := h.BlockTime()
+	cost.AddAssign(cost0)
+	if h.db.NextScheduledSlot() == 0 {
+		h.db.SetNextScheduledSlot(ntime / 1e9)
+	}
+	publisher := h.ctx.Value("publisher").(string)
+	call := &database.ScheduledCall{
+		ID:       h.db.NewScheduledCallID(),
+		Contract: con,
+		API:      api,
+		Args:     args,
+		Time:     ntime + delay*1e9,
+		GasLimit: gasLimit,
+		Payer:    publisher,
+	}
+	l := h.db.StoreScheduledCall(call)
+	cost.AddAssign(Costs["PutCost"].Multiply(3))
+	cost.AddAssign(DelayTxCost(l, publisher))
+	cost.AddAssign(contract.NewCost(0, 0, gasLimit))
+	return call.ID, cost, nil
+}
+
+// CancelScheduledCall cancels a scheduled call with the authority of its contract, it returns false if the call
+// isn't pending, i.e. it has run.
+func (h *Host) CancelScheduledCall(id string) (bool, contract.Cost, error) {
+	cost := Costs["GetCost"]
+	call := h.db.ScheduledCall(id)
+	if call == nil {
+		return false, cost, nil
+	}
+	ok, cost0 := h.RequireAuth(call.Contract, "active")
+	cost.AddAssign(cost0)
+	if !ok {
+		return false, cost, fmt.Errorf("cancel scheduled call need %v@active permission", call.Contract)
+	}
+	cost.AddAssign(h.unscheduleCall(call))
+	return true, cost, nil
+}
+
+func (h *Host) unscheduleCall(call *database.ScheduledCall) contract.Cost {
+	ids := h.db.ScheduledSlot(call.Slot())
+	for i, id := range ids {
+		if id == call.ID {
+			ids = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+	h.db.SetScheduledSlot(call.Slot(), ids)
+	l := h.db.DelScheduledCall(call.ID)
+	cost := Costs["PutCost"]
+	cost.AddAssign(DelDelayTxCost(l, call.Payer))
+	return cost
+}
+
+// PopScheduledCalls removes at most n calls due at the block time from the queue in the order of their slots,
+// the ram of them is returned to their payers by paying the costs.
+func (h *Host) PopScheduledCalls(n int) []*database.ScheduledCall {
+	next := h.db.NextScheduledSlot()
+	if next == 0 {
+		return nil
+	}
+	ntime, _ := h.BlockTime()
+	last := ntime / 1e9
+	calls := make([]*database.ScheduledCall, 0)
+	for i := 0; i < maxScheduledSlotsPerBlock && next <= last && len(calls) < n; i++ {
+		ids := h.db.ScheduledSlot(next)
+		if len(ids) > 0 {
+			for len(ids) > 0 && len(calls) < n {
+				call := h.db.ScheduledCall(ids[0])
+				ids = ids[1:]
+				if call == nil {
+					continue
+				}
+				l := h.db.DelScheduledCall(call.ID)
+				h.PayCost(DelDelayTxCost(l, call.Payer), call.Payer)
+				calls = append(calls, call)
+			}
+			h.db.SetScheduledSlot(next, ids)
+			if len(ids) > 0 {
+				break
+			}
+		}
+		next++
+	}
+	if next != h.db.NextScheduledSlot() {
+		h.db.SetNextScheduledSlot(next)
+	}
+	return calls
+}
@@ -0,0 +1,133 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/vm/database"
+)
+
+func scheduledCallHost(t *testing.T) (*Host, func()) {
+	mvccdb, err := db.NewMVCCDBWithStorage("", kv.MemoryStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := NewContext(nil)
+	ctx.Set("number", int64(0))
+	ctx.Set("time", int64(100*1e9))
+	ctx.Set("publisher", "user")
+	h := NewHost(ctx, database.NewVisitor(0, mvccdb), nil, nil)
+	h.DB().SetContract(&contract.Contract{
+		ID:   "rent.iost",
+		Info: &contract.Info{Lang: "javascript", Version: "1.0.0", Abi: []*contract.ABI{{Name: "expire"}}},
+	})
+	return h, func() { _ = mvccdb.Close() }
+}
+
+func TestHost_ScheduleCall(t *testing.T) {
+	h, closeDB := scheduledCallHost(t)
+	defer closeDB()
+
+	if _, _, err := h.ScheduleCall("rent.iost", "rent", "[]", 10, 1000); err == nil {
+		t.Fatal("scheduled a missing abi")
+	}
+	if _, _, err := h.ScheduleCall("rent.iost", "expire", "[]", 0, 1000); err == nil {
+		t.Fatal("scheduled without delay")
+	}
+	if _, _, err := h.ScheduleCall("rent.iost", "expire", "[]", 10, MaxScheduledCallGas+1); err == nil {
+		t.Fatal("scheduled over the gas limit")
+	}
+
+	id, cost, err := h.ScheduleCall("rent.iost", "expire", `["1"]`, 10, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost.CPU < 1000 || cost.Data <= 0 || cost.DataList[0].Payer != "user" {
+		t.Fatal(cost)
+	}
+	call := h.DB().ScheduledCall(id)
+	if call == nil || call.Time != 110*1e9 || call.Payer != "user" || call.Args != `["1"]` {
+		t.Fatal(call)
+	}
+
+	if calls := h.PopScheduledCalls(MaxScheduledCallsPerBlock); len(calls) != 0 {
+		t.Fatal("popped a call before its time", calls)
+	}
+	h.ctx.Set("time", int64(110*1e9))
+	calls := h.PopScheduledCalls(MaxScheduledCallsPerBlock)
+	if len(calls) != 1 || calls[0].ID != id {
+		t.Fatal(calls)
+	}
+	if h.DB().ScheduledCall(id) != nil {
+		t.Fatal("popped call is still stored")
+	}
+	if h.Costs()["user"].Data != -cost.Data {
+		t.Fatal("ram of the call isn't returned", h.Costs())
+	}
+	if h.DB().NextScheduledSlot() != 111 {
+		t.Fatal(h.DB().NextScheduledSlot())
+	}
+}
+
+func TestHost_PopScheduledCalls(t *testing.T) {
+	h, closeDB := scheduledCallHost(t)
+	defer closeDB()
+
+	ids := make([]string, 0)
+	for _, delay := range []int64{30, 10, 10, 20} {
+		id, _, err := h.ScheduleCall("rent.iost", "expire", "[]", delay, 1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	h.ctx.Set("time", int64(200*1e9))
+	calls := h.PopScheduledCalls(2)
+	if len(calls) != 2 || calls[0].ID != ids[1] || calls[1].ID != ids[2] {
+		t.Fatal(calls)
+	}
+	calls = h.PopScheduledCalls(1)
+	if len(calls) != 1 || calls[0].ID != ids[3] {
+		t.Fatal(calls)
+	}
+	calls = h.PopScheduledCalls(MaxScheduledCallsPerBlock)
+	if len(calls) != 1 || calls[0].ID != ids[0] {
+		t.Fatal(calls)
+	}
+	if calls = h.PopScheduledCalls(MaxScheduledCallsPerBlock); len(calls) != 0 {
+		t.Fatal(calls)
+	}
+}
+
+func TestHost_CancelScheduledCall(t *testing.T) {
+	h, closeDB := scheduledCallHost(t)
+	defer closeDB()
+
+	id, _, err := h.ScheduleCall("rent.iost", "expire", "[]", 10, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _, err := h.ScheduleCall("rent.iost", "expire", "[]", 10, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, cost, err := h.CancelScheduledCall(id)
+	if err != nil || !ok {
+		t.Fatal(ok, err)
+	}
+	if cost.Data >= 0 || cost.DataList[0].Payer != "user" {
+		t.Fatal(cost)
+	}
+	if ok, _, _ := h.CancelScheduledCall(id); ok {
+		t.Fatal("cancelled a call twice")
+	}
+
+	h.ctx.Set("time", int64(110*1e9))
+	calls := h.PopScheduledCalls(MaxScheduledCallsPerBlock)
+	if len(calls) != 1 || calls[0].ID != other {
+		t.Fatal(calls)
+	}
+}
@@ -0,0 +1,132 @@
+package host
+
+import (
+	"github.com/iost-official/go-iost/core/contract"
+)
+
+// StorageAccess is a storage read or write recorded by Tracer.
+// The values are the serialized strings in the state db, "n" means the value doesn't exist.
+type StorageAccess struct {
+	Op    string `json:"op"`
+	Key   string `json:"key"`
+	Field string `json:"field,omitempty"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// TraceReceipt is a receipt or an event emitted in a call frame.
+type TraceReceipt struct {
+	FuncName string `json:"func_name,omitempty"`
+	Content  string `json:"content"`
+}
+
+// CallFrame is a contract call recorded by Tracer.
+type CallFrame struct {
+	Contract string           `json:"contract"`
+	API      string           `json:"api"`
+	Args     string           `json:"args"`
+	Gas      int64            `json:"gas"`
+	RAM      int64            `json:"ram"`
+	Storage  []*StorageAccess `json:"storage,omitempty"`
+	Receipts []*TraceReceipt  `json:"receipts,omitempty"`
+	Events   []*TraceReceipt  `json:"events,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	Calls    []*CallFrame     `json:"calls,omitempty"`
+}
+
+// Tracer records the call tree of a tx, with the cost, storage accesses and receipts of each call.
+// It is only set to the host when a tx is traced for debugging.
+type Tracer struct {
+	Calls []*CallFrame `json:"calls"`
+	stack []*CallFrame
+}
+
+// NewTracer returns a new tracer
+func NewTracer() *Tracer {
+	return &Tracer{
+		Calls: make([]*CallFrame, 0),
+		stack: make([]*CallFrame, 0),
+	}
+}
+
+func (t *Tracer) current() *CallFrame {
+	if len(t.stack) == 0 {
+		return nil
+	}
+	return t.stack[len(t.stack)-1]
+}
+
+// Enter starts a call frame
+func (t *Tracer) Enter(contractName, api, args string) {
+	f := &CallFrame{
+		Contract: contractName,
+		API:      api,
+		Args:     args,
+	}
+	if cur := t.current(); cur != nil {
+		cur.Calls = append(cur.Calls, f)
+	} else {
+		t.Calls = append(t.Calls, f)
+	}
+	t.stack = append(t.stack, f)
+}
+
+// Exit ends the current call frame with its cost and error
+func (t *Tracer) Exit(cost contract.Cost, ram int64, err error) {
+	f := t.current()
+	if f == nil {
+		return
+	}
+	f.Gas = cost.ToGas()
+	f.RAM = ram
+	if err != nil {
+		f.Error = err.Error()
+	}
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// Failure returns the innermost failed call frame, nil if all calls succeed
+func (t *Tracer) Failure() *CallFrame {
+	var failure *CallFrame
+	frames := t.Calls
+	for len(frames) > 0 {
+		var next []*CallFrame
+		for _, f := range frames {
+			if f.Error != "" {
+				failure = f
+				next = f.Calls
+				break
+			}
+		}
+		frames = next
+	}
+	return failure
+}
+
+func (t *Tracer) storage(op, key, field, oldV, newV string) {
+	if f := t.current(); f != nil {
+		f.Storage = append(f.Storage, &StorageAccess{Op: op, Key: key, Field: field, Old: oldV, New: newV})
+	}
+}
+
+func (t *Tracer) receipt(funcName, content string) {
+	if f := t.current(); f != nil {
+		f.Receipts = append(f.Receipts, &TraceReceipt{FuncName: funcName, Content: content})
+	}
+}
+
+func (t *Tracer) event(content string) {
+	if f := t.current(); f != nil {
+		f.Events = append(f.Events, &TraceReceipt{Content: content})
+	}
+}
+
+// SetTracer sets the tracer of host, nil to stop tracing
+func (h *Host) SetTracer(t *Tracer) {
+	h.tracer = t
+}
+
+// Tracer returns the tracer of host, nil if the host is not traced
+func (h *Host) Tracer() *Tracer {
+	return h.tracer
+}
@@ -0,0 +1,60 @@
+package host
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/golang/mock/gomock"
+	"github.com/iost-official/go-iost/core/contract"
+)
+
+func TestTracer_CallTree(t *testing.T) {
+	tracer := NewTracer()
+	tracer.Enter("a", "outer", "[]")
+	tracer.Enter("b", "inner", `["x"]`)
+	tracer.receipt("b/inner", "done")
+	tracer.Exit(contract.NewCost(0, 0, 10), 5, nil)
+	tracer.Enter("c", "failed", "[]")
+	tracer.Exit(contract.NewCost(0, 0, 20), 0, errors.New("boom"))
+	tracer.Exit(contract.NewCost(0, 0, 100), 0, errors.New("call c failed"))
+
+	if len(tracer.Calls) != 1 || len(tracer.Calls[0].Calls) != 2 {
+		t.Fatal(tracer.Calls)
+	}
+	b := tracer.Calls[0].Calls[0]
+	if b.Gas != 10 || b.RAM != 5 || len(b.Receipts) != 1 || b.Error != "" {
+		t.Fatal(b)
+	}
+	failure := tracer.Failure()
+	if failure == nil || failure.Contract != "c" || failure.Error != "boom" {
+		t.Fatal(failure)
+	}
+}
+
+func TestTracer_Storage(t *testing.T) {
+	ctx := NewContext(nil)
+	ctx.Set("contract_name", "contractName")
+
+	mock, host := myinit(t, ctx)
+	tracer := NewTracer()
+	host.SetTracer(tracer)
+	tracer.Enter("contractName", "api", "[]")
+
+	mock.EXPECT().Put("state", "b-contractName-hello", Any()).Return(nil)
+	mock.EXPECT().Get("state", "b-contractName-hello").Return("sold@contractName", nil)
+	mock.EXPECT().Has("state", "ix-contractName").AnyTimes().Return(false, nil)
+
+	_, _ = host.Put("hello", "world")
+	host.Get("hello")
+
+	storage := tracer.Calls[0].Storage
+	if len(storage) != 2 {
+		t.Fatal(storage)
+	}
+	if storage[0].Op != "put" || storage[0].Key != "contractName-hello" || storage[0].Old != "sold@contractName" || storage[0].New != "sworld@contractName" {
+		t.Fatal(storage[0])
+	}
+	if storage[1].Op != "get" {
+		t.Fatal(storage[1])
+	}
+}
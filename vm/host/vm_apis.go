@@ -25,6 +25,9 @@ func (h *APIDelegate) receipt(s string) {
 
 	rs := h.h.ctx.GValue("receipts").([]*tx.Receipt)
 	h.h.ctx.GSet("receipts", append(rs, rec))
+	if h.h.tracer != nil {
+		h.h.tracer.receipt(fn, s)
+	}
 
 	// post event for receipt
 	event.GetCollector().Post(event.NewEvent(event.ContractReceipt, rec.Content),
@@ -38,7 +38,7 @@ func (i *Isolator) TriggerBlockBaseMode() {
 // Prepare Isolator
 func (i *Isolator) Prepare(bh *block.BlockHead, db *database.Visitor, logger *ilog.Logger) error {
 	if db.Contract("system.iost") == nil {
-		db.SetContract(native.SystemABI())
+		db.SetContract(native.SystemContractABI("system.iost", "1.0.0"))
 	}
 	if bh.Number == 0 {
 		i.genesisMode = true
@@ -53,6 +53,11 @@ func (i *Isolator) Prepare(bh *block.BlockHead, db *database.Visitor, logger *il
 	return nil
 }
 
+// SetTracer sets the tracer to record the call tree of the following txs, nil to stop tracing
+func (i *Isolator) SetTracer(t *host.Tracer) {
+	i.h.SetTracer(t)
+}
+
 // PrepareTx read tx and ready to run
 func (i *Isolator) PrepareTx(t *tx.Tx, limit time.Duration) error {
 	i.t = t
@@ -62,7 +67,7 @@ func (i *Isolator) PrepareTx(t *tx.Tx, limit time.Duration) error {
 	i.h.PayCost(contract.NewCost(0, int64(l), 0), t.Publisher)
 
 	if !i.genesisMode && !i.blockBaseMode {
-		err := checkTxParams(t)
+		err := checkTxParams(t, i.blockBaseCtx.Value("number").(int64))
 		if err != nil {
 			return err
 		}
@@ -241,9 +246,77 @@ func (i *Isolator) Run() (*tx.TxReceipt, error) { // nolint
 		vmGasLimit -= actionCost.ToGas()
 		i.h.Context().GSet("gas_limit", vmGasLimit)
 	}
+	if i.blockBaseMode && !i.genesisMode && i.tr.Status.Code == tx.Success {
+		i.runScheduledCalls()
+	}
 	return i.tr, nil
 }
 
+// scheduledCallStatus is the receipt of a scheduled call run in the block base
+type scheduledCallStatus struct {
+	ID      string `json:"id"`
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+}
+
+// runScheduledCalls runs the calls scheduled by contracts which are due at the block in the block base.
+// A failed call is rolled back alone, the receipts of a call are followed by a receipt of its status.
+func (i *Isolator) runScheduledCalls() {
+	i.h.ClearCosts()
+	calls := i.h.PopScheduledCalls(host.MaxScheduledCallsPerBlock)
+	if len(calls) == 0 {
+		return
+	}
+	// the gas of the calls is paid when they are scheduled, only the ram is paid here
+	if _, err := i.h.DoPay(i.h.Context().Value("witness").(string), 0); err != nil {
+		ilog.Fatalf("return ram of scheduled calls failed: %v", err)
+	}
+	i.h.DB().Commit()
+	for _, call := range calls {
+		status, receipts := i.runScheduledCall(call)
+		i.tr.Receipts = append(i.tr.Receipts, receipts...)
+		s, _ := json.Marshal(&scheduledCallStatus{ID: call.ID, Code: int32(status.Code), Message: status.Message})
+		i.tr.Receipts = append(i.tr.Receipts, &tx.Receipt{FuncName: "system.iost/scheduledCall", Content: string(s)})
+	}
+	i.h.ClearCosts()
+}
+
+func (i *Isolator) runScheduledCall(call *database.ScheduledCall) (*tx.Status, []*tx.Receipt) {
+	i.h.ClearCosts()
+	action := tx.NewAction(call.Contract, call.API, call.Args)
+	t := &tx.Tx{
+		Publisher: call.Contract,
+		GasLimit:  call.GasLimit * 100,
+		GasRatio:  100,
+		Actions:   []*tx.Action{action},
+		Time:      i.blockBaseCtx.Value("time").(int64),
+		// no account signs the call, it can only spend what is approved to the contract
+		AmountLimit: []*contract.Amount{{Token: "*", Val: "unlimited"}},
+	}
+	loadTxInfo(i.h, t, call.Contract)
+	defer i.h.PopCtx()
+	i.h.Context().GSet("gas_limit", call.GasLimit)
+	i.h.Context().GSet("receipts", make([]*tx.Receipt, 0))
+
+	cost, status, _, receipts, err := i.runAction(*action)
+	if err == nil && status.Code == tx.Success {
+		if cost.ToGas() > call.GasLimit {
+			status = &tx.Status{Code: tx.ErrorRuntime, Message: "out of gas"}
+		} else {
+			i.h.PayCost(cost, call.Contract)
+			if _, err = i.h.DoPay(i.h.Context().Value("witness").(string), 0); err != nil {
+				status = &tx.Status{Code: tx.ErrorBalanceNotEnough, Message: err.Error()}
+			}
+		}
+	}
+	if status.Code != tx.Success {
+		i.h.DB().Rollback()
+		receipts = nil
+	}
+	i.h.DB().Commit()
+	return status, receipts
+}
+
 // PayCost as name
 func (i *Isolator) PayCost() (*tx.TxReceipt, error) {
 	if i.t.GasLimit < i.h.GasPaid()*i.t.GasRatio {
@@ -291,8 +364,8 @@ func (i *Isolator) ClearTx() {
 	i.h.ClearCosts()
 	i.h.DB().Rollback()
 }
-func checkTxParams(t *tx.Tx) error {
-	return t.CheckGas()
+func checkTxParams(t *tx.Tx, number int64) error {
+	return t.CheckGas(number)
 }
 
 func loadBlkInfo(ctx *host.Context, bh *block.BlockHead) *host.Context {
@@ -301,6 +374,9 @@ func loadBlkInfo(ctx *host.Context, bh *block.BlockHead) *host.Context {
 	c.Set("number", bh.Number)
 	c.Set("witness", bh.Witness)
 	c.Set("time", bh.Time)
+	if bh.Version >= block.V2 {
+		c.Set("random", common.Base58Encode(bh.Random()))
+	}
 	if bh.Time <= 1 {
 		panic(fmt.Sprintf("invalid blockhead time %v", bh.Time))
 	}
@@ -338,4 +414,6 @@ func loadTxInfo(h *host.Host, t *tx.Tx, publisherID string) {
 	h.Context().Set("auth_list", authList)
 	h.Context().Set("signer_list", signers)
 	h.Context().Set("auth_contract_list", make(map[string]int))
+	h.Context().Set("session_list", make(map[string]bool))
+	h.Context().Set("actions", t.Actions)
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/iost-official/go-iost/vm/host"
 	"github.com/iost-official/go-iost/vm/native"
 	"github.com/iost-official/go-iost/vm/v8vm"
+	"github.com/iost-official/go-iost/vm/wasm"
 )
 
 // Monitor ...
@@ -33,6 +34,7 @@ func NewMonitor() *Monitor {
 	}
 	jsvm := Factory("javascript")
 	m.vms["javascript"] = jsvm
+	m.vms["wasm"] = Factory("wasm")
 	return m
 }
 
@@ -102,6 +104,19 @@ func getAmountLimitMap(h *host.Host, amountList []*contract.Amount) (map[string]
 // Call ...
 // nolint
 func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn []interface{}, cost contract.Cost, err error) {
+	var ram int64
+	if tracer := h.Tracer(); tracer != nil {
+		tracer.Enter(contractName, api, jarg)
+		defer func() {
+			tracer.Exit(cost, ram, err)
+		}()
+	}
+
+	// migrate converts the storage of the old code, from system.iost 1.1.0 it's only called by system.iost when the code is updated
+	if api == "migrate" && h.Context().Value("contract_name") != "system.iost" && native.SystemVersionAtLeast(h, native.NativeVersion) {
+		return nil, host.CommonErrorCost(1), errors.New("migrate can only be called by system.iost")
+	}
+
 	c, abi, args, err := m.prepareContract(h, contractName, api, jarg)
 	if err != nil {
 		return nil, host.Costs["GetCost"], fmt.Errorf("prepare contract: %v", err)
@@ -125,6 +140,8 @@ func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn
 	switch c.Info.Lang {
 	case "javascript":
 		cost.AddAssign(host.Costs["JSCost"])
+	case "wasm":
+		cost.AddAssign(host.Costs["WASMCost"])
 	}
 
 	vm, ok := m.vms[c.Info.Lang]
@@ -175,19 +192,33 @@ func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn
 			receipts = h.Context().GValue("receipts").([]*tx.Receipt)
 		}
 		needLimit := make(map[string]*common.Fixed)
+		// tokens spent by accounts, they are checked with the spend limit of session permissions
+		spent := make(map[string]map[string]*common.Fixed)
 		for i := oldReceiptLen; i < len(receipts); i++ {
 			cost.AddAssign(host.CommonOpCost(1))
 			receipt := receipts[i]
 			token := ""
+			spender := ""
 			amount, _ := common.NewFixed("0", 0)
 			args := []interface{}{}
-			if receipt.FuncName == "token.iost/transfer" || receipt.FuncName == "token.iost/transferFreeze" {
+			if receipt.FuncName == "token.iost/transfer" || receipt.FuncName == "token.iost/transferFreeze" ||
+				receipt.FuncName == "token.iost/vest" || receipt.FuncName == "token.iost/batchTransfer" {
 				_ = json.Unmarshal([]byte(receipt.Content), &args)
 				token = args[0].(string)
 				from := args[1].(string)
 				to := args[2].(string)
 				if from != to && !h.IsContract(from) {
 					amount, _ = common.NewFixed(args[3].(string), h.DB().Decimal(token))
+					spender = from
+				}
+			} else if receipt.FuncName == "token.iost/approve" {
+				// the approved allowance may be spent later by transferFrom, so it's limited as transfer
+				_ = json.Unmarshal([]byte(receipt.Content), &args)
+				token = args[0].(string)
+				owner := args[1].(string)
+				if !h.IsContract(owner) {
+					amount, _ = common.NewFixed(args[3].(string), h.DB().Decimal(token))
+					spender = owner
 				}
 			} else if receipt.FuncName == "token.iost/destroy" {
 				_ = json.Unmarshal([]byte(receipt.Content), &args)
@@ -195,6 +226,7 @@ func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn
 				from := args[1].(string)
 				if !h.IsContract(from) {
 					amount, _ = common.NewFixed(args[2].(string), h.DB().Decimal(token))
+					spender = from
 				}
 			}
 			if token != "" && amount.Value >= 0 {
@@ -204,6 +236,16 @@ func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn
 					needLimit[token] = amount
 				}
 			}
+			if spender != "" && amount.Value > 0 {
+				if _, ok := spent[spender]; !ok {
+					spent[spender] = make(map[string]*common.Fixed)
+				}
+				if a, ok := spent[spender][token]; ok {
+					spent[spender][token] = a.Add(amount)
+				} else {
+					spent[spender][token] = amount
+				}
+			}
 		}
 		for token, amount := range needLimit {
 			if !checkLimit(amountLimit, token, amount) {
@@ -217,9 +259,15 @@ func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn
 						token, amount.ToString(), txAmountLimit)
 			}
 		}
+		cost0, err = h.CheckSessionSpend(spent)
+		cost.AddAssign(cost0)
+		if err != nil {
+			return nil, cost, err
+		}
 	}
 	// check ram auth
 	cacheCost := h.CacheCost()
+	ram = cacheCost.Data
 	h.FlushCacheCost()
 	payer := make(map[string]bool)
 	for _, c := range cacheCost.DataList {
@@ -240,26 +288,44 @@ func (m *Monitor) Call(h *host.Host, contractName, api string, jarg string) (rtn
 	return
 }
 
+// checkLang checks whether the language of the contract is enabled, wasm is enabled by system.iost 1.1.0
+func checkLang(h *host.Host, con *contract.Contract) error {
+	if con.Info.Lang == "wasm" && !native.SystemVersionAtLeast(h, native.NativeVersion) {
+		return fmt.Errorf("wasm is enabled by system.iost %v", native.NativeVersion)
+	}
+	return nil
+}
+
 // Compile ...
-func (m *Monitor) Compile(con *contract.Contract) (string, error) {
+func (m *Monitor) Compile(h *host.Host, con *contract.Contract) (string, error) {
+	if err := checkLang(h, con); err != nil {
+		return "", err
+	}
 	switch con.Info.Lang {
 	case "native":
 		return "", nil
 	case "javascript":
 		jsvm, _ := m.vms["javascript"]
 		return jsvm.Compile(con)
+	case "wasm":
+		return m.vms["wasm"].Compile(con)
 	}
 	return "", errors.New("vm unsupported")
 }
 
 // Validate ...
-func (m *Monitor) Validate(con *contract.Contract) error {
+func (m *Monitor) Validate(h *host.Host, con *contract.Contract) error {
+	if err := checkLang(h, con); err != nil {
+		return err
+	}
 	switch con.Info.Lang {
 	case "native":
 		return nil
 	case "javascript":
 		jsvm, _ := m.vms["javascript"]
 		return jsvm.Validate(con)
+	case "wasm":
+		return m.vms["wasm"].Validate(con)
 	}
 	return errors.New("vm unsupported")
 }
@@ -276,6 +342,10 @@ func Factory(lang string) VM {
 		vm.Init()
 		//vm.SetJSPath(jsPath)
 		return vm
+	case "wasm":
+		vm := wasm.NewVM()
+		vm.Init()
+		return vm
 	}
 	return nil
 }
@@ -2,6 +2,7 @@ package native
 
 import (
 	"sort"
+	"strings"
 
 	"fmt"
 	"github.com/iost-official/go-iost/core/contract"
@@ -10,6 +11,13 @@ import (
 	"strconv"
 )
 
+// NativeVersion is the latest version of system.iost, token.iost, token721.iost and domain.iost.
+// The abis added to them after 1.0.0 are only in this version, and the checks added to the old abis
+// are enabled by versionAtLeast, so the blocks before the upgrade are replayed the same.
+// A running chain switches each contract to it by admin@system calling system.iost updateNativeCode
+// with ["<contract id>", "1.1.0", ""]. Genesis keeps deploying the old versions, so new chains upgrade the same way.
+const NativeVersion = "1.1.0"
+
 var (
 	onlyAdminCanUpdateABI = &abi{
 		name: "can_update",
@@ -23,7 +31,7 @@ var (
 
 // SystemABI generate system.iost abi and contract
 func SystemABI() *contract.Contract {
-	return SystemContractABI("system.iost", "1.0.0")
+	return SystemContractABI("system.iost", NativeVersion)
 }
 
 // GasABI generate gas.iost abi and contract
@@ -33,17 +41,17 @@ func GasABI() *contract.Contract {
 
 // TokenABI generate token.iost abi and contract
 func TokenABI() *contract.Contract {
-	return SystemContractABI("token.iost", "1.0.0")
+	return SystemContractABI("token.iost", NativeVersion)
 }
 
 // Token721ABI generate token.iost abi and contract
 func Token721ABI() *contract.Contract {
-	return SystemContractABI("token721.iost", "1.0.0")
+	return SystemContractABI("token721.iost", NativeVersion)
 }
 
 // DomainABI generate domain.iost abi and contract
 func DomainABI() *contract.Contract {
-	return SystemContractABI("domain.iost", "1.0.0")
+	return SystemContractABI("domain.iost", NativeVersion)
 }
 
 // SystemContractABI return system contract abi
@@ -56,16 +64,20 @@ func SystemContractABI(conID, version string) *contract.Contract {
 func getABISetByVersion(conID string, version string) (aset *abiSet, err error) {
 	abiMap := make(map[string]map[string]*abiSet)
 	abiMap["system.iost"] = make(map[string]*abiSet)
-	abiMap["system.iost"]["1.0.0"] = systemABIs
+	abiMap["system.iost"]["1.0.0"] = system0ABIs
+	abiMap["system.iost"][NativeVersion] = systemABIs
 	abiMap["domain.iost"] = make(map[string]*abiSet)
 	abiMap["domain.iost"]["0.0.0"] = domain0ABIs
-	abiMap["domain.iost"]["1.0.0"] = domainABIs
+	abiMap["domain.iost"]["1.0.0"] = domain1ABIs
+	abiMap["domain.iost"][NativeVersion] = domainABIs
 	abiMap["gas.iost"] = make(map[string]*abiSet)
 	abiMap["gas.iost"]["1.0.0"] = gasABIs
 	abiMap["token.iost"] = make(map[string]*abiSet)
-	abiMap["token.iost"]["1.0.0"] = tokenABIs
+	abiMap["token.iost"]["1.0.0"] = token0ABIs
+	abiMap["token.iost"][NativeVersion] = tokenABIs
 	abiMap["token721.iost"] = make(map[string]*abiSet)
-	abiMap["token721.iost"]["1.0.0"] = token721ABIs
+	abiMap["token721.iost"]["1.0.0"] = token7210ABIs
+	abiMap["token721.iost"][NativeVersion] = token721ABIs
 
 	var amap map[string]*abiSet
 	var ok bool
@@ -84,6 +96,40 @@ func getABISetByVersion(conID string, version string) (aset *abiSet, err error)
 	return aset, nil
 }
 
+// versionAtLeast returns whether the version of the native contract being called is not older than version
+func versionAtLeast(h *host.Host, version string) bool {
+	v, _ := h.Context().Value("native_version").(string)
+	return compareVersion(v, version) >= 0
+}
+
+// SystemVersionAtLeast returns whether the deployed system.iost is not older than version
+func SystemVersionAtLeast(h *host.Host, version string) bool {
+	c := h.DB().Contract("system.iost")
+	return c != nil && compareVersion(c.Info.Version, version) >= 0
+}
+
+// compareVersion compares versions like 1.0.0 by their numbers
+func compareVersion(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int64
+		if i < len(as) {
+			x, _ = strconv.ParseInt(as[i], 10, 64)
+		}
+		if i < len(bs) {
+			y, _ = strconv.ParseInt(bs[i], 10, 64)
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 // ABI generate native abis
 func ABI(id string, abiSet *abiSet, version string) *contract.Contract {
 	c := &contract.Contract{
@@ -1,11 +1,13 @@
 package native
 
 import (
+	"encoding/json"
 	"errors"
 
 	"fmt"
 
 	"github.com/bitly/go-simplejson"
+	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/contract"
 	"github.com/iost-official/go-iost/vm/host"
 	"strings"
@@ -13,17 +15,161 @@ import (
 
 // DomainABIs list of domain abi
 var domainABIs *abiSet
+var domain1ABIs *abiSet
 var domain0ABIs *abiSet
 
 func init() {
 	domainABIs = newAbiSet()
-	domainABIs.Register(initDomainABI, true)
-	domainABIs.Register(linkDomainABI)
-	domainABIs.Register(transferDomainABI)
+	domain1ABIs = newAbiSet()
+	for _, as := range []*abiSet{domainABIs, domain1ABIs} {
+		as.Register(initDomainABI, true)
+		as.Register(linkDomainABI)
+		as.Register(transferDomainABI)
+	}
+	domainABIs.Register(renewDomainABI)
+	domainABIs.Register(setPremiumABI)
+	domainABIs.Register(bidABI)
+	domainABIs.Register(settleAuctionABI)
+	domainABIs.Register(claimAccountABI)
 	domain0ABIs = newAbiSet()
 	domain0ABIs.Register(initDomainABI, true)
 }
 
+// times of domains and auctions in seconds
+const (
+	// DomainTerm a url is held for one term after being linked or renewed
+	DomainTerm int64 = 365 * 24 * 3600
+	// DomainRenewWindow a url can be renewed in the last days of its term
+	DomainRenewWindow int64 = 30 * 24 * 3600
+	// AuctionDuration an auction ends after the duration since the first bid
+	AuctionDuration int64 = 3 * 24 * 3600
+	// AuctionExtension a bid near the end extends the auction to at least the extension after it
+	AuctionExtension int64 = 10 * 60
+	// AccountClaimPeriod a won account should be signed up in the period after the auction, or it can be auctioned again
+	AccountClaimPeriod int64 = 30 * 24 * 3600
+)
+
+// names not longer than these are premium, they should be won in auctions
+const (
+	PremiumAccountLength = 5
+	PremiumURLLength     = 8
+)
+
+// AuctionMinPrice min iost of a bid
+var AuctionMinPrice = &common.Fixed{Value: 100 * 1e8, Decimal: 8}
+
+// kinds of names in auctions
+const (
+	auctionAccount = "account"
+	auctionDomain  = "domain"
+)
+
+type auction struct {
+	Bidder string `json:"bidder"`
+	Amount string `json:"amount"`
+	End    int64  `json:"end"`
+}
+
+func checkAccountNameValid(name string) error {
+	if len(name) < 5 || len(name) > 11 {
+		return fmt.Errorf("id invalid. id length should be between 5,11 got %v", name)
+	}
+	if strings.HasPrefix(name, "Contract") {
+		return fmt.Errorf("id invalid. id shouldn't start with 'Contract'")
+	}
+	for _, ch := range name {
+		if !(ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '_') {
+			return fmt.Errorf("id invalid. id contains invalid character %v", ch)
+		}
+	}
+	return nil
+}
+
+func isPremium(h *host.Host, kind, name string) (bool, contract.Cost) {
+	if kind == auctionAccount && len(name) <= PremiumAccountLength || kind == auctionDomain && len(name) <= PremiumURLLength {
+		return true, host.CommonOpCost(1)
+	}
+	return h.MapHas("premium_"+kind, name)
+}
+
+// checkAuctionName checks the name is a valid premium name which has no owner
+func checkAuctionName(h *host.Host, kind, name string) (contract.Cost, error) {
+	cost := host.CommonOpCost(1)
+	switch kind {
+	case auctionAccount:
+		if err := checkAccountNameValid(name); err != nil {
+			return cost, err
+		}
+	case auctionDomain:
+		if err := checkURLValid(name); err != nil {
+			return cost, err
+		}
+		if strings.HasSuffix(name, ".iost") {
+			return cost, errors.New("url .iost can't be auctioned")
+		}
+	default:
+		return cost, fmt.Errorf("kind should be %v or %v, got %v", auctionAccount, auctionDomain, kind)
+	}
+	ok, cost0 := isPremium(h, kind, name)
+	cost.AddAssign(cost0)
+	if !ok {
+		return cost, fmt.Errorf("%v %v is not premium, sign up or link it directly", kind, name)
+	}
+	cost.AddAssign(host.Costs["GetCost"])
+	if kind == auctionAccount {
+		if h.IsValidAccount(name) {
+			return cost, fmt.Errorf("account %v exists", name)
+		}
+		winner, cost0 := h.MapGet("account_winner", name)
+		cost.AddAssign(cost0)
+		if winner != nil && !claimEnded(h, name) {
+			return cost, fmt.Errorf("account %v is won by %v", name, winner)
+		}
+	} else if owner := h.URLOwner(name); owner != "" {
+		return cost, fmt.Errorf("url %v is owned by %v", name, owner)
+	}
+	return cost, nil
+}
+
+// claimEnded checks the claim period of a won account is over
+func claimEnded(h *host.Host, name string) bool {
+	end, _ := h.MapGet("account_claim_end", name)
+	t, ok := end.(int64)
+	return ok && h.Context().Value("time").(int64) >= t
+}
+
+// delWinner removes the winner of an account once it is claimed or auctioned again
+func delWinner(h *host.Host, name string) (contract.Cost, error) {
+	cost, err := h.MapDel("account_winner", name)
+	if err != nil {
+		return cost, err
+	}
+	cost0, err := h.MapDel("account_claim_end", name)
+	cost.AddAssign(cost0)
+	return cost, err
+}
+
+func getAuction(h *host.Host, kind, name string) (*auction, contract.Cost) {
+	v, cost := h.MapGet(kind+"_auction", name)
+	s, ok := v.(string)
+	if !ok {
+		return nil, cost
+	}
+	a := &auction{}
+	if err := json.Unmarshal([]byte(s), a); err != nil {
+		panic(err)
+	}
+	return a, cost
+}
+
+func setAuction(h *host.Host, kind, name string, a *auction) (contract.Cost, error) {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return host.CommonErrorCost(1), err
+	}
+	return h.MapPut(kind+"_auction", name, string(b))
+}
+
 func checkURLValid(name string) error {
 	if len(name) < 5 || len(name) > 16 {
 		return fmt.Errorf("url invalid. url length should be between 5,16 got %v", name)
@@ -92,10 +238,32 @@ var (
 				return nil, cost, errors.New("no permission of claimed url")
 			}
 
+			// from 1.1.0 a new url is held for one term, urls of .iost never expire
+			v110 := versionAtLeast(h, NativeVersion)
+			expires := v110 && owner == "" && !strings.HasSuffix(url, ".iost")
+			if expires {
+				ok, c = isPremium(h, auctionDomain, url)
+				cost.AddAssign(c)
+				if ok {
+					return nil, cost, errors.New("premium url should be won in auction")
+				}
+			}
+			if v110 && owner == "" {
+				// remove the link of the expired url
+				if oldCid := h.LinkedContractID(url); oldCid != "" {
+					h.RemoveLink(url, oldCid)
+					cost.AddAssign(host.Costs["DelCost"])
+				}
+			}
+
 			h.WriteLink(url, cid, applicant)
 			cost.AddAssign(host.Costs["PutCost"])
 			cost.AddAssign(host.Costs["PutCost"])
 			cost.AddAssign(host.Costs["PutCost"])
+			if expires {
+				h.SetURLExpiry(url, h.Context().Value("time").(int64)+DomainTerm*1e9)
+				cost.AddAssign(host.Costs["PutCost"])
+			}
 
 			return nil, cost, nil
 		},
@@ -143,4 +311,290 @@ var (
 
 		},
 	}
+	renewDomainABI = &abi{
+		name: "renew",
+		args: []string{"string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			url := args[0].(string)
+
+			txInfo, c := h.TxInfo()
+			cost.AddAssign(c)
+			tij, err := simplejson.NewJson(txInfo)
+			if err != nil {
+				panic(err)
+			}
+
+			applicant := tij.Get("publisher").MustString()
+
+			cost.AddAssign(host.Costs["GetCost"])
+			owner := h.DNS.URLOwner(url)
+			if owner != applicant {
+				cost.AddAssign(host.CommonErrorCost(1))
+				return nil, cost, errors.New("no privilege of claimed url")
+			}
+
+			ok, c := h.RequireAuth(applicant, DomainPermission)
+			cost.AddAssign(c)
+			if !ok {
+				return nil, cost, errors.New("no permission of claimed url")
+			}
+
+			cost.AddAssign(host.Costs["GetCost"])
+			expiry := h.URLExpiry(url)
+			if expiry == 0 {
+				return nil, cost, errors.New("url never expires")
+			}
+			now := h.Context().Value("time").(int64)
+			if now < expiry-DomainRenewWindow*1e9 {
+				return nil, cost, fmt.Errorf("url can be renewed after %v", expiry-DomainRenewWindow*1e9)
+			}
+			h.SetURLExpiry(url, expiry+DomainTerm*1e9)
+			cost.AddAssign(host.Costs["PutCost"])
+
+			return nil, cost, nil
+		},
+	}
+	setPremiumABI = &abi{
+		name: "setPremium",
+		args: []string{"string", "string", "bool"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			kind := args[0].(string)
+			name := args[1].(string)
+			premium := args[2].(bool)
+
+			ok, c := h.RequireAuth(AdminAccount, DomainPermission)
+			cost.AddAssign(c)
+			if !ok {
+				return nil, cost, errors.New("only admin can set premium names")
+			}
+			cost.AddAssign(host.CommonOpCost(1))
+			switch kind {
+			case auctionAccount:
+				err = checkAccountNameValid(name)
+			case auctionDomain:
+				err = checkURLValid(name)
+			default:
+				err = fmt.Errorf("kind should be %v or %v, got %v", auctionAccount, auctionDomain, kind)
+			}
+			if err != nil {
+				return nil, cost, err
+			}
+
+			ok, c = h.MapHas("premium_"+kind, name)
+			cost.AddAssign(c)
+			if premium && !ok {
+				c, err = h.MapPut("premium_"+kind, name, true)
+			} else if !premium && ok {
+				c, err = h.MapDel("premium_"+kind, name)
+			}
+			cost.AddAssign(c)
+			if err != nil {
+				return nil, cost, err
+			}
+			return []interface{}{}, cost, nil
+		},
+	}
+	bidABI = &abi{
+		name: "bid",
+		args: []string{"string", "string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			kind := args[0].(string)
+			name := args[1].(string)
+			bidder := args[2].(string)
+			amountStr := args[3].(string)
+
+			cost0, err := checkAuctionName(h, kind, name)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			ok, cost0 := h.RequireAuth(bidder, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			amount, err := common.NewFixed(amountStr, 8)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil || amount.LessThan(AuctionMinPrice) {
+				return nil, cost, fmt.Errorf("bid should be at least %v", AuctionMinPrice.ToString())
+			}
+
+			now := h.Context().Value("time").(int64)
+			a, cost0 := getAuction(h, kind, name)
+			cost.AddAssign(cost0)
+			var last *auction
+			if a == nil {
+				a = &auction{End: now + AuctionDuration*1e9}
+				if kind == auctionAccount {
+					// the winner of the last auction didn't claim the account in time
+					ok, cost0 := h.MapHas("account_winner", name)
+					cost.AddAssign(cost0)
+					if ok {
+						cost0, err = delWinner(h, name)
+						cost.AddAssign(cost0)
+						if err != nil {
+							return nil, cost, err
+						}
+					}
+				}
+			} else {
+				if now >= a.End {
+					return nil, cost, fmt.Errorf("auction of %v ended, settle it", name)
+				}
+				highest, err := common.NewFixed(a.Amount, 8)
+				if err != nil {
+					return nil, cost, err
+				}
+				if amount.LessThan(highest.Add(highest.Div(10))) {
+					return nil, cost, fmt.Errorf("bid should be 10%% higher than %v", a.Amount)
+				}
+				last = &auction{Bidder: a.Bidder, Amount: a.Amount}
+			}
+
+			// the bid is escrowed in the contract until the auction is settled or outbid
+			contractName, cost0 := h.ContractName()
+			cost.AddAssign(cost0)
+			_, cost0, err = h.Call("token.iost", "transfer",
+				fmt.Sprintf(`["iost", "%v", "%v", "%v", "bid for %v %v"]`, bidder, contractName, amount.ToString(), kind, name))
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if last != nil {
+				_, cost0, err = h.CallWithAuth("token.iost", "transfer",
+					fmt.Sprintf(`["iost", "%v", "%v", "%v", "refund of %v %v"]`, contractName, last.Bidder, last.Amount, kind, name))
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
+
+			if a.End-now < AuctionExtension*1e9 {
+				a.End = now + AuctionExtension*1e9
+			}
+			a.Bidder = bidder
+			a.Amount = amount.ToString()
+			cost0, err = setAuction(h, kind, name, a)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			message, err := json.Marshal([]interface{}{kind, name, bidder, a.Amount, a.End})
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost.AddAssign(h.Receipt(string(message)))
+			return []interface{}{}, cost, nil
+		},
+	}
+	settleAuctionABI = &abi{
+		name: "settle",
+		args: []string{"string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			kind := args[0].(string)
+			name := args[1].(string)
+
+			a, cost0 := getAuction(h, kind, name)
+			cost.AddAssign(cost0)
+			if a == nil {
+				return nil, cost, fmt.Errorf("no auction of %v", name)
+			}
+			now := h.Context().Value("time").(int64)
+			if now < a.End {
+				return nil, cost, fmt.Errorf("auction of %v ends at %v", name, a.End)
+			}
+			cost0, err = h.MapDel(kind+"_auction", name)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			contractName, cost0 := h.ContractName()
+			cost.AddAssign(cost0)
+			// the name may be taken by admin during the auction, then the bid is refunded
+			cost.AddAssign(host.Costs["GetCost"])
+			taken := kind == auctionAccount && h.IsValidAccount(name) || kind == auctionDomain && h.URLOwner(name) != ""
+			if taken {
+				_, cost0, err = h.CallWithAuth("token.iost", "transfer",
+					fmt.Sprintf(`["iost", "%v", "%v", "%v", "refund of %v %v"]`, contractName, a.Bidder, a.Amount, kind, name))
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+			} else {
+				if kind == auctionAccount {
+					cost0, err = h.MapPut("account_winner", name, a.Bidder)
+					cost.AddAssign(cost0)
+					if err != nil {
+						return nil, cost, err
+					}
+					cost0, err = h.MapPut("account_claim_end", name, now+AccountClaimPeriod*1e9)
+					cost.AddAssign(cost0)
+					if err != nil {
+						return nil, cost, err
+					}
+				} else {
+					if oldCid := h.LinkedContractID(name); oldCid != "" {
+						h.RemoveLink(name, oldCid)
+						cost.AddAssign(host.Costs["DelCost"])
+					}
+					h.URLTransfer(name, a.Bidder)
+					h.SetURLExpiry(name, now+DomainTerm*1e9)
+					cost.AddAssign(host.Costs["PutCost"])
+					cost.AddAssign(host.Costs["PutCost"])
+				}
+				// the winning bid is burned
+				_, cost0, err = h.CallWithAuth("token.iost", "destroy",
+					fmt.Sprintf(`["iost", "%v", "%v"]`, contractName, a.Amount))
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
+
+			message, err := json.Marshal([]interface{}{kind, name, a.Bidder, a.Amount, !taken})
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost.AddAssign(h.Receipt(string(message)))
+			return []interface{}{}, cost, nil
+		},
+	}
+	claimAccountABI = &abi{
+		name: "claimAccount",
+		args: []string{"string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			name := args[0].(string)
+			claimer := args[1].(string)
+
+			ok, cost0 := h.RequireAuth("account.iost", "active")
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, errors.New("only account.iost can claim accounts")
+			}
+			winner, cost0 := h.MapGet("account_winner", name)
+			cost.AddAssign(cost0)
+			if winner != claimer {
+				return nil, cost, fmt.Errorf("account %v is not won by %v", name, claimer)
+			}
+			cost.AddAssign(host.Costs["GetCost"])
+			if claimEnded(h, name) {
+				return nil, cost, fmt.Errorf("claim of account %v ended", name)
+			}
+			cost0, err = delWinner(h, name)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			return []interface{}{}, cost, nil
+		},
+	}
 )
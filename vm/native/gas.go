@@ -20,6 +20,9 @@ var gasABIs *abiSet
 // GasContractName the contract name
 const GasContractName = "gas.iost"
 
+// RentContractName the market contract which pledges rented gas for users
+const RentContractName = "rent.iost"
+
 func init() {
 	gasABIs = newAbiSet()
 	gasABIs.Register(initFunc, true)
@@ -35,14 +38,32 @@ func pledge(h *host.Host, pledger string, name string, pledgeAmountF *common.Fix
 	if pledgeAmountF.IsZero() {
 		return finalCost, fmt.Errorf("invalid pledge amount %v", pledgeAmountF.ToString())
 	}
+	if pledger == RentContractName && pledgeAmountF.IsPositive() {
+		// rented gas is unpledged on expiry, the user should keep the min pledge without it
+		totalPledge, cost := h.GasPledgeTotal(name)
+		finalCost.AddAssign(cost)
+		rented, cost := h.GasManager.GasPledge(name, RentContractName)
+		finalCost.AddAssign(cost)
+		if totalPledge.Sub(rented).LessThan(database.GasMinPledgeOfUser) {
+			return finalCost, fmt.Errorf("gas can be rented only by users who pledge %v", database.GasMinPledgeOfUser.ToString())
+		}
+	}
 	if pledgeAmountF.IsNegative() {
 		// do some checking
 		unpledgeAmount := pledgeAmountF.Neg()
-		// check total pledge is valid
+		// check total pledge is valid, the rented gas is not counted in the min pledge and it can always be unpledged
 		oldTotalPledge, cost := h.GasPledgeTotal(name)
 		finalCost.AddAssign(cost)
-		if oldTotalPledge.Sub(unpledgeAmount).LessThan(database.GasMinPledgeOfUser) {
-			return finalCost, fmt.Errorf("unpledge to much %v - %v less than %v", oldTotalPledge.ToString(), unpledgeAmount.ToString(), database.GasMinPledgeOfUser.ToString())
+		minPledge := database.GasMinPledgeOfUser
+		if pledger == RentContractName {
+			minPledge = &common.Fixed{Value: 0, Decimal: 8}
+		} else {
+			rented, cost := h.GasManager.GasPledge(name, RentContractName)
+			finalCost.AddAssign(cost)
+			minPledge = minPledge.Add(rented)
+		}
+		if oldTotalPledge.Sub(unpledgeAmount).LessThan(minPledge) {
+			return finalCost, fmt.Errorf("unpledge to much %v - %v less than %v", oldTotalPledge.ToString(), unpledgeAmount.ToString(), minPledge.ToString())
 		}
 		// check personal pledge
 		pledged, cost := h.GasManager.GasPledge(name, pledger)
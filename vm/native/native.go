@@ -97,6 +97,7 @@ func (i *Impl) LoadAndCall(h *host.Host, con *contract.Contract, api string, arg
 		return nil, cost, err
 	}
 
+	h.Context().Set("native_version", con.Info.Version)
 	cost = host.CommonErrorCost(1)
 	a, ok = aset.Get(api)
 	if !ok {
@@ -2,6 +2,7 @@ package native
 
 import (
 	"errors"
+	"fmt"
 
 	"encoding/json"
 
@@ -11,17 +12,29 @@ import (
 )
 
 var systemABIs *abiSet
+var system0ABIs *abiSet
 
 func init() {
+	system0ABIs = newAbiSet()
 	systemABIs = newAbiSet()
-	systemABIs.Register(requireAuth)
-	systemABIs.Register(receipt)
-	systemABIs.Register(setCode)
-	systemABIs.Register(updateCode)
-	systemABIs.Register(initSetCode)
-	systemABIs.Register(cancelDelaytx)
-	systemABIs.Register(hostSettings)
-	systemABIs.Register(updateNativeCode)
+	for _, as := range []*abiSet{system0ABIs, systemABIs} {
+		as.Register(requireAuth)
+		as.Register(receipt)
+		as.Register(setCode)
+		as.Register(updateCode)
+		as.Register(initSetCode)
+		as.Register(cancelDelaytx)
+		as.Register(hostSettings)
+		as.Register(updateNativeCode)
+	}
+	systemABIs.Register(setUpgradePolicyABI)
+	systemABIs.Register(proposeUpgradeABI)
+	systemABIs.Register(approveUpgradeABI)
+	systemABIs.Register(applyUpgradeABI)
+	systemABIs.Register(cancelUpgradeABI)
+	systemABIs.Register(indexKeys)
+	systemABIs.Register(scheduleCall)
+	systemABIs.Register(cancelScheduledCall)
 }
 
 // var .
@@ -84,6 +97,15 @@ var (
 			actID := "Contract" + id
 			con.ID = actID
 
+			// the keys of contracts deployed by 1.1.0 are indexed from the beginning
+			if versionAtLeast(h, NativeVersion) {
+				cost1, err = h.IndexKeys(actID, "", nil)
+				cost.AddAssign(cost1)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
+
 			publisher := h.Context().Value("publisher").(string)
 			cost2, err := h.SetCode(con, publisher)
 			cost.AddAssign(cost2)
@@ -124,8 +146,29 @@ var (
 				}
 			}
 
+			// upgrade policies are set by the abis of 1.1.0
+			if versionAtLeast(h, NativeVersion) {
+				policy, cost1, err := getUpgradePolicy(h, con.ID)
+				cost.AddAssign(cost1)
+				if err != nil {
+					return nil, cost, err
+				}
+				if policy != nil {
+					return nil, cost, fmt.Errorf("contract %v has an upgrade policy, use proposeUpgrade and applyUpgrade", con.ID)
+				}
+			}
+
 			cost1, err := h.UpdateCode(con, []byte(args[1].(string)))
 			cost.AddAssign(cost1)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// migrate is called by the abis of 1.1.0
+			if versionAtLeast(h, NativeVersion) {
+				cost1, err = migrate(h, con)
+				cost.AddAssign(cost1)
+			}
 			return []interface{}{}, cost, err
 		},
 	}
@@ -212,6 +255,44 @@ var (
 		},
 	}
 
+	// scheduleCall schedules a call of a contract api, the contract should give its authority
+	scheduleCall = &abi{
+		name: "scheduleCall",
+		args: []string{"string", "string", "json", "number", "number"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			conID := args[0].(string)
+			cost = host.CommonOpCost(1)
+			var callArgs []interface{}
+			if err := json.Unmarshal(args[2].([]byte), &callArgs); err != nil {
+				return nil, cost, fmt.Errorf("invalid args: %v", err)
+			}
+			ok, cost0 := h.RequireAuth(conID, "active")
+			cost.AddAssign(cost0)
+			if !ok || !h.IsContract(conID) {
+				return nil, cost, fmt.Errorf("schedule call need %v@active permission of the contract", conID)
+			}
+			id, cost0, err := h.ScheduleCall(conID, args[1].(string), string(args[2].([]byte)), args[3].(int64), args[4].(int64))
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			return []interface{}{id}, cost, nil
+		},
+	}
+
+	// cancelScheduledCall cancels a scheduled call, returns false if it has run
+	cancelScheduledCall = &abi{
+		name: "cancelScheduledCall",
+		args: []string{"string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			ok, cost, err := h.CancelScheduledCall(args[0].(string))
+			if err != nil {
+				return nil, cost, err
+			}
+			return []interface{}{ok}, cost, nil
+		},
+	}
+
 	// hostSettings set host json
 	hostSettings = &abi{
 		name: "hostSettings",
@@ -229,4 +310,30 @@ var (
 			return nil, cost, nil
 		},
 	}
+
+	// indexKeys indexes the keys of a contract written before its keys are indexed, or the fields of one of its maps,
+	// so the contract can list them with storage keys and mapFields. The index ram is paid by the payer of each key.
+	indexKeys = &abi{
+		name: "indexKeys",
+		args: []string{"string", "string", "json"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			conID := args[0].(string)
+			cost = host.CommonOpCost(1)
+			var keys []string
+			if err := json.Unmarshal(args[2].([]byte), &keys); err != nil {
+				return nil, cost, fmt.Errorf("invalid keys: %v", err)
+			}
+			if h.DB().Contract(conID) == nil {
+				return nil, cost, fmt.Errorf("contract %v not found", conID)
+			}
+			_, cost0, err := requireContractOwner(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0, err = h.IndexKeys(conID, args[1].(string), keys)
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, err
+		},
+	}
 )
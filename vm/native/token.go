@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
 
 	"github.com/iost-official/go-iost/common"
 	"github.com/iost-official/go-iost/core/contract"
@@ -14,12 +15,20 @@ import (
 )
 
 var tokenABIs *abiSet
+var token0ABIs *abiSet
+
+// maxBatchTransfer is the max count of transfers in one batchTransfer
+const maxBatchTransfer = 1000
 
 // const prefix
 const (
 	TokenInfoMapPrefix            = "TI"
 	TokenBalanceMapPrefix         = "TB"
 	TokenFreezeMapPrefix          = "TF"
+	TokenAllowanceMapPrefix       = "TA"
+	TokenBlacklistMapPrefix       = "TL"
+	TokenVestingMapPrefix         = "TV"
+	TokenVestingCountKey          = "TVN"
 	IssuerMapField                = "issuer"
 	SupplyMapField                = "supply"
 	TotalSupplyMapField           = "totalSupply"
@@ -28,19 +37,46 @@ const (
 	DefaultRateMapField           = "defaultRate"
 	DecimalMapField               = "decimal"
 	FullNameMapField              = "fullName"
+	URLMapField                   = "url"
+	LogoMapField                  = "logo"
+	PausedMapField                = "paused"
+	CanPauseMapField              = "canPause"
+	CanBlacklistMapField          = "canBlacklist"
+	CanUpdateMetadataMapField     = "canUpdateMetadata"
+	CanChangeIssuerMapField       = "canChangeIssuer"
 )
 
+// controls of token which can be enabled by the config at creation
+var tokenControlFields = []string{CanPauseMapField, CanBlacklistMapField, CanUpdateMetadataMapField, CanChangeIssuerMapField}
+
 func init() {
+	token0ABIs = newAbiSet()
 	tokenABIs = newAbiSet()
-	tokenABIs.Register(initTokenABI, true)
-	tokenABIs.Register(createTokenABI)
-	tokenABIs.Register(issueTokenABI)
-	tokenABIs.Register(transferTokenABI)
-	tokenABIs.Register(transferFreezeTokenABI)
-	tokenABIs.Register(balanceOfTokenABI)
-	tokenABIs.Register(supplyTokenABI)
-	tokenABIs.Register(totalSupplyTokenABI)
-	tokenABIs.Register(destroyTokenABI)
+	for _, as := range []*abiSet{token0ABIs, tokenABIs} {
+		as.Register(initTokenABI, true)
+		as.Register(createTokenABI)
+		as.Register(issueTokenABI)
+		as.Register(transferTokenABI)
+		as.Register(transferFreezeTokenABI)
+		as.Register(balanceOfTokenABI)
+		as.Register(supplyTokenABI)
+		as.Register(totalSupplyTokenABI)
+		as.Register(destroyTokenABI)
+	}
+	tokenABIs.Register(batchTransferTokenABI)
+	tokenABIs.Register(approveTokenABI)
+	tokenABIs.Register(allowanceTokenABI)
+	tokenABIs.Register(transferFromTokenABI)
+	tokenABIs.Register(revokeTokenABI)
+	tokenABIs.Register(pauseTokenABI)
+	tokenABIs.Register(unpauseTokenABI)
+	tokenABIs.Register(blacklistTokenABI)
+	tokenABIs.Register(unblacklistTokenABI)
+	tokenABIs.Register(updateMetadataTokenABI)
+	tokenABIs.Register(changeIssuerTokenABI)
+	tokenABIs.Register(vestTokenABI)
+	tokenABIs.Register(claimTokenABI)
+	tokenABIs.Register(revokeVestingTokenABI)
 }
 
 func checkTokenExists(h *host.Host, tokenSym string) (ok bool, cost contract.Cost) {
@@ -158,6 +194,195 @@ func freezeBalance(h *host.Host, tokenSym string, from string, balance int64, ft
 	return cost, nil
 }
 
+// allowanceField is the field of the allowance in the map of the owner
+func allowanceField(tokenSym string, spender string) string {
+	return tokenSym + ":" + spender
+}
+
+func getAllowance(h *host.Host, tokenSym string, owner string, spender string) (allowance int64, cost contract.Cost) {
+	tmp, cost := h.MapGet(TokenAllowanceMapPrefix+owner, allowanceField(tokenSym, spender))
+	if tmp == nil {
+		return 0, cost
+	}
+	return tmp.(int64), cost
+}
+
+// setAllowance puts the allowance paid by the owner, zero allowance is deleted to release the ram
+func setAllowance(h *host.Host, tokenSym string, owner string, spender string, allowance int64) (cost contract.Cost, err error) {
+	field := allowanceField(tokenSym, spender)
+	ok, cost := h.MapHas(TokenAllowanceMapPrefix+owner, field)
+	var cost0 contract.Cost
+	if allowance == 0 {
+		if !ok {
+			return cost, nil
+		}
+		cost0, err = h.MapDel(TokenAllowanceMapPrefix+owner, field)
+	} else if ok {
+		cost0, err = h.MapPut(TokenAllowanceMapPrefix+owner, field, allowance)
+	} else {
+		cost0, err = h.MapPut(TokenAllowanceMapPrefix+owner, field, allowance, owner)
+	}
+	cost.AddAssign(cost0)
+	return cost, err
+}
+
+// vestingConfig is the schedule in the config of vest, times are in nanoseconds as the ftime of transferFreeze
+type vestingConfig struct {
+	Start     int64 `json:"start"`
+	Cliff     int64 `json:"cliff"`
+	Duration  int64 `json:"duration"`
+	Interval  int64 `json:"interval"`
+	Revocable bool  `json:"revocable"`
+}
+
+func (c *vestingConfig) check() error {
+	if c.Start < 0 || c.Duration <= 0 || c.Start > math.MaxInt64-c.Duration {
+		return fmt.Errorf("invalid vesting start %v or duration %v", c.Start, c.Duration)
+	}
+	if c.Cliff < 0 || c.Cliff > c.Duration {
+		return fmt.Errorf("invalid vesting cliff %v, should be between 0,%v", c.Cliff, c.Duration)
+	}
+	if c.Interval <= 0 || c.Interval > c.Duration {
+		return fmt.Errorf("invalid vesting interval %v, should be between 1,%v", c.Interval, c.Duration)
+	}
+	return nil
+}
+
+// vestingMapKey is the map of the schedules of token granted to holder, its fields are the ids of the schedules
+func vestingMapKey(tokenSym string, holder string) string {
+	return TokenVestingMapPrefix + tokenSym + "#" + holder
+}
+
+// getVesting returns the schedule id of token granted to holder, or nil if it doesn't exist
+func getVesting(h *host.Host, tokenSym string, holder string, id int64) (v *database.VestingItem, cost contract.Cost, err error) {
+	vestingJSON, cost := h.MapGet(vestingMapKey(tokenSym, holder), strconv.FormatInt(id, 10))
+	if vestingJSON == nil {
+		return nil, cost, nil
+	}
+	v = &database.VestingItem{}
+	err = json.Unmarshal([]byte(vestingJSON.(database.SerializedJSON)), v)
+	cost.AddAssign(host.CommonOpCost(1))
+	return v, cost, err
+}
+
+// setVesting puts the schedule of holder, the schedule fully claimed is deleted to release the ram
+func setVesting(h *host.Host, tokenSym string, holder string, v *database.VestingItem, ramPayer ...string) (cost contract.Cost, err error) {
+	field := strconv.FormatInt(v.ID, 10)
+	if v.Claimed >= v.Amount {
+		return h.MapDel(vestingMapKey(tokenSym, holder), field)
+	}
+	vestingJSON, err := json.Marshal(v)
+	cost = host.CommonOpCost(1)
+	if err != nil {
+		return cost, err
+	}
+	cost0, err := h.MapPut(vestingMapKey(tokenSym, holder), field, database.SerializedJSON(vestingJSON), ramPayer...)
+	cost.AddAssign(cost0)
+	return cost, err
+}
+
+// nextVestingID returns the id of the new vesting schedule, ids are unique in token.iost
+func nextVestingID(h *host.Host, ramPayer string) (id int64, cost contract.Cost, err error) {
+	tmp, cost := h.Get(TokenVestingCountKey)
+	var cost0 contract.Cost
+	if tmp == nil {
+		cost0, err = h.Put(TokenVestingCountKey, int64(1), ramPayer)
+	} else {
+		id = tmp.(int64)
+		cost0, err = h.Put(TokenVestingCountKey, id+1)
+	}
+	cost.AddAssign(cost0)
+	return id, cost, err
+}
+
+// checkTokenControls checks the pause of the token and the blacklist for the accounts.
+func checkTokenControls(h *host.Host, tokenSym string, accounts ...string) (cost contract.Cost, err error) {
+	cost = contract.Cost0()
+	// the controls are set by the abis of 1.1.0, 1.0.0 keeps its cost without checking them
+	if !versionAtLeast(h, NativeVersion) {
+		return cost, nil
+	}
+	canPause, cost0 := h.MapHas(TokenInfoMapPrefix+tokenSym, CanPauseMapField)
+	cost.AddAssign(cost0)
+	if canPause {
+		paused, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, PausedMapField)
+		cost.AddAssign(cost0)
+		if p, _ := paused.(bool); p {
+			return cost, host.ErrTokenPaused
+		}
+	}
+	canBlacklist, cost0 := h.MapHas(TokenInfoMapPrefix+tokenSym, CanBlacklistMapField)
+	cost.AddAssign(cost0)
+	if canBlacklist {
+		for _, acc := range accounts {
+			ok, cost0 := h.MapHas(TokenBlacklistMapPrefix+tokenSym, acc)
+			cost.AddAssign(cost0)
+			if ok {
+				return cost, fmt.Errorf("account %v is blacklisted", acc)
+			}
+		}
+	}
+	return cost, nil
+}
+
+// parseTokenConfig returns the metadata and controls set in the config of create
+func parseTokenConfig(config map[string]interface{}) (metadata map[string]string, controls map[string]bool, err error) {
+	metadata = make(map[string]string)
+	for _, field := range []string{URLMapField, LogoMapField} {
+		if tmp, ok := config[field]; ok {
+			value, ok := tmp.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("%v in config should be string", field)
+			}
+			if err = checkTokenMetadata(field, value); err != nil {
+				return nil, nil, err
+			}
+			metadata[field] = value
+		}
+	}
+	controls = make(map[string]bool)
+	for _, field := range tokenControlFields {
+		if tmp, ok := config[field]; ok {
+			enabled, ok := tmp.(bool)
+			if !ok {
+				return nil, nil, fmt.Errorf("%v in config should be bool", field)
+			}
+			controls[field] = enabled
+		}
+	}
+	return metadata, controls, nil
+}
+
+// requireTokenControl checks the control is enabled and the issuer has authorized, it returns the issuer
+func requireTokenControl(h *host.Host, tokenSym string, control string) (issuer string, cost contract.Cost, err error) {
+	ok, cost := checkTokenExists(h, tokenSym)
+	if !ok {
+		return "", cost, host.ErrTokenNotExists
+	}
+	enabled, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, control)
+	cost.AddAssign(cost0)
+	if e, _ := enabled.(bool); !e {
+		return "", cost, fmt.Errorf("%v: %v", host.ErrTokenControlDisabled, control)
+	}
+	tmp, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, IssuerMapField)
+	cost.AddAssign(cost0)
+	issuer = tmp.(string)
+	ok, cost0 = h.RequireAuth(issuer, TokenPermission)
+	cost.AddAssign(cost0)
+	if !ok {
+		return "", cost, host.ErrPermissionLost
+	}
+	return issuer, cost, nil
+}
+
+func checkTokenMetadata(field string, value string) error {
+	maxLen := map[string]int{FullNameMapField: 50, URLMapField: 256, LogoMapField: 128}[field]
+	if len(value) > maxLen {
+		return fmt.Errorf("%v is too long", field)
+	}
+	return nil
+}
+
 func parseAmount(h *host.Host, tokenSym string, amountStr string) (amount int64, cost contract.Cost, err error) {
 	decimal, cost := h.MapGet(TokenInfoMapPrefix+tokenSym, DecimalMapField)
 	amountNumber, err := common.NewFixed(amountStr, int(decimal.(int64)))
@@ -259,6 +484,16 @@ var (
 					return nil, cost, errors.New("fullName is too long")
 				}
 			}
+			// optional metadata and controls are only stored when set, 1.0.0 ignores them
+			metadata := make(map[string]string)
+			controls := make(map[string]bool)
+			if versionAtLeast(h, NativeVersion) {
+				metadata, controls, err = parseTokenConfig(config)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
+			cost.AddAssign(host.CommonOpCost(len(metadata) + len(controls)))
 			if !CheckCost(h, cost) {
 				return nil, cost, host.ErrOutOfGas
 			}
@@ -306,6 +541,18 @@ var (
 			cost.AddAssign(cost0)
 			cost0, _ = h.MapPut(TokenInfoMapPrefix+tokenSym, FullNameMapField, fullName, issuer)
 			cost.AddAssign(cost0)
+			for _, field := range []string{URLMapField, LogoMapField} {
+				if value, ok := metadata[field]; ok && value != "" {
+					cost0, _ = h.MapPut(TokenInfoMapPrefix+tokenSym, field, value, issuer)
+					cost.AddAssign(cost0)
+				}
+			}
+			for _, field := range tokenControlFields {
+				if controls[field] {
+					cost0, _ = h.MapPut(TokenInfoMapPrefix+tokenSym, field, true, issuer)
+					cost.AddAssign(cost0)
+				}
+			}
 
 			// generate receipt
 			message, err := json.Marshal(args)
@@ -336,6 +583,12 @@ var (
 			if !ok {
 				return nil, cost, host.ErrTokenNotExists
 			}
+
+			cost0, err = checkTokenControls(h, tokenSym, to)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
 			issuer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, IssuerMapField)
 			cost.AddAssign(cost0)
 			supply, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, SupplyMapField)
@@ -436,6 +689,12 @@ var (
 			if !ok {
 				return nil, cost, host.ErrTokenNotExists
 			}
+
+			cost0, err = checkTokenControls(h, tokenSym, from, to)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
 			canTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, CanTransferMapField)
 			cost.AddAssign(cost0)
 			if !(canTransfer.(bool)) {
@@ -545,6 +804,12 @@ var (
 			if !ok {
 				return nil, cost, host.ErrTokenNotExists
 			}
+
+			cost0, err = checkTokenControls(h, tokenSym, from, to)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
 			canTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, CanTransferMapField)
 			cost.AddAssign(cost0)
 			if !(canTransfer.(bool)) {
@@ -629,6 +894,157 @@ var (
 		},
 	}
 
+	batchTransferTokenABI = &abi{
+		name: "batchTransfer",
+		args: []string{"string", "string", "json"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			from := args[1].(string)
+			transfersJSON := args[2].([]byte)
+
+			// transfers is a list of [to, amount, memo]
+			transfers := make([][]string, 0)
+			err = json.Unmarshal(transfersJSON, &transfers)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, fmt.Errorf("invalid transfers %v, should be list of [to, amount, memo]", err)
+			}
+			if len(transfers) == 0 || len(transfers) > maxBatchTransfer {
+				return nil, cost, fmt.Errorf("transfers count should be between 1,%v got %v", maxBatchTransfer, len(transfers))
+			}
+			if !h.IsValidAccount(from) {
+				return nil, cost, fmt.Errorf("invalid account %v", from)
+			}
+
+			// get token info
+			ok, cost0 := checkTokenExists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+			canTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, CanTransferMapField)
+			cost.AddAssign(cost0)
+			if !(canTransfer.(bool)) {
+				return nil, cost, host.ErrTokenNoTransfer
+			}
+			onlyIssuerCanTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, OnlyIssuerCanTransferMapField)
+			cost.AddAssign(cost0)
+			if onlyIssuerCanTransfer.(bool) {
+				issuer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, IssuerMapField)
+				cost.AddAssign(cost0)
+				ok, cost0 = h.RequireAuth(issuer.(string), TransferPermission)
+				cost.AddAssign(cost0)
+				if !ok {
+					return nil, cost, fmt.Errorf("transfer need issuer permission")
+				}
+			}
+			d, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, DecimalMapField)
+			cost.AddAssign(cost0)
+			decimal := int(d.(int64))
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			// check auth once for all the transfers
+			ok, cost0 = h.RequireAuth(from, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+
+			// parse the transfers, transfers to self are skipped as transfer does
+			tos := make([]string, 0, len(transfers))
+			amounts := make([]int64, 0, len(transfers))
+			receipts := make([][]interface{}, 0, len(transfers))
+			total := int64(0)
+			for i, t := range transfers {
+				cost.AddAssign(host.CommonOpCost(2))
+				if len(t) < 2 || len(t) > 3 {
+					return nil, cost, fmt.Errorf("invalid transfer %v, should be [to, amount, memo]", i)
+				}
+				if len(t) == 3 && len(t[2]) > 512 {
+					return nil, cost, host.ErrMemoTooLarge
+				}
+				if !h.IsValidAccount(t[0]) {
+					return nil, cost, fmt.Errorf("invalid account %v", t[0])
+				}
+				amountNumber, err := common.NewFixed(t[1], decimal)
+				if err != nil {
+					return nil, cost, fmt.Errorf("invalid amount %v %v", t[1], err)
+				}
+				if amountNumber.Value <= 0 {
+					return nil, cost, host.ErrInvalidAmount
+				}
+				if t[0] == from {
+					continue
+				}
+				if amountNumber.Value > math.MaxInt64-total {
+					return nil, cost, host.ErrInvalidAmount
+				}
+				total += amountNumber.Value
+				tos = append(tos, t[0])
+				amounts = append(amounts, amountNumber.Value)
+				memo := ""
+				if len(t) == 3 {
+					memo = t[2]
+				}
+				receipts = append(receipts, []interface{}{tokenSym, from, t[0], t[1], memo})
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+			if len(tos) == 0 {
+				return []interface{}{}, cost, nil
+			}
+
+			cost0, err = checkTokenControls(h, tokenSym, append([]string{from}, tos...)...)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// set balance
+			fbalance, cost0, err := getBalance(h, tokenSym, from, from)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if fbalance < total {
+				fBalanceFixed := &common.Fixed{Value: fbalance, Decimal: decimal}
+				totalFixed := &common.Fixed{Value: total, Decimal: decimal}
+				return nil, cost, fmt.Errorf("balance not enough %v < %v", fBalanceFixed.ToString(), totalFixed.ToString())
+			}
+			for i, to := range tos {
+				tbalance, cost0, err := getBalance(h, tokenSym, to, from)
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+				cost0 = setBalance(h, tokenSym, to, tbalance+amounts[i], from)
+				cost.AddAssign(cost0)
+				if !CheckCost(h, cost) {
+					return nil, cost, host.ErrOutOfGas
+				}
+			}
+			cost0 = setBalance(h, tokenSym, from, fbalance-total, from)
+			cost.AddAssign(cost0)
+
+			// generate a receipt for each transfer as transfer does
+			for _, r := range receipts {
+				message, err := json.Marshal(r)
+				cost.AddAssign(host.CommonOpCost(1))
+				if err != nil {
+					return nil, cost, err
+				}
+				cost0 = h.Receipt(string(message))
+				cost.AddAssign(cost0)
+			}
+			return []interface{}{}, cost, nil
+		},
+	}
+
 	destroyTokenABI = &abi{
 		name: "destroy",
 		args: []string{"string", "string", "string"},
@@ -646,6 +1062,12 @@ var (
 				return nil, cost, host.ErrTokenNotExists
 			}
 
+			cost0, err = checkTokenControls(h, tokenSym, from)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
 			// check auth
 			ok, cost0 = h.RequireAuth(from, TransferPermission)
 			cost.AddAssign(cost0)
@@ -798,4 +1220,833 @@ var (
 			return []interface{}{totalSupplyStr}, cost, nil
 		},
 	}
+
+	approveTokenABI = &abi{
+		name: "approve",
+		args: []string{"string", "string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			owner := args[1].(string)
+			spender := args[2].(string)
+			amountStr := args[3].(string)
+			if !h.IsValidAccount(owner) {
+				return nil, cost, fmt.Errorf("invalid account %v", owner)
+			}
+			if !h.IsValidAccount(spender) {
+				return nil, cost, fmt.Errorf("invalid account %v", spender)
+			}
+			if owner == spender {
+				return nil, cost, errors.New("approve to self")
+			}
+
+			// get token info
+			ok, cost0 := checkTokenExists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			cost0, err = checkTokenControls(h, tokenSym, owner, spender)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			canTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, CanTransferMapField)
+			cost.AddAssign(cost0)
+			if !(canTransfer.(bool)) {
+				return nil, cost, host.ErrTokenNoTransfer
+			}
+
+			// check auth
+			ok, cost0 = h.RequireAuth(owner, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			// get amount by fixed point number
+			amount, cost0, err := parseAmount(h, tokenSym, amountStr)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if amount <= 0 {
+				return nil, cost, host.ErrInvalidAmount
+			}
+
+			// the allowance is replaced rather than increased, ram is paid by owner
+			cost0, err = setAllowance(h, tokenSym, owner, spender, amount)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt, approve is checked with amountLimit of owner as transfer
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	allowanceTokenABI = &abi{
+		name: "allowance",
+		args: []string{"string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			owner := args[1].(string)
+			spender := args[2].(string)
+
+			// check token info
+			ok, cost0 := checkTokenExists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			allowance, cost0 := getAllowance(h, tokenSym, owner, spender)
+			cost.AddAssign(cost0)
+			allowanceStr, cost0 := genAmount(h, tokenSym, allowance)
+			cost.AddAssign(cost0)
+
+			return []interface{}{allowanceStr}, cost, nil
+		},
+	}
+
+	transferFromTokenABI = &abi{
+		name: "transferFrom",
+		args: []string{"string", "string", "string", "string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			spender := args[1].(string)
+			from := args[2].(string)
+			to := args[3].(string)
+			amountStr := args[4].(string)
+			memo := args[5].(string) // memo
+			if len(memo) > 512 {
+				return nil, cost, host.ErrMemoTooLarge
+			}
+			if !h.IsValidAccount(to) {
+				return nil, cost, fmt.Errorf("invalid account %v", to)
+			}
+
+			// get token info
+			ok, cost0 := checkTokenExists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			cost0, err = checkTokenControls(h, tokenSym, spender, from, to)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			canTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, CanTransferMapField)
+			cost.AddAssign(cost0)
+			if !(canTransfer.(bool)) {
+				return nil, cost, host.ErrTokenNoTransfer
+			}
+			onlyIssuerCanTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, OnlyIssuerCanTransferMapField)
+			cost.AddAssign(cost0)
+			if onlyIssuerCanTransfer.(bool) {
+				issuer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, IssuerMapField)
+				cost.AddAssign(cost0)
+				ok, cost0 = h.RequireAuth(issuer.(string), TransferPermission)
+				cost.AddAssign(cost0)
+				if !ok {
+					return nil, cost, fmt.Errorf("transfer need issuer permission")
+				}
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			// check auth of spender, the owner authorized by approve
+			ok, cost0 = h.RequireAuth(spender, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			// get amount by fixed point number
+			amount, cost0, err := parseAmount(h, tokenSym, amountStr)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if amount <= 0 {
+				return nil, cost, host.ErrInvalidAmount
+			}
+
+			// sub allowance
+			allowance, cost0 := getAllowance(h, tokenSym, from, spender)
+			cost.AddAssign(cost0)
+			if allowance < amount {
+				allowanceStr, cost0 := genAmount(h, tokenSym, allowance)
+				cost.AddAssign(cost0)
+				return nil, cost, fmt.Errorf("allowance not enough %v < %v", allowanceStr, amountStr)
+			}
+			cost0, err = setAllowance(h, tokenSym, from, spender, allowance-amount)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			if from != to {
+				// set balance, ram of new balance is paid by spender
+				fbalance, cost0, err := getBalance(h, tokenSym, from, from)
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+				tbalance, cost0, err := getBalance(h, tokenSym, to, spender)
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+				if fbalance < amount {
+					fbalanceStr, cost0 := genAmount(h, tokenSym, fbalance)
+					cost.AddAssign(cost0)
+					return nil, cost, fmt.Errorf("balance not enough %v < %v", fbalanceStr, amountStr)
+				}
+				if !CheckCost(h, cost) {
+					return nil, cost, host.ErrOutOfGas
+				}
+
+				cost0 = setBalance(h, tokenSym, to, tbalance+amount, spender)
+				cost.AddAssign(cost0)
+				cost0 = setBalance(h, tokenSym, from, fbalance-amount, spender)
+				cost.AddAssign(cost0)
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	revokeTokenABI = &abi{
+		name: "revoke",
+		args: []string{"string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			owner := args[1].(string)
+			spender := args[2].(string)
+
+			// check auth, both owner and spender can revoke the allowance
+			ok, cost0 := h.RequireAuth(owner, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				ok, cost0 = h.RequireAuth(spender, TransferPermission)
+				cost.AddAssign(cost0)
+			}
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			cost0, err = setAllowance(h, tokenSym, owner, spender, 0)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	pauseTokenABI = &abi{
+		name: "pause",
+		args: []string{"string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+
+			issuer, cost0, err := requireTokenControl(h, tokenSym, CanPauseMapField)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			cost0, err = h.MapPut(TokenInfoMapPrefix+tokenSym, PausedMapField, true, issuer)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	unpauseTokenABI = &abi{
+		name: "unpause",
+		args: []string{"string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+
+			issuer, cost0, err := requireTokenControl(h, tokenSym, CanPauseMapField)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			cost0, err = h.MapPut(TokenInfoMapPrefix+tokenSym, PausedMapField, false, issuer)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	blacklistTokenABI = &abi{
+		name: "blacklist",
+		args: []string{"string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			account := args[1].(string)
+			if !h.IsValidAccount(account) {
+				return nil, cost, fmt.Errorf("invalid account %v", account)
+			}
+
+			issuer, cost0, err := requireTokenControl(h, tokenSym, CanBlacklistMapField)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if account == issuer {
+				return nil, cost, errors.New("can't blacklist issuer")
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			cost0, err = h.MapPut(TokenBlacklistMapPrefix+tokenSym, account, true, issuer)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	unblacklistTokenABI = &abi{
+		name: "unblacklist",
+		args: []string{"string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			account := args[1].(string)
+
+			_, cost0, err := requireTokenControl(h, tokenSym, CanBlacklistMapField)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			ok, cost0 := h.MapHas(TokenBlacklistMapPrefix+tokenSym, account)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, fmt.Errorf("account %v is not blacklisted", account)
+			}
+			cost0, err = h.MapDel(TokenBlacklistMapPrefix+tokenSym, account)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	updateMetadataTokenABI = &abi{
+		name: "updateMetadata",
+		args: []string{"string", "json"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			metadataJSON := args[1].([]byte)
+
+			metadata := make(map[string]string)
+			err = json.Unmarshal(metadataJSON, &metadata)
+			cost.AddAssign(host.CommonOpCost(2))
+			if err != nil {
+				return nil, cost, err
+			}
+			fields := make([]string, 0, len(metadata))
+			for field := range metadata {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				if field != FullNameMapField && field != URLMapField && field != LogoMapField {
+					return nil, cost, fmt.Errorf("invalid metadata field %v", field)
+				}
+				if err = checkTokenMetadata(field, metadata[field]); err != nil {
+					return nil, cost, err
+				}
+			}
+			cost.AddAssign(host.CommonOpCost(len(metadata)))
+
+			issuer, cost0, err := requireTokenControl(h, tokenSym, CanUpdateMetadataMapField)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			for _, field := range fields {
+				cost0, err = h.MapPut(TokenInfoMapPrefix+tokenSym, field, metadata[field], issuer)
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
+
+			// generate receipt
+			message, err := json.Marshal([]interface{}{tokenSym, string(metadataJSON)})
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	changeIssuerTokenABI = &abi{
+		name: "changeIssuer",
+		args: []string{"string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			newIssuer := args[1].(string)
+			if !h.IsValidAccount(newIssuer) {
+				return nil, cost, fmt.Errorf("invalid account %v", newIssuer)
+			}
+
+			_, cost0, err := requireTokenControl(h, tokenSym, CanChangeIssuerMapField)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// the new issuer should authorize too, so that the token won't be given to a lost key
+			ok, cost0 := h.RequireAuth(newIssuer, TokenPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			cost0, err = h.MapPut(TokenInfoMapPrefix+tokenSym, IssuerMapField, newIssuer)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	vestTokenABI = &abi{
+		name: "vest",
+		args: []string{"string", "string", "string", "string", "json"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			from := args[1].(string)
+			to := args[2].(string)
+			amountStr := args[3].(string)
+			configJSON := args[4].([]byte)
+
+			config := vestingConfig{}
+			err = json.Unmarshal(configJSON, &config)
+			cost.AddAssign(host.CommonOpCost(2))
+			if err != nil {
+				return nil, cost, fmt.Errorf("invalid vesting config %v", err)
+			}
+			if config.Start == 0 {
+				ntime, cost0 := h.BlockTime()
+				cost.AddAssign(cost0)
+				config.Start = ntime
+			}
+			if config.Interval == 0 {
+				config.Interval = 1
+			}
+			err = config.check()
+			if err != nil {
+				return nil, cost, err
+			}
+			if from == to {
+				return nil, cost, errors.New("vest to self")
+			}
+			if !h.IsValidAccount(to) {
+				return nil, cost, fmt.Errorf("invalid account %v", to)
+			}
+
+			// get token info
+			ok, cost0 := checkTokenExists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			cost0, err = checkTokenControls(h, tokenSym, from, to)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			canTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, CanTransferMapField)
+			cost.AddAssign(cost0)
+			if !(canTransfer.(bool)) {
+				return nil, cost, host.ErrTokenNoTransfer
+			}
+			onlyIssuerCanTransfer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, OnlyIssuerCanTransferMapField)
+			cost.AddAssign(cost0)
+			if onlyIssuerCanTransfer.(bool) {
+				issuer, cost0 := h.MapGet(TokenInfoMapPrefix+tokenSym, IssuerMapField)
+				cost.AddAssign(cost0)
+				ok, cost0 = h.RequireAuth(issuer.(string), TransferPermission)
+				cost.AddAssign(cost0)
+				if !ok {
+					return nil, cost, fmt.Errorf("transfer need issuer permission")
+				}
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			// check auth
+			ok, cost0 = h.RequireAuth(from, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+
+			// get amount by fixed point number
+			amount, cost0, err := parseAmount(h, tokenSym, amountStr)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if amount <= 0 {
+				return nil, cost, host.ErrInvalidAmount
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			// sub balance of from
+			fbalance, cost0, err := getBalance(h, tokenSym, from, from)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if fbalance < amount {
+				fBalanceStr, cost0 := genAmount(h, tokenSym, fbalance)
+				cost.AddAssign(cost0)
+				return nil, cost, fmt.Errorf("balance not enough %v < %v", fBalanceStr, amountStr)
+			}
+			cost0 = setBalance(h, tokenSym, from, fbalance-amount, from)
+			cost.AddAssign(cost0)
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			// add the schedule to the holder, the ram is paid by the grantor
+			id, cost0, err := nextVestingID(h, from)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			// the schedules are listed by GetVesting
+			cost0, err = h.IndexKeys(database.TokenContractName, vestingMapKey(tokenSym, to), nil)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0, err = setVesting(h, tokenSym, to, &database.VestingItem{
+				ID:        id,
+				Grantor:   from,
+				Amount:    amount,
+				Start:     config.Start,
+				Cliff:     config.Cliff,
+				Duration:  config.Duration,
+				Interval:  config.Interval,
+				Revocable: config.Revocable,
+			}, from)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal([]interface{}{tokenSym, from, to, amountStr, string(configJSON)})
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{strconv.FormatInt(id, 10)}, cost, nil
+		},
+	}
+
+	claimTokenABI = &abi{
+		name: "claim",
+		args: []string{"string", "string", "number"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			holder := args[1].(string)
+			id := args[2].(int64)
+
+			ok, cost0 := checkTokenExists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+			cost0, err = checkTokenControls(h, tokenSym, holder)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// check auth
+			ok, cost0 = h.RequireAuth(holder, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+
+			v, cost0, err := getVesting(h, tokenSym, holder, id)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if v == nil {
+				return nil, cost, fmt.Errorf("vesting %v of %v not exists", id, holder)
+			}
+			ntime, cost0 := h.BlockTime()
+			cost.AddAssign(cost0)
+
+			// release the vested amount, the finished schedule is removed
+			vested := v.Vested(ntime)
+			claimed := vested - v.Claimed
+			v.Claimed = vested
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			claimedStr, cost0 := genAmount(h, tokenSym, claimed)
+			cost.AddAssign(cost0)
+			if claimed == 0 {
+				return []interface{}{claimedStr}, cost, nil
+			}
+			cost0, err = setVesting(h, tokenSym, holder, v)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			balance, cost0, err := getBalance(h, tokenSym, holder, holder)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = setBalance(h, tokenSym, holder, balance+claimed, holder)
+			cost.AddAssign(cost0)
+
+			// generate receipt
+			message, err := json.Marshal([]interface{}{tokenSym, holder, id, claimedStr})
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{claimedStr}, cost, nil
+		},
+	}
+
+	revokeVestingTokenABI = &abi{
+		name: "revokeVesting",
+		args: []string{"string", "string", "number"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			holder := args[1].(string)
+			id := args[2].(int64)
+
+			v, cost0, err := getVesting(h, tokenSym, holder, id)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if v == nil {
+				return nil, cost, fmt.Errorf("vesting %v of %v not exists", id, holder)
+			}
+			if !v.Revocable {
+				return nil, cost, fmt.Errorf("vesting %v is not revocable", id)
+			}
+			cost0, err = checkTokenControls(h, tokenSym, v.Grantor)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// check auth
+			ok, cost0 := h.RequireAuth(v.Grantor, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			// the vested amount goes to the holder and the rest returns to the grantor
+			ntime, cost0 := h.BlockTime()
+			cost.AddAssign(cost0)
+			vested := v.Vested(ntime)
+			cost0, err = h.MapDel(vestingMapKey(tokenSym, holder), strconv.FormatInt(id, 10))
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if vested > v.Claimed {
+				balance, cost0, err := getBalance(h, tokenSym, holder, v.Grantor)
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+				cost0 = setBalance(h, tokenSym, holder, balance+vested-v.Claimed, v.Grantor)
+				cost.AddAssign(cost0)
+			}
+			if v.Amount > vested {
+				balance, cost0, err := getBalance(h, tokenSym, v.Grantor, v.Grantor)
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+				cost0 = setBalance(h, tokenSym, v.Grantor, balance+v.Amount-vested, v.Grantor)
+				cost.AddAssign(cost0)
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
 )
@@ -9,10 +9,12 @@ import (
 	"fmt"
 
 	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/database"
 	"github.com/iost-official/go-iost/vm/host"
 )
 
 var token721ABIs *abiSet
+var token7210ABIs *abiSet
 
 // const prefix
 const (
@@ -21,18 +23,38 @@ const (
 	Token721IssuerMapField       = "T721issuer"
 	Token721MetadataMapPrefix    = "T721M"
 	Token721MetadataKeySeparator = "#"
+	Token721ApprovalMapPrefix    = "T721A"
+	Token721OperatorMapPrefix    = "T721O"
+	Token721SupplyMapPrefix      = "T721S"
+	Token721BurnedMapField       = "burned"
 )
 
+// maxIndexTokens is the max number of token ids checked by one indexTokens
+const maxIndexTokens = 1000
+
 func init() {
+	token7210ABIs = newAbiSet()
 	token721ABIs = newAbiSet()
-	token721ABIs.Register(initToken721ABI, true)
-	token721ABIs.Register(createToken721ABI)
-	token721ABIs.Register(issueToken721ABI)
-	token721ABIs.Register(transferToken721ABI)
-	token721ABIs.Register(balanceOfToken721ABI)
-	token721ABIs.Register(ownerOfToken721ABI)
-	token721ABIs.Register(tokenOfOwnerByIndexToken721ABI)
-	token721ABIs.Register(tokenMetadataToken721ABI)
+	for _, as := range []*abiSet{token7210ABIs, token721ABIs} {
+		as.Register(initToken721ABI, true)
+		as.Register(createToken721ABI)
+		as.Register(issueToken721ABI)
+		as.Register(transferToken721ABI)
+		as.Register(balanceOfToken721ABI)
+		as.Register(ownerOfToken721ABI)
+		as.Register(tokenOfOwnerByIndexToken721ABI)
+		as.Register(tokenMetadataToken721ABI)
+	}
+	token721ABIs.Register(approveToken721ABI)
+	token721ABIs.Register(getApprovedToken721ABI)
+	token721ABIs.Register(setApprovalForAllToken721ABI)
+	token721ABIs.Register(isApprovedForAllToken721ABI)
+	token721ABIs.Register(transferFromToken721ABI)
+	token721ABIs.Register(burnToken721ABI)
+	token721ABIs.Register(totalSupplyToken721ABI)
+	token721ABIs.Register(tokenByIndexToken721ABI)
+	token721ABIs.Register(indexTokensToken721ABI)
+	token721ABIs.Register(updateMetadataToken721ABI)
 }
 
 func checkToken721Exists(h *host.Host, tokenSym string) (ok bool, cost contract.Cost) {
@@ -59,6 +81,85 @@ func setToken721Balance(h *host.Host, tokenSym string, from string, balance int6
 
 }
 
+func getToken721Owner(h *host.Host, tokenSym string, tokenID string) (owner string, cost contract.Cost, err error) {
+	tmp, cost := h.MapGet(Token721InfoMapPrefix+tokenSym, tokenID)
+	if tmp == nil {
+		return "", cost, fmt.Errorf("error tokenID not exists. %v %v", tokenSym, tokenID)
+	}
+	return tmp.(string), cost, nil
+}
+
+func isToken721Operator(h *host.Host, tokenSym string, owner string, operator string) (ok bool, cost contract.Cost) {
+	return h.MapHas(Token721OperatorMapPrefix+tokenSym+Token721MetadataKeySeparator+owner, operator)
+}
+
+// clearToken721Approval deletes the approval of the token, it's called when the owner changes
+func clearToken721Approval(h *host.Host, tokenSym string, tokenID string) (cost contract.Cost, err error) {
+	ok, cost := h.MapHas(Token721ApprovalMapPrefix+tokenSym, tokenID)
+	if !ok {
+		return cost, nil
+	}
+	cost0, err := h.MapDel(Token721ApprovalMapPrefix+tokenSym, tokenID)
+	cost.AddAssign(cost0)
+	return cost, err
+}
+
+// moveToken721 moves the token with its metadata from the owner to another account, ram is paid by ramPayer
+func moveToken721(h *host.Host, tokenSym string, from string, to string, tokenID string, ramPayer string) (cost contract.Cost, err error) {
+	cost, err = h.MapPut(Token721InfoMapPrefix+tokenSym, tokenID, to, ramPayer)
+	if err != nil {
+		return cost, err
+	}
+
+	fbalance, cost0, err := getToken721Balance(h, tokenSym, from)
+	cost.AddAssign(cost0)
+	if err != nil {
+		return cost, err
+	}
+	tbalance, cost0, err := getToken721Balance(h, tokenSym, to)
+	cost.AddAssign(cost0)
+	if err != nil {
+		return cost, err
+	}
+
+	fbalance--
+	tbalance++
+
+	cost0 = setToken721Balance(h, tokenSym, from, fbalance, ramPayer)
+	cost.AddAssign(cost0)
+	cost0 = setToken721Balance(h, tokenSym, to, tbalance, ramPayer)
+	cost.AddAssign(cost0)
+
+	metaDataJSON, cost0 := h.MapGet(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+from, tokenID)
+	cost.AddAssign(cost0)
+	cost0, err = h.MapDel(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+from, tokenID)
+	cost.AddAssign(cost0)
+	if err != nil {
+		return cost, err
+	}
+	cost0, err = h.MapPut(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+to, tokenID, metaDataJSON, ramPayer)
+	cost.AddAssign(cost0)
+	if err != nil {
+		return cost, err
+	}
+
+	// approvals are set by the abis of 1.1.0
+	if versionAtLeast(h, NativeVersion) {
+		cost0, err = clearToken721Approval(h, tokenSym, tokenID)
+		cost.AddAssign(cost0)
+	}
+	return cost, err
+}
+
+// getToken721Supply returns the number of tokens issued and not burned, which is derived from the counters in the info map
+func getToken721Supply(h *host.Host, tokenSym string) (supply int64, cost contract.Cost) {
+	issued, cost := h.MapGet(Token721InfoMapPrefix+tokenSym, SupplyMapField)
+	burned, cost0 := h.MapGet(Token721InfoMapPrefix+tokenSym, Token721BurnedMapField)
+	cost.AddAssign(cost0)
+	b, _ := burned.(int64)
+	return issued.(int64) - b, cost
+}
+
 var (
 	initToken721ABI = &abi{
 		name: "init",
@@ -111,6 +212,14 @@ var (
 			cost.AddAssign(cost0)
 			cost0, _ = h.MapPut(Token721InfoMapPrefix+tokenSym, SupplyMapField, int64(0), issuer)
 			cost.AddAssign(cost0)
+			// the issued tokens are listed by tokenByIndex from 1.1.0
+			if versionAtLeast(h, NativeVersion) {
+				cost0, err = h.IndexKeys(database.Token721ContractName, Token721SupplyMapPrefix+tokenSym, nil)
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
 
 			// generate receipt
 			message, err := json.Marshal(args)
@@ -190,6 +299,16 @@ var (
 
 			cost0, err = h.MapPut(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+to, tokenID, metaDataJSON, issuer.(string))
 			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if versionAtLeast(h, NativeVersion) {
+				cost0, err = h.MapPut(Token721SupplyMapPrefix+tokenSym, tokenID, true, issuer.(string))
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
 
 			// generate receipt
 			message, err := json.Marshal(args)
@@ -246,41 +365,12 @@ var (
 				return nil, cost, fmt.Errorf("error token owner isn't from. owner: %v, from: %v", owner, from)
 			}
 
-			cost0, err = h.MapPut(Token721InfoMapPrefix+tokenSym, tokenID, to, from)
-			cost.AddAssign(cost0)
-			if err != nil {
-				return nil, cost, err
-			}
-
-			fbalance, cost0, err := getToken721Balance(h, tokenSym, from)
-			cost.AddAssign(cost0)
-			if err != nil {
-				return nil, cost, err
-			}
-			tbalance, cost0, err := getToken721Balance(h, tokenSym, to)
+			cost0, err = moveToken721(h, tokenSym, from, to, tokenID, from)
 			cost.AddAssign(cost0)
 			if err != nil {
 				return nil, cost, err
 			}
 
-			fbalance--
-			tbalance++
-
-			cost0 = setToken721Balance(h, tokenSym, from, fbalance, from)
-			cost.AddAssign(cost0)
-			cost0 = setToken721Balance(h, tokenSym, to, tbalance, from)
-			cost.AddAssign(cost0)
-
-			metaDataJSON, cost0 := h.MapGet(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+from, tokenID)
-			cost.AddAssign(cost0)
-			cost0, err = h.MapDel(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+from, tokenID)
-			cost.AddAssign(cost0)
-			if err != nil {
-				return nil, cost, err
-			}
-			cost0, err = h.MapPut(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+to, tokenID, metaDataJSON, from)
-			cost.AddAssign(cost0)
-
 			// generate receipt
 			message, err := json.Marshal(args)
 			cost.AddAssign(host.CommonOpCost(1))
@@ -405,4 +495,504 @@ var (
 			return []interface{}{metaDataJSON.(string)}, cost, nil
 		},
 	}
+
+	approveToken721ABI = &abi{
+		name: "approve",
+		args: []string{"string", "string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			from := args[1].(string)
+			to := args[2].(string)
+			tokenID := args[3].(string)
+			if from == to {
+				return nil, cost, errors.New("approve to self")
+			}
+			if !h.IsValidAccount(to) {
+				return nil, cost, fmt.Errorf("invalid account %v", to)
+			}
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			// check auth
+			ok, cost0 = h.RequireAuth(from, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			owner, cost0, err := getToken721Owner(h, tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if owner != from {
+				return nil, cost, fmt.Errorf("error token owner isn't from. owner: %v, from: %v", owner, from)
+			}
+
+			// one token has at most one approved account, the new approval replaces the old one
+			cost0, err = h.MapPut(Token721ApprovalMapPrefix+tokenSym, tokenID, to, from)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	getApprovedToken721ABI = &abi{
+		name: "getApproved",
+		args: []string{"string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			tokenID := args[1].(string)
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+			_, cost0, err = getToken721Owner(h, tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			approved, cost0 := h.MapGet(Token721ApprovalMapPrefix+tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if approved == nil {
+				return []interface{}{""}, cost, nil
+			}
+			return []interface{}{approved.(string)}, cost, nil
+		},
+	}
+
+	setApprovalForAllToken721ABI = &abi{
+		name: "setApprovalForAll",
+		args: []string{"string", "string", "string", "bool"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			owner := args[1].(string)
+			operator := args[2].(string)
+			approved := args[3].(bool)
+			if owner == operator {
+				return nil, cost, errors.New("approve to self")
+			}
+			if !h.IsValidAccount(operator) {
+				return nil, cost, fmt.Errorf("invalid account %v", operator)
+			}
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			// check auth
+			ok, cost0 = h.RequireAuth(owner, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			key := Token721OperatorMapPrefix + tokenSym + Token721MetadataKeySeparator + owner
+			ok, cost0 = h.MapHas(key, operator)
+			cost.AddAssign(cost0)
+			if approved && !ok {
+				cost0, err = h.MapPut(key, operator, true, owner)
+			} else if !approved && ok {
+				cost0, err = h.MapDel(key, operator)
+			}
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	isApprovedForAllToken721ABI = &abi{
+		name: "isApprovedForAll",
+		args: []string{"string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			owner := args[1].(string)
+			operator := args[2].(string)
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+			ok, cost0 = isToken721Operator(h, tokenSym, owner, operator)
+			cost.AddAssign(cost0)
+			return []interface{}{ok}, cost, nil
+		},
+	}
+
+	transferFromToken721ABI = &abi{
+		name: "transferFrom",
+		args: []string{"string", "string", "string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			spender := args[1].(string)
+			from := args[2].(string)
+			to := args[3].(string)
+			tokenID := args[4].(string)
+			if !h.IsValidAccount(to) {
+				return nil, cost, fmt.Errorf("invalid account %v", to)
+			}
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			// check auth of spender, the owner authorized by approve or setApprovalForAll
+			ok, cost0 = h.RequireAuth(spender, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			owner, cost0, err := getToken721Owner(h, tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if owner != from {
+				return nil, cost, fmt.Errorf("error token owner isn't from. owner: %v, from: %v", owner, from)
+			}
+			tmp, cost0 := h.MapGet(Token721ApprovalMapPrefix+tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if approved, _ := tmp.(string); approved != spender {
+				ok, cost0 = isToken721Operator(h, tokenSym, from, spender)
+				cost.AddAssign(cost0)
+				if !ok {
+					return nil, cost, fmt.Errorf("%v is not approved for token %v %v", spender, tokenSym, tokenID)
+				}
+			}
+			if from == to {
+				return []interface{}{}, cost, nil
+			}
+
+			cost0, err = moveToken721(h, tokenSym, from, to, tokenID, spender)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	burnToken721ABI = &abi{
+		name: "burn",
+		args: []string{"string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			from := args[1].(string)
+			tokenID := args[2].(string)
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			// check auth
+			ok, cost0 = h.RequireAuth(from, TransferPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			owner, cost0, err := getToken721Owner(h, tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if owner != from {
+				return nil, cost, fmt.Errorf("error token owner isn't from. owner: %v, from: %v", owner, from)
+			}
+
+			// the supply is kept as the counter of token id, burned token id won't be issued again
+			cost0, err = h.MapDel(Token721InfoMapPrefix+tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			burned, cost0 := h.MapGet(Token721InfoMapPrefix+tokenSym, Token721BurnedMapField)
+			cost.AddAssign(cost0)
+			b, _ := burned.(int64)
+			cost0, err = h.MapPut(Token721InfoMapPrefix+tokenSym, Token721BurnedMapField, b+1, from)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0, err = h.MapDel(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+from, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0, err = h.MapDel(Token721SupplyMapPrefix+tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0, err = clearToken721Approval(h, tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			fbalance, cost0, err := getToken721Balance(h, tokenSym, from)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			fbalance--
+			cost0 = setToken721Balance(h, tokenSym, from, fbalance, from)
+			cost.AddAssign(cost0)
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	totalSupplyToken721ABI = &abi{
+		name: "totalSupply",
+		args: []string{"string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+
+			// number of tokens issued and not burned
+			supply, cost0 := getToken721Supply(h, tokenSym)
+			cost.AddAssign(cost0)
+			return []interface{}{supply}, cost, nil
+		},
+	}
+
+	tokenByIndexToken721ABI = &abi{
+		name: "tokenByIndex",
+		args: []string{"string", "number"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			index := args[1].(int64)
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+			if index < 0 || index > math.MaxInt32 {
+				return nil, cost, errors.New("out of range")
+			}
+
+			// the tokens issued before 1.1.0 are listed after indexTokens
+			supply, cost0 := getToken721Supply(h, tokenSym)
+			cost.AddAssign(cost0)
+			indexed, cost0 := h.MapFieldsLen(Token721SupplyMapPrefix + tokenSym)
+			cost.AddAssign(cost0)
+			if int64(indexed) != supply {
+				return nil, cost, fmt.Errorf("%v of %v tokens are indexed, the issuer can index the others by indexTokens", indexed, supply)
+			}
+
+			// the order of tokens changes when a token is burned
+			tokens, cost0, err := h.MapFields(Token721SupplyMapPrefix+tokenSym, int(index), 1)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if len(tokens) == 0 {
+				return nil, cost, errors.New("out of range")
+			}
+			return []interface{}{tokens[0]}, cost, nil
+		},
+	}
+
+	// indexTokens adds the tokens issued before 1.1.0 with ids in [from, from+count) to the list of tokenByIndex
+	indexTokensToken721ABI = &abi{
+		name: "indexTokens",
+		args: []string{"string", "number", "number"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			from := args[1].(int64)
+			count := args[2].(int64)
+			if from < 0 || count <= 0 || count > maxIndexTokens {
+				return nil, cost, fmt.Errorf("invalid range, count should be between 1,%v", maxIndexTokens)
+			}
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+			issuer, cost0 := h.MapGet(Token721InfoMapPrefix+tokenSym, Token721IssuerMapField)
+			cost.AddAssign(cost0)
+			ok, cost0 = h.RequireAuth(issuer.(string), TokenPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			issued, cost0 := h.MapGet(Token721InfoMapPrefix+tokenSym, SupplyMapField)
+			cost.AddAssign(cost0)
+
+			mapKey := Token721SupplyMapPrefix + tokenSym
+			cost0, err = h.IndexKeys(database.Token721ContractName, mapKey, nil)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			for id := from; id < from+count && id < issued.(int64); id++ {
+				if !CheckCost(h, cost) {
+					return nil, cost, host.ErrOutOfGas
+				}
+				tokenID := strconv.FormatInt(id, 10)
+				ok, cost0 = h.MapHas(mapKey, tokenID)
+				cost.AddAssign(cost0)
+				if ok {
+					// issued by 1.1.0 before the index of the token is enabled
+					cost0, err = h.IndexKeys(database.Token721ContractName, mapKey, []string{tokenID})
+				} else {
+					ok, cost0 = h.MapHas(Token721InfoMapPrefix+tokenSym, tokenID)
+					if ok {
+						cost.AddAssign(cost0)
+						cost0, err = h.MapPut(mapKey, tokenID, true, issuer.(string))
+					}
+				}
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	updateMetadataToken721ABI = &abi{
+		name: "updateMetadata",
+		args: []string{"string", "string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			cost = contract.Cost0()
+			cost.AddAssign(host.CommonOpCost(1))
+			tokenSym := args[0].(string)
+			tokenID := args[1].(string)
+			metaDataJSON := args[2].(string)
+
+			ok, cost0 := checkToken721Exists(h, tokenSym)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrTokenNotExists
+			}
+			issuer, cost0 := h.MapGet(Token721InfoMapPrefix+tokenSym, Token721IssuerMapField)
+			cost.AddAssign(cost0)
+
+			// check auth
+			ok, cost0 = h.RequireAuth(issuer.(string), TokenPermission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, host.ErrPermissionLost
+			}
+			if !CheckCost(h, cost) {
+				return nil, cost, host.ErrOutOfGas
+			}
+
+			owner, cost0, err := getToken721Owner(h, tokenSym, tokenID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// ram of the new metadata is paid by issuer
+			cost0, err = h.MapPut(Token721MetadataMapPrefix+tokenSym+Token721MetadataKeySeparator+owner, tokenID, metaDataJSON, issuer.(string))
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			// generate receipt
+			message, err := json.Marshal(args)
+			cost.AddAssign(host.CommonOpCost(1))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0 = h.Receipt(string(message))
+			cost.AddAssign(cost0)
+			return []interface{}{}, cost, nil
+		},
+	}
 )
@@ -0,0 +1,410 @@
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
+)
+
+// maps of the upgrade governance in system.iost, the fields are contract ids
+const (
+	UpgradePolicyMap  = "upgrade_policy"
+	UpgradePendingMap = "upgrade_pending"
+	UpgradeCodeMap    = "upgrade_code"
+)
+
+// MaxUpgradeDelay is the max delay of upgrade policies in seconds
+const MaxUpgradeDelay int64 = 3 * 365 * 24 * 3600
+
+// UpgradePolicy restricts the code updates of a contract. A contract with a policy can only be updated
+// by proposeUpgrade and applyUpgrade. The new code is applied Delay seconds after it's proposed, and
+// only after approved by Approver@Permission if Approver is not empty.
+type UpgradePolicy struct {
+	Delay      int64  `json:"delay"`
+	Approver   string `json:"approver"`
+	Permission string `json:"permission"`
+}
+
+// looserThan returns whether p allows something which old doesn't allow
+func (p *UpgradePolicy) looserThan(old *UpgradePolicy) bool {
+	if p.Delay < old.Delay {
+		return true
+	}
+	if old.Approver == "" {
+		return false
+	}
+	return p.Approver != old.Approver || p.Permission != old.Permission
+}
+
+// PendingUpgrade is the code proposed for a contract which isn't applied yet
+type PendingUpgrade struct {
+	CodeHash       string `json:"code_hash"`
+	Proposer       string `json:"proposer"`
+	ProposedTime   int64  `json:"proposed_time"`
+	ExecutableTime int64  `json:"executable_time"`
+	Approved       bool   `json:"approved"`
+}
+
+type upgradeEvent struct {
+	Event          string `json:"event"`
+	Contract       string `json:"contract"`
+	CodeHash       string `json:"code_hash,omitempty"`
+	ExecutableTime int64  `json:"executable_time,omitempty"`
+}
+
+func postUpgradeEvent(h *host.Host, e *upgradeEvent) contract.Cost {
+	b, _ := json.Marshal(e)
+	// system.iost declares no event, so the event is never refused
+	cost, _ := h.PostEvent(string(b))
+	return cost
+}
+
+func getUpgradePolicy(h *host.Host, conID string) (*UpgradePolicy, contract.Cost, error) {
+	v, cost := h.MapGet(UpgradePolicyMap, conID)
+	if v == nil {
+		return nil, cost, nil
+	}
+	p := &UpgradePolicy{}
+	cost.AddAssign(host.CommonOpCost(1))
+	if err := json.Unmarshal([]byte(v.(database.SerializedJSON)), p); err != nil {
+		return nil, cost, err
+	}
+	return p, cost, nil
+}
+
+func getPendingUpgrade(h *host.Host, conID string) (*PendingUpgrade, contract.Cost, error) {
+	v, cost := h.MapGet(UpgradePendingMap, conID)
+	if v == nil {
+		return nil, cost, fmt.Errorf("contract %v has no pending upgrade", conID)
+	}
+	p := &PendingUpgrade{}
+	cost.AddAssign(host.CommonOpCost(1))
+	if err := json.Unmarshal([]byte(v.(database.SerializedJSON)), p); err != nil {
+		return nil, cost, err
+	}
+	return p, cost, nil
+}
+
+// requireContractOwner checks the active permission of the owner of the contract, returns the owner
+func requireContractOwner(h *host.Host, conID string) (string, contract.Cost, error) {
+	owner, cost := h.MapGet("contract_owner", conID)
+	ownerStr, ok := owner.(string)
+	if !ok {
+		return "", cost, fmt.Errorf("contract %v has no owner", conID)
+	}
+	ok, cost0 := h.RequireAuth(ownerStr, "active")
+	cost.AddAssign(cost0)
+	if !ok {
+		return "", cost, fmt.Errorf("need owner %v@active permission", ownerStr)
+	}
+	return ownerStr, cost, nil
+}
+
+func decodeContract(codeRaw string) (*contract.Contract, error) {
+	con := &contract.Contract{}
+	if codeRaw == "" {
+		return nil, errors.New("empty code")
+	}
+	if codeRaw[0] == '{' {
+		return con, json.Unmarshal([]byte(codeRaw), con)
+	}
+	return con, con.B64Decode(codeRaw)
+}
+
+// CodeHash returns the hash of the code of a contract, it's the code hash in PendingUpgrade
+func CodeHash(code string) string {
+	return common.Base58Encode(common.Sha3([]byte(code)))
+}
+
+var (
+	// setUpgradePolicy sets the upgrade policy of a contract. The contract owner can make the policy
+	// stricter by itself, but loosening it needs the approval of the current approver.
+	setUpgradePolicyABI = &abi{
+		name: "setUpgradePolicy",
+		args: []string{"string", "json"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			conID := args[0].(string)
+			policy := &UpgradePolicy{}
+			cost = host.CommonOpCost(1)
+			if err := json.Unmarshal(args[1].([]byte), policy); err != nil {
+				return nil, cost, fmt.Errorf("invalid upgrade policy: %v", err)
+			}
+			if policy.Delay < 0 || policy.Delay > MaxUpgradeDelay {
+				return nil, cost, fmt.Errorf("delay should be between 0,%v", MaxUpgradeDelay)
+			}
+			if policy.Approver != "" {
+				if !h.IsValidAccount(policy.Approver) {
+					return nil, cost, fmt.Errorf("invalid approver %v", policy.Approver)
+				}
+				if policy.Permission == "" {
+					policy.Permission = "active"
+				}
+			} else {
+				policy.Permission = ""
+			}
+
+			_, cost0, err := requireContractOwner(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			old, cost0, err := getUpgradePolicy(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if old != nil && policy.looserThan(old) {
+				if old.Approver == "" {
+					return nil, cost, errors.New("upgrade policy without approver can't be loosened")
+				}
+				ok, cost0 := h.RequireAuth(old.Approver, old.Permission)
+				cost.AddAssign(cost0)
+				if !ok {
+					return nil, cost, fmt.Errorf("loosening upgrade policy needs %v@%v permission", old.Approver, old.Permission)
+				}
+			}
+
+			b, _ := json.Marshal(policy)
+			cost0, err = h.MapPut(UpgradePolicyMap, conID, database.SerializedJSON(b))
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost.AddAssign(postUpgradeEvent(h, &upgradeEvent{Event: "upgradePolicySet", Contract: conID}))
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	// proposeUpgrade saves the new code of a contract with upgrade policy, a previous proposal is replaced
+	proposeUpgradeABI = &abi{
+		name: "proposeUpgrade",
+		args: []string{"string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			conID := args[0].(string)
+			codeRaw := args[1].(string)
+			cost = host.CommonOpCost(1)
+
+			con, err := decodeContract(codeRaw)
+			if err != nil {
+				return nil, host.CommonErrorCost(1), err
+			}
+			if con.ID != conID {
+				return nil, cost, fmt.Errorf("contract id of the code %v doesn't match %v", con.ID, conID)
+			}
+			if err := con.VerifySelf(); err != nil {
+				return nil, cost, err
+			}
+			policy, cost0, err := getUpgradePolicy(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if policy == nil {
+				return nil, cost, fmt.Errorf("contract %v has no upgrade policy, use updateCode", conID)
+			}
+			owner, cost0, err := requireContractOwner(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			ntime, cost0 := h.BlockTime()
+			cost.AddAssign(cost0)
+			if policy.Delay > (math.MaxInt64-ntime)/1e9 {
+				return nil, cost, fmt.Errorf("delay %v of the upgrade policy overflows", policy.Delay)
+			}
+			pending := &PendingUpgrade{
+				CodeHash:       CodeHash(con.Code),
+				Proposer:       owner,
+				ProposedTime:   ntime,
+				ExecutableTime: ntime + policy.Delay*1e9,
+				Approved:       policy.Approver == "",
+			}
+			b, _ := json.Marshal(pending)
+			cost.AddAssign(host.CommonOpCost(1))
+			cost0, err = h.MapPut(UpgradePendingMap, conID, database.SerializedJSON(b), owner)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0, err = h.MapPut(UpgradeCodeMap, conID, codeRaw, owner)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost.AddAssign(postUpgradeEvent(h, &upgradeEvent{
+				Event:          "upgradeProposed",
+				Contract:       conID,
+				CodeHash:       pending.CodeHash,
+				ExecutableTime: pending.ExecutableTime,
+			}))
+			return []interface{}{pending.CodeHash}, cost, nil
+		},
+	}
+
+	// approveUpgrade approves the pending upgrade with the code hash, it needs the approver permission in the policy
+	approveUpgradeABI = &abi{
+		name: "approveUpgrade",
+		args: []string{"string", "string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			conID := args[0].(string)
+			codeHash := args[1].(string)
+
+			policy, cost, err := getUpgradePolicy(h, conID)
+			if err != nil {
+				return nil, cost, err
+			}
+			if policy == nil || policy.Approver == "" {
+				return nil, cost, fmt.Errorf("contract %v doesn't need upgrade approval", conID)
+			}
+			ok, cost0 := h.RequireAuth(policy.Approver, policy.Permission)
+			cost.AddAssign(cost0)
+			if !ok {
+				return nil, cost, fmt.Errorf("need %v@%v permission", policy.Approver, policy.Permission)
+			}
+			pending, cost0, err := getPendingUpgrade(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if pending.CodeHash != codeHash {
+				return nil, cost, fmt.Errorf("code hash of the pending upgrade is %v, not %v", pending.CodeHash, codeHash)
+			}
+
+			pending.Approved = true
+			b, _ := json.Marshal(pending)
+			cost.AddAssign(host.CommonOpCost(1))
+			cost0, err = h.MapPut(UpgradePendingMap, conID, database.SerializedJSON(b))
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost.AddAssign(postUpgradeEvent(h, &upgradeEvent{Event: "upgradeApproved", Contract: conID, CodeHash: codeHash}))
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	// applyUpgrade updates the contract with the pending code after the timelock and the approval
+	applyUpgradeABI = &abi{
+		name: "applyUpgrade",
+		args: []string{"string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			conID := args[0].(string)
+			cost = host.CommonOpCost(1)
+			stackHeight := h.Context().Value("stack_height").(int)
+			if stackHeight != 1 {
+				return nil, cost, errors.New("can't call applyUpgrade from other contract")
+			}
+
+			_, cost0, err := requireContractOwner(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			pending, cost0, err := getPendingUpgrade(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			if !pending.Approved {
+				return nil, cost, errors.New("upgrade is not approved")
+			}
+			ntime, cost0 := h.BlockTime()
+			cost.AddAssign(cost0)
+			if ntime < pending.ExecutableTime {
+				return nil, cost, fmt.Errorf("upgrade is locked until %v", pending.ExecutableTime)
+			}
+
+			codeRaw, cost0 := h.MapGet(UpgradeCodeMap, conID)
+			cost.AddAssign(cost0)
+			con, err := decodeContract(codeRaw.(string))
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0, err = h.UpgradeCode(con)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost0, err = migrate(h, con)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+
+			cost0, err = clearPendingUpgrade(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost.AddAssign(postUpgradeEvent(h, &upgradeEvent{Event: "upgradeApplied", Contract: conID, CodeHash: pending.CodeHash}))
+			return []interface{}{}, cost, nil
+		},
+	}
+
+	// cancelUpgrade drops the pending upgrade, it can be called by the owner or the approver
+	cancelUpgradeABI = &abi{
+		name: "cancelUpgrade",
+		args: []string{"string"},
+		do: func(h *host.Host, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+			conID := args[0].(string)
+			pending, cost, err := getPendingUpgrade(h, conID)
+			if err != nil {
+				return nil, cost, err
+			}
+			policy, cost0, err := getUpgradePolicy(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			ok := false
+			if policy != nil && policy.Approver != "" {
+				ok, cost0 = h.RequireAuth(policy.Approver, policy.Permission)
+				cost.AddAssign(cost0)
+			}
+			if !ok {
+				_, cost0, err = requireContractOwner(h, conID)
+				cost.AddAssign(cost0)
+				if err != nil {
+					return nil, cost, err
+				}
+			}
+
+			cost0, err = clearPendingUpgrade(h, conID)
+			cost.AddAssign(cost0)
+			if err != nil {
+				return nil, cost, err
+			}
+			cost.AddAssign(postUpgradeEvent(h, &upgradeEvent{Event: "upgradeCanceled", Contract: conID, CodeHash: pending.CodeHash}))
+			return []interface{}{}, cost, nil
+		},
+	}
+)
+
+func clearPendingUpgrade(h *host.Host, conID string) (contract.Cost, error) {
+	cost, err := h.MapDel(UpgradePendingMap, conID)
+	if err != nil {
+		return cost, err
+	}
+	cost0, err := h.MapDel(UpgradeCodeMap, conID)
+	cost.AddAssign(cost0)
+	return cost, err
+}
+
+// migrate calls migrate of the new code if it has one, it converts the storage of the old code
+func migrate(h *host.Host, con *contract.Contract) (contract.Cost, error) {
+	if con.ABI("migrate") == nil {
+		return contract.Cost0(), nil
+	}
+	_, cost, err := h.Call(con.ID, "migrate", "[]")
+	if err != nil {
+		return cost, fmt.Errorf("call migrate: %v", err)
+	}
+	return cost, nil
+}
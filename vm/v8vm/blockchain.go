@@ -173,9 +173,12 @@ func goEvent(cSbx C.SandboxPtr, content C.CStr, gasUsed *C.size_t) *C.char {
 
 	contentStr := content.GoString()
 
-	cost := sbx.host.PostEvent(contentStr)
+	cost, err := sbx.host.PostEvent(contentStr)
 
 	*gasUsed = C.size_t(cost.CPU)
+	if err != nil {
+		return C.CString(err.Error())
+	}
 
 	return nil
 }
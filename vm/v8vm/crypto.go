@@ -4,7 +4,12 @@ package v8
 #include "v8/vm.h"
 */
 import "C"
-import "github.com/iost-official/go-iost/common"
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/iost-official/go-iost/common"
+)
 
 const cryptGasBase = 100
 
@@ -17,3 +22,63 @@ func goSha3(cSbx C.SandboxPtr, msg C.CStr, gasUsed *C.size_t) C.CStr {
 
 	return newCStr(val)
 }
+
+//export goHash
+func goHash(cSbx C.SandboxPtr, algo, msg C.CStr, result *C.CStr, gasUsed *C.size_t) *C.char {
+	sbx, ok := GetSandbox(cSbx)
+	if !ok {
+		return C.CString(ErrGetSandbox.Error())
+	}
+
+	digest, cost, err := sbx.host.Hash(algo.GoString(), []byte(msg.GoString()))
+	*gasUsed = C.size_t(cost.CPU)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	result.SetString(common.Base58Encode(digest))
+
+	return nil
+}
+
+//export goVerify
+func goVerify(cSbx C.SandboxPtr, algo, msg, sig, pubkey C.CStr, result *C.bool, gasUsed *C.size_t) *C.char {
+	sbx, ok := GetSandbox(cSbx)
+	if !ok {
+		return C.CString(ErrGetSandbox.Error())
+	}
+
+	valid, cost, err := sbx.host.VerifySignature(
+		algo.GoString(),
+		common.Base58Decode(msg.GoString()),
+		common.Base58Decode(sig.GoString()),
+		common.Base58Decode(pubkey.GoString()),
+	)
+	*gasUsed = C.size_t(cost.CPU)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	*result = C.bool(valid)
+
+	return nil
+}
+
+//export goParsePubkey
+func goParsePubkey(cSbx C.SandboxPtr, pubkey C.CStr, result *C.CStr, gasUsed *C.size_t) *C.char {
+	sbx, ok := GetSandbox(cSbx)
+	if !ok {
+		return C.CString(ErrGetSandbox.Error())
+	}
+
+	algo, raw, cost, err := sbx.host.ParsePubkey(pubkey.GoString())
+	*gasUsed = C.size_t(cost.CPU)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	b, err := json.Marshal(map[string]string{"algorithm": algo, "pubkey": hex.EncodeToString(raw)})
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	result.SetString(string(b))
+
+	return nil
+}
@@ -22,6 +22,7 @@ char* goMapGet(SandboxPtr, const CStr, const CStr, const CStr, CStr *, size_t *)
 char* goMapDel(SandboxPtr, const CStr, const CStr, const CStr, size_t *);
 char* goMapKeys(SandboxPtr, const CStr, const CStr, CStr *, size_t *);
 char* goMapLen(SandboxPtr, const CStr, const CStr, size_t *, size_t *);
+char* goKeys(SandboxPtr, const CStr, size_t, size_t, CStr *, size_t *);
 
 char* goGlobalHas(SandboxPtr, const CStr, const CStr, const CStr, bool *, size_t *);
 char* goGlobalGet(SandboxPtr, const CStr, const CStr, const CStr, CStr *, size_t *);
@@ -33,6 +34,9 @@ char* goGlobalMapLen(SandboxPtr, const CStr, const CStr, const CStr, size_t *, s
 char* goConsoleLog(SandboxPtr, const CStr, const CStr);
 
 CStr goSha3(SandboxPtr, const CStr, size_t *);
+char* goHash(SandboxPtr, const CStr, const CStr, CStr *, size_t *);
+char* goVerify(SandboxPtr, const CStr, const CStr, const CStr, const CStr, bool *, size_t *);
+char* goParsePubkey(SandboxPtr, const CStr, CStr *, size_t *);
 */
 import "C"
 import (
@@ -129,6 +133,7 @@ func (sbx *Sandbox) Init(vmType vmPoolType) {
 		(C.mapDelFunc)(C.goMapDel),
 		(C.mapKeysFunc)(C.goMapKeys),
 		(C.mapLenFunc)(C.goMapLen),
+		(C.keysFunc)(C.goKeys),
 
 		(C.globalHasFunc)(C.goGlobalHas),
 		(C.globalGetFunc)(C.goGlobalGet),
@@ -137,7 +142,12 @@ func (sbx *Sandbox) Init(vmType vmPoolType) {
 		(C.globalMapKeysFunc)(C.goGlobalMapKeys),
 		(C.globalMapLenFunc)(C.goGlobalMapLen),
 	)
-	C.InitGoCrypto((C.sha3Func)(C.goSha3))
+	C.InitGoCrypto(
+		(C.sha3Func)(C.goSha3),
+		(C.hashFunc)(C.goHash),
+		(C.verifyFunc)(C.goVerify),
+		(C.parsePubkeyFunc)(C.goParsePubkey),
+	)
 	C.loadVM(sbx.context, C.int(vmType))
 }
 
@@ -185,9 +195,56 @@ func (sbx *Sandbox) Validate(contract *contract.Contract) error {
 		return fmt.Errorf("validate code error: %v, result: %v", errMsg, result)
 	}
 
+	if contract.Info.Lint {
+		findings, err := sbx.Lint(contract.Code)
+		if err != nil {
+			return err
+		}
+		if len(findings) > 0 {
+			return fmt.Errorf("lint code error: %v", findings[0])
+		}
+	}
+
 	return nil
 }
 
+// LintFinding is a non-deterministic or dangerous construct reported by the linter
+type LintFinding struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+func (f *LintFinding) String() string {
+	return fmt.Sprintf("%v:%v %v %v", f.Line, f.Column, f.Rule, f.Message)
+}
+
+// Lint reports the non-deterministic or dangerous constructs in the code
+func (sbx *Sandbox) Lint(code string) ([]*LintFinding, error) {
+	cCode := newCStr(moduleReplacer.Replace(code))
+	defer C.free(unsafe.Pointer(cCode.data))
+
+	var (
+		cResult C.CStr
+		cErrMsg C.CStr
+	)
+	ret := C.lint(sbx.context, cCode, &cResult, &cErrMsg)
+	if ret == 1 {
+		errMsg := cErrMsg.GoString()
+		C.free(unsafe.Pointer(cErrMsg.data))
+		return nil, fmt.Errorf("lint code error: %v", errMsg)
+	}
+	result := cResult.GoString()
+	C.free(unsafe.Pointer(cResult.data))
+
+	findings := make([]*LintFinding, 0)
+	if err := json.Unmarshal([]byte(result), &findings); err != nil {
+		return nil, fmt.Errorf("lint code error: %v, result: %v", err, result)
+	}
+	return findings, nil
+}
+
 // Compile contract before execution, return compiled code
 func (sbx *Sandbox) Compile(contract *contract.Contract) (string, error) {
 	code := moduleReplacer.Replace(contract.Code)
@@ -240,6 +240,36 @@ func goMapLen(cSbx C.SandboxPtr, key, ramPayer C.CStr, result *C.size_t, gasUsed
 	return nil
 }
 
+//export goKeys
+func goKeys(cSbx C.SandboxPtr, key C.CStr, offset, limit C.size_t, result *C.CStr, gasUsed *C.size_t) *C.char {
+	sbx, ok := GetSandbox(cSbx)
+	if !ok {
+		return C.CString(ErrGetSandbox.Error())
+	}
+
+	k := key.GoString()
+
+	var cost contract.Cost
+	var keys []string
+	var err error
+	if k == "" {
+		keys, cost, err = sbx.host.Keys(int(offset), int(limit))
+	} else {
+		keys, cost, err = sbx.host.MapFields(k, int(offset), int(limit))
+	}
+	*gasUsed = C.size_t(cost.CPU)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	j, err := json.Marshal(keys)
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	result.SetString(string(j))
+
+	return nil
+}
+
 //export goGlobalHas
 func goGlobalHas(cSbx C.SandboxPtr, contractName, key, ramPayer C.CStr, result *C.bool, gasUsed *C.size_t) *C.char {
 	sbx, ok := GetSandbox(cSbx)
@@ -14,6 +14,7 @@
 #include "escodegen.js.h"
 #include "inject_gas.js.h"
 #include "validate.js.h"
+#include "lint.js.h"
 
 intptr_t externalRef[] = {
         reinterpret_cast<intptr_t>(NewConsoleLog),
@@ -32,6 +33,12 @@ static char validateFormat[] =
     "return validate(source, %s);\n"
     "})();";
 
+static char lintFormat[] =
+    "(function(){\n"
+    "const source = \"%s\";\n"
+    "return lint(source);\n"
+    "})();";
+
 static char codeFormat[] =
         "let module = {};\n"
         "module.exports = {};\n"
@@ -52,6 +59,7 @@ static char compileCodeFormat[] =
     "%s\n"  // load escodegen
     "const escodegen = module.exports;\n"
     "%s\n"  // load validate
+    "%s\n"  // load lint
     "%s\n"; // load inject_gas
 
 int compileInternal(SandboxPtr ptr, const CStr code, const CStr extra, char *format, const char *file, CStr *ret, CStr *errMsg) {
@@ -114,6 +122,10 @@ int validate(SandboxPtr ptr, const CStr code, const CStr abi, CStr *result, CStr
     return compileInternal(ptr, code, abi, validateFormat, "__validate.js", result, errMsg);
 }
 
+int lint(SandboxPtr ptr, const CStr code, CStr *result, CStr *errMsg) {
+    return compileInternal(ptr, code, {nullptr, 0}, lintFormat, "__lint.js", result, errMsg);
+}
+
 CustomStartupData createStartupData() {
     char *jsonjs = reinterpret_cast<char *>(__libjs_json_js);
     char *bignumberjs = reinterpret_cast<char *>(__libjs_bignumber_js);
@@ -170,6 +182,7 @@ CustomStartupData createCompileStartupData() {
     char *esprimajs = reinterpret_cast<char *>(__libjs_esprima_js);
     char *escodegenjs = reinterpret_cast<char *>(__libjs_escodegen_js);
     char *validatejs = reinterpret_cast<char *>(__libjs_validate_js);
+    char *lintjs = reinterpret_cast<char *>(__libjs_lint_js);
     char *injectgasjs = reinterpret_cast<char *>(__libjs_inject_gas_js);
 
     char *code = nullptr;
@@ -177,6 +190,7 @@ CustomStartupData createCompileStartupData() {
         esprimajs,
         escodegenjs,
         validatejs,
+        lintjs,
         injectgasjs);
 
     StartupData blob;
@@ -5,6 +5,7 @@
 
 int compile(SandboxPtr, const CStr code, CStr *compiledCode, CStr *errMsg);
 int validate(SandboxPtr ptr, const CStr code, const CStr abi, CStr *result, CStr *errMsg);
+int lint(SandboxPtr ptr, const CStr code, CStr *result, CStr *errMsg);
 CustomStartupData createStartupData();
 CustomStartupData createCompileStartupData();
 
@@ -1,10 +1,17 @@
 #include "crypto.h"
+#include <cstring>
 #include <iostream>
 
 static sha3Func CSha3 = nullptr;
+static hashFunc CHash = nullptr;
+static verifyFunc CVerify = nullptr;
+static parsePubkeyFunc CParsePubkey = nullptr;
 
-void InitGoCrypto(sha3Func sha3) {
+void InitGoCrypto(sha3Func sha3, hashFunc hash, verifyFunc verify, parsePubkeyFunc parsePubkey) {
     CSha3 = sha3;
+    CHash = hash;
+    CVerify = verify;
+    CParsePubkey = parsePubkey;
 }
 
 CStr IOSTCrypto::sha3(const CStr msg) {
@@ -15,6 +22,30 @@ CStr IOSTCrypto::sha3(const CStr msg) {
     return ret;
 }
 
+char* IOSTCrypto::hash(const CStr algo, const CStr msg, CStr *result) {
+    size_t gasUsed = 0;
+    char* ret = CHash(sbxPtr, algo, msg, result, &gasUsed);
+    Sandbox *sbx = static_cast<Sandbox*>(sbxPtr);
+    sbx->gasUsed += gasUsed;
+    return ret;
+}
+
+char* IOSTCrypto::verify(const CStr algo, const CStr msg, const CStr sig, const CStr pubkey, bool *result) {
+    size_t gasUsed = 0;
+    char* ret = CVerify(sbxPtr, algo, msg, sig, pubkey, result, &gasUsed);
+    Sandbox *sbx = static_cast<Sandbox*>(sbxPtr);
+    sbx->gasUsed += gasUsed;
+    return ret;
+}
+
+char* IOSTCrypto::parsePubkey(const CStr pubkey, CStr *result) {
+    size_t gasUsed = 0;
+    char* ret = CParsePubkey(sbxPtr, pubkey, result, &gasUsed);
+    Sandbox *sbx = static_cast<Sandbox*>(sbxPtr);
+    sbx->gasUsed += gasUsed;
+    return ret;
+}
+
 void NewCrypto(const FunctionCallbackInfo<Value> &args) {
     Isolate *isolate = args.GetIsolate();
     Local<Context> context = isolate->GetCurrentContext();
@@ -74,6 +105,154 @@ void IOSTCrypto_sha3(const FunctionCallbackInfo<Value> &args) {
     args.GetReturnValue().SetNull();
 }
 
+static void IOSTCrypto_hash(const FunctionCallbackInfo<Value> &args, const char *algo) {
+    Isolate *isolate = args.GetIsolate();
+    Local<Object> self = args.Holder();
+
+    if (args.Length() != 1) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, "IOSTCrypto_hash invalid argument length.")
+        );
+        isolate->ThrowException(err);
+        return;
+    }
+
+    Local<Value> msg = args[0];
+    if (!msg->IsString()) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, "IOSTCrypto_hash msg must be string.")
+        );
+        isolate->ThrowException(err);
+        return;
+    }
+
+    NewCStrChecked(msgStr, msg, isolate);
+    CStr algoStr = {const_cast<char *>(algo), static_cast<int>(strlen(algo))};
+    CStr resultStr = {nullptr, 0};
+
+    Local<External> extVal = Local<External>::Cast(self->GetInternalField(0));
+    if (!extVal->IsExternal()) {
+        std::cout << "IOSTCrypto_hash val error" << std::endl;
+        return;
+    }
+
+    IOSTCrypto *ic = static_cast<IOSTCrypto *>(extVal->Value());
+    char *ret = ic->hash(algoStr, msgStr, &resultStr);
+    if (ret != nullptr) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, ret)
+        );
+        isolate->ThrowException(err);
+        free(ret);
+        return;
+    }
+    args.GetReturnValue().Set(String::NewFromUtf8(isolate, resultStr.data, String::kNormalString, resultStr.size));
+    if (resultStr.data != nullptr) free(resultStr.data);
+}
+
+void IOSTCrypto_sha256(const FunctionCallbackInfo<Value> &args) {
+    IOSTCrypto_hash(args, "sha256");
+}
+
+void IOSTCrypto_ripemd160(const FunctionCallbackInfo<Value> &args) {
+    IOSTCrypto_hash(args, "ripemd160");
+}
+
+void IOSTCrypto_keccak256(const FunctionCallbackInfo<Value> &args) {
+    IOSTCrypto_hash(args, "keccak256");
+}
+
+void IOSTCrypto_verify(const FunctionCallbackInfo<Value> &args) {
+    Isolate *isolate = args.GetIsolate();
+    Local<Object> self = args.Holder();
+
+    if (args.Length() != 4) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, "IOSTCrypto_verify invalid argument length.")
+        );
+        isolate->ThrowException(err);
+        return;
+    }
+
+    for (int i = 0; i < 4; i++) {
+        if (!args[i]->IsString()) {
+            Local<Value> err = Exception::Error(
+                String::NewFromUtf8(isolate, "IOSTCrypto_verify algo, msg, sig and pubkey must be string.")
+            );
+            isolate->ThrowException(err);
+            return;
+        }
+    }
+
+    NewCStrChecked(algoStr, args[0], isolate);
+    NewCStrChecked(msgStr, args[1], isolate);
+    NewCStrChecked(sigStr, args[2], isolate);
+    NewCStrChecked(pubkeyStr, args[3], isolate);
+    bool result = false;
+
+    Local<External> extVal = Local<External>::Cast(self->GetInternalField(0));
+    if (!extVal->IsExternal()) {
+        std::cout << "IOSTCrypto_verify val error" << std::endl;
+        return;
+    }
+
+    IOSTCrypto *ic = static_cast<IOSTCrypto *>(extVal->Value());
+    char *ret = ic->verify(algoStr, msgStr, sigStr, pubkeyStr, &result);
+    if (ret != nullptr) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, ret)
+        );
+        isolate->ThrowException(err);
+        free(ret);
+        return;
+    }
+    args.GetReturnValue().Set(result);
+}
+
+void IOSTCrypto_parsePubkey(const FunctionCallbackInfo<Value> &args) {
+    Isolate *isolate = args.GetIsolate();
+    Local<Object> self = args.Holder();
+
+    if (args.Length() != 1) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, "IOSTCrypto_parsePubkey invalid argument length.")
+        );
+        isolate->ThrowException(err);
+        return;
+    }
+
+    Local<Value> pubkey = args[0];
+    if (!pubkey->IsString()) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, "IOSTCrypto_parsePubkey pubkey must be string.")
+        );
+        isolate->ThrowException(err);
+        return;
+    }
+
+    NewCStrChecked(pubkeyStr, pubkey, isolate);
+    CStr resultStr = {nullptr, 0};
+
+    Local<External> extVal = Local<External>::Cast(self->GetInternalField(0));
+    if (!extVal->IsExternal()) {
+        std::cout << "IOSTCrypto_parsePubkey val error" << std::endl;
+        return;
+    }
+
+    IOSTCrypto *ic = static_cast<IOSTCrypto *>(extVal->Value());
+    char *ret = ic->parsePubkey(pubkeyStr, &resultStr);
+    if (ret != nullptr) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, ret)
+        );
+        isolate->ThrowException(err);
+        free(ret);
+        return;
+    }
+    args.GetReturnValue().Set(String::NewFromUtf8(isolate, resultStr.data, String::kNormalString, resultStr.size));
+    if (resultStr.data != nullptr) free(resultStr.data);
+}
+
 void InitCrypto(Isolate *isolate, Local<ObjectTemplate> globalTpl) {
     Local<FunctionTemplate> cryptoClass =
         FunctionTemplate::New(isolate, NewCrypto);
@@ -86,6 +265,26 @@ void InitCrypto(Isolate *isolate, Local<ObjectTemplate> globalTpl) {
         String::NewFromUtf8(isolate, "sha3"),
         FunctionTemplate::New(isolate, IOSTCrypto_sha3)
     );
+    cryptoTpl->Set(
+        String::NewFromUtf8(isolate, "sha256"),
+        FunctionTemplate::New(isolate, IOSTCrypto_sha256)
+    );
+    cryptoTpl->Set(
+        String::NewFromUtf8(isolate, "ripemd160"),
+        FunctionTemplate::New(isolate, IOSTCrypto_ripemd160)
+    );
+    cryptoTpl->Set(
+        String::NewFromUtf8(isolate, "keccak256"),
+        FunctionTemplate::New(isolate, IOSTCrypto_keccak256)
+    );
+    cryptoTpl->Set(
+        String::NewFromUtf8(isolate, "verify"),
+        FunctionTemplate::New(isolate, IOSTCrypto_verify)
+    );
+    cryptoTpl->Set(
+        String::NewFromUtf8(isolate, "parsePubkey"),
+        FunctionTemplate::New(isolate, IOSTCrypto_parsePubkey)
+    );
 
     globalTpl->Set(cryptoClassName, cryptoClass);
 }
\ No newline at end of file
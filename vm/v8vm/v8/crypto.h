@@ -14,6 +14,9 @@ public:
     IOSTCrypto(SandboxPtr ptr): sbxPtr(ptr) {}
 
     CStr sha3(const CStr msg);
+    char* hash(const CStr algo, const CStr msg, CStr *result);
+    char* verify(const CStr algo, const CStr msg, const CStr sig, const CStr pubkey, bool *result);
+    char* parsePubkey(const CStr pubkey, CStr *result);
 };
 
 #endif // IOST_V8_CRYPTO_H
\ No newline at end of file
@@ -34,6 +34,10 @@ let BlockChain = (function () {
         blockInfo: function () {
             return bc.blockInfo();
         },
+        // get the random value of current block, it's verified by every node, so the witness can't choose it
+        random: function () {
+            return JSON.parse(bc.blockInfo())["random"];
+        },
         // get transactionInfo
         txInfo: function () {
             return bc.txInfo();
@@ -76,6 +80,10 @@ let BlockChain = (function () {
         event: function (content) {
             return bc.event(content);
         },
+        // post event declared in abi, data is an object of the fields
+        emit: function (name, data) {
+            return bc.event(JSON.stringify({name: name, data: data}));
+        },
     }
 })();
 
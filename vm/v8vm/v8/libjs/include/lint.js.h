@@ -0,0 +1,768 @@
+unsigned char __libjs_lint_js[] = {
+  0x27, 0x75, 0x73, 0x65, 0x20, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x27,
+  0x3b, 0x0a, 0x0a, 0x2f, 0x2f, 0x20, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69,
+  0x6f, 0x6e, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x4d, 0x61, 0x74, 0x68, 0x20,
+  0x77, 0x68, 0x69, 0x63, 0x68, 0x20, 0x61, 0x6c, 0x77, 0x61, 0x79, 0x73,
+  0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x69, 0x6e, 0x74, 0x65,
+  0x67, 0x65, 0x72, 0x20, 0x66, 0x6f, 0x72, 0x20, 0x69, 0x6e, 0x74, 0x65,
+  0x67, 0x65, 0x72, 0x20, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+  0x73, 0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x20, 0x69, 0x6e, 0x74, 0x65,
+  0x67, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x68, 0x46, 0x75, 0x6e, 0x63, 0x74,
+  0x69, 0x6f, 0x6e, 0x73, 0x20, 0x3d, 0x20, 0x5b, 0x22, 0x61, 0x62, 0x73,
+  0x22, 0x2c, 0x20, 0x22, 0x63, 0x65, 0x69, 0x6c, 0x22, 0x2c, 0x20, 0x22,
+  0x66, 0x6c, 0x6f, 0x6f, 0x72, 0x22, 0x2c, 0x20, 0x22, 0x6d, 0x61, 0x78,
+  0x22, 0x2c, 0x20, 0x22, 0x6d, 0x69, 0x6e, 0x22, 0x2c, 0x20, 0x22, 0x72,
+  0x6f, 0x75, 0x6e, 0x64, 0x22, 0x2c, 0x20, 0x22, 0x73, 0x69, 0x67, 0x6e,
+  0x22, 0x2c, 0x20, 0x22, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x22, 0x5d, 0x3b,
+  0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x20, 0x61, 0x72, 0x72, 0x61, 0x79,
+  0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74,
+  0x68, 0x6f, 0x64, 0x73, 0x20, 0x3d, 0x20, 0x5b, 0x22, 0x66, 0x6f, 0x72,
+  0x45, 0x61, 0x63, 0x68, 0x22, 0x2c, 0x20, 0x22, 0x6d, 0x61, 0x70, 0x22,
+  0x2c, 0x20, 0x22, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x2c, 0x20,
+  0x22, 0x72, 0x65, 0x64, 0x75, 0x63, 0x65, 0x22, 0x2c, 0x20, 0x22, 0x72,
+  0x65, 0x64, 0x75, 0x63, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x22, 0x2c,
+  0x20, 0x22, 0x73, 0x6f, 0x6d, 0x65, 0x22, 0x2c, 0x20, 0x22, 0x65, 0x76,
+  0x65, 0x72, 0x79, 0x22, 0x2c, 0x20, 0x22, 0x66, 0x69, 0x6e, 0x64, 0x22,
+  0x2c, 0x20, 0x22, 0x66, 0x69, 0x6e, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78,
+  0x22, 0x5d, 0x3b, 0x0a, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x20, 0x6f, 0x62,
+  0x6a, 0x65, 0x63, 0x74, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+  0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x20, 0x3d, 0x20, 0x5b,
+  0x22, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x76, 0x61, 0x6c,
+  0x75, 0x65, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x65, 0x6e, 0x74, 0x72, 0x69,
+  0x65, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x67, 0x65, 0x74, 0x4f, 0x77, 0x6e,
+  0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+  0x73, 0x22, 0x5d, 0x3b, 0x0a, 0x2f, 0x2f, 0x20, 0x63, 0x6c, 0x61, 0x73,
+  0x73, 0x65, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x65, 0x78, 0x61, 0x63, 0x74,
+  0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x2c, 0x20, 0x74, 0x68,
+  0x65, 0x69, 0x72, 0x20, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x20,
+  0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x73,
+  0x61, 0x6d, 0x65, 0x20, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x0a, 0x63, 0x6f,
+  0x6e, 0x73, 0x74, 0x20, 0x62, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+  0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x20, 0x3d, 0x20, 0x5b,
+  0x22, 0x42, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x2c,
+  0x20, 0x22, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x22, 0x2c, 0x20, 0x22, 0x46,
+  0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x22, 0x5d, 0x3b, 0x0a, 0x0a, 0x66,
+  0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x69, 0x73, 0x4d, 0x65,
+  0x6d, 0x62, 0x65, 0x72, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x6f,
+  0x62, 0x6a, 0x65, 0x63, 0x74, 0x2c, 0x20, 0x70, 0x72, 0x6f, 0x70, 0x65,
+  0x72, 0x74, 0x79, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x72,
+  0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74,
+  0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x4d, 0x65, 0x6d,
+  0x62, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+  0x6e, 0x22, 0x20, 0x26, 0x26, 0x20, 0x21, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65,
+  0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x2e, 0x74, 0x79, 0x70, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
+  0x66, 0x69, 0x65, 0x72, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64,
+  0x65, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x2e, 0x6e, 0x61, 0x6d,
+  0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x26, 0x26, 0x20,
+  0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+  0x79, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22,
+  0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x20,
+  0x26, 0x26, 0x20, 0x28, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x75, 0x6e, 0x64, 0x65, 0x66, 0x69, 0x6e,
+  0x65, 0x64, 0x20, 0x7c, 0x7c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x70,
+  0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x2e, 0x6e, 0x61, 0x6d, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+  0x79, 0x29, 0x3b, 0x0a, 0x7d, 0x0a, 0x0a, 0x66, 0x75, 0x6e, 0x63, 0x74,
+  0x69, 0x6f, 0x6e, 0x20, 0x69, 0x73, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+  0x73, 0x43, 0x61, 0x6c, 0x6c, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x29, 0x20,
+  0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
+  0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d,
+  0x3d, 0x3d, 0x20, 0x22, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x78, 0x70, 0x72,
+  0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e,
+  0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x2e, 0x74,
+  0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x4d, 0x65, 0x6d,
+  0x62, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+  0x6e, 0x22, 0x20, 0x26, 0x26, 0x20, 0x21, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+  0x74, 0x65, 0x64, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c,
+  0x65, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x2e,
+  0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x49, 0x64,
+  0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x26, 0x26, 0x20, 0x28, 0x6e, 0x6f,
+  0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x2e, 0x70, 0x72,
+  0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x20,
+  0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73,
+  0x22, 0x20, 0x7c, 0x7c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61,
+  0x6c, 0x6c, 0x65, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+  0x79, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22,
+  0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+  0x73, 0x22, 0x29, 0x3b, 0x0a, 0x7d, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x73,
+  0x73, 0x20, 0x4c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x20, 0x7b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+  0x6f, 0x72, 0x28, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x66, 0x69, 0x6e, 0x64,
+  0x69, 0x6e, 0x67, 0x73, 0x20, 0x3d, 0x20, 0x5b, 0x5d, 0x3b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x2f, 0x2f, 0x20, 0x6e, 0x61,
+  0x6d, 0x65, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x76, 0x61, 0x72, 0x69, 0x61,
+  0x62, 0x6c, 0x65, 0x73, 0x20, 0x68, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67,
+  0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x20,
+  0x6f, 0x66, 0x20, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e,
+  0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+  0x20, 0x3d, 0x20, 0x7b, 0x7d, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x2f, 0x2f, 0x20, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x20,
+  0x6f, 0x66, 0x20, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73,
+  0x20, 0x68, 0x6f, 0x6c, 0x64, 0x69, 0x6e, 0x67, 0x20, 0x62, 0x69, 0x67,
+  0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x62, 0x69,
+  0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+  0x20, 0x3d, 0x20, 0x7b, 0x7d, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x7d,
+  0x0a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x72, 0x75, 0x6c, 0x65, 0x2c,
+  0x20, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x29, 0x20, 0x7b, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73,
+  0x2e, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x70, 0x75,
+  0x73, 0x68, 0x28, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x72, 0x75, 0x6c, 0x65, 0x3a, 0x20, 0x72,
+  0x75, 0x6c, 0x65, 0x2c, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+  0x3a, 0x20, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2c, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x6c,
+  0x69, 0x6e, 0x65, 0x3a, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6c, 0x6f,
+  0x63, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x2e, 0x6c, 0x69, 0x6e, 0x65,
+  0x2c, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x3a, 0x20, 0x6e, 0x6f,
+  0x64, 0x65, 0x2e, 0x6c, 0x6f, 0x63, 0x2e, 0x73, 0x74, 0x61, 0x72, 0x74,
+  0x2e, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x7d, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x7d, 0x0a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x69, 0x73, 0x4d, 0x61, 0x70,
+  0x4b, 0x65, 0x79, 0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x29, 0x20, 0x7b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x6e, 0x75,
+  0x6c, 0x6c, 0x20, 0x7c, 0x7c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x20, 0x3d,
+  0x3d, 0x3d, 0x20, 0x75, 0x6e, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64,
+  0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x66,
+  0x61, 0x6c, 0x73, 0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x69, 0x66, 0x20, 0x28, 0x69, 0x73, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+  0x73, 0x43, 0x61, 0x6c, 0x6c, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x29, 0x29,
+  0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x74, 0x72,
+  0x75, 0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66,
+  0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20,
+  0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+  0x69, 0x65, 0x72, 0x22, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75,
+  0x72, 0x6e, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x6d, 0x61, 0x70, 0x4b,
+  0x65, 0x79, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x5b, 0x6e, 0x6f, 0x64,
+  0x65, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x20, 0x3d, 0x3d, 0x3d, 0x20,
+  0x74, 0x72, 0x75, 0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70,
+  0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x4d, 0x65, 0x6d, 0x62, 0x65,
+  0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+  0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x74,
+  0x68, 0x69, 0x73, 0x2e, 0x69, 0x73, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+  0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+  0x74, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66,
+  0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20,
+  0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x45,
+  0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x20, 0x7c,
+  0x7c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20,
+  0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+  0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x29,
+  0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x74, 0x68,
+  0x69, 0x73, 0x2e, 0x69, 0x73, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6c, 0x65, 0x66, 0x74, 0x29, 0x20,
+  0x7c, 0x7c, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x69, 0x73, 0x4d, 0x61,
+  0x70, 0x4b, 0x65, 0x79, 0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x72,
+  0x69, 0x67, 0x68, 0x74, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x66, 0x61, 0x6c, 0x73,
+  0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x69, 0x73, 0x42, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+  0x72, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f,
+  0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20,
+  0x22, 0x4e, 0x65, 0x77, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+  0x6f, 0x6e, 0x22, 0x20, 0x7c, 0x7c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x43, 0x61,
+  0x6c, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+  0x22, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64,
+  0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x2e, 0x74, 0x79, 0x70,
+  0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x49, 0x64, 0x65, 0x6e, 0x74,
+  0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x62, 0x69, 0x67,
+  0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65,
+  0x73, 0x2e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x73, 0x28, 0x6e,
+  0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x2e, 0x6e,
+  0x61, 0x6d, 0x65, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x2f, 0x2f, 0x20, 0x6d,
+  0x65, 0x74, 0x68, 0x6f, 0x64, 0x20, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x20,
+  0x6f, 0x66, 0x20, 0x62, 0x69, 0x67, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+  0x72, 0x73, 0x2c, 0x20, 0x65, 0x2e, 0x67, 0x2e, 0x20, 0x6e, 0x65, 0x77,
+  0x20, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x28, 0x61, 0x29, 0x2e,
+  0x6d, 0x75, 0x6c, 0x74, 0x69, 0x28, 0x62, 0x29, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74,
+  0x75, 0x72, 0x6e, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70,
+  0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x43, 0x61, 0x6c, 0x6c, 0x45,
+  0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x20, 0x26,
+  0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65,
+  0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22,
+  0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+  0x73, 0x69, 0x6f, 0x6e, 0x22, 0x20, 0x26, 0x26, 0x20, 0x74, 0x68, 0x69,
+  0x73, 0x2e, 0x69, 0x73, 0x42, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+  0x72, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65,
+  0x65, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x29, 0x3b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64,
+  0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22,
+  0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x29,
+  0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x74, 0x68,
+  0x69, 0x73, 0x2e, 0x62, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+  0x4e, 0x61, 0x6d, 0x65, 0x73, 0x5b, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6e,
+  0x61, 0x6d, 0x65, 0x5d, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x74, 0x72, 0x75,
+  0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74,
+  0x75, 0x72, 0x6e, 0x20, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x3b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x7d, 0x0a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x63, 0x6f,
+  0x6c, 0x6c, 0x65, 0x63, 0x74, 0x42, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62,
+  0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65,
+  0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x20, 0x3d, 0x3d, 0x3d,
+  0x20, 0x6e, 0x75, 0x6c, 0x6c, 0x20, 0x7c, 0x7c, 0x20, 0x74, 0x79, 0x70,
+  0x65, 0x6f, 0x66, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x20, 0x21, 0x3d, 0x3d,
+  0x20, 0x22, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x29, 0x20, 0x7b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x3b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x56, 0x61,
+  0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72,
+  0x61, 0x74, 0x6f, 0x72, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64,
+  0x65, 0x2e, 0x69, 0x64, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d,
+  0x3d, 0x20, 0x22, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+  0x72, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x69,
+  0x6e, 0x69, 0x74, 0x20, 0x21, 0x3d, 0x3d, 0x20, 0x6e, 0x75, 0x6c, 0x6c,
+  0x20, 0x26, 0x26, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x69, 0x73, 0x42,
+  0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x28, 0x6e, 0x6f, 0x64,
+  0x65, 0x2e, 0x69, 0x6e, 0x69, 0x74, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74,
+  0x68, 0x69, 0x73, 0x2e, 0x62, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+  0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x5b, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x69, 0x64, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x20, 0x3d, 0x20, 0x74,
+  0x72, 0x75, 0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69,
+  0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e,
+  0x6d, 0x65, 0x6e, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+  0x6f, 0x6e, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x20, 0x3d, 0x3d, 0x3d,
+  0x20, 0x22, 0x3d, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65,
+  0x2e, 0x6c, 0x65, 0x66, 0x74, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d,
+  0x3d, 0x3d, 0x20, 0x22, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+  0x65, 0x72, 0x22, 0x20, 0x26, 0x26, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e,
+  0x69, 0x73, 0x42, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x28,
+  0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x72, 0x69, 0x67, 0x68, 0x74, 0x29, 0x29,
+  0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x62, 0x69, 0x67, 0x4e,
+  0x75, 0x6d, 0x62, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x5b, 0x6e,
+  0x6f, 0x64, 0x65, 0x2e, 0x6c, 0x65, 0x66, 0x74, 0x2e, 0x6e, 0x61, 0x6d,
+  0x65, 0x5d, 0x20, 0x3d, 0x20, 0x74, 0x72, 0x75, 0x65, 0x3b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x66, 0x6f, 0x72, 0x20, 0x28, 0x63, 0x6f,
+  0x6e, 0x73, 0x74, 0x20, 0x6b, 0x65, 0x79, 0x20, 0x69, 0x6e, 0x20, 0x6e,
+  0x6f, 0x64, 0x65, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6b,
+  0x65, 0x79, 0x20, 0x21, 0x3d, 0x3d, 0x20, 0x22, 0x6c, 0x6f, 0x63, 0x22,
+  0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x68, 0x61, 0x73,
+  0x4f, 0x77, 0x6e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x28,
+  0x6b, 0x65, 0x79, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x74, 0x68, 0x69, 0x73, 0x2e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+  0x42, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4e, 0x61, 0x6d,
+  0x65, 0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x5b, 0x6b, 0x65, 0x79, 0x5d,
+  0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x2f, 0x2f, 0x20, 0x61, 0x20, 0x64, 0x69, 0x76, 0x69, 0x73,
+  0x69, 0x6f, 0x6e, 0x20, 0x69, 0x73, 0x20, 0x66, 0x6c, 0x6f, 0x61, 0x74,
+  0x20, 0x75, 0x6e, 0x6c, 0x65, 0x73, 0x73, 0x20, 0x62, 0x6f, 0x74, 0x68,
+  0x20, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x20, 0x61, 0x72,
+  0x65, 0x20, 0x62, 0x69, 0x67, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+  0x73, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x69, 0x73, 0x46, 0x6c, 0x6f, 0x61,
+  0x74, 0x44, 0x69, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x28, 0x6c, 0x65,
+  0x66, 0x74, 0x2c, 0x20, 0x72, 0x69, 0x67, 0x68, 0x74, 0x29, 0x20, 0x7b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x63, 0x6f, 0x6e,
+  0x73, 0x74, 0x20, 0x69, 0x73, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4c,
+  0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x20, 0x3d, 0x20, 0x6e, 0x20, 0x3d,
+  0x3e, 0x20, 0x6e, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d,
+  0x20, 0x22, 0x4c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x22, 0x20, 0x26,
+  0x26, 0x20, 0x74, 0x79, 0x70, 0x65, 0x6f, 0x66, 0x20, 0x6e, 0x2e, 0x76,
+  0x61, 0x6c, 0x75, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x6e, 0x75,
+  0x6d, 0x62, 0x65, 0x72, 0x22, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x69, 0x73, 0x4e, 0x75, 0x6d,
+  0x62, 0x65, 0x72, 0x4c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x28, 0x6c,
+  0x65, 0x66, 0x74, 0x29, 0x20, 0x7c, 0x7c, 0x20, 0x69, 0x73, 0x4e, 0x75,
+  0x6d, 0x62, 0x65, 0x72, 0x4c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x6c, 0x28,
+  0x72, 0x69, 0x67, 0x68, 0x74, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65,
+  0x74, 0x75, 0x72, 0x6e, 0x20, 0x74, 0x72, 0x75, 0x65, 0x3b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20,
+  0x21, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x69, 0x73, 0x42, 0x69, 0x67, 0x4e,
+  0x75, 0x6d, 0x62, 0x65, 0x72, 0x28, 0x6c, 0x65, 0x66, 0x74, 0x29, 0x20,
+  0x7c, 0x7c, 0x20, 0x21, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x69, 0x73, 0x42,
+  0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x28, 0x72, 0x69, 0x67,
+  0x68, 0x74, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x4d,
+  0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x28,
+  0x6e, 0x6f, 0x64, 0x65, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x6e, 0x75, 0x6c, 0x6c, 0x20, 0x7c, 0x7c,
+  0x20, 0x74, 0x79, 0x70, 0x65, 0x6f, 0x66, 0x20, 0x6e, 0x6f, 0x64, 0x65,
+  0x20, 0x21, 0x3d, 0x3d, 0x20, 0x22, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+  0x22, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x3b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6e,
+  0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d,
+  0x20, 0x22, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x44, 0x65,
+  0x63, 0x6c, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x20, 0x26, 0x26,
+  0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x69, 0x64, 0x2e, 0x74, 0x79, 0x70,
+  0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x49, 0x64, 0x65, 0x6e, 0x74,
+  0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f,
+  0x64, 0x65, 0x2e, 0x69, 0x6e, 0x69, 0x74, 0x20, 0x21, 0x3d, 0x3d, 0x20,
+  0x6e, 0x75, 0x6c, 0x6c, 0x20, 0x26, 0x26, 0x20, 0x74, 0x68, 0x69, 0x73,
+  0x2e, 0x69, 0x73, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x28, 0x6e,
+  0x6f, 0x64, 0x65, 0x2e, 0x69, 0x6e, 0x69, 0x74, 0x29, 0x29, 0x20, 0x7b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+  0x73, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x5b, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x69, 0x64, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x20, 0x3d, 0x20, 0x74,
+  0x72, 0x75, 0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69,
+  0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e,
+  0x6d, 0x65, 0x6e, 0x74, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+  0x6f, 0x6e, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x6c, 0x65, 0x66, 0x74, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d,
+  0x3d, 0x20, 0x22, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+  0x72, 0x22, 0x20, 0x26, 0x26, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x69,
+  0x73, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x28, 0x6e, 0x6f, 0x64,
+  0x65, 0x2e, 0x72, 0x69, 0x67, 0x68, 0x74, 0x29, 0x29, 0x20, 0x7b, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x74, 0x68, 0x69, 0x73, 0x2e, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73,
+  0x4e, 0x61, 0x6d, 0x65, 0x73, 0x5b, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6c,
+  0x65, 0x66, 0x74, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x5d, 0x20, 0x3d, 0x20,
+  0x74, 0x72, 0x75, 0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x66, 0x6f, 0x72, 0x20, 0x28, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x20, 0x6b,
+  0x65, 0x79, 0x20, 0x69, 0x6e, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x29, 0x20,
+  0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6b, 0x65, 0x79, 0x20, 0x21, 0x3d,
+  0x3d, 0x20, 0x22, 0x6c, 0x6f, 0x63, 0x22, 0x20, 0x26, 0x26, 0x20, 0x6e,
+  0x6f, 0x64, 0x65, 0x2e, 0x68, 0x61, 0x73, 0x4f, 0x77, 0x6e, 0x50, 0x72,
+  0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x28, 0x6b, 0x65, 0x79, 0x29, 0x29,
+  0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e,
+  0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x70, 0x4b, 0x65,
+  0x79, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65,
+  0x5b, 0x6b, 0x65, 0x79, 0x5d, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x7d, 0x0a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x63, 0x68, 0x65, 0x63, 0x6b,
+  0x4e, 0x6f, 0x64, 0x65, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x29, 0x20, 0x7b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x73, 0x77, 0x69,
+  0x74, 0x63, 0x68, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79,
+  0x70, 0x65, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x63, 0x61, 0x73, 0x65, 0x20, 0x22, 0x49, 0x64, 0x65, 0x6e,
+  0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x3a, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x3d,
+  0x3d, 0x3d, 0x20, 0x22, 0x44, 0x61, 0x74, 0x65, 0x22, 0x29, 0x20, 0x7b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x72, 0x65,
+  0x70, 0x6f, 0x72, 0x74, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22,
+  0x6e, 0x6f, 0x2d, 0x64, 0x61, 0x74, 0x65, 0x22, 0x2c, 0x20, 0x22, 0x44,
+  0x61, 0x74, 0x65, 0x20, 0x69, 0x73, 0x20, 0x6e, 0x6f, 0x74, 0x20, 0x64,
+  0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63,
+  0x2c, 0x20, 0x75, 0x73, 0x65, 0x20, 0x74, 0x68, 0x65, 0x20, 0x74, 0x69,
+  0x6d, 0x65, 0x20, 0x69, 0x6e, 0x20, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63,
+  0x68, 0x61, 0x69, 0x6e, 0x2e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e,
+  0x66, 0x6f, 0x28, 0x29, 0x20, 0x69, 0x6e, 0x73, 0x74, 0x65, 0x61, 0x64,
+  0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x7d, 0x20, 0x65, 0x6c, 0x73, 0x65, 0x20, 0x69,
+  0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6e, 0x61, 0x6d, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x65, 0x76, 0x61, 0x6c, 0x22, 0x20,
+  0x7c, 0x7c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6e, 0x61, 0x6d, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69,
+  0x6f, 0x6e, 0x22, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74,
+  0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x28, 0x6e,
+  0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x65, 0x76, 0x61,
+  0x6c, 0x22, 0x2c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6e, 0x61, 0x6d,
+  0x65, 0x20, 0x2b, 0x20, 0x22, 0x20, 0x72, 0x75, 0x6e, 0x73, 0x20, 0x63,
+  0x6f, 0x64, 0x65, 0x20, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+  0x64, 0x20, 0x61, 0x74, 0x20, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+  0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x7d, 0x20, 0x65, 0x6c, 0x73, 0x65, 0x20, 0x69,
+  0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6e, 0x61, 0x6d, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x70, 0x61, 0x72, 0x73, 0x65, 0x46,
+  0x6c, 0x6f, 0x61, 0x74, 0x22, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x66,
+  0x6c, 0x6f, 0x61, 0x74, 0x22, 0x2c, 0x20, 0x22, 0x70, 0x61, 0x72, 0x73,
+  0x65, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72,
+  0x6e, 0x73, 0x20, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x2c, 0x20, 0x75, 0x73,
+  0x65, 0x20, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x20, 0x69, 0x6e,
+  0x73, 0x74, 0x65, 0x61, 0x64, 0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x62,
+  0x72, 0x65, 0x61, 0x6b, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x63, 0x61, 0x73, 0x65, 0x20, 0x22, 0x4c, 0x69, 0x74, 0x65,
+  0x72, 0x61, 0x6c, 0x22, 0x3a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x74, 0x79,
+  0x70, 0x65, 0x6f, 0x66, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x61,
+  0x6c, 0x75, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x6e, 0x75, 0x6d,
+  0x62, 0x65, 0x72, 0x22, 0x20, 0x26, 0x26, 0x20, 0x21, 0x4e, 0x75, 0x6d,
+  0x62, 0x65, 0x72, 0x2e, 0x69, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65,
+  0x72, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x65,
+  0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69,
+  0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x28, 0x6e, 0x6f, 0x64,
+  0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x66, 0x6c, 0x6f, 0x61, 0x74,
+  0x22, 0x2c, 0x20, 0x22, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x20, 0x6c, 0x69,
+  0x74, 0x65, 0x72, 0x61, 0x6c, 0x20, 0x22, 0x20, 0x2b, 0x20, 0x6e, 0x6f,
+  0x64, 0x65, 0x2e, 0x72, 0x61, 0x77, 0x20, 0x2b, 0x20, 0x22, 0x2c, 0x20,
+  0x75, 0x73, 0x65, 0x20, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36, 0x34, 0x20,
+  0x69, 0x6e, 0x73, 0x74, 0x65, 0x61, 0x64, 0x22, 0x29, 0x3b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x63, 0x61, 0x73, 0x65, 0x20, 0x22, 0x4d, 0x65,
+  0x6d, 0x62, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+  0x6f, 0x6e, 0x22, 0x3a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x69, 0x73, 0x4d,
+  0x65, 0x6d, 0x62, 0x65, 0x72, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20,
+  0x22, 0x4d, 0x61, 0x74, 0x68, 0x22, 0x2c, 0x20, 0x22, 0x72, 0x61, 0x6e,
+  0x64, 0x6f, 0x6d, 0x22, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x6d,
+  0x61, 0x74, 0x68, 0x2d, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x22, 0x2c,
+  0x20, 0x22, 0x4d, 0x61, 0x74, 0x68, 0x2e, 0x72, 0x61, 0x6e, 0x64, 0x6f,
+  0x6d, 0x20, 0x69, 0x73, 0x20, 0x6e, 0x6f, 0x74, 0x20, 0x64, 0x65, 0x74,
+  0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x2c, 0x20,
+  0x75, 0x73, 0x65, 0x20, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61,
+  0x69, 0x6e, 0x2e, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x20, 0x69, 0x6e,
+  0x73, 0x74, 0x65, 0x61, 0x64, 0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x20, 0x65,
+  0x6c, 0x73, 0x65, 0x20, 0x69, 0x66, 0x20, 0x28, 0x69, 0x73, 0x4d, 0x65,
+  0x6d, 0x62, 0x65, 0x72, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22,
+  0x4d, 0x61, 0x74, 0x68, 0x22, 0x29, 0x20, 0x26, 0x26, 0x20, 0x21, 0x69,
+  0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x68, 0x46, 0x75,
+  0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x69, 0x6e, 0x63, 0x6c,
+  0x75, 0x64, 0x65, 0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72,
+  0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x29,
+  0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73,
+  0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x28, 0x6e, 0x6f, 0x64, 0x65,
+  0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x22,
+  0x2c, 0x20, 0x22, 0x4d, 0x61, 0x74, 0x68, 0x2e, 0x22, 0x20, 0x2b, 0x20,
+  0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+  0x79, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x2b, 0x20, 0x22, 0x20, 0x69,
+  0x73, 0x20, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x20, 0x6d, 0x61, 0x74, 0x68,
+  0x2c, 0x20, 0x75, 0x73, 0x65, 0x20, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x36,
+  0x34, 0x20, 0x69, 0x6e, 0x73, 0x74, 0x65, 0x61, 0x64, 0x22, 0x29, 0x3b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x7d, 0x20, 0x65, 0x6c, 0x73, 0x65, 0x20, 0x69, 0x66, 0x20, 0x28,
+  0x69, 0x73, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x28, 0x6e, 0x6f, 0x64,
+  0x65, 0x2c, 0x20, 0x22, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x29,
+  0x20, 0x26, 0x26, 0x20, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x74,
+  0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+  0x64, 0x73, 0x2e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x73, 0x28,
+  0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
+  0x79, 0x2e, 0x6e, 0x61, 0x6d, 0x65, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+  0x72, 0x74, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f,
+  0x2d, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x2d, 0x69, 0x74, 0x65, 0x72,
+  0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c, 0x20, 0x22, 0x4f, 0x62, 0x6a,
+  0x65, 0x63, 0x74, 0x2e, 0x22, 0x20, 0x2b, 0x20, 0x6e, 0x6f, 0x64, 0x65,
+  0x2e, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x2e, 0x6e, 0x61,
+  0x6d, 0x65, 0x20, 0x2b, 0x20, 0x22, 0x20, 0x64, 0x65, 0x70, 0x65, 0x6e,
+  0x64, 0x73, 0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x70, 0x72,
+  0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x20, 0x6f, 0x72, 0x64, 0x65, 0x72,
+  0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x6f, 0x62, 0x6a, 0x65,
+  0x63, 0x74, 0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x62, 0x72, 0x65, 0x61,
+  0x6b, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x63,
+  0x61, 0x73, 0x65, 0x20, 0x22, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x45,
+  0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x3a, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x63, 0x61, 0x73, 0x65,
+  0x20, 0x22, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74,
+  0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x3a,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x69, 0x66, 0x20, 0x28, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x6f,
+  0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x20, 0x3d, 0x3d, 0x3d, 0x20,
+  0x22, 0x2f, 0x22, 0x20, 0x7c, 0x7c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x20, 0x3d, 0x3d, 0x3d,
+  0x20, 0x22, 0x2f, 0x3d, 0x22, 0x29, 0x20, 0x26, 0x26, 0x20, 0x74, 0x68,
+  0x69, 0x73, 0x2e, 0x69, 0x73, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x44, 0x69,
+  0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x6c, 0x65, 0x66, 0x74, 0x2c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x72,
+  0x69, 0x67, 0x68, 0x74, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x66,
+  0x6c, 0x6f, 0x61, 0x74, 0x22, 0x2c, 0x20, 0x22, 0x64, 0x69, 0x76, 0x69,
+  0x73, 0x69, 0x6f, 0x6e, 0x20, 0x6f, 0x66, 0x20, 0x6e, 0x75, 0x6d, 0x62,
+  0x65, 0x72, 0x73, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73, 0x20,
+  0x66, 0x6c, 0x6f, 0x61, 0x74, 0x2c, 0x20, 0x75, 0x73, 0x65, 0x20, 0x4d,
+  0x61, 0x74, 0x68, 0x2e, 0x66, 0x6c, 0x6f, 0x6f, 0x72, 0x2c, 0x20, 0x49,
+  0x6e, 0x74, 0x36, 0x34, 0x20, 0x6f, 0x72, 0x20, 0x46, 0x6c, 0x6f, 0x61,
+  0x74, 0x36, 0x34, 0x20, 0x69, 0x6e, 0x73, 0x74, 0x65, 0x61, 0x64, 0x22,
+  0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x3b, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x63, 0x61, 0x73, 0x65,
+  0x20, 0x22, 0x46, 0x6f, 0x72, 0x49, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65,
+  0x6d, 0x65, 0x6e, 0x74, 0x22, 0x3a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e,
+  0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c,
+  0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x2d,
+  0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c, 0x20,
+  0x22, 0x66, 0x6f, 0x72, 0x2e, 0x2e, 0x2e, 0x69, 0x6e, 0x20, 0x64, 0x65,
+  0x70, 0x65, 0x6e, 0x64, 0x73, 0x20, 0x6f, 0x6e, 0x20, 0x74, 0x68, 0x65,
+  0x20, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x20, 0x6f, 0x72,
+  0x64, 0x65, 0x72, 0x20, 0x6f, 0x66, 0x20, 0x74, 0x68, 0x65, 0x20, 0x6f,
+  0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20,
+  0x28, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x69, 0x73, 0x4d, 0x61, 0x70, 0x4b,
+  0x65, 0x79, 0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x72, 0x69, 0x67,
+  0x68, 0x74, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74,
+  0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x28, 0x6e,
+  0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x75, 0x6e, 0x62,
+  0x6f, 0x75, 0x6e, 0x64, 0x65, 0x64, 0x2d, 0x6d, 0x61, 0x70, 0x6b, 0x65,
+  0x79, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x6c, 0x6f, 0x6f, 0x70, 0x20, 0x6f,
+  0x76, 0x65, 0x72, 0x20, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x20,
+  0x69, 0x73, 0x20, 0x75, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x65, 0x64,
+  0x2c, 0x20, 0x75, 0x73, 0x65, 0x20, 0x6d, 0x61, 0x70, 0x46, 0x69, 0x65,
+  0x6c, 0x64, 0x73, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x70, 0x61, 0x67,
+  0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x69, 0x6e, 0x73, 0x74,
+  0x65, 0x61, 0x64, 0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x62, 0x72, 0x65,
+  0x61, 0x6b, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x63, 0x61, 0x73, 0x65, 0x20, 0x22, 0x46, 0x6f, 0x72, 0x4f, 0x66, 0x53,
+  0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x3a, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x69,
+  0x66, 0x20, 0x28, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x69, 0x73, 0x4d, 0x61,
+  0x70, 0x4b, 0x65, 0x79, 0x73, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x72,
+  0x69, 0x67, 0x68, 0x74, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x75,
+  0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x65, 0x64, 0x2d, 0x6d, 0x61, 0x70,
+  0x6b, 0x65, 0x79, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x6c, 0x6f, 0x6f, 0x70,
+  0x20, 0x6f, 0x76, 0x65, 0x72, 0x20, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+  0x73, 0x20, 0x69, 0x73, 0x20, 0x75, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64,
+  0x65, 0x64, 0x2c, 0x20, 0x75, 0x73, 0x65, 0x20, 0x6d, 0x61, 0x70, 0x46,
+  0x69, 0x65, 0x6c, 0x64, 0x73, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x70,
+  0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x69, 0x6e,
+  0x73, 0x74, 0x65, 0x61, 0x64, 0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x62,
+  0x72, 0x65, 0x61, 0x6b, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x63, 0x61, 0x73, 0x65, 0x20, 0x22, 0x46, 0x6f, 0x72, 0x53,
+  0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x3a, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x63, 0x61, 0x73, 0x65, 0x20,
+  0x22, 0x57, 0x68, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d,
+  0x65, 0x6e, 0x74, 0x22, 0x3a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x63, 0x61, 0x73, 0x65, 0x20, 0x22, 0x44, 0x6f, 0x57, 0x68,
+  0x69, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+  0x22, 0x3a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x74, 0x68, 0x69, 0x73, 0x2e,
+  0x69, 0x73, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x28, 0x6e, 0x6f,
+  0x64, 0x65, 0x2e, 0x74, 0x65, 0x73, 0x74, 0x29, 0x29, 0x20, 0x7b, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70,
+  0x6f, 0x72, 0x74, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e,
+  0x6f, 0x2d, 0x75, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x65, 0x64, 0x2d,
+  0x6d, 0x61, 0x70, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x6c,
+  0x6f, 0x6f, 0x70, 0x20, 0x6f, 0x76, 0x65, 0x72, 0x20, 0x6d, 0x61, 0x70,
+  0x4b, 0x65, 0x79, 0x73, 0x20, 0x69, 0x73, 0x20, 0x75, 0x6e, 0x62, 0x6f,
+  0x75, 0x6e, 0x64, 0x65, 0x64, 0x2c, 0x20, 0x75, 0x73, 0x65, 0x20, 0x6d,
+  0x61, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x20, 0x77, 0x69, 0x74,
+  0x68, 0x20, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+  0x20, 0x69, 0x6e, 0x73, 0x74, 0x65, 0x61, 0x64, 0x22, 0x29, 0x3b, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x3b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x63, 0x61, 0x73, 0x65, 0x20, 0x22, 0x43,
+  0x61, 0x6c, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+  0x6e, 0x22, 0x3a, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65,
+  0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65,
+  0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72,
+  0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x20,
+  0x26, 0x26, 0x20, 0x21, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c,
+  0x6c, 0x65, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64,
+  0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c,
+  0x6c, 0x65, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79,
+  0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x49,
+  0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x22, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x26, 0x26, 0x20, 0x61, 0x72, 0x72, 0x61, 0x79, 0x49,
+  0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68,
+  0x6f, 0x64, 0x73, 0x2e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x73,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65,
+  0x2e, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x2e, 0x6e, 0x61,
+  0x6d, 0x65, 0x29, 0x20, 0x26, 0x26, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e,
+  0x69, 0x73, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x28, 0x6e, 0x6f,
+  0x64, 0x65, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x65, 0x2e, 0x6f, 0x62,
+  0x6a, 0x65, 0x63, 0x74, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2c, 0x20, 0x22, 0x6e, 0x6f, 0x2d, 0x75,
+  0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x65, 0x64, 0x2d, 0x6d, 0x61, 0x70,
+  0x6b, 0x65, 0x79, 0x73, 0x22, 0x2c, 0x20, 0x22, 0x6c, 0x6f, 0x6f, 0x70,
+  0x20, 0x6f, 0x76, 0x65, 0x72, 0x20, 0x6d, 0x61, 0x70, 0x4b, 0x65, 0x79,
+  0x73, 0x20, 0x69, 0x73, 0x20, 0x75, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64,
+  0x65, 0x64, 0x2c, 0x20, 0x75, 0x73, 0x65, 0x20, 0x6d, 0x61, 0x70, 0x46,
+  0x69, 0x65, 0x6c, 0x64, 0x73, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x70,
+  0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x69, 0x6e,
+  0x73, 0x74, 0x65, 0x61, 0x64, 0x22, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x62,
+  0x72, 0x65, 0x61, 0x6b, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x77, 0x61, 0x6c, 0x6b, 0x28, 0x6e, 0x6f, 0x64, 0x65,
+  0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x69, 0x66, 0x20, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x20, 0x3d, 0x3d, 0x3d,
+  0x20, 0x6e, 0x75, 0x6c, 0x6c, 0x20, 0x7c, 0x7c, 0x20, 0x74, 0x79, 0x70,
+  0x65, 0x6f, 0x66, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x20, 0x21, 0x3d, 0x3d,
+  0x20, 0x22, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0x29, 0x20, 0x7b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x3b, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x41, 0x72, 0x72, 0x61, 0x79,
+  0x2e, 0x69, 0x73, 0x41, 0x72, 0x72, 0x61, 0x79, 0x28, 0x6e, 0x6f, 0x64,
+  0x65, 0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x66, 0x6f, 0x72, 0x20, 0x28, 0x63,
+  0x6f, 0x6e, 0x73, 0x74, 0x20, 0x6e, 0x20, 0x6f, 0x66, 0x20, 0x6e, 0x6f,
+  0x64, 0x65, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68,
+  0x69, 0x73, 0x2e, 0x77, 0x61, 0x6c, 0x6b, 0x28, 0x6e, 0x29, 0x3b, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x3b, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x74, 0x79, 0x70, 0x65,
+  0x6f, 0x66, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65,
+  0x20, 0x21, 0x3d, 0x3d, 0x20, 0x22, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+  0x22, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x3b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e,
+  0x63, 0x68, 0x65, 0x63, 0x6b, 0x4e, 0x6f, 0x64, 0x65, 0x28, 0x6e, 0x6f,
+  0x64, 0x65, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x66, 0x6f, 0x72, 0x20, 0x28, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x20,
+  0x6b, 0x65, 0x79, 0x20, 0x69, 0x6e, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x29,
+  0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6b, 0x65, 0x79, 0x20, 0x3d,
+  0x3d, 0x3d, 0x20, 0x22, 0x6c, 0x6f, 0x63, 0x22, 0x20, 0x7c, 0x7c, 0x20,
+  0x21, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x68, 0x61, 0x73, 0x4f, 0x77, 0x6e,
+  0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x28, 0x6b, 0x65, 0x79,
+  0x29, 0x29, 0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x63, 0x6f, 0x6e,
+  0x74, 0x69, 0x6e, 0x75, 0x65, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x2f, 0x2f, 0x20,
+  0x6e, 0x61, 0x6d, 0x65, 0x73, 0x20, 0x6f, 0x66, 0x20, 0x70, 0x72, 0x6f,
+  0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x20, 0x61, 0x6e, 0x64, 0x20,
+  0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x20, 0x61, 0x72, 0x65, 0x20,
+  0x6e, 0x6f, 0x74, 0x20, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+  0x65, 0x73, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6b, 0x65, 0x79, 0x20, 0x3d,
+  0x3d, 0x3d, 0x20, 0x22, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79,
+  0x22, 0x20, 0x26, 0x26, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79,
+  0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x4d, 0x65, 0x6d, 0x62,
+  0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+  0x22, 0x20, 0x26, 0x26, 0x20, 0x21, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x63,
+  0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x29, 0x20, 0x7b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x3b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x69, 0x66, 0x20, 0x28, 0x6b, 0x65, 0x79, 0x20, 0x3d,
+  0x3d, 0x3d, 0x20, 0x22, 0x6b, 0x65, 0x79, 0x22, 0x20, 0x26, 0x26, 0x20,
+  0x28, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x20, 0x3d,
+  0x3d, 0x3d, 0x20, 0x22, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79,
+  0x22, 0x20, 0x7c, 0x7c, 0x20, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x74, 0x79,
+  0x70, 0x65, 0x20, 0x3d, 0x3d, 0x3d, 0x20, 0x22, 0x4d, 0x65, 0x74, 0x68,
+  0x6f, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+  0x22, 0x29, 0x20, 0x26, 0x26, 0x20, 0x21, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+  0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x29, 0x20, 0x7b, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65,
+  0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x7d, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20,
+  0x20, 0x20, 0x20, 0x20, 0x74, 0x68, 0x69, 0x73, 0x2e, 0x77, 0x61, 0x6c,
+  0x6b, 0x28, 0x6e, 0x6f, 0x64, 0x65, 0x5b, 0x6b, 0x65, 0x79, 0x5d, 0x29,
+  0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x7d, 0x0a, 0x7d, 0x0a, 0x0a, 0x2f, 0x2f, 0x20,
+  0x6c, 0x69, 0x6e, 0x74, 0x20, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+  0x20, 0x74, 0x68, 0x65, 0x20, 0x6e, 0x6f, 0x6e, 0x2d, 0x64, 0x65, 0x74,
+  0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x20, 0x6f,
+  0x72, 0x20, 0x64, 0x61, 0x6e, 0x67, 0x65, 0x72, 0x6f, 0x75, 0x73, 0x20,
+  0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x20, 0x69,
+  0x6e, 0x20, 0x74, 0x68, 0x65, 0x20, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
+  0x63, 0x74, 0x20, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2c, 0x0a, 0x2f,
+  0x2f, 0x20, 0x69, 0x74, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x73,
+  0x20, 0x61, 0x20, 0x6a, 0x73, 0x6f, 0x6e, 0x20, 0x61, 0x72, 0x72, 0x61,
+  0x79, 0x20, 0x6f, 0x66, 0x20, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+  0x73, 0x20, 0x77, 0x69, 0x74, 0x68, 0x20, 0x72, 0x75, 0x6c, 0x65, 0x2c,
+  0x20, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2c, 0x20, 0x6c, 0x69,
+  0x6e, 0x65, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+  0x6e, 0x2e, 0x0a, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x20,
+  0x6c, 0x69, 0x6e, 0x74, 0x28, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x29,
+  0x20, 0x7b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x63, 0x6f, 0x6e, 0x73, 0x74,
+  0x20, 0x61, 0x73, 0x74, 0x20, 0x3d, 0x20, 0x65, 0x73, 0x70, 0x72, 0x69,
+  0x6d, 0x61, 0x2e, 0x70, 0x61, 0x72, 0x73, 0x65, 0x53, 0x63, 0x72, 0x69,
+  0x70, 0x74, 0x28, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2c, 0x20, 0x7b,
+  0x6c, 0x6f, 0x63, 0x3a, 0x20, 0x74, 0x72, 0x75, 0x65, 0x7d, 0x29, 0x3b,
+  0x0a, 0x20, 0x20, 0x20, 0x20, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x20, 0x6c,
+  0x69, 0x6e, 0x74, 0x65, 0x72, 0x20, 0x3d, 0x20, 0x6e, 0x65, 0x77, 0x20,
+  0x4c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x28, 0x29, 0x3b, 0x0a, 0x20, 0x20,
+  0x20, 0x20, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x2e, 0x63, 0x6f, 0x6c,
+  0x6c, 0x65, 0x63, 0x74, 0x4d, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x73, 0x4e,
+  0x61, 0x6d, 0x65, 0x73, 0x28, 0x61, 0x73, 0x74, 0x29, 0x3b, 0x0a, 0x20,
+  0x20, 0x20, 0x20, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x2e, 0x63, 0x6f,
+  0x6c, 0x6c, 0x65, 0x63, 0x74, 0x42, 0x69, 0x67, 0x4e, 0x75, 0x6d, 0x62,
+  0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x28, 0x61, 0x73, 0x74, 0x29,
+  0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72,
+  0x2e, 0x77, 0x61, 0x6c, 0x6b, 0x28, 0x61, 0x73, 0x74, 0x29, 0x3b, 0x0a,
+  0x20, 0x20, 0x20, 0x20, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x2e, 0x66,
+  0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x73, 0x6f, 0x72, 0x74,
+  0x28, 0x28, 0x61, 0x2c, 0x20, 0x62, 0x29, 0x20, 0x3d, 0x3e, 0x20, 0x61,
+  0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x20, 0x2d, 0x20, 0x62, 0x2e, 0x6c, 0x69,
+  0x6e, 0x65, 0x20, 0x7c, 0x7c, 0x20, 0x61, 0x2e, 0x63, 0x6f, 0x6c, 0x75,
+  0x6d, 0x6e, 0x20, 0x2d, 0x20, 0x62, 0x2e, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+  0x6e, 0x29, 0x3b, 0x0a, 0x20, 0x20, 0x20, 0x20, 0x72, 0x65, 0x74, 0x75,
+  0x72, 0x6e, 0x20, 0x4a, 0x53, 0x4f, 0x4e, 0x2e, 0x73, 0x74, 0x72, 0x69,
+  0x6e, 0x67, 0x69, 0x66, 0x79, 0x28, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72,
+  0x2e, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x29, 0x3b, 0x0a,
+  0x7d, 0x0a, 0x0a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x65, 0x78,
+  0x70, 0x6f, 0x72, 0x74, 0x73, 0x20, 0x3d, 0x20, 0x6c, 0x69, 0x6e, 0x74,
+  0x3b, 0x0a, 0x00
+};
+unsigned int __libjs_lint_js_len = 9170;
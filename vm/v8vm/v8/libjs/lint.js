@@ -0,0 +1,216 @@
+'use strict';
+
+// functions of Math which always return integer for integer arguments
+const integerMathFunctions = ["abs", "ceil", "floor", "max", "min", "round", "sign", "trunc"];
+const arrayIterationMethods = ["forEach", "map", "filter", "reduce", "reduceRight", "some", "every", "find", "findIndex"];
+const objectIterationMethods = ["keys", "values", "entries", "getOwnPropertyNames"];
+// classes of exact numbers, their methods return the same class
+const bigNumberClasses = ["BigNumber", "Int64", "Float64"];
+
+function isMember(node, object, property) {
+    return node.type === "MemberExpression" && !node.computed
+        && node.object.type === "Identifier" && node.object.name === object
+        && node.property.type === "Identifier" && (property === undefined || node.property.name === property);
+}
+
+function isMapKeysCall(node) {
+    return node.type === "CallExpression" && node.callee.type === "MemberExpression" && !node.callee.computed
+        && node.callee.property.type === "Identifier"
+        && (node.callee.property.name === "mapKeys" || node.callee.property.name === "globalMapKeys");
+}
+
+class Linter {
+    constructor() {
+        this.findings = [];
+        // names of variables holding the result of mapKeys
+        this.mapKeysNames = {};
+        // names of variables holding big numbers
+        this.bigNumberNames = {};
+    }
+
+    report(node, rule, message) {
+        this.findings.push({
+            rule: rule,
+            message: message,
+            line: node.loc.start.line,
+            column: node.loc.start.column
+        });
+    }
+
+    isMapKeys(node) {
+        if (node === null || node === undefined) {
+            return false;
+        }
+        if (isMapKeysCall(node)) {
+            return true;
+        }
+        if (node.type === "Identifier") {
+            return this.mapKeysNames[node.name] === true;
+        }
+        if (node.type === "MemberExpression") {
+            return this.isMapKeys(node.object);
+        }
+        if (node.type === "BinaryExpression" || node.type === "LogicalExpression") {
+            return this.isMapKeys(node.left) || this.isMapKeys(node.right);
+        }
+        return false;
+    }
+
+    isBigNumber(node) {
+        if (node.type === "NewExpression" || node.type === "CallExpression") {
+            if (node.callee.type === "Identifier") {
+                return bigNumberClasses.includes(node.callee.name);
+            }
+            // method calls of big numbers, e.g. new Float64(a).multi(b)
+            return node.type === "CallExpression" && node.callee.type === "MemberExpression" && this.isBigNumber(node.callee.object);
+        }
+        if (node.type === "Identifier") {
+            return this.bigNumberNames[node.name] === true;
+        }
+        return false;
+    }
+
+    collectBigNumberNames(node) {
+        if (node === null || typeof node !== "object") {
+            return;
+        }
+        if (node.type === "VariableDeclarator" && node.id.type === "Identifier" && node.init !== null && this.isBigNumber(node.init)) {
+            this.bigNumberNames[node.id.name] = true;
+        }
+        if (node.type === "AssignmentExpression" && node.operator === "=" && node.left.type === "Identifier" && this.isBigNumber(node.right)) {
+            this.bigNumberNames[node.left.name] = true;
+        }
+        for (const key in node) {
+            if (key !== "loc" && node.hasOwnProperty(key)) {
+                this.collectBigNumberNames(node[key]);
+            }
+        }
+    }
+
+    // a division is float unless both operands are big numbers
+    isFloatDivision(left, right) {
+        const isNumberLiteral = n => n.type === "Literal" && typeof n.value === "number";
+        if (isNumberLiteral(left) || isNumberLiteral(right)) {
+            return true;
+        }
+        return !this.isBigNumber(left) || !this.isBigNumber(right);
+    }
+
+    collectMapKeysNames(node) {
+        if (node === null || typeof node !== "object") {
+            return;
+        }
+        if (node.type === "VariableDeclarator" && node.id.type === "Identifier" && node.init !== null && this.isMapKeys(node.init)) {
+            this.mapKeysNames[node.id.name] = true;
+        }
+        if (node.type === "AssignmentExpression" && node.left.type === "Identifier" && this.isMapKeys(node.right)) {
+            this.mapKeysNames[node.left.name] = true;
+        }
+        for (const key in node) {
+            if (key !== "loc" && node.hasOwnProperty(key)) {
+                this.collectMapKeysNames(node[key]);
+            }
+        }
+    }
+
+    checkNode(node) {
+        switch (node.type) {
+        case "Identifier":
+            if (node.name === "Date") {
+                this.report(node, "no-date", "Date is not deterministic, use the time in blockchain.blockInfo() instead");
+            } else if (node.name === "eval" || node.name === "Function") {
+                this.report(node, "no-eval", node.name + " runs code generated at runtime");
+            } else if (node.name === "parseFloat") {
+                this.report(node, "no-float", "parseFloat returns float, use Float64 instead");
+            }
+            break;
+        case "Literal":
+            if (typeof node.value === "number" && !Number.isInteger(node.value)) {
+                this.report(node, "no-float", "float literal " + node.raw + ", use Float64 instead");
+            }
+            break;
+        case "MemberExpression":
+            if (isMember(node, "Math", "random")) {
+                this.report(node, "no-math-random", "Math.random is not deterministic, use blockchain.random instead");
+            } else if (isMember(node, "Math") && !integerMathFunctions.includes(node.property.name)) {
+                this.report(node, "no-float", "Math." + node.property.name + " is float math, use Float64 instead");
+            } else if (isMember(node, "Object") && objectIterationMethods.includes(node.property.name)) {
+                this.report(node, "no-object-iteration", "Object." + node.property.name + " depends on the property order of the object");
+            }
+            break;
+        case "BinaryExpression":
+        case "AssignmentExpression":
+            if ((node.operator === "/" || node.operator === "/=") && this.isFloatDivision(node.left, node.right)) {
+                this.report(node, "no-float", "division of numbers returns float, use Math.floor, Int64 or Float64 instead");
+            }
+            break;
+        case "ForInStatement":
+            this.report(node, "no-object-iteration", "for...in depends on the property order of the object");
+            if (this.isMapKeys(node.right)) {
+                this.report(node, "no-unbounded-mapkeys", "loop over mapKeys is unbounded, use mapFields with pagination instead");
+            }
+            break;
+        case "ForOfStatement":
+            if (this.isMapKeys(node.right)) {
+                this.report(node, "no-unbounded-mapkeys", "loop over mapKeys is unbounded, use mapFields with pagination instead");
+            }
+            break;
+        case "ForStatement":
+        case "WhileStatement":
+        case "DoWhileStatement":
+            if (this.isMapKeys(node.test)) {
+                this.report(node, "no-unbounded-mapkeys", "loop over mapKeys is unbounded, use mapFields with pagination instead");
+            }
+            break;
+        case "CallExpression":
+            if (node.callee.type === "MemberExpression" && !node.callee.computed && node.callee.property.type === "Identifier"
+                && arrayIterationMethods.includes(node.callee.property.name) && this.isMapKeys(node.callee.object)) {
+                this.report(node, "no-unbounded-mapkeys", "loop over mapKeys is unbounded, use mapFields with pagination instead");
+            }
+            break;
+        }
+    }
+
+    walk(node) {
+        if (node === null || typeof node !== "object") {
+            return;
+        }
+        if (Array.isArray(node)) {
+            for (const n of node) {
+                this.walk(n);
+            }
+            return;
+        }
+        if (typeof node.type !== "string") {
+            return;
+        }
+        this.checkNode(node);
+        for (const key in node) {
+            if (key === "loc" || !node.hasOwnProperty(key)) {
+                continue;
+            }
+            // names of properties and methods are not references
+            if (key === "property" && node.type === "MemberExpression" && !node.computed) {
+                continue;
+            }
+            if (key === "key" && (node.type === "Property" || node.type === "MethodDefinition") && !node.computed) {
+                continue;
+            }
+            this.walk(node[key]);
+        }
+    }
+}
+
+// lint reports the non-deterministic or dangerous constructs in the contract source,
+// it returns a json array of findings with rule, message, line and column.
+function lint(source) {
+    const ast = esprima.parseScript(source, {loc: true});
+    const linter = new Linter();
+    linter.collectMapKeysNames(ast);
+    linter.collectBigNumberNames(ast);
+    linter.walk(ast);
+    linter.findings.sort((a, b) => a.line - b.line || a.column - b.column);
+    return JSON.stringify(linter.findings);
+}
+
+module.exports = lint;
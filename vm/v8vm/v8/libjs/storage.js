@@ -24,6 +24,9 @@ let IOSTContractStorage = (function () {
         this.del = function (k) {
             let p = "";
             return storage.del(k, p);
+        };
+        this.keys = function (offset, limit) {
+            return JSON.parse(storage.keys("", offset, limit));
         }
     };
     let simpleStorageObj = new simpleStorage;
@@ -55,6 +58,9 @@ let IOSTContractStorage = (function () {
             let p = "";
             return storage.mapLen(k, p);
         };
+        this.mapFields = function (k, offset, limit) {
+            return JSON.parse(storage.keys(k, offset, limit));
+        };
         this.mapDel = function (k, f) {
             let p = "";
             return storage.mapDel(k, f, p);
@@ -102,6 +108,10 @@ let IOSTContractStorage = (function () {
         // simply del a k-v pair using key.
         // del(key)
         del: simpleStorageObj.del,
+        // list keys of the contract by pages, at most 1000 keys a page.
+        // it throws if the keys aren't indexed, the contract owner indexes them with system.iost indexKeys.
+        // keys(offset, limit)
+        keys: simpleStorageObj.keys,
         // map put a (k, f, value) pair. use k + f to find value.
         // mapPut(key, field, value)
         mapPut: mapStorageObj.mapPut,
@@ -115,6 +125,9 @@ let IOSTContractStorage = (function () {
         // mapKeys(key)
         mapKeys: mapStorageObj.mapKeys,
         mapLen: mapStorageObj.mapLen,
+        // map Get fields inside a key by pages, works for maps of any size.
+        // mapFields(key, offset, limit)
+        mapFields: mapStorageObj.mapFields,
         // map Delete a (k, f) pair. use k + f to delete value.
         // mapDel(key, field)
         mapDel: mapStorageObj.mapDel,
@@ -13,6 +13,7 @@ static mapGetFunc CMapGet = nullptr;
 static mapDelFunc CMapDel = nullptr;
 static mapKeysFunc CMapKeys = nullptr;
 static mapLenFunc CMapLen = nullptr;
+static keysFunc CKeys = nullptr;
 
 static globalHasFunc CGHas = nullptr;
 static globalGetFunc CGGet = nullptr;
@@ -22,7 +23,7 @@ static globalMapKeysFunc CGMapKeys = nullptr;
 static globalMapLenFunc CGMapLen = nullptr;
 
 void InitGoStorage(putFunc put, hasFunc has, getFunc get, delFunc del,
-    mapPutFunc mput, mapHasFunc mhas, mapGetFunc mget, mapDelFunc mdel, mapKeysFunc mkeys, mapLenFunc mlen,
+    mapPutFunc mput, mapHasFunc mhas, mapGetFunc mget, mapDelFunc mdel, mapKeysFunc mkeys, mapLenFunc mlen, keysFunc keys,
     globalHasFunc ghas, globalGetFunc gget, globalMapHasFunc gmhas, globalMapGetFunc gmget, globalMapKeysFunc gmkeys, globalMapLenFunc gmlen) {
 
     CPut = put;
@@ -35,6 +36,7 @@ void InitGoStorage(putFunc put, hasFunc has, getFunc get, delFunc del,
     CMapDel = mdel;
     CMapKeys = mkeys;
     CMapLen = mlen;
+    CKeys = keys;
     CGHas = ghas;
     CGGet = gget;
     CGMapHas = gmhas;
@@ -124,6 +126,14 @@ char* IOSTContractStorage::MapLen(const CStr key, const CStr ramPayer, size_t *r
     return ret;
 }
 
+char* IOSTContractStorage::Keys(const CStr key, size_t offset, size_t limit, CStr *result) {
+    size_t gasUsed = 0;
+    char *ret = CKeys(sbxPtr, key, offset, limit, result, &gasUsed);
+    Sandbox *sbx = static_cast<Sandbox*>(sbxPtr);
+    sbx->gasUsed += gasUsed;
+    return ret;
+}
+
 char* IOSTContractStorage::GlobalHas(const CStr contract, const CStr key, const CStr ramPayer, bool *result) {
     size_t gasUsed = 0;
     char *ret = CGHas(sbxPtr, contract, key, ramPayer, result, &gasUsed);
@@ -732,6 +742,59 @@ void IOSTContractStorage_MapKeys(const FunctionCallbackInfo<Value> &args) {
     if (resultStr.data != nullptr) free(resultStr.data);
 }
 
+void IOSTContractStorage_Keys(const FunctionCallbackInfo<Value> &args) {
+    Isolate *isolate = args.GetIsolate();
+    Local<Object> self = args.Holder();
+
+    if (args.Length() != 3) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, "IOSTContractStorage_Keys invalid argument length")
+        );
+        isolate->ThrowException(err);
+        return;
+    }
+
+    Local<Value> key = args[0];
+    if (!key->IsString()) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, "IOSTContractStorage_Keys key must be string")
+        );
+        isolate->ThrowException(err);
+        return;
+    }
+    Local<Value> offset = args[1];
+    Local<Value> limit = args[2];
+    if (!offset->IsUint32() || !limit->IsUint32()) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, "IOSTContractStorage_Keys offset and limit must be non-negative integer")
+        );
+        isolate->ThrowException(err);
+        return;
+    }
+
+    NewCStrChecked(keyStr, key, isolate);
+    CStr resultStr = {nullptr, 0};
+
+    Local<External> extVal = Local<External>::Cast(self->GetInternalField(0));
+    if (!extVal->IsExternal()) {
+        std::cout << "IOSTContractStorage_Keys val error" << std::endl;
+        return;
+    }
+
+    IOSTContractStorage *ics = static_cast<IOSTContractStorage *>(extVal->Value());
+    char *ret = ics->Keys(keyStr, offset->Uint32Value(), limit->Uint32Value(), &resultStr);
+    if (ret != nullptr) {
+        Local<Value> err = Exception::Error(
+            String::NewFromUtf8(isolate, ret)
+        );
+        isolate->ThrowException(err);
+        free(ret);
+        return;
+    }
+    args.GetReturnValue().Set(String::NewFromUtf8(isolate, resultStr.data, String::kNormalString, resultStr.size));
+    if (resultStr.data != nullptr) free(resultStr.data);
+}
+
 void IOSTContractStorage_MapLen(const FunctionCallbackInfo<Value> &args) {
     Isolate *isolate = args.GetIsolate();
     Local<Object> self = args.Holder();
@@ -1241,6 +1304,10 @@ void InitStorage(Isolate *isolate, Local<ObjectTemplate> globalTpl) {
         String::NewFromUtf8(isolate, "mapLen"),
         FunctionTemplate::New(isolate, IOSTContractStorage_MapLen)
     );
+    storageTpl->Set(
+        String::NewFromUtf8(isolate, "keys"),
+        FunctionTemplate::New(isolate, IOSTContractStorage_Keys)
+    );
     // todo
     storageTpl->Set(
         String::NewFromUtf8(isolate, "globalGet"),
@@ -25,6 +25,7 @@ public:
 	char* MapDel(const CStr key, const CStr field, const CStr owner);
 	char* MapKeys(const CStr key, const CStr owner, CStr *result);
 	char* MapLen(const CStr key, const CStr owner, size_t *result);
+	char* Keys(const CStr key, size_t offset, size_t limit, CStr *result);
 	
 	char* GlobalHas(const CStr contract, const CStr key, const CStr owner, bool *result);
 	char* GlobalGet(const CStr contract, const CStr key, const CStr owner, CStr *result);
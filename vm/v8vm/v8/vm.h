@@ -81,6 +81,7 @@ typedef char* (*mapGetFunc)(SandboxPtr, const CStr, const CStr, const CStr, CStr
 typedef char* (*mapDelFunc)(SandboxPtr, const CStr, const CStr, const CStr, size_t *);
 typedef char* (*mapKeysFunc)(SandboxPtr, const CStr, const CStr, CStr *, size_t *);
 typedef char* (*mapLenFunc)(SandboxPtr, const CStr, const CStr, size_t *, size_t *);
+typedef char* (*keysFunc)(SandboxPtr, const CStr, size_t, size_t, CStr *, size_t *);
 typedef char* (*globalHasFunc)(SandboxPtr, const CStr, const CStr, const CStr, bool *, size_t *);
 typedef char* (*globalGetFunc)(SandboxPtr, const CStr, const CStr, const CStr, CStr *, size_t *);
 typedef char* (*globalMapHasFunc)(SandboxPtr, const CStr, const CStr, const CStr, const CStr, bool *, size_t *);
@@ -89,16 +90,20 @@ typedef char* (*globalMapKeysFunc)(SandboxPtr, const CStr,  const CStr, const CS
 typedef char* (*globalMapLenFunc)(SandboxPtr, const CStr, const CStr, const CStr, size_t *, size_t *);
 
 void InitGoStorage(putFunc, hasFunc, getFunc, delFunc,
-    mapPutFunc, mapHasFunc, mapGetFunc, mapDelFunc, mapKeysFunc, mapLenFunc,
+    mapPutFunc, mapHasFunc, mapGetFunc, mapDelFunc, mapKeysFunc, mapLenFunc, keysFunc,
     globalHasFunc, globalGetFunc, globalMapHasFunc, globalMapGetFunc, globalMapKeysFunc, globalMapLenFunc);
 
 // crypto
 typedef CStr (*sha3Func)(SandboxPtr, const CStr, size_t *);
+typedef char* (*hashFunc)(SandboxPtr, const CStr, const CStr, CStr *, size_t *);
+typedef char* (*verifyFunc)(SandboxPtr, const CStr, const CStr, const CStr, const CStr, bool *, size_t *);
+typedef char* (*parsePubkeyFunc)(SandboxPtr, const CStr, CStr *, size_t *);
 
-void InitGoCrypto(sha3Func);
+void InitGoCrypto(sha3Func, hashFunc, verifyFunc, parsePubkeyFunc);
 
 extern int compile(SandboxPtr, const CStr code, CStr *compiledCode, CStr *errMsg);
 extern int validate(SandboxPtr ptr, const CStr code, const CStr abi, CStr *result, CStr *errMsg);
+extern int lint(SandboxPtr ptr, const CStr code, CStr *result, CStr *errMsg);
 extern CustomStartupData createStartupData();
 extern CustomStartupData createCompileStartupData();
 
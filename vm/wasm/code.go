@@ -0,0 +1,391 @@
+package wasm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// opcodes, the ones after the 0xfc prefix are mapped to 0x100 + the sub opcode
+const (
+	opUnreachable  uint16 = 0x00
+	opNop          uint16 = 0x01
+	opBlock        uint16 = 0x02
+	opLoop         uint16 = 0x03
+	opIf           uint16 = 0x04
+	opElse         uint16 = 0x05
+	opEnd          uint16 = 0x0b
+	opBr           uint16 = 0x0c
+	opBrIf         uint16 = 0x0d
+	opBrTable      uint16 = 0x0e
+	opReturn       uint16 = 0x0f
+	opCall         uint16 = 0x10
+	opCallIndirect uint16 = 0x11
+	opDrop         uint16 = 0x1a
+	opSelect       uint16 = 0x1b
+	opSelectT      uint16 = 0x1c
+	opLocalGet     uint16 = 0x20
+	opLocalSet     uint16 = 0x21
+	opLocalTee     uint16 = 0x22
+	opGlobalGet    uint16 = 0x23
+	opGlobalSet    uint16 = 0x24
+	opI32Load      uint16 = 0x28
+	opI64Load      uint16 = 0x29
+	opF32Load      uint16 = 0x2a
+	opF64Load      uint16 = 0x2b
+	opI32Load8S    uint16 = 0x2c
+	opI32Load8U    uint16 = 0x2d
+	opI32Load16S   uint16 = 0x2e
+	opI32Load16U   uint16 = 0x2f
+	opI64Load8S    uint16 = 0x30
+	opI64Load8U    uint16 = 0x31
+	opI64Load16S   uint16 = 0x32
+	opI64Load16U   uint16 = 0x33
+	opI64Load32S   uint16 = 0x34
+	opI64Load32U   uint16 = 0x35
+	opI32Store     uint16 = 0x36
+	opI64Store     uint16 = 0x37
+	opF32Store     uint16 = 0x38
+	opF64Store     uint16 = 0x39
+	opI32Store8    uint16 = 0x3a
+	opI32Store16   uint16 = 0x3b
+	opI64Store8    uint16 = 0x3c
+	opI64Store16   uint16 = 0x3d
+	opI64Store32   uint16 = 0x3e
+	opMemorySize   uint16 = 0x3f
+	opMemoryGrow   uint16 = 0x40
+	opI32Const     uint16 = 0x41
+	opI64Const     uint16 = 0x42
+	opF32Const     uint16 = 0x43
+	opF64Const     uint16 = 0x44
+
+	opI32Eqz  uint16 = 0x45
+	opI32Eq   uint16 = 0x46
+	opI32Ne   uint16 = 0x47
+	opI32LtS  uint16 = 0x48
+	opI32LtU  uint16 = 0x49
+	opI32GtS  uint16 = 0x4a
+	opI32GtU  uint16 = 0x4b
+	opI32LeS  uint16 = 0x4c
+	opI32LeU  uint16 = 0x4d
+	opI32GeS  uint16 = 0x4e
+	opI32GeU  uint16 = 0x4f
+	opI64Eqz  uint16 = 0x50
+	opI64Eq   uint16 = 0x51
+	opI64Ne   uint16 = 0x52
+	opI64LtS  uint16 = 0x53
+	opI64LtU  uint16 = 0x54
+	opI64GtS  uint16 = 0x55
+	opI64GtU  uint16 = 0x56
+	opI64LeS  uint16 = 0x57
+	opI64LeU  uint16 = 0x58
+	opI64GeS  uint16 = 0x59
+	opI64GeU  uint16 = 0x5a
+	opI32Clz  uint16 = 0x67
+	opI32Ctz  uint16 = 0x68
+	opI32Pop  uint16 = 0x69
+	opI32Add  uint16 = 0x6a
+	opI32Sub  uint16 = 0x6b
+	opI32Mul  uint16 = 0x6c
+	opI32DivS uint16 = 0x6d
+	opI32DivU uint16 = 0x6e
+	opI32RemS uint16 = 0x6f
+	opI32RemU uint16 = 0x70
+	opI32And  uint16 = 0x71
+	opI32Or   uint16 = 0x72
+	opI32Xor  uint16 = 0x73
+	opI32Shl  uint16 = 0x74
+	opI32ShrS uint16 = 0x75
+	opI32ShrU uint16 = 0x76
+	opI32Rotl uint16 = 0x77
+	opI32Rotr uint16 = 0x78
+	opI64Clz  uint16 = 0x79
+	opI64Ctz  uint16 = 0x7a
+	opI64Pop  uint16 = 0x7b
+	opI64Add  uint16 = 0x7c
+	opI64Sub  uint16 = 0x7d
+	opI64Mul  uint16 = 0x7e
+	opI64DivS uint16 = 0x7f
+	opI64DivU uint16 = 0x80
+	opI64RemS uint16 = 0x81
+	opI64RemU uint16 = 0x82
+	opI64And  uint16 = 0x83
+	opI64Or   uint16 = 0x84
+	opI64Xor  uint16 = 0x85
+	opI64Shl  uint16 = 0x86
+	opI64ShrS uint16 = 0x87
+	opI64ShrU uint16 = 0x88
+	opI64Rotl uint16 = 0x89
+	opI64Rotr uint16 = 0x8a
+
+	opI32WrapI64    uint16 = 0xa7
+	opI64ExtendI32S uint16 = 0xac
+	opI64ExtendI32U uint16 = 0xad
+	opI32Extend8S   uint16 = 0xc0
+	opI32Extend16S  uint16 = 0xc1
+	opI64Extend8S   uint16 = 0xc2
+	opI64Extend16S  uint16 = 0xc3
+	opI64Extend32S  uint16 = 0xc4
+
+	opPrefix     uint16 = 0xfc
+	opMemoryCopy uint16 = 0x100 + 10
+	opMemoryFill uint16 = 0x100 + 11
+
+	// opGas is injected at the beginning of every basic block, it charges the gas of the instructions in the block
+	opGas uint16 = 0x200
+)
+
+const (
+	maxLocals       = 50000
+	maxBrTableSize  = 65536
+	callCost        = 5
+	callIndirecCost = 10
+	memoryGrowCost  = 10
+)
+
+// instr is a decoded instruction
+type instr struct {
+	op      uint16
+	a       uint64    // immediate: index, constant, branch depth, memory offset or gas
+	in, out uint32    // params and results of block, loop and if
+	sig     *funcType // type of block, loop and if
+	end     uint32    // pc of the matching end of block, loop and if
+	els     uint32    // pc of the else of if, the same as end if there is no else
+	table   []uint32  // depths of br_table, the last one is the default
+}
+
+func isFloatOp(op uint16) bool {
+	switch {
+	case op == opF32Load || op == opF64Load || op == opF32Store || op == opF64Store:
+		return true
+	case op == opF32Const || op == opF64Const:
+		return true
+	case op >= 0x5b && op <= 0x66, op >= 0x8b && op <= 0xa6:
+		return true
+	case op >= 0xa8 && op <= 0xab, op >= 0xae && op <= 0xbf:
+		return true
+	}
+	return false
+}
+
+func isNumericOp(op uint16) bool {
+	switch {
+	case op >= opI32Eqz && op <= opI64GeU, op >= opI32Clz && op <= opI64Rotr:
+		return true
+	case op == opI32WrapI64 || op == opI64ExtendI32S || op == opI64ExtendI32U:
+		return true
+	case op >= opI32Extend8S && op <= opI64Extend32S:
+		return true
+	}
+	return false
+}
+
+// endsBasicBlock returns whether a new basic block begins after op, either it changes the control flow,
+// or the next instruction is the target of a branch.
+func endsBasicBlock(op uint16) bool {
+	switch op {
+	case opBlock, opLoop, opIf, opElse, opEnd, opBr, opBrIf, opBrTable, opReturn, opUnreachable:
+		return true
+	}
+	return false
+}
+
+func opCost(op uint16) uint64 {
+	switch op {
+	case opCall:
+		return callCost
+	case opCallIndirect:
+		return callIndirecCost
+	case opMemoryGrow:
+		return memoryGrowCost
+	}
+	return 1
+}
+
+func (r *reader) peek() byte {
+	if r.err != nil || r.len() == 0 {
+		return 0
+	}
+	return r.b[r.pos]
+}
+
+func (m *Module) blockType(r *reader) (*funcType, error) {
+	switch t := valType(r.peek()); t {
+	case 0x40:
+		r.byte()
+		return &funcType{}, nil
+	case i32, i64:
+		r.byte()
+		return &funcType{results: []valType{t}}, nil
+	case f32, f64:
+		return nil, errFloat
+	}
+	idx := r.sleb(33)
+	if idx < 0 || idx >= int64(len(m.types)) {
+		return nil, fmt.Errorf("unknown block type %v", idx)
+	}
+	return m.types[idx], nil
+}
+
+// decodeBody decodes the code of f and checks its immediates, and injects the gas instructions
+func (m *Module) decodeBody(f *function, body []byte) error {
+	r := newReader(body)
+	n := r.u32()
+	total := uint64(0)
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		count := r.u32()
+		t, err := decodeValType(r)
+		if err != nil {
+			return err
+		}
+		total += uint64(count)
+		if total > maxLocals {
+			return errors.New("too many locals")
+		}
+		for j := uint32(0); j < count; j++ {
+			f.locals = append(f.locals, t)
+		}
+	}
+	if r.err != nil {
+		return r.err
+	}
+	nLocals := uint64(len(m.types[f.typ].params) + len(f.locals))
+
+	code := []instr{{op: opGas}}
+	gas := 0
+	ctrl := make([]int, 0)
+	for {
+		if r.len() == 0 {
+			return errors.New("missing end of function")
+		}
+		ins := instr{op: uint16(r.byte())}
+		op := ins.op
+		switch {
+		case op == opUnreachable, op == opNop, op == opReturn, op == opDrop, op == opSelect:
+		case op == opSelectT:
+			ins.op = opSelect
+			cnt := r.u32()
+			for i := uint32(0); i < cnt && r.err == nil; i++ {
+				if _, err := decodeValType(r); err != nil {
+					return err
+				}
+			}
+		case op == opBlock, op == opLoop, op == opIf:
+			var err error
+			ins.sig, err = m.blockType(r)
+			if err != nil {
+				return err
+			}
+			ins.in, ins.out = uint32(len(ins.sig.params)), uint32(len(ins.sig.results))
+			ctrl = append(ctrl, len(code))
+		case op == opElse:
+			if len(ctrl) == 0 || code[ctrl[len(ctrl)-1]].op != opIf || code[ctrl[len(ctrl)-1]].els != 0 {
+				return errors.New("else without if")
+			}
+			code[ctrl[len(ctrl)-1]].els = uint32(len(code))
+		case op == opEnd:
+			if len(ctrl) == 0 {
+				if r.len() != 0 {
+					return errors.New("code after the end of function")
+				}
+				code = append(code, ins)
+				code[gas].a += opCost(op)
+				f.code = code
+				return r.err
+			}
+			open := ctrl[len(ctrl)-1]
+			ctrl = ctrl[:len(ctrl)-1]
+			code[open].end = uint32(len(code))
+			if code[open].els == 0 {
+				code[open].els = uint32(len(code))
+			}
+		case op == opBr, op == opBrIf:
+			ins.a = uint64(r.u32())
+			if ins.a > uint64(len(ctrl)) {
+				return fmt.Errorf("invalid branch depth %v", ins.a)
+			}
+		case op == opBrTable:
+			cnt := r.u32()
+			if cnt > maxBrTableSize {
+				return errors.New("br_table too large")
+			}
+			for i := uint32(0); i <= cnt && r.err == nil; i++ {
+				d := r.u32()
+				if d > uint32(len(ctrl)) {
+					return fmt.Errorf("invalid branch depth %v", d)
+				}
+				ins.table = append(ins.table, d)
+			}
+		case op == opCall:
+			ins.a = uint64(r.u32())
+			if ins.a >= uint64(m.funcCount()) {
+				return fmt.Errorf("unknown function %v", ins.a)
+			}
+		case op == opCallIndirect:
+			ins.a = uint64(r.u32())
+			if ins.a >= uint64(len(m.types)) {
+				return fmt.Errorf("unknown type %v", ins.a)
+			}
+			if r.byte() != 0 || m.table == nil {
+				return errors.New("unknown table")
+			}
+		case op == opLocalGet, op == opLocalSet, op == opLocalTee:
+			ins.a = uint64(r.u32())
+			if ins.a >= nLocals {
+				return fmt.Errorf("unknown local %v", ins.a)
+			}
+		case op == opGlobalGet, op == opGlobalSet:
+			ins.a = uint64(r.u32())
+			if ins.a >= uint64(len(m.globals)) {
+				return fmt.Errorf("unknown global %v", ins.a)
+			}
+			if op == opGlobalSet && !m.globals[ins.a].mutable {
+				return fmt.Errorf("global %v is immutable", ins.a)
+			}
+		case isFloatOp(op):
+			return errFloat
+		case op >= opI32Load && op <= opI64Store32:
+			if m.memory == nil {
+				return errors.New("unknown memory")
+			}
+			r.u32() // alignment is only a hint
+			ins.a = uint64(r.u32())
+		case op == opMemorySize, op == opMemoryGrow:
+			if r.byte() != 0 || m.memory == nil {
+				return errors.New("unknown memory")
+			}
+		case op == opI32Const:
+			ins.a = uint64(uint32(r.s32()))
+		case op == opI64Const:
+			ins.a = uint64(r.s64())
+		case isNumericOp(op):
+		case op == opPrefix:
+			switch sub := r.u32(); sub {
+			case 0, 1, 2, 3, 4, 5, 6, 7:
+				return errFloat
+			case 10:
+				if r.byte() != 0 || r.byte() != 0 || m.memory == nil {
+					return errors.New("unknown memory")
+				}
+				ins.op = opMemoryCopy
+			case 11:
+				if r.byte() != 0 || m.memory == nil {
+					return errors.New("unknown memory")
+				}
+				ins.op = opMemoryFill
+			default:
+				return fmt.Errorf("unsupported instruction 0xfc %v", sub)
+			}
+		default:
+			return fmt.Errorf("unsupported instruction 0x%x", op)
+		}
+		if r.err != nil {
+			return r.err
+		}
+		code = append(code, ins)
+		code[gas].a += opCost(ins.op)
+		if endsBasicBlock(ins.op) {
+			code = append(code, instr{op: opGas})
+			gas = len(code) - 1
+		}
+	}
+}
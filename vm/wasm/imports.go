@@ -0,0 +1,300 @@
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/host"
+)
+
+// HostModule is the module name of the functions provided to contracts
+const HostModule = "iost"
+
+// resultMaxLength is the max length of the return value, it's the same as the js vm
+const resultMaxLength = 65536
+
+// errors of host functions
+var (
+	ErrInvalidDbValType = errors.New("invalid db value type")
+	ErrResultTooLong    = errors.New("result too long")
+)
+
+// env is the environment of a contract call. Strings are passed to the host as (ptr, len) in the memory.
+// Functions returning a string put it in the result buffer and return its length,
+// the contract reads it with read_result.
+type env struct {
+	h      *host.Host
+	args   []string
+	result []byte
+	ret    string
+	hasRet bool
+}
+
+type hostImport struct {
+	typ *funcType
+	fn  func(e *env, in *Instance, args []uint64) []uint64
+}
+
+func sig(params int, results int) *funcType {
+	t := &funcType{}
+	for i := 0; i < params; i++ {
+		t.params = append(t.params, i32)
+	}
+	for i := 0; i < results; i++ {
+		t.results = append(t.results, i32)
+	}
+	return t
+}
+
+var hostImports = map[string]*hostImport{
+	"abort": {sig(2, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		panic(trap{errors.New(in.str(a[0], a[1]))})
+	}},
+	"arg_count": {sig(0, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		return []uint64{uint64(len(e.args))}
+	}},
+	"arg": {sig(1, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		i := uint32(a[0])
+		if int(i) >= len(e.args) {
+			throw("argument %v out of range", i)
+		}
+		return e.setResult(e.args[i])
+	}},
+	"read_result": {sig(1, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		copy(in.mem(a[0], 0, uint64(len(e.result))), e.result)
+		return nil
+	}},
+	"set_return": {sig(2, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		e.ret = in.str(a[0], a[1])
+		e.hasRet = true
+		return nil
+	}},
+	"put": {sig(6, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		k, v, payer := in.str(a[0], a[1]), in.str(a[2], a[3]), in.str(a[4], a[5])
+		var cost contract.Cost
+		var err error
+		if payer == "" {
+			cost, err = e.h.Put(k, v)
+		} else {
+			cost, err = e.h.Put(k, v, payer)
+		}
+		e.check(in, cost, err)
+		return nil
+	}},
+	"get": {sig(2, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		v, cost := e.h.Get(in.str(a[0], a[1]))
+		in.useGas(cost.CPU)
+		return e.setValue(v)
+	}},
+	"has": {sig(2, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		ok, cost := e.h.Has(in.str(a[0], a[1]))
+		in.useGas(cost.CPU)
+		return []uint64{b2u(ok)}
+	}},
+	"del": {sig(2, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		cost, err := e.h.Del(in.str(a[0], a[1]))
+		e.check(in, cost, err)
+		return nil
+	}},
+	"map_put": {sig(8, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		k, f, v, payer := in.str(a[0], a[1]), in.str(a[2], a[3]), in.str(a[4], a[5]), in.str(a[6], a[7])
+		var cost contract.Cost
+		var err error
+		if payer == "" {
+			cost, err = e.h.MapPut(k, f, v)
+		} else {
+			cost, err = e.h.MapPut(k, f, v, payer)
+		}
+		e.check(in, cost, err)
+		return nil
+	}},
+	"map_get": {sig(4, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		v, cost := e.h.MapGet(in.str(a[0], a[1]), in.str(a[2], a[3]))
+		in.useGas(cost.CPU)
+		return e.setValue(v)
+	}},
+	"map_has": {sig(4, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		ok, cost := e.h.MapHas(in.str(a[0], a[1]), in.str(a[2], a[3]))
+		in.useGas(cost.CPU)
+		return []uint64{b2u(ok)}
+	}},
+	"map_del": {sig(4, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		cost, err := e.h.MapDel(in.str(a[0], a[1]), in.str(a[2], a[3]))
+		e.check(in, cost, err)
+		return nil
+	}},
+	"map_keys": {sig(2, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		keys, cost := e.h.MapKeys(in.str(a[0], a[1]))
+		in.useGas(cost.CPU)
+		return e.setJSON(keys)
+	}},
+	"map_len": {sig(2, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		l, cost := e.h.MapLen(in.str(a[0], a[1]))
+		in.useGas(cost.CPU)
+		return []uint64{uint64(l)}
+	}},
+	"keys": {sig(2, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		keys, cost, err := e.h.Keys(int(uint32(a[0])), int(uint32(a[1])))
+		e.check(in, cost, err)
+		return e.setJSON(keys)
+	}},
+	"map_fields": {sig(4, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		fields, cost, err := e.h.MapFields(in.str(a[0], a[1]), int(uint32(a[2])), int(uint32(a[3])))
+		e.check(in, cost, err)
+		return e.setJSON(fields)
+	}},
+	"global_has": {sig(4, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		ok, cost := e.h.GlobalHas(in.str(a[0], a[1]), in.str(a[2], a[3]))
+		in.useGas(cost.CPU)
+		return []uint64{b2u(ok)}
+	}},
+	"global_get": {sig(4, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		v, cost := e.h.GlobalGet(in.str(a[0], a[1]), in.str(a[2], a[3]))
+		in.useGas(cost.CPU)
+		return e.setValue(v)
+	}},
+	"global_map_has": {sig(6, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		ok, cost := e.h.GlobalMapHas(in.str(a[0], a[1]), in.str(a[2], a[3]), in.str(a[4], a[5]))
+		in.useGas(cost.CPU)
+		return []uint64{b2u(ok)}
+	}},
+	"global_map_get": {sig(6, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		v, cost := e.h.GlobalMapGet(in.str(a[0], a[1]), in.str(a[2], a[3]), in.str(a[4], a[5]))
+		in.useGas(cost.CPU)
+		return e.setValue(v)
+	}},
+	"global_map_keys": {sig(4, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		keys, cost := e.h.GlobalMapKeys(in.str(a[0], a[1]), in.str(a[2], a[3]))
+		in.useGas(cost.CPU)
+		return e.setJSON(keys)
+	}},
+	"global_map_len": {sig(4, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		l, cost := e.h.GlobalMapLen(in.str(a[0], a[1]), in.str(a[2], a[3]))
+		in.useGas(cost.CPU)
+		return []uint64{uint64(l)}
+	}},
+	"call": {sig(6, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		rtn, cost, err := e.h.Call(in.str(a[0], a[1]), in.str(a[2], a[3]), in.str(a[4], a[5]))
+		e.check(in, cost, err)
+		return e.setJSON(rtn)
+	}},
+	"call_with_auth": {sig(6, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		rtn, cost, err := e.h.CallWithAuth(in.str(a[0], a[1]), in.str(a[2], a[3]), in.str(a[4], a[5]))
+		e.check(in, cost, err)
+		return e.setJSON(rtn)
+	}},
+	"require_auth": {sig(4, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		ok, cost := e.h.RequireAuth(in.str(a[0], a[1]), in.str(a[2], a[3]))
+		in.useGas(cost.CPU)
+		return []uint64{b2u(ok)}
+	}},
+	"receipt": {sig(2, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		in.useGas(e.h.Receipt(in.str(a[0], a[1])).CPU)
+		return nil
+	}},
+	"event": {sig(2, 0), func(e *env, in *Instance, a []uint64) []uint64 {
+		cost, err := e.h.PostEvent(in.str(a[0], a[1]))
+		e.check(in, cost, err)
+		return nil
+	}},
+	"block_info": {sig(0, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		info, cost := e.h.BlockInfo()
+		in.useGas(cost.CPU)
+		return e.setResult(string(info))
+	}},
+	"tx_info": {sig(0, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		info, cost := e.h.TxInfo()
+		in.useGas(cost.CPU)
+		return e.setResult(string(info))
+	}},
+	"context_info": {sig(0, 1), func(e *env, in *Instance, a []uint64) []uint64 {
+		info, cost := e.h.ContextInfo()
+		in.useGas(cost.CPU)
+		return e.setResult(string(info))
+	}},
+}
+
+// checkImports checks that all the imports of m are provided by the host with the right signatures
+func checkImports(m *Module) error {
+	for _, imp := range m.imports {
+		hi, ok := hostImports[imp.name]
+		if imp.module != HostModule || !ok {
+			return fmt.Errorf("unknown import %v.%v", imp.module, imp.name)
+		}
+		if !hi.typ.equal(m.types[imp.typ]) {
+			return fmt.Errorf("import %v.%v has wrong signature", imp.module, imp.name)
+		}
+	}
+	return nil
+}
+
+// bind binds the imports of m to e
+func (e *env) bind(m *Module) []hostFunc {
+	fns := make([]hostFunc, len(m.imports))
+	for i, imp := range m.imports {
+		fn := hostImports[imp.name].fn
+		fns[i] = func(in *Instance, args []uint64) []uint64 {
+			return fn(e, in, args)
+		}
+	}
+	return fns
+}
+
+func (e *env) check(in *Instance, cost contract.Cost, err error) {
+	in.useGas(cost.CPU)
+	if err != nil {
+		panic(trap{err})
+	}
+}
+
+func (e *env) setResult(s string) []uint64 {
+	if len(s) > math.MaxInt32 {
+		panic(trap{ErrResultTooLong})
+	}
+	e.result = []byte(s)
+	return []uint64{uint64(len(e.result))}
+}
+
+// setValue puts the value in the storage into the result buffer, it returns -1 if the value doesn't exist
+func (e *env) setValue(v interface{}) []uint64 {
+	if v == nil {
+		e.result = nil
+		return []uint64{uint64(math.MaxUint32)}
+	}
+	s, err := dbValToString(v)
+	if err != nil {
+		panic(trap{err})
+	}
+	return e.setResult(s)
+}
+
+func (e *env) setJSON(v interface{}) []uint64 {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(trap{host.ErrInvalidData})
+	}
+	return e.setResult(string(b))
+}
+
+func (in *Instance) str(ptr, l uint64) string {
+	return string(in.mem(ptr, 0, uint64(uint32(l))))
+}
+
+func dbValToString(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", ErrInvalidDbValType
+	}
+}
@@ -0,0 +1,620 @@
+package wasm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// Limits of the execution
+const (
+	maxCallDepth    = 256
+	maxStackHeight  = 1 << 16
+	memoryPageCost  = 1000
+	memoryByteCost  = 8 // charge 1 gas every memoryByteCost bytes copied or filled
+	deadlineCheckGC = 1024
+)
+
+// ErrOutOfGas is returned when the gas used exceeds the limit
+var ErrOutOfGas = errors.New("out of gas")
+
+// errTimeout contains "execution killed", which is reported as timeout like the js vm
+var errTimeout = errors.New("execution killed: timeout")
+
+// trap aborts the execution, it's recovered and returned as an error
+type trap struct {
+	err error
+}
+
+func throw(format string, args ...interface{}) {
+	panic(trap{fmt.Errorf(format, args...)})
+}
+
+// hostFunc is a function imported from the host
+type hostFunc func(in *Instance, args []uint64) []uint64
+
+// Instance is an instantiated module
+type Instance struct {
+	module   *Module
+	imports  []hostFunc
+	memory   []byte
+	globals  []uint64
+	table    []int64
+	stack    []uint64
+	depth    int
+	gas      int64
+	gasLimit int64
+	deadline time.Time
+	checks   int
+}
+
+// instantiate creates the instance of m, allocates memory, initializes globals, table and memory,
+// and then runs the start function.
+func instantiate(m *Module, imports []hostFunc, gasLimit int64, deadline time.Time) (in *Instance, err error) {
+	in = &Instance{
+		module:   m,
+		imports:  imports,
+		gasLimit: gasLimit,
+		deadline: deadline,
+		stack:    make([]uint64, 0, 64),
+	}
+	defer in.recover(&err)
+	for _, g := range m.globals {
+		in.globals = append(in.globals, g.init)
+	}
+	if m.memory != nil {
+		in.useGas(int64(m.memory.min) * memoryPageCost)
+		in.memory = make([]byte, int(m.memory.min)*PageSize)
+	}
+	if m.table != nil {
+		in.table = make([]int64, m.table.min)
+		for i := range in.table {
+			in.table[i] = -1
+		}
+	}
+	for _, e := range m.elements {
+		if uint64(e.offset)+uint64(len(e.funcs)) > uint64(len(in.table)) {
+			throw("element segment out of table")
+		}
+		for i, f := range e.funcs {
+			in.table[int(e.offset)+i] = int64(f)
+		}
+	}
+	for _, d := range m.data {
+		if uint64(d.offset)+uint64(len(d.data)) > uint64(len(in.memory)) {
+			throw("data segment out of memory")
+		}
+		copy(in.memory[d.offset:], d.data)
+	}
+	if m.start >= 0 {
+		in.call(uint32(m.start))
+	}
+	return in, nil
+}
+
+func (in *Instance) recover(err *error) {
+	if r := recover(); r != nil {
+		switch t := r.(type) {
+		case trap:
+			*err = t.err
+		case error:
+			// validated code should not panic with runtime errors, this is only a backstop
+			*err = fmt.Errorf("wasm trap: %v", t)
+		default:
+			*err = fmt.Errorf("wasm trap: %v", t)
+		}
+	}
+}
+
+// Call calls the exported function with args, returns its results
+func (in *Instance) Call(name string, args ...uint64) (rtn []uint64, err error) {
+	idx, t, ok := in.module.exportedFunc(name)
+	if !ok {
+		return nil, fmt.Errorf("function %v is not exported", name)
+	}
+	if len(args) != len(t.params) {
+		return nil, fmt.Errorf("function %v needs %v args, got %v", name, len(t.params), len(args))
+	}
+	defer in.recover(&err)
+	in.stack = append(in.stack[:0], args...)
+	in.call(idx)
+	rtn = make([]uint64, len(t.results))
+	copy(rtn, in.stack[len(in.stack)-len(t.results):])
+	return rtn, nil
+}
+
+// GasUsed returns the gas used by the instance
+func (in *Instance) GasUsed() int64 {
+	return in.gas
+}
+
+func (in *Instance) useGas(gas int64) {
+	in.gas += gas
+	if in.gas > in.gasLimit || in.gas < 0 {
+		panic(trap{ErrOutOfGas})
+	}
+	in.checks++
+	if in.checks%deadlineCheckGC == 0 && !in.deadline.IsZero() && time.Now().After(in.deadline) {
+		panic(trap{errTimeout})
+	}
+}
+
+func (in *Instance) pop() uint64 {
+	v := in.stack[len(in.stack)-1]
+	in.stack = in.stack[:len(in.stack)-1]
+	return v
+}
+
+func (in *Instance) push(v uint64) {
+	if len(in.stack) >= maxStackHeight {
+		throw("stack overflow")
+	}
+	in.stack = append(in.stack, v)
+}
+
+// mem returns the memory of [addr+offset, addr+offset+size)
+func (in *Instance) mem(addr uint64, offset uint64, size uint64) []byte {
+	ea := uint64(uint32(addr)) + offset
+	if ea+size > uint64(len(in.memory)) {
+		throw("out of bounds memory access")
+	}
+	return in.memory[ea : ea+size]
+}
+
+func (in *Instance) call(idx uint32) {
+	m := in.module
+	t := m.funcType(idx)
+	if int(idx) < len(m.imports) {
+		n := len(t.params)
+		args := make([]uint64, n)
+		copy(args, in.stack[len(in.stack)-n:])
+		in.stack = in.stack[:len(in.stack)-n]
+		for _, v := range in.imports[idx](in, args) {
+			in.push(v)
+		}
+		return
+	}
+	in.depth++
+	if in.depth > maxCallDepth {
+		throw("call stack exhausted")
+	}
+	f := m.funcs[int(idx)-len(m.imports)]
+	locals := make([]uint64, len(t.params)+len(f.locals))
+	n := len(t.params)
+	copy(locals, in.stack[len(in.stack)-n:])
+	in.stack = in.stack[:len(in.stack)-n]
+	in.exec(f.code, locals, len(t.results))
+	in.depth--
+}
+
+type label struct {
+	height int    // stack height at the beginning of the block, excluding params
+	arity  int    // number of values carried by a branch to the label
+	pc     uint32 // pc of the block instruction
+	loop   bool
+}
+
+// exec runs the code of a function, the results are left on the stack
+func (in *Instance) exec(code []instr, locals []uint64, results int) {
+	labels := []label{{height: len(in.stack), arity: results, pc: uint32(len(code) - 1)}}
+	pc := uint32(0)
+
+	// br branches to the label at depth, returns whether the function returns
+	br := func(depth uint32) bool {
+		l := labels[len(labels)-1-int(depth)]
+		copy(in.stack[l.height:], in.stack[len(in.stack)-l.arity:])
+		in.stack = in.stack[:l.height+l.arity]
+		labels = labels[:len(labels)-1-int(depth)]
+		if len(labels) == 0 {
+			return true
+		}
+		if l.loop {
+			pc = l.pc
+		} else {
+			pc = code[l.pc].end + 1
+		}
+		return false
+	}
+
+	for {
+		ins := &code[pc]
+		pc++
+		switch ins.op {
+		case opGas:
+			in.useGas(int64(ins.a))
+		case opUnreachable:
+			throw("unreachable")
+		case opNop:
+		case opBlock:
+			labels = append(labels, label{height: len(in.stack) - int(ins.in), arity: int(ins.out), pc: pc - 1})
+		case opLoop:
+			labels = append(labels, label{height: len(in.stack) - int(ins.in), arity: int(ins.in), pc: pc - 1, loop: true})
+		case opIf:
+			cond := uint32(in.pop())
+			labels = append(labels, label{height: len(in.stack) - int(ins.in), arity: int(ins.out), pc: pc - 1})
+			if cond == 0 {
+				if ins.els == ins.end {
+					pc = ins.end
+				} else {
+					pc = ins.els + 1
+				}
+			}
+		case opElse:
+			// the end of the then branch
+			pc = code[labels[len(labels)-1].pc].end
+		case opEnd:
+			labels = labels[:len(labels)-1]
+			if len(labels) == 0 {
+				return
+			}
+		case opBr:
+			if br(uint32(ins.a)) {
+				return
+			}
+		case opBrIf:
+			if uint32(in.pop()) != 0 && br(uint32(ins.a)) {
+				return
+			}
+		case opBrTable:
+			i := uint32(in.pop())
+			d := ins.table[len(ins.table)-1]
+			if int(i) < len(ins.table)-1 {
+				d = ins.table[i]
+			}
+			if br(d) {
+				return
+			}
+		case opReturn:
+			if br(uint32(len(labels) - 1)) {
+				return
+			}
+		case opCall:
+			in.call(uint32(ins.a))
+		case opCallIndirect:
+			i := uint32(in.pop())
+			if int(i) >= len(in.table) {
+				throw("undefined table element %v", i)
+			}
+			f := in.table[i]
+			if f < 0 {
+				throw("uninitialized table element %v", i)
+			}
+			if !in.module.funcType(uint32(f)).equal(in.module.types[ins.a]) {
+				throw("indirect call type mismatch")
+			}
+			in.call(uint32(f))
+		case opDrop:
+			in.pop()
+		case opSelect:
+			c := uint32(in.pop())
+			b := in.pop()
+			a := in.pop()
+			if c != 0 {
+				in.push(a)
+			} else {
+				in.push(b)
+			}
+		case opLocalGet:
+			in.push(locals[ins.a])
+		case opLocalSet:
+			locals[ins.a] = in.pop()
+		case opLocalTee:
+			locals[ins.a] = in.stack[len(in.stack)-1]
+		case opGlobalGet:
+			in.push(in.globals[ins.a])
+		case opGlobalSet:
+			in.globals[ins.a] = in.pop()
+		case opMemorySize:
+			in.push(uint64(len(in.memory) / PageSize))
+		case opMemoryGrow:
+			in.push(in.grow(uint32(in.pop())))
+		case opMemoryCopy:
+			n := uint64(uint32(in.pop()))
+			src := in.pop()
+			dst := in.pop()
+			in.useGas(int64(n / memoryByteCost))
+			copy(in.mem(dst, 0, n), in.mem(src, 0, n))
+		case opMemoryFill:
+			n := uint64(uint32(in.pop()))
+			v := byte(in.pop())
+			dst := in.pop()
+			in.useGas(int64(n / memoryByteCost))
+			b := in.mem(dst, 0, n)
+			for i := range b {
+				b[i] = v
+			}
+		case opI32Const, opI64Const:
+			in.push(ins.a)
+		default:
+			if ins.op >= opI32Load && ins.op <= opI64Store32 {
+				in.memoryOp(ins)
+			} else {
+				in.numericOp(ins.op)
+			}
+		}
+	}
+}
+
+func (in *Instance) grow(pages uint32) uint64 {
+	old := uint32(len(in.memory) / PageSize)
+	max := uint32(MaxMemoryPages)
+	if l := in.module.memory; l.hasMax && l.max < max {
+		max = l.max
+	}
+	if uint64(old)+uint64(pages) > uint64(max) {
+		return uint64(math.MaxUint32)
+	}
+	in.useGas(int64(pages) * memoryPageCost)
+	in.memory = append(in.memory, make([]byte, int(pages)*PageSize)...)
+	return uint64(old)
+}
+
+func (in *Instance) memoryOp(ins *instr) {
+	switch ins.op {
+	case opI32Load:
+		in.push(uint64(binary.LittleEndian.Uint32(in.mem(in.pop(), ins.a, 4))))
+	case opI64Load:
+		in.push(binary.LittleEndian.Uint64(in.mem(in.pop(), ins.a, 8)))
+	case opI32Load8S:
+		in.push(uint64(uint32(int32(int8(in.mem(in.pop(), ins.a, 1)[0])))))
+	case opI32Load8U:
+		in.push(uint64(in.mem(in.pop(), ins.a, 1)[0]))
+	case opI32Load16S:
+		in.push(uint64(uint32(int32(int16(binary.LittleEndian.Uint16(in.mem(in.pop(), ins.a, 2)))))))
+	case opI32Load16U:
+		in.push(uint64(binary.LittleEndian.Uint16(in.mem(in.pop(), ins.a, 2))))
+	case opI64Load8S:
+		in.push(uint64(int64(int8(in.mem(in.pop(), ins.a, 1)[0]))))
+	case opI64Load8U:
+		in.push(uint64(in.mem(in.pop(), ins.a, 1)[0]))
+	case opI64Load16S:
+		in.push(uint64(int64(int16(binary.LittleEndian.Uint16(in.mem(in.pop(), ins.a, 2))))))
+	case opI64Load16U:
+		in.push(uint64(binary.LittleEndian.Uint16(in.mem(in.pop(), ins.a, 2))))
+	case opI64Load32S:
+		in.push(uint64(int64(int32(binary.LittleEndian.Uint32(in.mem(in.pop(), ins.a, 4))))))
+	case opI64Load32U:
+		in.push(uint64(binary.LittleEndian.Uint32(in.mem(in.pop(), ins.a, 4))))
+	default:
+		v := in.pop()
+		addr := in.pop()
+		switch ins.op {
+		case opI32Store:
+			binary.LittleEndian.PutUint32(in.mem(addr, ins.a, 4), uint32(v))
+		case opI64Store:
+			binary.LittleEndian.PutUint64(in.mem(addr, ins.a, 8), v)
+		case opI32Store8, opI64Store8:
+			in.mem(addr, ins.a, 1)[0] = byte(v)
+		case opI32Store16, opI64Store16:
+			binary.LittleEndian.PutUint16(in.mem(addr, ins.a, 2), uint16(v))
+		case opI64Store32:
+			binary.LittleEndian.PutUint32(in.mem(addr, ins.a, 4), uint32(v))
+		}
+	}
+}
+
+func b2u(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// numericOp runs the integer instructions
+// nolint: gocyclo
+func (in *Instance) numericOp(op uint16) {
+	// unary operations
+	switch op {
+	case opI32Eqz:
+		in.push(b2u(uint32(in.pop()) == 0))
+		return
+	case opI64Eqz:
+		in.push(b2u(in.pop() == 0))
+		return
+	case opI32Clz:
+		in.push(uint64(bits.LeadingZeros32(uint32(in.pop()))))
+		return
+	case opI32Ctz:
+		in.push(uint64(bits.TrailingZeros32(uint32(in.pop()))))
+		return
+	case opI32Pop:
+		in.push(uint64(bits.OnesCount32(uint32(in.pop()))))
+		return
+	case opI64Clz:
+		in.push(uint64(bits.LeadingZeros64(in.pop())))
+		return
+	case opI64Ctz:
+		in.push(uint64(bits.TrailingZeros64(in.pop())))
+		return
+	case opI64Pop:
+		in.push(uint64(bits.OnesCount64(in.pop())))
+		return
+	case opI32WrapI64:
+		in.push(uint64(uint32(in.pop())))
+		return
+	case opI64ExtendI32S:
+		in.push(uint64(int64(int32(uint32(in.pop())))))
+		return
+	case opI64ExtendI32U:
+		in.push(uint64(uint32(in.pop())))
+		return
+	case opI32Extend8S:
+		in.push(uint64(uint32(int32(int8(in.pop())))))
+		return
+	case opI32Extend16S:
+		in.push(uint64(uint32(int32(int16(in.pop())))))
+		return
+	case opI64Extend8S:
+		in.push(uint64(int64(int8(in.pop()))))
+		return
+	case opI64Extend16S:
+		in.push(uint64(int64(int16(in.pop()))))
+		return
+	case opI64Extend32S:
+		in.push(uint64(int64(int32(in.pop()))))
+		return
+	}
+
+	y := in.pop()
+	x := in.pop()
+	if op <= opI32GeU || (op >= opI32Clz && op <= opI32Rotr) {
+		in.push(uint64(i32BinOp(op, uint32(x), uint32(y))))
+		return
+	}
+	in.push(i64BinOp(op, x, y))
+}
+
+// nolint: gocyclo
+func i32BinOp(op uint16, x, y uint32) uint32 {
+	switch op {
+	case opI32Eq:
+		return uint32(b2u(x == y))
+	case opI32Ne:
+		return uint32(b2u(x != y))
+	case opI32LtS:
+		return uint32(b2u(int32(x) < int32(y)))
+	case opI32LtU:
+		return uint32(b2u(x < y))
+	case opI32GtS:
+		return uint32(b2u(int32(x) > int32(y)))
+	case opI32GtU:
+		return uint32(b2u(x > y))
+	case opI32LeS:
+		return uint32(b2u(int32(x) <= int32(y)))
+	case opI32LeU:
+		return uint32(b2u(x <= y))
+	case opI32GeS:
+		return uint32(b2u(int32(x) >= int32(y)))
+	case opI32GeU:
+		return uint32(b2u(x >= y))
+	case opI32Add:
+		return x + y
+	case opI32Sub:
+		return x - y
+	case opI32Mul:
+		return x * y
+	case opI32DivS:
+		if y == 0 {
+			throw("integer divide by zero")
+		}
+		if int32(x) == math.MinInt32 && int32(y) == -1 {
+			throw("integer overflow")
+		}
+		return uint32(int32(x) / int32(y))
+	case opI32DivU:
+		if y == 0 {
+			throw("integer divide by zero")
+		}
+		return x / y
+	case opI32RemS:
+		if y == 0 {
+			throw("integer divide by zero")
+		}
+		if int32(y) == -1 {
+			return 0
+		}
+		return uint32(int32(x) % int32(y))
+	case opI32RemU:
+		if y == 0 {
+			throw("integer divide by zero")
+		}
+		return x % y
+	case opI32And:
+		return x & y
+	case opI32Or:
+		return x | y
+	case opI32Xor:
+		return x ^ y
+	case opI32Shl:
+		return x << (y & 31)
+	case opI32ShrS:
+		return uint32(int32(x) >> (y & 31))
+	case opI32ShrU:
+		return x >> (y & 31)
+	case opI32Rotl:
+		return bits.RotateLeft32(x, int(y&31))
+	case opI32Rotr:
+		return bits.RotateLeft32(x, -int(y&31))
+	}
+	throw("unsupported instruction 0x%x", op)
+	return 0
+}
+
+// nolint: gocyclo
+func i64BinOp(op uint16, x, y uint64) uint64 {
+	switch op {
+	case opI64Eq:
+		return b2u(x == y)
+	case opI64Ne:
+		return b2u(x != y)
+	case opI64LtS:
+		return b2u(int64(x) < int64(y))
+	case opI64LtU:
+		return b2u(x < y)
+	case opI64GtS:
+		return b2u(int64(x) > int64(y))
+	case opI64GtU:
+		return b2u(x > y)
+	case opI64LeS:
+		return b2u(int64(x) <= int64(y))
+	case opI64LeU:
+		return b2u(x <= y)
+	case opI64GeS:
+		return b2u(int64(x) >= int64(y))
+	case opI64GeU:
+		return b2u(x >= y)
+	case opI64Add:
+		return x + y
+	case opI64Sub:
+		return x - y
+	case opI64Mul:
+		return x * y
+	case opI64DivS:
+		if y == 0 {
+			throw("integer divide by zero")
+		}
+		if int64(x) == math.MinInt64 && int64(y) == -1 {
+			throw("integer overflow")
+		}
+		return uint64(int64(x) / int64(y))
+	case opI64DivU:
+		if y == 0 {
+			throw("integer divide by zero")
+		}
+		return x / y
+	case opI64RemS:
+		if y == 0 {
+			throw("integer divide by zero")
+		}
+		if int64(y) == -1 {
+			return 0
+		}
+		return uint64(int64(x) % int64(y))
+	case opI64RemU:
+		if y == 0 {
+			throw("integer divide by zero")
+		}
+		return x % y
+	case opI64And:
+		return x & y
+	case opI64Or:
+		return x | y
+	case opI64Xor:
+		return x ^ y
+	case opI64Shl:
+		return x << (y & 63)
+	case opI64ShrS:
+		return uint64(int64(x) >> (y & 63))
+	case opI64ShrU:
+		return x >> (y & 63)
+	case opI64Rotl:
+		return bits.RotateLeft64(x, int(y&63))
+	case opI64Rotr:
+		return bits.RotateLeft64(x, -int(y&63))
+	}
+	throw("unsupported instruction 0x%x", op)
+	return 0
+}
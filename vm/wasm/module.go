@@ -0,0 +1,519 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Limits of wasm modules
+const (
+	MaxMemoryPages = 64
+	MaxTableSize   = 65536
+	PageSize       = 65536
+)
+
+var magic = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// section ids
+const (
+	sectionCustom byte = iota
+	sectionType
+	sectionImport
+	sectionFunction
+	sectionTable
+	sectionMemory
+	sectionGlobal
+	sectionExport
+	sectionStart
+	sectionElement
+	sectionCode
+	sectionData
+	sectionDataCount
+)
+
+// sectionOrder is the order of the sections in a module, the data count section is before the code section
+var sectionOrder = map[byte]int{
+	sectionType:      1,
+	sectionImport:    2,
+	sectionFunction:  3,
+	sectionTable:     4,
+	sectionMemory:    5,
+	sectionGlobal:    6,
+	sectionExport:    7,
+	sectionStart:     8,
+	sectionElement:   9,
+	sectionDataCount: 10,
+	sectionCode:      11,
+	sectionData:      12,
+}
+
+// external kinds
+const (
+	externalFunction byte = iota
+	externalTable
+	externalMemory
+	externalGlobal
+)
+
+type valType byte
+
+// value types, floats are not supported to keep the execution deterministic
+const (
+	i32 valType = 0x7f
+	i64 valType = 0x7e
+	f32 valType = 0x7d
+	f64 valType = 0x7c
+)
+
+var errFloat = errors.New("float is not supported")
+
+func (t valType) String() string {
+	switch t {
+	case i32:
+		return "i32"
+	case i64:
+		return "i64"
+	case f32:
+		return "f32"
+	case f64:
+		return "f64"
+	}
+	return "unknown"
+}
+
+type funcType struct {
+	params  []valType
+	results []valType
+}
+
+func (t *funcType) equal(o *funcType) bool {
+	return bytes.Equal(valTypesBytes(t.params), valTypesBytes(o.params)) &&
+		bytes.Equal(valTypesBytes(t.results), valTypesBytes(o.results))
+}
+
+func (t *funcType) String() string {
+	return fmt.Sprintf("%v -> %v", t.params, t.results)
+}
+
+func valTypesBytes(ts []valType) []byte {
+	b := make([]byte, len(ts))
+	for i, t := range ts {
+		b[i] = byte(t)
+	}
+	return b
+}
+
+type limits struct {
+	min    uint32
+	max    uint32
+	hasMax bool
+}
+
+type importEntry struct {
+	module string
+	name   string
+	typ    uint32
+}
+
+type global struct {
+	typ     valType
+	mutable bool
+	init    uint64
+}
+
+type export struct {
+	kind  byte
+	index uint32
+}
+
+type element struct {
+	offset uint32
+	funcs  []uint32
+}
+
+type dataSegment struct {
+	offset uint32
+	data   []byte
+}
+
+type function struct {
+	typ    uint32
+	locals []valType
+	code   []instr
+}
+
+// Module is a decoded and validated wasm module, the code of functions is metered.
+type Module struct {
+	types    []*funcType
+	imports  []*importEntry
+	funcs    []*function
+	table    *limits
+	memory   *limits
+	globals  []*global
+	exports  map[string]*export
+	start    int64
+	elements []*element
+	data     []*dataSegment
+}
+
+// funcType returns the type of function idx, including imported ones
+func (m *Module) funcType(idx uint32) *funcType {
+	if int(idx) < len(m.imports) {
+		return m.types[m.imports[idx].typ]
+	}
+	return m.types[m.funcs[int(idx)-len(m.imports)].typ]
+}
+
+func (m *Module) funcCount() int {
+	return len(m.imports) + len(m.funcs)
+}
+
+// ExportedFunc returns the index and type of the exported function
+func (m *Module) exportedFunc(name string) (uint32, *funcType, bool) {
+	e, ok := m.exports[name]
+	if !ok || e.kind != externalFunction {
+		return 0, nil, false
+	}
+	return e.index, m.funcType(e.index), true
+}
+
+// Decode decodes and validates the wasm binary
+func Decode(b []byte) (m *Module, err error) {
+	if len(b) < len(magic) || !bytes.Equal(b[:len(magic)], magic) {
+		return nil, errors.New("invalid wasm magic or version")
+	}
+	m = &Module{
+		exports: make(map[string]*export),
+		start:   -1,
+	}
+	r := newReader(b[len(magic):])
+	var funcTypes []uint32
+	var bodies [][]byte
+	var last int
+	for r.len() > 0 {
+		id := r.byte()
+		size := r.u32()
+		s := newReader(r.bytes(int(size)))
+		if r.err != nil {
+			return nil, r.err
+		}
+		if _, ok := sectionOrder[id]; !ok && id != sectionCustom {
+			return nil, fmt.Errorf("unknown section %v", id)
+		}
+		if id != sectionCustom {
+			if sectionOrder[id] <= last {
+				return nil, fmt.Errorf("section %v out of order", id)
+			}
+			last = sectionOrder[id]
+		}
+		switch id {
+		case sectionCustom:
+			continue
+		case sectionType:
+			err = m.decodeTypes(s)
+		case sectionImport:
+			err = m.decodeImports(s)
+		case sectionFunction:
+			funcTypes, err = decodeFunctions(s)
+		case sectionTable:
+			err = m.decodeTable(s)
+		case sectionMemory:
+			err = m.decodeMemory(s)
+		case sectionGlobal:
+			err = m.decodeGlobals(s)
+		case sectionExport:
+			err = m.decodeExports(s)
+		case sectionStart:
+			m.start = int64(s.u32())
+		case sectionElement:
+			err = m.decodeElements(s)
+		case sectionCode:
+			bodies, err = decodeBodies(s)
+		case sectionData:
+			err = m.decodeData(s)
+		case sectionDataCount:
+			s.u32()
+		}
+		if err == nil {
+			err = s.err
+		}
+		if err == nil && s.len() != 0 {
+			err = errors.New("section size mismatch")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("section %v: %v", id, err)
+		}
+	}
+	if len(funcTypes) != len(bodies) {
+		return nil, errors.New("function and code section have inconsistent lengths")
+	}
+	for _, t := range funcTypes {
+		if int(t) >= len(m.types) {
+			return nil, fmt.Errorf("unknown type %v", t)
+		}
+		m.funcs = append(m.funcs, &function{typ: t})
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	for i, body := range bodies {
+		if err := m.decodeBody(m.funcs[i], body); err != nil {
+			return nil, fmt.Errorf("function %v: %v", i+len(m.imports), err)
+		}
+		if err := m.validateBody(m.funcs[i]); err != nil {
+			return nil, fmt.Errorf("function %v: %v", i+len(m.imports), err)
+		}
+	}
+	return m, nil
+}
+
+func decodeValType(r *reader) (valType, error) {
+	t := valType(r.byte())
+	switch t {
+	case i32, i64:
+		return t, nil
+	case f32, f64:
+		return 0, errFloat
+	default:
+		return 0, fmt.Errorf("invalid value type 0x%x", byte(t))
+	}
+}
+
+func (m *Module) decodeTypes(r *reader) error {
+	n := r.u32()
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		if r.byte() != 0x60 {
+			return errors.New("invalid function type")
+		}
+		t := &funcType{}
+		np := r.u32()
+		for j := uint32(0); j < np && r.err == nil; j++ {
+			v, err := decodeValType(r)
+			if err != nil {
+				return err
+			}
+			t.params = append(t.params, v)
+		}
+		nr := r.u32()
+		for j := uint32(0); j < nr && r.err == nil; j++ {
+			v, err := decodeValType(r)
+			if err != nil {
+				return err
+			}
+			t.results = append(t.results, v)
+		}
+		m.types = append(m.types, t)
+	}
+	return r.err
+}
+
+func (m *Module) decodeImports(r *reader) error {
+	n := r.u32()
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		e := &importEntry{module: r.name(), name: r.name()}
+		if kind := r.byte(); kind != externalFunction {
+			return fmt.Errorf("import %v.%v: only functions can be imported", e.module, e.name)
+		}
+		e.typ = r.u32()
+		if int(e.typ) >= len(m.types) {
+			return fmt.Errorf("import %v.%v: unknown type %v", e.module, e.name, e.typ)
+		}
+		m.imports = append(m.imports, e)
+	}
+	return r.err
+}
+
+func decodeFunctions(r *reader) ([]uint32, error) {
+	n := r.u32()
+	types := make([]uint32, 0)
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		types = append(types, r.u32())
+	}
+	return types, r.err
+}
+
+func decodeLimits(r *reader) *limits {
+	l := &limits{}
+	switch r.byte() {
+	case 0:
+		l.min = r.u32()
+	case 1:
+		l.min = r.u32()
+		l.max = r.u32()
+		l.hasMax = true
+	default:
+		r.fail(errors.New("invalid limits"))
+	}
+	return l
+}
+
+func (m *Module) decodeTable(r *reader) error {
+	n := r.u32()
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		if m.table != nil {
+			return errors.New("multiple tables")
+		}
+		if r.byte() != 0x70 {
+			return errors.New("invalid table element type")
+		}
+		m.table = decodeLimits(r)
+		if m.table.min > MaxTableSize {
+			return fmt.Errorf("table size %v exceeds the limit %v", m.table.min, MaxTableSize)
+		}
+	}
+	return r.err
+}
+
+func (m *Module) decodeMemory(r *reader) error {
+	n := r.u32()
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		if m.memory != nil {
+			return errors.New("multiple memories")
+		}
+		m.memory = decodeLimits(r)
+		if m.memory.min > MaxMemoryPages {
+			return fmt.Errorf("memory of %v pages exceeds the limit %v", m.memory.min, MaxMemoryPages)
+		}
+	}
+	return r.err
+}
+
+// decodeConstExpr decodes an i32.const or i64.const initializer
+func decodeConstExpr(r *reader, t valType) uint64 {
+	var v uint64
+	switch op := uint16(r.byte()); {
+	case op == opI32Const && t == i32:
+		v = uint64(uint32(r.s32()))
+	case op == opI64Const && t == i64:
+		v = uint64(r.s64())
+	default:
+		r.fail(errors.New("unsupported initializer expression"))
+	}
+	if uint16(r.byte()) != opEnd {
+		r.fail(errors.New("invalid initializer expression"))
+	}
+	return v
+}
+
+func (m *Module) decodeGlobals(r *reader) error {
+	n := r.u32()
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		t, err := decodeValType(r)
+		if err != nil {
+			return err
+		}
+		g := &global{typ: t}
+		switch r.byte() {
+		case 0:
+		case 1:
+			g.mutable = true
+		default:
+			return errors.New("invalid global mutability")
+		}
+		g.init = decodeConstExpr(r, t)
+		m.globals = append(m.globals, g)
+	}
+	return r.err
+}
+
+func (m *Module) decodeExports(r *reader) error {
+	n := r.u32()
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		name := r.name()
+		e := &export{kind: r.byte(), index: r.u32()}
+		if _, ok := m.exports[name]; ok {
+			return fmt.Errorf("duplicate export %v", name)
+		}
+		m.exports[name] = e
+	}
+	return r.err
+}
+
+func (m *Module) decodeElements(r *reader) error {
+	n := r.u32()
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		if r.u32() != 0 {
+			return errors.New("only active element segments of table 0 are supported")
+		}
+		e := &element{offset: uint32(decodeConstExpr(r, i32))}
+		nf := r.u32()
+		for j := uint32(0); j < nf && r.err == nil; j++ {
+			e.funcs = append(e.funcs, r.u32())
+		}
+		m.elements = append(m.elements, e)
+	}
+	return r.err
+}
+
+func decodeBodies(r *reader) ([][]byte, error) {
+	n := r.u32()
+	bodies := make([][]byte, 0)
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		size := r.u32()
+		bodies = append(bodies, r.bytes(int(size)))
+	}
+	return bodies, r.err
+}
+
+func (m *Module) decodeData(r *reader) error {
+	n := r.u32()
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		if r.u32() != 0 {
+			return errors.New("only active data segments of memory 0 are supported")
+		}
+		d := &dataSegment{offset: uint32(decodeConstExpr(r, i32))}
+		size := r.u32()
+		d.data = r.bytes(int(size))
+		m.data = append(m.data, d)
+	}
+	return r.err
+}
+
+// validate checks the indices referred out of the code
+func (m *Module) validate() error {
+	for name, e := range m.exports {
+		switch e.kind {
+		case externalFunction:
+			if int(e.index) >= m.funcCount() {
+				return fmt.Errorf("export %v: unknown function %v", name, e.index)
+			}
+		case externalTable:
+			if m.table == nil || e.index != 0 {
+				return fmt.Errorf("export %v: unknown table %v", name, e.index)
+			}
+		case externalMemory:
+			if m.memory == nil || e.index != 0 {
+				return fmt.Errorf("export %v: unknown memory %v", name, e.index)
+			}
+		case externalGlobal:
+			if int(e.index) >= len(m.globals) {
+				return fmt.Errorf("export %v: unknown global %v", name, e.index)
+			}
+		default:
+			return fmt.Errorf("export %v: invalid kind %v", name, e.kind)
+		}
+	}
+	if m.start >= 0 {
+		if m.start >= int64(m.funcCount()) {
+			return fmt.Errorf("unknown start function %v", m.start)
+		}
+		if t := m.funcType(uint32(m.start)); len(t.params) != 0 || len(t.results) != 0 {
+			return errors.New("start function should have no params and results")
+		}
+	}
+	if len(m.elements) > 0 && m.table == nil {
+		return errors.New("element segments without table")
+	}
+	for _, e := range m.elements {
+		for _, f := range e.funcs {
+			if int(f) >= m.funcCount() {
+				return fmt.Errorf("element segment: unknown function %v", f)
+			}
+		}
+	}
+	if len(m.data) > 0 && m.memory == nil {
+		return errors.New("data segments without memory")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package wasm
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+var errUnexpectedEnd = errors.New("unexpected end")
+
+// reader reads the wasm binary, the first error is kept and the following reads return zero values
+type reader struct {
+	b   []byte
+	pos int
+	err error
+}
+
+func newReader(b []byte) *reader {
+	return &reader{b: b}
+}
+
+func (r *reader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *reader) len() int {
+	return len(r.b) - r.pos
+}
+
+func (r *reader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if r.pos >= len(r.b) {
+		r.fail(errUnexpectedEnd)
+		return 0
+	}
+	c := r.b[r.pos]
+	r.pos++
+	return c
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > r.len() {
+		r.fail(errUnexpectedEnd)
+		return nil
+	}
+	b := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *reader) name() string {
+	b := r.bytes(int(r.u32()))
+	if r.err == nil && !utf8.Valid(b) {
+		r.fail(errors.New("invalid utf8 name"))
+	}
+	return string(b)
+}
+
+// uleb reads an unsigned LEB128 integer of at most bits bits
+func (r *reader) uleb(bits uint) uint64 {
+	var v uint64
+	var shift uint
+	for {
+		c := r.byte()
+		if r.err != nil {
+			return 0
+		}
+		if shift+7 > bits && c>>(bits-shift) != 0 {
+			r.fail(errors.New("integer too large"))
+			return 0
+		}
+		v |= uint64(c&0x7f) << shift
+		if c&0x80 == 0 {
+			return v
+		}
+		shift += 7
+	}
+}
+
+// sleb reads a signed LEB128 integer of at most bits bits
+func (r *reader) sleb(bits uint) int64 {
+	var v int64
+	var shift uint
+	for {
+		c := r.byte()
+		if r.err != nil {
+			return 0
+		}
+		if shift+7 > bits {
+			// the unused bits of the last byte should be the extension of the sign bit
+			used := bits - shift
+			unused := byte(0x7f) &^ (1<<used - 1)
+			sign := c&(1<<(used-1)) != 0
+			if c&0x80 != 0 || (sign && c&unused != unused) || (!sign && c&unused != 0) {
+				r.fail(errors.New("integer too large"))
+				return 0
+			}
+		}
+		v |= int64(c&0x7f) << shift
+		shift += 7
+		if c&0x80 == 0 {
+			if shift < 64 && c&0x40 != 0 {
+				v |= -1 << shift
+			}
+			return v
+		}
+	}
+}
+
+func (r *reader) u32() uint32 {
+	return uint32(r.uleb(32))
+}
+
+func (r *reader) s32() int32 {
+	return int32(r.sleb(32))
+}
+
+func (r *reader) s64() int64 {
+	return r.sleb(64)
+}
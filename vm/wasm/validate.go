@@ -0,0 +1,255 @@
+package wasm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// unknown is the type of values popped from the stack of unreachable code, it matches any type
+const unknown valType = 0
+
+var (
+	vNone   []valType
+	vI32    = []valType{i32}
+	vI64    = []valType{i64}
+	vI32x2  = []valType{i32, i32}
+	vI32x3  = []valType{i32, i32, i32}
+	vI64x2  = []valType{i64, i64}
+	vI32I64 = []valType{i32, i64}
+)
+
+// ctrlFrame is a block, loop, if or else being validated
+type ctrlFrame struct {
+	op          uint16
+	sig         *funcType
+	height      int
+	unreachable bool
+}
+
+// validator checks the types of the operand stack of a function like the validation algorithm of the spec
+type validator struct {
+	vals  []valType
+	ctrls []ctrlFrame
+	max   int
+	err   error
+}
+
+func (v *validator) fail(err error) {
+	if v.err == nil {
+		v.err = err
+	}
+}
+
+func (v *validator) push(t valType) {
+	v.vals = append(v.vals, t)
+	if len(v.vals) > v.max {
+		v.max = len(v.vals)
+	}
+}
+
+func (v *validator) pushAll(ts []valType) {
+	for _, t := range ts {
+		v.push(t)
+	}
+}
+
+// pop pops a value of type expect, or of any type if expect is unknown, and returns its type
+func (v *validator) pop(expect valType) valType {
+	c := &v.ctrls[len(v.ctrls)-1]
+	if len(v.vals) == c.height {
+		if !c.unreachable {
+			v.fail(errors.New("stack underflow"))
+		}
+		return expect
+	}
+	t := v.vals[len(v.vals)-1]
+	v.vals = v.vals[:len(v.vals)-1]
+	if t == unknown {
+		return expect
+	}
+	if expect != unknown && t != expect {
+		v.fail(fmt.Errorf("type mismatch, expect %v, got %v", expect, t))
+		return expect
+	}
+	return t
+}
+
+func (v *validator) popAll(ts []valType) {
+	for i := len(ts) - 1; i >= 0; i-- {
+		v.pop(ts[i])
+	}
+}
+
+func (v *validator) pushCtrl(op uint16, sig *funcType) {
+	v.ctrls = append(v.ctrls, ctrlFrame{op: op, sig: sig, height: len(v.vals)})
+	v.pushAll(sig.params)
+}
+
+func (v *validator) popCtrl() ctrlFrame {
+	c := v.ctrls[len(v.ctrls)-1]
+	v.popAll(c.sig.results)
+	if len(v.vals) != c.height {
+		v.fail(errors.New("values remain on the stack at the end of block"))
+	}
+	v.ctrls = v.ctrls[:len(v.ctrls)-1]
+	return c
+}
+
+// label returns the types carried by a branch to the block at depth
+func (v *validator) label(depth uint32) []valType {
+	c := v.ctrls[len(v.ctrls)-1-int(depth)]
+	if c.op == opLoop {
+		return c.sig.params
+	}
+	return c.sig.results
+}
+
+func (v *validator) setUnreachable() {
+	c := &v.ctrls[len(v.ctrls)-1]
+	v.vals = v.vals[:c.height]
+	c.unreachable = true
+}
+
+// validateBody checks that every instruction of f gets operands of the right types,
+// and that every block leaves exactly its results on the stack.
+// nolint: gocyclo
+func (m *Module) validateBody(f *function) error {
+	t := m.types[f.typ]
+	locals := append(append([]valType{}, t.params...), f.locals...)
+	v := &validator{ctrls: []ctrlFrame{{op: opBlock, sig: &funcType{results: t.results}}}}
+	for i := range f.code {
+		ins := &f.code[i]
+		switch op := ins.op; op {
+		case opGas, opNop:
+		case opUnreachable:
+			v.setUnreachable()
+		case opBlock, opLoop:
+			v.popAll(ins.sig.params)
+			v.pushCtrl(op, ins.sig)
+		case opIf:
+			v.pop(i32)
+			v.popAll(ins.sig.params)
+			v.pushCtrl(op, ins.sig)
+		case opElse:
+			c := v.popCtrl()
+			v.pushCtrl(opElse, c.sig)
+		case opEnd:
+			c := v.popCtrl()
+			if c.op == opIf && !bytes.Equal(valTypesBytes(c.sig.params), valTypesBytes(c.sig.results)) {
+				v.fail(errors.New("if without else should have the same params and results"))
+			}
+			v.pushAll(c.sig.results)
+		case opBr:
+			v.popAll(v.label(uint32(ins.a)))
+			v.setUnreachable()
+		case opBrIf:
+			v.pop(i32)
+			ts := v.label(uint32(ins.a))
+			v.popAll(ts)
+			v.pushAll(ts)
+		case opBrTable:
+			v.pop(i32)
+			ts := v.label(ins.table[len(ins.table)-1])
+			for _, d := range ins.table {
+				if !bytes.Equal(valTypesBytes(v.label(d)), valTypesBytes(ts)) {
+					v.fail(errors.New("br_table targets carry different types"))
+				}
+			}
+			v.popAll(ts)
+			v.setUnreachable()
+		case opReturn:
+			v.popAll(v.ctrls[0].sig.results)
+			v.setUnreachable()
+		case opCall:
+			ft := m.funcType(uint32(ins.a))
+			v.popAll(ft.params)
+			v.pushAll(ft.results)
+		case opCallIndirect:
+			v.pop(i32)
+			ft := m.types[ins.a]
+			v.popAll(ft.params)
+			v.pushAll(ft.results)
+		case opDrop:
+			v.pop(unknown)
+		case opSelect:
+			v.pop(i32)
+			t1 := v.pop(unknown)
+			v.push(v.pop(t1))
+		case opLocalGet:
+			v.push(locals[ins.a])
+		case opLocalSet:
+			v.pop(locals[ins.a])
+		case opLocalTee:
+			v.push(v.pop(locals[ins.a]))
+		case opGlobalGet:
+			v.push(m.globals[ins.a].typ)
+		case opGlobalSet:
+			v.pop(m.globals[ins.a].typ)
+		default:
+			params, results, ok := opSignature(op)
+			if !ok {
+				return fmt.Errorf("unsupported instruction 0x%x", op)
+			}
+			v.popAll(params)
+			v.pushAll(results)
+		}
+		if v.err != nil {
+			return fmt.Errorf("instruction %v: %v", i, v.err)
+		}
+		if v.max > maxStackHeight {
+			return errors.New("stack overflow")
+		}
+	}
+	return nil
+}
+
+// opSignature returns the operand and result types of memory and numeric instructions
+// nolint: gocyclo
+func opSignature(op uint16) (params, results []valType, ok bool) {
+	switch {
+	case op == opI32Const:
+		return vNone, vI32, true
+	case op == opI64Const:
+		return vNone, vI64, true
+	case op == opI32Load, op >= opI32Load8S && op <= opI32Load16U:
+		return vI32, vI32, true
+	case op == opI64Load, op >= opI64Load8S && op <= opI64Load32U:
+		return vI32, vI64, true
+	case op == opI32Store, op == opI32Store8, op == opI32Store16:
+		return vI32x2, vNone, true
+	case op == opI64Store, op >= opI64Store8 && op <= opI64Store32:
+		return vI32I64, vNone, true
+	case op == opMemorySize:
+		return vNone, vI32, true
+	case op == opMemoryGrow:
+		return vI32, vI32, true
+	case op == opMemoryCopy, op == opMemoryFill:
+		return vI32x3, vNone, true
+	case op == opI32Eqz:
+		return vI32, vI32, true
+	case op >= opI32Eq && op <= opI32GeU:
+		return vI32x2, vI32, true
+	case op == opI64Eqz:
+		return vI64, vI32, true
+	case op >= opI64Eq && op <= opI64GeU:
+		return vI64x2, vI32, true
+	case op >= opI32Clz && op <= opI32Pop:
+		return vI32, vI32, true
+	case op >= opI32Add && op <= opI32Rotr:
+		return vI32x2, vI32, true
+	case op >= opI64Clz && op <= opI64Pop:
+		return vI64, vI64, true
+	case op >= opI64Add && op <= opI64Rotr:
+		return vI64x2, vI64, true
+	case op == opI32WrapI64:
+		return vI64, vI32, true
+	case op == opI64ExtendI32S, op == opI64ExtendI32U:
+		return vI32, vI64, true
+	case op == opI32Extend8S, op == opI32Extend16S:
+		return vI32, vI32, true
+	case op >= opI64Extend8S && op <= opI64Extend32S:
+		return vI64, vI64, true
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,168 @@
+package wasm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/iost-official/go-iost/common"
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/vm/host"
+)
+
+const moduleCacheSize = 256
+
+// VM runs contracts compiled to WebAssembly. The contracts can't use floats, their gas is
+// charged per instruction and their memory is limited to MaxMemoryPages.
+type VM struct {
+	mu    sync.Mutex
+	cache map[string]*Module
+}
+
+// NewVM returns a wasm vm
+func NewVM() *VM {
+	return &VM{}
+}
+
+// Init ...
+func (vm *VM) Init() error {
+	vm.cache = make(map[string]*Module)
+	return nil
+}
+
+// Validate checks the code and that every api in the abi is exported as a function without params
+func (vm *VM) Validate(c *contract.Contract) error {
+	m, err := vm.load(c)
+	if err != nil {
+		return err
+	}
+	for _, abi := range c.Info.Abi {
+		_, t, ok := m.exportedFunc(abi.Name)
+		if !ok {
+			return fmt.Errorf("abi %v is not exported", abi.Name)
+		}
+		if len(t.params) != 0 {
+			return fmt.Errorf("function %v should not have params, use arg to read the args", abi.Name)
+		}
+	}
+	return nil
+}
+
+// Compile validates the code, the code is saved as is
+func (vm *VM) Compile(c *contract.Contract) (string, error) {
+	if err := vm.Validate(c); err != nil {
+		return "", err
+	}
+	return c.Code, nil
+}
+
+// LoadAndCall instantiates the contract and calls api with args
+func (vm *VM) LoadAndCall(h *host.Host, c *contract.Contract, api string, args ...interface{}) (rtn []interface{}, cost contract.Cost, err error) {
+	cost = contract.Cost0()
+	m, err := vm.load(c)
+	if err != nil {
+		return nil, cost, err
+	}
+	_, t, ok := m.exportedFunc(api)
+	if !ok {
+		if api == "init" {
+			return []interface{}{""}, cost, nil
+		}
+		return nil, cost, fmt.Errorf("function %v is not exported", api)
+	}
+
+	e := &env{h: h}
+	for _, arg := range args {
+		s, err := formatArg(arg)
+		if err != nil {
+			return nil, cost, err
+		}
+		e.args = append(e.args, s)
+	}
+	gasLimit := int64(math.MaxInt64)
+	if h.Context().GValue("gas_limit") != nil {
+		gasLimit = h.GasLimitValue()
+	}
+
+	in, err := instantiate(m, e.bind(m), gasLimit, h.Deadline())
+	if err != nil {
+		return nil, contract.NewCost(0, 0, in.GasUsed()), err
+	}
+	results, err := in.Call(api)
+	cost = contract.NewCost(0, 0, in.GasUsed())
+	if err != nil {
+		return nil, cost, err
+	}
+
+	ret := e.ret
+	if !e.hasRet && len(results) == 1 {
+		if t.results[0] == i32 {
+			ret = strconv.FormatInt(int64(int32(results[0])), 10)
+		} else {
+			ret = strconv.FormatInt(int64(results[0]), 10)
+		}
+	}
+	if len(ret) > resultMaxLength {
+		return nil, cost, ErrResultTooLong
+	}
+	return []interface{}{ret}, cost, nil
+}
+
+// Release ...
+func (vm *VM) Release() {
+	vm.mu.Lock()
+	vm.cache = make(map[string]*Module)
+	vm.mu.Unlock()
+}
+
+// load decodes the code of c, decoded modules are cached by the hash of the code
+func (vm *VM) load(c *contract.Contract) (*Module, error) {
+	key := string(common.Sha3([]byte(c.Code)))
+	vm.mu.Lock()
+	m, ok := vm.cache[key]
+	vm.mu.Unlock()
+	if ok {
+		return m, nil
+	}
+
+	b, err := contract.DecodeCode("wasm", c.Code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wasm code: %v", err)
+	}
+	m, err = Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImports(m); err != nil {
+		return nil, err
+	}
+
+	vm.mu.Lock()
+	if vm.cache == nil || len(vm.cache) >= moduleCacheSize {
+		vm.cache = make(map[string]*Module)
+	}
+	vm.cache[key] = m
+	vm.mu.Unlock()
+	return m, nil
+}
+
+func formatArg(arg interface{}) (string, error) {
+	switch v := arg.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "", errors.New("nil argument")
+	default:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+}
@@ -0,0 +1,489 @@
+package wasm
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iost-official/go-iost/core/contract"
+	"github.com/iost-official/go-iost/db"
+	"github.com/iost-official/go-iost/db/kv"
+	"github.com/iost-official/go-iost/vm/database"
+	"github.com/iost-official/go-iost/vm/host"
+)
+
+type testImport struct {
+	name            string
+	params, results []valType
+}
+
+type testFunc struct {
+	params, results []valType
+	locals          []valType
+	body            []byte
+	export          string
+}
+
+// testModule assembles a wasm binary
+type testModule struct {
+	imports []testImport
+	funcs   []testFunc
+	memory  []uint32 // min and optional max pages
+	data    string   // data at address 0
+}
+
+func uleb(v uint64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			c |= 0x80
+		}
+		b = append(b, c)
+		if v == 0 {
+			return b
+		}
+	}
+}
+
+func sleb(v int64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func vec(items ...[]byte) []byte {
+	b := uleb(uint64(len(items)))
+	for _, item := range items {
+		b = append(b, item...)
+	}
+	return b
+}
+
+func name(s string) []byte {
+	return append(uleb(uint64(len(s))), s...)
+}
+
+func section(id byte, content []byte) []byte {
+	return append(append([]byte{id}, uleb(uint64(len(content)))...), content...)
+}
+
+func types(ts []valType) []byte {
+	b := uleb(uint64(len(ts)))
+	for _, t := range ts {
+		b = append(b, byte(t))
+	}
+	return b
+}
+
+func (m *testModule) bytes() []byte {
+	var typeSec, importSec, funcSec, exportSec, codeSec [][]byte
+	for i, imp := range m.imports {
+		typeSec = append(typeSec, append(append([]byte{0x60}, types(imp.params)...), types(imp.results)...))
+		importSec = append(importSec, append(append(append(name(HostModule), name(imp.name)...), externalFunction), uleb(uint64(i))...))
+	}
+	for i, f := range m.funcs {
+		typeSec = append(typeSec, append(append([]byte{0x60}, types(f.params)...), types(f.results)...))
+		funcSec = append(funcSec, uleb(uint64(len(m.imports)+i)))
+		if f.export != "" {
+			exportSec = append(exportSec, append(append(name(f.export), externalFunction), uleb(uint64(len(m.imports)+i))...))
+		}
+		var locals [][]byte
+		for _, l := range f.locals {
+			locals = append(locals, []byte{0x01, byte(l)})
+		}
+		body := append(append(vec(locals...), f.body...), byte(opEnd))
+		codeSec = append(codeSec, append(uleb(uint64(len(body))), body...))
+	}
+
+	b := append([]byte{}, magic...)
+	b = append(b, section(sectionType, vec(typeSec...))...)
+	if len(importSec) > 0 {
+		b = append(b, section(sectionImport, vec(importSec...))...)
+	}
+	b = append(b, section(sectionFunction, vec(funcSec...))...)
+	if len(m.memory) == 1 {
+		b = append(b, section(sectionMemory, vec(append([]byte{0x00}, uleb(uint64(m.memory[0]))...)))...)
+	} else if len(m.memory) == 2 {
+		b = append(b, section(sectionMemory, vec(append(append([]byte{0x01}, uleb(uint64(m.memory[0]))...), uleb(uint64(m.memory[1]))...)))...)
+	}
+	b = append(b, section(sectionExport, vec(exportSec...))...)
+	b = append(b, section(sectionCode, vec(codeSec...))...)
+	if m.data != "" {
+		seg := append([]byte{0x00, byte(opI32Const), 0x00, byte(opEnd)}, name(m.data)...)
+		b = append(b, section(sectionData, vec(seg))...)
+	}
+	return b
+}
+
+func code(ops ...interface{}) []byte {
+	var b []byte
+	for _, op := range ops {
+		switch v := op.(type) {
+		case uint16:
+			b = append(b, byte(v))
+		case int:
+			b = append(b, byte(v))
+		case byte:
+			b = append(b, v)
+		case []byte:
+			b = append(b, v...)
+		}
+	}
+	return b
+}
+
+func i32c(v int32) []byte {
+	return append([]byte{byte(opI32Const)}, sleb(int64(v))...)
+}
+
+func i64c(v int64) []byte {
+	return append([]byte{byte(opI64Const)}, sleb(v)...)
+}
+
+func newInstance(t *testing.T, m *testModule, gasLimit int64) *Instance {
+	mod, err := Decode(m.bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	in, err := instantiate(mod, nil, gasLimit, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return in
+}
+
+func TestInstance_Arithmetic(t *testing.T) {
+	m := &testModule{funcs: []testFunc{
+		{params: []valType{i64, i64}, results: []valType{i64}, export: "add",
+			body: code(opLocalGet, 0, opLocalGet, 1, opI64Add)},
+		{params: []valType{i32, i32}, results: []valType{i32}, export: "div",
+			body: code(opLocalGet, 0, opLocalGet, 1, opI32DivS)},
+		{params: []valType{i32, i32}, results: []valType{i32}, export: "rotl",
+			body: code(opLocalGet, 0, opLocalGet, 1, opI32Rotl)},
+		{params: []valType{i32}, results: []valType{i64}, export: "extend",
+			body: code(opLocalGet, 0, opI64ExtendI32S)},
+	}}
+	in := newInstance(t, m, math.MaxInt64)
+
+	tests := []struct {
+		f    string
+		args []uint64
+		rtn  uint64
+		err  string
+	}{
+		{"add", []uint64{1, 2}, 3, ""},
+		{"add", []uint64{math.MaxUint64, 2}, 1, ""},
+		{"div", []uint64{uint64(uint32(math.MaxUint32 - 6)), 2}, uint64(uint32(math.MaxUint32 - 2)), ""},
+		{"div", []uint64{1, 0}, 0, "integer divide by zero"},
+		{"div", []uint64{uint64(uint32(1 << 31)), uint64(math.MaxUint32)}, 0, "integer overflow"},
+		{"rotl", []uint64{uint64(uint32(1 << 31)), 33}, 1, ""},
+		{"extend", []uint64{uint64(math.MaxUint32)}, math.MaxUint64, ""},
+	}
+	for _, tt := range tests {
+		rtn, err := in.Call(tt.f, tt.args...)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Fatalf("%v%v: expect error %v, got %v", tt.f, tt.args, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || rtn[0] != tt.rtn {
+			t.Fatalf("%v%v: expect %v, got %v %v", tt.f, tt.args, tt.rtn, rtn, err)
+		}
+	}
+}
+
+func TestInstance_ControlFlow(t *testing.T) {
+	m := &testModule{funcs: []testFunc{
+		{params: []valType{i64}, results: []valType{i64}, locals: []valType{i64}, export: "fac",
+			body: code(i64c(1), opLocalSet, 1,
+				opBlock, 0x40, opLoop, 0x40,
+				opLocalGet, 0, opI64Eqz, opBrIf, 1,
+				opLocalGet, 1, opLocalGet, 0, opI64Mul, opLocalSet, 1,
+				opLocalGet, 0, i64c(1), opI64Sub, opLocalSet, 0,
+				opBr, 0,
+				opEnd, opEnd,
+				opLocalGet, 1)},
+		{params: []valType{i32}, results: []valType{i32}, export: "sign",
+			body: code(opLocalGet, 0, i32c(0), opI32LtS,
+				opIf, byte(i32), i32c(-1), opElse, i32c(1), opEnd)},
+		{params: []valType{i32}, results: []valType{i32}, export: "switch",
+			body: code(opBlock, 0x40, opBlock, 0x40, opBlock, 0x40,
+				opLocalGet, 0, opBrTable, 2, 0, 1, 2,
+				opEnd, i32c(10), opReturn,
+				opEnd, i32c(20), opReturn,
+				opEnd, i32c(30))},
+		{params: []valType{i32}, results: []valType{i32}, export: "fib",
+			body: code(opLocalGet, 0, i32c(2), opI32LtU,
+				opIf, byte(i32), opLocalGet, 0,
+				opElse,
+				opLocalGet, 0, i32c(1), opI32Sub, opCall, 3,
+				opLocalGet, 0, i32c(2), opI32Sub, opCall, 3,
+				opI32Add,
+				opEnd)},
+		{export: "recurse", body: code(opCall, 4)},
+	}}
+	in := newInstance(t, m, math.MaxInt64)
+
+	tests := []struct {
+		f   string
+		arg uint64
+		rtn uint64
+	}{
+		{"fac", 10, 3628800},
+		{"sign", uint64(uint32(math.MaxUint32)), uint64(uint32(math.MaxUint32))},
+		{"sign", 5, 1},
+		{"switch", 0, 10},
+		{"switch", 1, 20},
+		{"switch", 7, 30},
+		{"fib", 15, 610},
+	}
+	for _, tt := range tests {
+		rtn, err := in.Call(tt.f, tt.arg)
+		if err != nil || rtn[0] != tt.rtn {
+			t.Fatalf("%v(%v): expect %v, got %v %v", tt.f, tt.arg, tt.rtn, rtn, err)
+		}
+	}
+	if _, err := in.Call("recurse"); err == nil || err.Error() != "call stack exhausted" {
+		t.Fatal(err)
+	}
+}
+
+func TestInstance_Gas(t *testing.T) {
+	m := &testModule{funcs: []testFunc{
+		{params: []valType{i64, i64}, results: []valType{i64}, export: "add",
+			body: code(opLocalGet, 0, opLocalGet, 1, opI64Add)},
+		{export: "loop", body: code(opLoop, 0x40, opBr, 0, opEnd)},
+	}}
+	in := newInstance(t, m, 10000)
+	if _, err := in.Call("add", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	gas := in.GasUsed()
+	if gas <= 0 {
+		t.Fatal(gas)
+	}
+	if _, err := in.Call("add", 3, 4); err != nil || in.GasUsed() != 2*gas {
+		t.Fatal(in.GasUsed(), err)
+	}
+	if _, err := in.Call("loop"); err != ErrOutOfGas {
+		t.Fatal(err)
+	}
+}
+
+func TestInstance_Memory(t *testing.T) {
+	m := &testModule{
+		memory: []uint32{1, 4},
+		data:   "hello",
+		funcs: []testFunc{
+			{params: []valType{i32}, results: []valType{i32}, export: "grow",
+				body: code(opLocalGet, 0, opMemoryGrow, 0)},
+			{params: []valType{i32}, results: []valType{i32}, export: "load",
+				body: code(opLocalGet, 0, opI32Load8U, 0, 0)},
+			{params: []valType{i32, i64}, results: []valType{i64}, export: "store",
+				body: code(opLocalGet, 0, opLocalGet, 1, opI64Store, 3, 0,
+					opLocalGet, 0, opI64Load, 3, 0)},
+		}}
+	in := newInstance(t, m, math.MaxInt64)
+	if rtn, err := in.Call("load", 1); err != nil || rtn[0] != 'e' {
+		t.Fatal(rtn, err)
+	}
+	if rtn, err := in.Call("store", 100, 1<<40); err != nil || rtn[0] != 1<<40 {
+		t.Fatal(rtn, err)
+	}
+	if _, err := in.Call("load", PageSize); err == nil || err.Error() != "out of bounds memory access" {
+		t.Fatal(err)
+	}
+	if rtn, err := in.Call("grow", 3); err != nil || rtn[0] != 1 {
+		t.Fatal(rtn, err)
+	}
+	if rtn, err := in.Call("grow", 1); err != nil || uint32(rtn[0]) != math.MaxUint32 {
+		t.Fatal(rtn, err)
+	}
+	if _, err := in.Call("load", 4*PageSize-1); err != nil {
+		t.Fatal(err)
+	}
+
+	m.memory = []uint32{MaxMemoryPages + 1}
+	if _, err := Decode(m.bytes()); err == nil {
+		t.Fatal("memory exceeding the limit should be rejected")
+	}
+}
+
+func TestDecode_Float(t *testing.T) {
+	m := &testModule{funcs: []testFunc{
+		{export: "f", body: code(byte(opF32Const), 0, 0, 0, 0, opDrop)},
+	}}
+	if _, err := Decode(m.bytes()); err == nil || !strings.Contains(err.Error(), errFloat.Error()) {
+		t.Fatal(err)
+	}
+	m = &testModule{funcs: []testFunc{
+		{params: []valType{0x7d}, export: "f"},
+	}}
+	if _, err := Decode(m.bytes()); err == nil || !strings.Contains(err.Error(), errFloat.Error()) {
+		t.Fatal(err)
+	}
+}
+
+func TestDecode_Validate(t *testing.T) {
+	tests := []struct {
+		f   testFunc
+		err string
+	}{
+		{testFunc{body: code(i32c(1), opI32Add, opDrop)}, "stack underflow"},
+		{testFunc{results: []valType{i32}, body: code(i32c(1), i64c(2), opI32Add)}, "type mismatch"},
+		{testFunc{body: code(i32c(1))}, "values remain on the stack"},
+		{testFunc{results: []valType{i64}, body: code(opBlock, byte(i32), i32c(1), opEnd)}, "type mismatch"},
+		{testFunc{results: []valType{i32}, body: code(opLocalGet, 0, opIf, byte(i32), i32c(1), opEnd), params: []valType{i32}},
+			"if without else"},
+		{testFunc{params: []valType{i64}, body: code(opLocalGet, 0, opBrIf, 0)}, "type mismatch"},
+		{testFunc{body: code(opBlock, byte(i32), opBlock, 0x40, i32c(0), opBrTable, 1, 0, 1, opEnd, i32c(0), opEnd, opDrop)},
+			"br_table targets carry different types"},
+	}
+	for _, tt := range tests {
+		m := &testModule{funcs: []testFunc{tt.f}}
+		if _, err := Decode(m.bytes()); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Fatalf("%x: expect error %v, got %v", tt.f.body, tt.err, err)
+		}
+	}
+
+	// the stack after unreachable code is polymorphic
+	m := &testModule{funcs: []testFunc{
+		{results: []valType{i64}, body: code(opUnreachable, opI64Add)},
+		{results: []valType{i32}, body: code(opBlock, byte(i32), i32c(1), opBr, 0, opI64Eqz, opEnd)},
+	}}
+	if _, err := Decode(m.bytes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var storageImports = []testImport{
+	{"put", []valType{i32, i32, i32, i32, i32, i32}, nil},
+	{"get", []valType{i32, i32}, []valType{i32}},
+	{"read_result", []valType{i32}, nil},
+	{"set_return", []valType{i32, i32}, nil},
+	{"arg", []valType{i32}, []valType{i32}},
+}
+
+func storageContract() *contract.Contract {
+	m := &testModule{
+		imports: storageImports,
+		memory:  []uint32{1},
+		funcs: []testFunc{
+			// save stores its argument under "key", the argument is read into address 100
+			{export: "save", locals: []valType{i32},
+				body: code(i32c(0), opCall, 4, opLocalSet, 0,
+					i32c(100), opCall, 2,
+					i32c(0), i32c(3), i32c(100), opLocalGet, 0, i32c(0), i32c(0), opCall, 0)},
+			{export: "load", locals: []valType{i32},
+				body: code(i32c(0), i32c(3), opCall, 1, opLocalSet, 0,
+					i32c(100), opCall, 2,
+					i32c(100), opLocalGet, 0, opCall, 3)},
+			{export: "double", results: []valType{i64},
+				body: code(i64c(-21), i64c(2), opI64Mul)},
+		},
+		data: "key",
+	}
+	return &contract.Contract{
+		ID:   "Contractwasm",
+		Code: contract.EncodeCode("wasm", m.bytes()),
+		Info: &contract.Info{
+			Lang:    "wasm",
+			Version: "1.0.0",
+			Abi: []*contract.ABI{
+				{Name: "save", Args: []string{"string"}},
+				{Name: "load"},
+				{Name: "double"},
+			},
+		},
+	}
+}
+
+func newHost(t *testing.T) (*host.Host, func()) {
+	mvccdb, err := db.NewMVCCDBWithStorage("", kv.MemoryStorage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := host.NewContext(nil)
+	ctx.Set("contract_name", "Contractwasm")
+	ctx.GSet("gas_limit", int64(1000000))
+	h := host.NewHost(ctx, database.NewVisitor(0, mvccdb), nil, nil)
+	h.SetDeadline(time.Now().Add(time.Second))
+	return h, func() { _ = mvccdb.Close() }
+}
+
+func TestVM_LoadAndCall(t *testing.T) {
+	h, closeDB := newHost(t)
+	defer closeDB()
+	vm := NewVM()
+	vm.Init()
+	c := storageContract()
+	if err := vm.Validate(c); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := vm.LoadAndCall(h, c, "init"); err != nil {
+		t.Fatal(err)
+	}
+	rtn, cost, err := vm.LoadAndCall(h, c, "save", "hello wasm")
+	if err != nil || cost.CPU <= host.Costs["PutCost"].CPU {
+		t.Fatal(cost, err)
+	}
+	if v, _ := h.Get("key"); v != "hello wasm" {
+		t.Fatal(v)
+	}
+	rtn, _, err = vm.LoadAndCall(h, c, "load")
+	if err != nil || rtn[0] != "hello wasm" {
+		t.Fatal(rtn, err)
+	}
+	rtn, _, err = vm.LoadAndCall(h, c, "double")
+	if err != nil || rtn[0] != "-42" {
+		t.Fatal(rtn, err)
+	}
+
+	h.Context().GSet("gas_limit", int64(10))
+	if _, _, err = vm.LoadAndCall(h, c, "save", "x"); err != ErrOutOfGas {
+		t.Fatal(err)
+	}
+}
+
+func TestVM_Validate(t *testing.T) {
+	vm := NewVM()
+	vm.Init()
+
+	c := storageContract()
+	c.Info.Abi = append(c.Info.Abi, &contract.ABI{Name: "missing"})
+	if err := vm.Validate(c); err == nil || !strings.Contains(err.Error(), "not exported") {
+		t.Fatal(err)
+	}
+
+	m := &testModule{imports: []testImport{{"put", []valType{i32}, nil}}}
+	c = &contract.Contract{Code: contract.EncodeCode("wasm", m.bytes()), Info: &contract.Info{Lang: "wasm"}}
+	if err := vm.Validate(c); err == nil || !strings.Contains(err.Error(), "wrong signature") {
+		t.Fatal(err)
+	}
+
+	m = &testModule{imports: []testImport{{"open", nil, nil}}}
+	c = &contract.Contract{Code: contract.EncodeCode("wasm", m.bytes()), Info: &contract.Info{Lang: "wasm"}}
+	if err := vm.Validate(c); err == nil || !strings.Contains(err.Error(), "unknown import") {
+		t.Fatal(err)
+	}
+
+	c = &contract.Contract{Code: contract.EncodeCode("wasm", []byte("(module)")), Info: &contract.Info{Lang: "wasm"}}
+	if err := vm.Validate(c); err == nil {
+		t.Fatal("text format should be rejected")
+	}
+	if b, _ := contract.DecodeCode("wasm", storageContract().Code); !bytes.HasPrefix(b, magic) {
+		t.Fatal(b)
+	}
+}